@@ -0,0 +1,83 @@
+package bmc
+
+import (
+	"crypto/md5"
+	"encoding/binary"
+	"fmt"
+	"hash"
+
+	"github.com/gebn/bmc/internal/pkg/md2"
+	"github.com/gebn/bmc/pkg/ipmi"
+)
+
+// passwordAuthCode implements the "straight password/key" IPMI v1.5
+// authentication type, where the AuthCode is simply the password. This
+// provides no integrity, and sends the password in the clear with every
+// packet.
+type passwordAuthCode struct {
+	password [16]byte
+}
+
+func (p passwordAuthCode) AuthCode(_, _ uint32, _ []byte) [16]byte {
+	return p.password
+}
+
+// digestAuthCode implements the MD2 and MD5 IPMI v1.5 authentication types.
+// These are identical aside from the underlying hash function: the AuthCode is
+// H(password + session ID + IPMI message + session sequence number + password),
+// where the password is null-padded to 16 bytes, and the session ID and
+// sequence number are in wire byte order.
+type digestAuthCode struct {
+	hash     hash.Hash
+	password [16]byte
+}
+
+func (d digestAuthCode) AuthCode(id, sequence uint32, message []byte) [16]byte {
+	buf := [4]byte{}
+	d.hash.Write(d.password[:])
+	binary.LittleEndian.PutUint32(buf[:], id)
+	d.hash.Write(buf[:])
+	d.hash.Write(message)
+	binary.LittleEndian.PutUint32(buf[:], sequence)
+	d.hash.Write(buf[:])
+	d.hash.Write(d.password[:])
+
+	code := [16]byte{}
+	copy(code[:], d.hash.Sum(nil))
+	d.hash.Reset()
+	return code
+}
+
+// algorithmAuthCode creates an IPMI v1.5 authentication algorithm loaded with
+// the provided password, to be used to sign and verify session headers. It
+// returns nil for AuthenticationTypeNone, which has no AuthCode. Passwords
+// longer than 16 bytes are rejected, as IPMI v1.5 cannot represent them.
+func algorithmAuthCode(t ipmi.AuthenticationType, password []byte) (ipmi.V1AuthenticationAlgorithm, error) {
+	if len(password) > 16 {
+		return nil, fmt.Errorf("IPMI v1.5 passwords cannot be longer than "+
+			"16 bytes, got %v", len(password))
+	}
+	padded := [16]byte{}
+	copy(padded[:], password)
+
+	switch t {
+	case ipmi.AuthenticationTypeNone:
+		return nil, nil
+	case ipmi.AuthenticationTypeMD2:
+		return digestAuthCode{
+			hash:     md2.New(),
+			password: padded,
+		}, nil
+	case ipmi.AuthenticationTypeMD5:
+		return digestAuthCode{
+			hash:     md5.New(),
+			password: padded,
+		}, nil
+	case ipmi.AuthenticationTypePassword:
+		return passwordAuthCode{
+			password: padded,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported authentication type: %v", t)
+	}
+}
@@ -0,0 +1,48 @@
+package bmc
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/gebn/bmc/pkg/ipmi"
+)
+
+func TestAlgorithmAuthCode(t *testing.T) {
+	// Get Channel Authentication Capabilities request message
+	message := []byte{0x20, 0x18, 0xc8, 0x81, 0x04, 0x38, 0x0e, 0x04, 0x31}
+	tests := []struct {
+		authType ipmi.AuthenticationType
+		password string
+		want     string
+	}{
+		{
+			ipmi.AuthenticationTypeMD5,
+			"password",
+			"7f74c6a015d1a5ecc37ed63617e58c1c",
+		},
+		{
+			ipmi.AuthenticationTypePassword,
+			"password",
+			"70617373776f72640000000000000000",
+		},
+	}
+	for _, test := range tests {
+		algorithm, err := algorithmAuthCode(test.authType, []byte(test.password))
+		if err != nil {
+			t.Errorf("algorithmAuthCode(%v) failed: %v", test.authType, err)
+			continue
+		}
+		code := algorithm.AuthCode(0x12345678, 5, message)
+		if got := hex.EncodeToString(code[:]); got != test.want {
+			t.Errorf("%v AuthCode = %v, want %v", test.authType, got,
+				test.want)
+		}
+	}
+}
+
+func TestAlgorithmAuthCodePasswordTooLong(t *testing.T) {
+	if _, err := algorithmAuthCode(ipmi.AuthenticationTypeMD5,
+		make([]byte, 17)); err == nil {
+		t.Errorf("expected error for 17 byte password")
+	}
+}
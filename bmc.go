@@ -12,6 +12,8 @@ import (
 	"github.com/gebn/bmc/pkg/ipmi"
 
 	"github.com/google/gopacket"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 )
 
 var (
@@ -21,10 +23,29 @@ var (
 	}
 
 	namespace = "bmc"
+
+	// connectionNegotiationFailures is not labelled by version, as failure
+	// means we did not get as far as determining one
+	connectionNegotiationFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "connection",
+		Name:      "negotiation_failures_total",
+		Help: "The number of times Dial() failed to determine which IPMI " +
+			"version to use with a BMC.",
+	})
 )
 
 type dialConfig struct {
 	timeout time.Duration
+
+	// version is the IPMI version to use without negotiation, either "1.5" or
+	// "2.0". It is only used by Dial(). If empty, the version is negotiated.
+	version string
+
+	// maxVersion is the highest IPMI version Dial() will negotiate, either
+	// "1.5" or "2.0". If empty, the highest version supported by the BMC is
+	// used.
+	maxVersion string
 }
 
 type DialConfigOption func(c *dialConfig)
@@ -35,14 +56,179 @@ func WithTimeout(t time.Duration) DialConfigOption {
 	}
 }
 
-// Dial is currently an alias for DialV2. When IPMI v1.5 is implemented, this
-// will query the BMC for IPMI v2.0 capability. If it supports IPMI v2.0, a
-// V2SessionlessTransport will be returned, otherwise a V1SessionlessTransport
-// will be returned. If you know the BMC's capabilities, or need a specific
-// feature (e.g. DCMI), use the DialV*() functions instead, which expose
-// additional information and functionality.
-func Dial(_ context.Context, addr string, opts ...DialConfigOption) (SessionlessTransport, error) {
-	return DialV2(addr, opts...)
+// WithVersion forces Dial() to use the specified IPMI version, "1.5" or "2.0",
+// without querying the BMC. This is equivalent to calling the corresponding
+// DialV*() function, but allows selecting the version at runtime, e.g. from
+// configuration. It is ignored by the DialV*() functions.
+func WithVersion(v string) DialConfigOption {
+	return func(c *dialConfig) {
+		c.version = v
+	}
+}
+
+// WithMaxVersion caps the IPMI version Dial() will negotiate, "1.5" or "2.0".
+// For example, setting this to "1.5" will return a V1SessionlessTransport for
+// BMCs supporting both v1.5 and v2.0, and fail for BMCs that only support
+// v2.0. It is ignored by the DialV*() functions.
+func WithMaxVersion(v string) DialConfigOption {
+	return func(c *dialConfig) {
+		c.maxVersion = v
+	}
+}
+
+func newDialConfig(opts []DialConfigOption) *dialConfig {
+	c := &dialConfig{
+		timeout: 1 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Dial queries the BMC for IPMI v2.0 capability using a Get Channel
+// Authentication Capabilities command sent in the v1.5 packet format, which
+// all BMCs must support. If it supports IPMI v2.0, a V2SessionlessTransport
+// will be returned, otherwise a V1SessionlessTransport will be returned. The
+// version can be forced or capped with WithVersion() and WithMaxVersion()
+// respectively. If you know the BMC's capabilities, or need a specific feature
+// (e.g. DCMI), use the DialV*() functions instead, which expose additional
+// information and functionality.
+func Dial(ctx context.Context, addr string, opts ...DialConfigOption) (SessionlessTransport, error) {
+	c := newDialConfig(opts)
+	if err := validateVersion(c.version); err != nil {
+		return nil, err
+	}
+	if err := validateVersion(c.maxVersion); err != nil {
+		return nil, err
+	}
+	switch c.version {
+	case "1.5":
+		return DialV1(addr, opts...)
+	case "2.0":
+		return DialV2(addr, opts...)
+	}
+
+	t, err := newTransport(addr)
+	if err != nil {
+		connectionNegotiationFailures.Inc()
+		return nil, err
+	}
+	version, err := negotiateVersion(ctx, newV1Sessionless(t, c.timeout),
+		c.maxVersion)
+	if err != nil {
+		connectionNegotiationFailures.Inc()
+		// we're already returning an error
+		t.Close()
+		return nil, err
+	}
+	// the connection has been opened successfully; we don't have to reopen
+	// the socket, as the same one can be used for either version
+	switch version {
+	case "1.5":
+		v1ConnectionOpenAttempts.Inc()
+		v1ConnectionsOpen.Inc()
+		return newV1SessionlessTransport(t, c), nil
+	default:
+		v2ConnectionOpenAttempts.Inc()
+		v2ConnectionsOpen.Inc()
+		return newV2SessionlessTransport(t, c), nil
+	}
+}
+
+func validateVersion(v string) error {
+	switch v {
+	case "", "1.5", "2.0":
+		return nil
+	default:
+		return fmt.Errorf("unsupported IPMI version %q, must be 1.5 or 2.0", v)
+	}
+}
+
+// negotiateVersion sends a Get Channel Authentication Capabilities command
+// over the provided connection, returning the highest IPMI version supported
+// by the BMC, not exceeding maxVersion. Some older BMCs reject the request if
+// the extended data bit is set, and others silently drop it, in which case
+// the command is retried without it. The first attempt is given at most half
+// the remaining time, so the retry is not starved.
+func negotiateVersion(ctx context.Context, s *V1Sessionless, maxVersion string) (string, error) {
+	cmd := &ipmi.GetChannelAuthenticationCapabilitiesCmd{
+		Req: ipmi.GetChannelAuthenticationCapabilitiesReq{
+			ExtendedData:      true,
+			Channel:           ipmi.ChannelPresentInterface,
+			MaxPrivilegeLevel: ipmi.PrivilegeLevelUser,
+		},
+	}
+	extendedCtx, cancel := context.WithTimeout(ctx,
+		extendedDataTimeout(ctx, s.timeout))
+	code, err := s.SendCommand(extendedCtx, cmd)
+	cancel()
+	if code != ipmi.CompletionCodeNormal || err != nil {
+		// this BMC doesn't understand IPMI v2.0
+		cmd.Req.ExtendedData = false
+		if err := ValidateResponse(s.SendCommand(ctx, cmd)); err != nil {
+			return "", err
+		}
+	}
+	return selectVersion(&cmd.Rsp, maxVersion)
+}
+
+// extendedDataTimeout returns the time allowed for the Get Channel
+// Authentication Capabilities attempt with the extended data bit set: two
+// request timeouts, or half the time remaining in the context, whichever is
+// shorter.
+func extendedDataTimeout(ctx context.Context, requestTimeout time.Duration) time.Duration {
+	timeout := requestTimeout * 2
+	if deadline, ok := ctx.Deadline(); ok {
+		if half := time.Until(deadline) / 2; half < timeout {
+			return half
+		}
+	}
+	return timeout
+}
+
+// selectVersion picks the highest IPMI version indicated as usable by a Get
+// Channel Authentication Capabilities response, not exceeding maxVersion.
+// IPMI v1.5 is only regarded as usable if at least one authentication type
+// supported by this library is available.
+func selectVersion(rsp *ipmi.GetChannelAuthenticationCapabilitiesRsp, maxVersion string) (string, error) {
+	if rsp.ExtendedCapabilities && rsp.SupportsV2 && maxVersion != "1.5" {
+		return "2.0", nil
+	}
+
+	// if the BMC didn't return extended capabilities, it only supports v1.5
+	supportsV1 := !rsp.ExtendedCapabilities || rsp.SupportsV1
+	if !supportsV1 {
+		return "", fmt.Errorf("BMC does not support IPMI v1.5")
+	}
+	if !rsp.AuthenticationTypeNone && !rsp.AuthenticationTypeMD2 &&
+		!rsp.AuthenticationTypeMD5 && !rsp.AuthenticationTypePassword {
+		return "", ErrNoSupportedAuthenticationType
+	}
+	return "1.5", nil
+}
+
+// DialV1 establishes a new IPMI v1.5 connection with the supplied BMC. The
+// address is of the form IP[:port] (IPv6 must be enclosed in square brackets).
+// Use this if you know the BMC only supports IPMI v1.5, or want to use v1.5
+// sessions with a BMC that also supports v2.0. Note v4 is preferred to v6 if a
+// hostname is passed returning both A and AAAA records.
+func DialV1(addr string, opts ...DialConfigOption) (*V1SessionlessTransport, error) {
+	v1ConnectionOpenAttempts.Inc()
+	t, err := newTransport(addr)
+	if err != nil {
+		v1ConnectionOpenFailures.Inc()
+		return nil, err
+	}
+	v1ConnectionsOpen.Inc()
+	return newV1SessionlessTransport(t, newDialConfig(opts)), nil
+}
+
+func newV1SessionlessTransport(t transport.Transport, c *dialConfig) *V1SessionlessTransport {
+	return &V1SessionlessTransport{
+		Transport:     t,
+		V1Sessionless: newV1Sessionless(t, c.timeout),
+	}
 }
 
 // DialV2 establishes a new IPMI v2.0 connection with the supplied BMC. The
@@ -58,13 +244,7 @@ func DialV2(addr string, opts ...DialConfigOption) (*V2SessionlessTransport, err
 		return nil, err
 	}
 	v2ConnectionsOpen.Inc()
-	c := &dialConfig{
-		timeout: 1 * time.Second,
-	}
-	for _, opt := range opts {
-		opt(c)
-	}
-	return newV2SessionlessTransport(t, c), nil
+	return newV2SessionlessTransport(t, newDialConfig(opts)), nil
 }
 
 func newV2SessionlessTransport(t transport.Transport, c *dialConfig) *V2SessionlessTransport {
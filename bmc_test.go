@@ -0,0 +1,142 @@
+package bmc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gebn/bmc/pkg/ipmi"
+)
+
+func TestSelectVersion(t *testing.T) {
+	tests := []struct {
+		rsp        ipmi.GetChannelAuthenticationCapabilitiesRsp
+		maxVersion string
+		want       string // empty if error
+	}{
+		// v1.5-only BMC, which does not return extended capabilities
+		{
+			rsp: ipmi.GetChannelAuthenticationCapabilitiesRsp{
+				AuthenticationTypeMD5: true,
+			},
+			want: "1.5",
+		},
+		// v1.5-only BMC without any usable authentication types
+		{
+			rsp: ipmi.GetChannelAuthenticationCapabilitiesRsp{
+				AuthenticationTypeOEM: true,
+			},
+		},
+		{
+			rsp: ipmi.GetChannelAuthenticationCapabilitiesRsp{
+				ExtendedCapabilities:  true,
+				AuthenticationTypeMD5: true,
+				SupportsV2:            true,
+				SupportsV1:            true,
+			},
+			want: "2.0",
+		},
+		{
+			rsp: ipmi.GetChannelAuthenticationCapabilitiesRsp{
+				ExtendedCapabilities:  true,
+				AuthenticationTypeMD5: true,
+				SupportsV2:            true,
+				SupportsV1:            true,
+			},
+			maxVersion: "1.5",
+			want:       "1.5",
+		},
+		// v2.0-only BMC capped at v1.5
+		{
+			rsp: ipmi.GetChannelAuthenticationCapabilitiesRsp{
+				ExtendedCapabilities: true,
+				SupportsV2:           true,
+			},
+			maxVersion: "1.5",
+		},
+		// SupportsV2 is meaningless without extended capabilities
+		{
+			rsp: ipmi.GetChannelAuthenticationCapabilitiesRsp{
+				AuthenticationTypePassword: true,
+				SupportsV2:                 true,
+			},
+			want: "1.5",
+		},
+	}
+	for _, test := range tests {
+		got, err := selectVersion(&test.rsp, test.maxVersion)
+		if err != nil && test.want != "" {
+			t.Errorf("selectVersion(%+v, %q) failed with %v, wanted %v",
+				test.rsp, test.maxVersion, err, test.want)
+			continue
+		}
+		if err == nil && got != test.want {
+			t.Errorf("selectVersion(%+v, %q) = %v, want %q", test.rsp,
+				test.maxVersion, got, test.want)
+		}
+	}
+}
+
+// extendedDataBMC returns a v1BMC that answers Get Channel Authentication
+// Capabilities requests. Those with the extended data bit set are answered
+// with extended, or the given completion code; if this is 0, they are dropped.
+func extendedDataBMC(extended []byte, code ipmi.CompletionCode) *v1BMC {
+	return &v1BMC{
+		respond: func(m *ipmi.Message) (ipmi.CompletionCode, []byte, bool) {
+			if m.Operation != ipmi.OperationGetChannelAuthenticationCapabilitiesReq {
+				return ipmi.CompletionCodeUnrecognisedCommand, nil, true
+			}
+			if m.LayerPayload()[0]&0x80 == 0 {
+				return ipmi.CompletionCodeNormal, []byte{0x01, 0x04, 0x04, 0, 0, 0, 0, 0}, true
+			}
+			switch {
+			case extended != nil:
+				return ipmi.CompletionCodeNormal, extended, true
+			case code != ipmi.CompletionCodeNormal:
+				return code, nil, true
+			default:
+				return 0, nil, false
+			}
+		},
+	}
+}
+
+func TestNegotiateVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		bmc  *v1BMC
+		want string
+	}{
+		{
+			name: "v2.0",
+			bmc:  extendedDataBMC([]byte{0x01, 0x84, 0x04, 0x03, 0, 0, 0, 0}, 0),
+			want: "2.0",
+		},
+		{
+			name: "extended data rejected",
+			bmc:  extendedDataBMC(nil, ipmi.CompletionCode(0xcc)),
+			want: "1.5",
+		},
+		{
+			name: "extended data dropped",
+			bmc:  extendedDataBMC(nil, ipmi.CompletionCodeNormal),
+			want: "1.5",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// the extended data attempt must not use up the context, which
+			// would otherwise expire while retrying it
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			s := newV1Sessionless(test.bmc, time.Millisecond*100)
+			version, err := negotiateVersion(ctx, s, "")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if version != test.want {
+				t.Errorf("negotiateVersion() = %v, want %v", version, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package bmc
+
+import (
+	"context"
+
+	"github.com/gebn/bmc/pkg/ipmi"
+)
+
+// ChannelDescription describes a channel discovered by RetrieveChannels.
+type ChannelDescription struct {
+
+	// Info contains the channel's medium, protocol and session support.
+	Info *ipmi.GetChannelInfoRsp
+
+	// Access contains the channel's access settings currently in effect. This
+	// is nil if the channel does not have access settings, e.g. IPMB.
+	Access *ipmi.ChannelAccess
+
+	// AuthenticationCapabilities contains the authentication types and login
+	// methods available on the channel. This is nil if the channel does not
+	// support sessions, or the BMC did not report them.
+	AuthenticationCapabilities *ipmi.GetChannelAuthenticationCapabilitiesRsp
+
+	// CipherSuites contains the cipher suites that can be used to establish
+	// an RMCP+ session over the channel. This is nil if the channel is not a
+	// LAN supporting IPMI v2.0, or the BMC did not report them.
+	CipherSuites []ipmi.CipherSuiteRecord
+}
+
+// RetrieveChannels walks the implementation-specific channel numbers 0x0
+// through 0xb, returning a description of each that exists, in ascending
+// order. Channel numbers the BMC rejects are omitted. Optional information a
+// channel does not support is left nil, so only connection errors and
+// malformed responses cause an error to be returned.
+func RetrieveChannels(ctx context.Context, s Session) ([]*ChannelDescription, error) {
+	channels := []*ChannelDescription{}
+	for channel := ipmi.Channel(0); channel <= 0xb; channel++ {
+		infoCmd := &ipmi.GetChannelInfoCmd{
+			Req: ipmi.GetChannelInfoReq{
+				Channel: channel,
+			},
+		}
+		// BMCs typically truncate the response after a non-normal code, so
+		// this is checked before any decode error
+		code, err := s.SendCommand(ctx, infoCmd)
+		if code != ipmi.CompletionCodeNormal {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		description, err := describeChannel(ctx, s, &infoCmd.Rsp)
+		if err != nil {
+			return nil, err
+		}
+		channels = append(channels, description)
+	}
+	return channels, nil
+}
+
+// describeChannel retrieves the access settings, authentication capabilities
+// and cipher suites of a channel that exists.
+func describeChannel(ctx context.Context, s Session, info *ipmi.GetChannelInfoRsp) (*ChannelDescription, error) {
+	description := &ChannelDescription{
+		Info: info,
+	}
+
+	accessCmd := &ipmi.GetChannelAccessCmd{
+		Req: ipmi.GetChannelAccessReq{
+			Channel: info.Channel,
+			Type:    ipmi.ChannelAccessTypeVolatile,
+		},
+	}
+	// as with Get Channel Info, a non-normal code takes precedence over any
+	// decode error
+	code, err := s.SendCommand(ctx, accessCmd)
+	if code == ipmi.CompletionCodeNormal {
+		if err != nil {
+			return nil, err
+		}
+		description.Access = &accessCmd.Rsp.ChannelAccess
+	}
+
+	if !info.SessionSupport.SupportsSessions() {
+		return description, nil
+	}
+	capsCmd := &ipmi.GetChannelAuthenticationCapabilitiesCmd{
+		Req: ipmi.GetChannelAuthenticationCapabilitiesReq{
+			ExtendedData:      true,
+			Channel:           info.Channel,
+			MaxPrivilegeLevel: ipmi.PrivilegeLevelUser,
+		},
+	}
+	code, err = s.SendCommand(ctx, capsCmd)
+	if code != ipmi.CompletionCodeNormal {
+		return description, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	description.AuthenticationCapabilities = &capsCmd.Rsp
+
+	if info.Medium.IsLAN() && capsCmd.Rsp.SupportsV2 {
+		code, suites, err := retrieveChannelCipherSuites(ctx, s, info.Channel)
+		if err != nil {
+			return nil, err
+		}
+		if code == ipmi.CompletionCodeNormal {
+			description.CipherSuites = suites
+		}
+	}
+	return description, nil
+}
@@ -0,0 +1,115 @@
+package bmc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gebn/bmc/pkg/iana"
+	"github.com/gebn/bmc/pkg/ipmi"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/gopacket/layers"
+)
+
+// respondByChannel responds to c with the data for channel, or a truncated
+// 0xcc if there is none, as a session would.
+func respondByChannel(c ipmi.Command, channel ipmi.Channel, responses map[ipmi.Channel][]byte) (ipmi.CompletionCode, error) {
+	data, ok := responses[channel]
+	if !ok {
+		return respond(c, 0xcc, nil)
+	}
+	return respond(c, ipmi.CompletionCodeNormal, data)
+}
+
+func TestRetrieveChannels(t *testing.T) {
+	session := &fakeSession{}
+	handle(session, func(c *ipmi.GetChannelInfoCmd) (ipmi.CompletionCode, error) {
+		return respondByChannel(c, c.Req.Channel, map[ipmi.Channel][]byte{
+			0x0: {0x00, 0x01, 0x01, 0x00, 0xf2, 0x1b, 0x00, 0x00, 0x00},
+			0x1: {0x01, 0x04, 0x01, 0x81, 0xf2, 0x1b, 0x00, 0x00, 0x00},
+			// rejects Get Channel Cipher Suites
+			0x2: {0x02, 0x04, 0x01, 0x81, 0xf2, 0x1b, 0x00, 0x00, 0x00},
+		})
+	})
+	handle(session, func(c *ipmi.GetChannelAccessCmd) (ipmi.CompletionCode, error) {
+		return respondByChannel(c, c.Req.Channel, map[ipmi.Channel][]byte{
+			0x1: {0x22, 0x04},
+		})
+	})
+	handle(session, func(c *ipmi.GetChannelAuthenticationCapabilitiesCmd) (ipmi.CompletionCode, error) {
+		return respondByChannel(c, c.Req.Channel, map[ipmi.Channel][]byte{
+			0x1: {0x01, 0x80, 0x04, 0x02, 0x00, 0x00, 0x00, 0x00},
+			0x2: {0x02, 0x80, 0x04, 0x02, 0x00, 0x00, 0x00, 0x00},
+		})
+	})
+	handle(session, func(c *ipmi.GetChannelCipherSuitesCmd) (ipmi.CompletionCode, error) {
+		return respondByChannel(c, c.Req.Channel, map[ipmi.Channel][]byte{
+			0x1: {0x01, 0xc0, 0x03, 0x01, 0x41, 0x81},
+		})
+	})
+	channels, err := RetrieveChannels(context.Background(), session)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*ChannelDescription{
+		{
+			Info: &ipmi.GetChannelInfoRsp{
+				Channel:  ipmi.ChannelPrimaryIPMB,
+				Medium:   ipmi.ChannelMediumIPMB,
+				Protocol: ipmi.ChannelProtocolIPMB,
+				Vendor:   iana.Enterprise(7154),
+			},
+		},
+		{
+			Info: &ipmi.GetChannelInfoRsp{
+				Channel:        0x1,
+				Medium:         ipmi.ChannelMediumLAN,
+				Protocol:       ipmi.ChannelProtocolIPMB,
+				SessionSupport: ipmi.ChannelSessionSupportMultiSession,
+				ActiveSessions: 1,
+				Vendor:         iana.Enterprise(7154),
+			},
+			Access: &ipmi.ChannelAccess{
+				PEFAlertingDisabled: true,
+				Mode:                ipmi.ChannelAccessModeAlwaysAvailable,
+				PrivilegeLimit:      ipmi.PrivilegeLevelAdministrator,
+			},
+			AuthenticationCapabilities: &ipmi.GetChannelAuthenticationCapabilitiesRsp{
+				Channel:                 0x1,
+				ExtendedCapabilities:    true,
+				NonNullUsernamesEnabled: true,
+				SupportsV2:              true,
+			},
+			CipherSuites: []ipmi.CipherSuiteRecord{
+				{
+					CipherSuiteID: 3,
+					CipherSuite: ipmi.CipherSuite{
+						AuthenticationAlgorithm:  ipmi.AuthenticationAlgorithmHMACSHA1,
+						IntegrityAlgorithm:       ipmi.IntegrityAlgorithmHMACSHA196,
+						ConfidentialityAlgorithm: ipmi.ConfidentialityAlgorithmAESCBC128,
+					},
+				},
+			},
+		},
+		{
+			Info: &ipmi.GetChannelInfoRsp{
+				Channel:        0x2,
+				Medium:         ipmi.ChannelMediumLAN,
+				Protocol:       ipmi.ChannelProtocolIPMB,
+				SessionSupport: ipmi.ChannelSessionSupportMultiSession,
+				ActiveSessions: 1,
+				Vendor:         iana.Enterprise(7154),
+			},
+			AuthenticationCapabilities: &ipmi.GetChannelAuthenticationCapabilitiesRsp{
+				Channel:                 0x2,
+				ExtendedCapabilities:    true,
+				NonNullUsernamesEnabled: true,
+				SupportsV2:              true,
+			},
+		},
+	}
+	if diff := cmp.Diff(want, channels, cmpopts.IgnoreTypes(layers.BaseLayer{})); diff != "" {
+		t.Errorf("RetrieveChannels() = %v, want %v: %v", channels, want, diff)
+	}
+}
@@ -14,10 +14,27 @@ import (
 func RetrieveSupportedCipherSuites(ctx context.Context, s *V2SessionlessTransport) ([]ipmi.CipherSuiteRecord, error) {
 	// we only need a *V2Sessionless, however then this method has to be called
 	// with machine.V2Sessionless, rather than just machine, which is awkward
+	return RetrieveChannelCipherSuites(ctx, s, ipmi.ChannelPresentInterface)
+}
+
+// RetrieveChannelCipherSuites queries an IPMI v2.0 connection for cipher
+// suites that can be used to establish a session over a given channel. The
+// connection can be session-less, or a session established over any channel.
+func RetrieveChannelCipherSuites(ctx context.Context, c Connection, channel ipmi.Channel) ([]ipmi.CipherSuiteRecord, error) {
+	code, records, err := retrieveChannelCipherSuites(ctx, c, channel)
+	if err := ValidateResponse(code, err); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
 
+// retrieveChannelCipherSuites is like RetrieveChannelCipherSuites, but if the
+// BMC responds with a non-normal completion code, returns it rather than an
+// error, with no records.
+func retrieveChannelCipherSuites(ctx context.Context, c Connection, channel ipmi.Channel) (ipmi.CompletionCode, []ipmi.CipherSuiteRecord, error) {
 	getChannelCipherSuitesCmd := ipmi.GetChannelCipherSuitesCmd{
 		Req: ipmi.GetChannelCipherSuitesReq{
-			Channel: ipmi.ChannelPresentInterface,
+			Channel: channel,
 		},
 	}
 
@@ -25,8 +42,14 @@ func RetrieveSupportedCipherSuites(ctx context.Context, s *V2SessionlessTranspor
 	// around this, retrieve all the bytes, then process them
 	cipherSuiteRecordData := bytes.Buffer{}
 	for {
-		if err := ValidateResponse(s.SendCommand(ctx, &getChannelCipherSuitesCmd)); err != nil {
-			return nil, err
+		// BMCs typically truncate the response after a non-normal code, so
+		// this is checked before any decode error
+		code, err := c.SendCommand(ctx, &getChannelCipherSuitesCmd)
+		if code != ipmi.CompletionCodeNormal {
+			return code, nil, nil
+		}
+		if err != nil {
+			return 0, nil, err
 		}
 		cipherSuiteRecordData.Write(getChannelCipherSuitesCmd.Rsp.CipherSuiteRecordsChunk)
 		if getChannelCipherSuitesCmd.Req.ListIndex == 64 ||
@@ -35,7 +58,8 @@ func RetrieveSupportedCipherSuites(ctx context.Context, s *V2SessionlessTranspor
 		}
 		getChannelCipherSuitesCmd.Req.ListIndex++
 	}
-	return parseCipherSuiteRecordData(cipherSuiteRecordData.Bytes())
+	records, err := parseCipherSuiteRecordData(cipherSuiteRecordData.Bytes())
+	return ipmi.CompletionCodeNormal, records, err
 }
 
 // parseCipherSuiteRecordData interprets a buffer of adjacent Cipher Suite
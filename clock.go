@@ -0,0 +1,66 @@
+package bmc
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrClockNotSet is returned by ClockSkew if the SEL Device's clock has
+	// not been set, so is counting from the BMC's initialisation rather than
+	// tracking the time of day.
+	ErrClockNotSet = errors.New("the BMC's clock has not been set")
+)
+
+// ClockSkew estimates the offset of the BMC's SEL Device clock from the local
+// clock. A positive value means the BMC is ahead. The BMC's reading is
+// assumed to have been taken halfway through the Get SEL Time round trip,
+// and halfway through the second it reports, as the clock has a resolution
+// of one second. The estimate is therefore only accurate to within half a
+// second plus half the round-trip time. ErrClockNotSet is returned if the
+// BMC's clock is relative to its initialisation.
+func ClockSkew(ctx context.Context, s Session) (time.Duration, error) {
+	skew, _, err := clockSkew(ctx, s, time.Now)
+	return skew, err
+}
+
+// clockSkew implements ClockSkew, reading the local clock via now. It also
+// returns the round-trip time of the Get SEL Time command.
+func clockSkew(ctx context.Context, s Session, now func() time.Time) (time.Duration, time.Duration, error) {
+	sent := now()
+	rsp, err := s.GetSELTime(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	rtt := now().Sub(sent)
+	if rsp.IsPreInit() {
+		return 0, rtt, ErrClockNotSet
+	}
+	midpoint := sent.Add(rtt / 2)
+	return rsp.Time.Add(time.Second / 2).Sub(midpoint), rtt, nil
+}
+
+// SyncClock sets the BMC's SEL Device clock to the local time if it is not
+// within tolerance of it, or has not been set at all. It returns the skew
+// estimated by ClockSkew prior to any correction, which is 0 if the clock had
+// not been set. The new time is advanced by half the measured round-trip time
+// to account for the request's transit, and rounded to the nearest second.
+// Note the BMC may synchronise its clock from another source, e.g. the host
+// or NTP, which may subsequently overwrite the value.
+func SyncClock(ctx context.Context, s Session, tolerance time.Duration) (time.Duration, error) {
+	return syncClock(ctx, s, tolerance, time.Now)
+}
+
+// syncClock implements SyncClock, reading the local clock via now.
+func syncClock(ctx context.Context, s Session, tolerance time.Duration, now func() time.Time) (time.Duration, error) {
+	skew, rtt, err := clockSkew(ctx, s, now)
+	switch {
+	case errors.Is(err, ErrClockNotSet):
+	case err != nil:
+		return 0, err
+	case skew.Abs() <= tolerance:
+		return skew, nil
+	}
+	return skew, s.SetSELTime(ctx, now().Add(rtt/2).Round(time.Second))
+}
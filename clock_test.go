@@ -0,0 +1,118 @@
+package bmc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gebn/bmc/pkg/ipmi"
+)
+
+// clockBMC implements Get and Set SEL Time against a fake clock. Each Get SEL
+// Time advances the local clock by rtt.
+type clockBMC struct {
+	reading ipmi.ClockReading
+	local   time.Time
+	rtt     time.Duration
+	set     *time.Time
+}
+
+// session returns a session handling SEL time commands against the BMC.
+func (b *clockBMC) session() *fakeSession {
+	s := &fakeSession{}
+	handle(s, func(c *ipmi.GetSELTimeCmd) (ipmi.CompletionCode, error) {
+		b.local = b.local.Add(b.rtt)
+		c.Rsp.ClockReading = b.reading
+		return ipmi.CompletionCodeNormal, nil
+	})
+	handle(s, func(c *ipmi.SetSELTimeCmd) (ipmi.CompletionCode, error) {
+		b.set = &c.Req.Time
+		return ipmi.CompletionCodeNormal, nil
+	})
+	return s
+}
+
+func (b *clockBMC) now() time.Time {
+	return b.local
+}
+
+func TestSyncClock(t *testing.T) {
+	local := time.Unix(1700000000, 0)
+	tests := []struct {
+		name     string
+		bmc      *clockBMC
+		wantSkew time.Duration
+		wantSet  *time.Time
+	}{
+		{
+			name: "within tolerance",
+			bmc: &clockBMC{
+				// the BMC's reading of local+1s is taken 1s in, and assumed
+				// to be half way through the second
+				reading: ipmi.ClockReading{
+					Time: local.Add(time.Second),
+				},
+				local: local,
+				rtt:   time.Second * 2,
+			},
+			wantSkew: time.Second / 2,
+		},
+		{
+			name: "behind",
+			bmc: &clockBMC{
+				reading: ipmi.ClockReading{
+					Time: local.Add(-time.Minute),
+				},
+				local: local,
+				rtt:   time.Second * 2,
+			},
+			wantSkew: -time.Minute - time.Second/2,
+			wantSet:  timePtr(local.Add(time.Second * 3)),
+		},
+		{
+			name: "not set",
+			bmc: &clockBMC{
+				reading: ipmi.ClockReading{
+					SinceInit: time.Hour,
+				},
+				local: local,
+			},
+			wantSet: &local,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			skew, err := syncClock(context.Background(), test.bmc.session(),
+				time.Second, test.bmc.now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if skew != test.wantSkew {
+				t.Errorf("skew = %v, want %v", skew, test.wantSkew)
+			}
+			switch {
+			case test.wantSet == nil && test.bmc.set != nil:
+				t.Errorf("clock set to %v, want unchanged", test.bmc.set)
+			case test.wantSet != nil && test.bmc.set == nil:
+				t.Errorf("clock unchanged, want set to %v", test.wantSet)
+			case test.wantSet != nil && !test.bmc.set.Equal(*test.wantSet):
+				t.Errorf("clock set to %v, want %v", test.bmc.set, test.wantSet)
+			}
+		})
+	}
+}
+
+func TestClockSkewNotSet(t *testing.T) {
+	b := &clockBMC{
+		reading: ipmi.ClockReading{
+			SinceInit: time.Minute,
+		},
+	}
+	if _, _, err := clockSkew(context.Background(), b.session(), b.now); err != ErrClockNotSet {
+		t.Errorf("err = %v, want %v", err, ErrClockNotSet)
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
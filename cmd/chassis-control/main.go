@@ -1,7 +1,8 @@
 package main
 
 // chassis-control sends a chassis control command to a system, e.g. to power it
-// on, or do a hard reset.
+// on, or do a hard reset. It can also blink the chassis identify light, and
+// report whether it is on.
 
 import (
 	"context"
@@ -19,7 +20,7 @@ var (
 	argBMCAddr = kingpin.Arg("addr", "IP[:port] of the BMC to control.").
 			Required().
 			String()
-	argCommand = kingpin.Arg("command", "The command to send (on/off/cycle/reset/interrupt/softoff).").
+	argCommand = kingpin.Arg("command", "The command to send (on/off/cycle/reset/interrupt/softoff/identify/identify-status).").
 			Required().
 			String()
 	flgUsername = kingpin.Flag("username", "The username to connect as.").
@@ -28,6 +29,13 @@ var (
 	flgPassword = kingpin.Flag("password", "The password of the user to connect as.").
 			Required().
 			String()
+	flgBootDevice = kingpin.Flag("boot-device", "Override the device booted from next time (pxe/disk/safe/diag/cdrom/bios).").
+			String()
+	flgIdentifyInterval = kingpin.Flag("identify-interval", "How long the identify command turns the light on for; 0 turns it off.").
+				Default("15s").
+				Duration()
+	flgIdentifyForce = kingpin.Flag("identify-force", "Turn the identify light on indefinitely.").
+				Bool()
 
 	cmdControls = map[string]ipmi.ChassisControl{
 		"off":       ipmi.ChassisControlPowerOff,
@@ -37,6 +45,15 @@ var (
 		"interrupt": ipmi.ChassisControlDiagnosticInterrupt,
 		"softoff":   ipmi.ChassisControlSoftPowerOff,
 	}
+
+	bootDevices = map[string]ipmi.BootDevice{
+		"pxe":   ipmi.BootDevicePXE,
+		"disk":  ipmi.BootDeviceDisk,
+		"safe":  ipmi.BootDeviceDiskSafeMode,
+		"diag":  ipmi.BootDeviceDiagnosticPartition,
+		"cdrom": ipmi.BootDeviceCDROM,
+		"bios":  ipmi.BootDeviceBIOSSetup,
+	}
 )
 
 func lookupCommand(cmd string) (ipmi.ChassisControl, error) {
@@ -46,6 +63,13 @@ func lookupCommand(cmd string) (ipmi.ChassisControl, error) {
 	return ipmi.ChassisControlPowerOff, fmt.Errorf("invalid command: %v", cmd)
 }
 
+func lookupBootDevice(device string) (ipmi.BootDevice, error) {
+	if d, ok := bootDevices[device]; ok {
+		return d, nil
+	}
+	return ipmi.BootDeviceNone, fmt.Errorf("invalid boot device: %v", device)
+}
+
 func main() {
 	kingpin.Parse()
 
@@ -70,11 +94,53 @@ func main() {
 	}
 	defer sess.Close(ctx)
 
+	switch *argCommand {
+	case "identify":
+		if err := sess.ChassisIdentify(ctx, *flgIdentifyInterval,
+			*flgIdentifyForce); err != nil {
+			log.Fatal(err)
+		}
+		fallthrough
+	case "identify-status":
+		if err := reportIdentifyState(ctx, sess); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	cmd, err := lookupCommand(*argCommand)
 	if err != nil {
 		log.Fatal(err)
 	}
+	if *flgBootDevice != "" {
+		device, err := lookupBootDevice(*flgBootDevice)
+		if err != nil {
+			log.Fatal(err)
+		}
+		// must be set shortly before the power cycle/reset, as the BMC clears
+		// the flags after 60 seconds
+		if err := sess.SetBootDevice(ctx, &ipmi.BootFlags{
+			Device: device,
+		}); err != nil {
+			log.Fatal(err)
+		}
+	}
 	if err := sess.ChassisControl(ctx, cmd); err != nil {
 		log.Fatal(err)
 	}
 }
+
+// reportIdentifyState logs the state of the chassis identify light, if the BMC
+// reveals it.
+func reportIdentifyState(ctx context.Context, sess bmc.Session) error {
+	status, err := sess.GetChassisStatus(ctx)
+	if err != nil {
+		return err
+	}
+	if status.ChassisIdentifyState == ipmi.ChassisIdentifyStateUnknown {
+		log.Print("the BMC does not report the identify state")
+		return nil
+	}
+	log.Printf("identify state: %v", status.ChassisIdentifyState.Description())
+	return nil
+}
@@ -1,8 +1,8 @@
 package main
 
 // Describe shows various information about a BMC using the ASF Presence Pong,
-// Get Channel Authentication Capabilities, Get System GUID and Get Device ID
-// commands.
+// Get Channel Authentication Capabilities, Get System GUID, Get Device ID and
+// Get System Info Parameters commands.
 
 import (
 	"context"
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"log"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/gebn/bmc"
@@ -96,6 +97,12 @@ func run(ctx context.Context) error {
 		printDeviceID(id)
 	}
 
+	if info, err := bmc.GetSystemInfo(ctx, sess); err != nil {
+		log.Printf("failed to get system info: %v", err)
+	} else {
+		printSystemInfo(info)
+	}
+
 	if status, err := sess.GetChassisStatus(ctx); err != nil {
 		log.Printf("failed to get chassis status: %v", err)
 	} else {
@@ -115,10 +122,19 @@ func run(ctx context.Context) error {
 	})
 	fmt.Println("Sensors:")
 	for _, recordID := range recordIDs {
-		fsr := repo[recordID]
+		record := repo[recordID]
+		if _, outputType := record.Kind(); outputType.IsDiscrete() {
+			printDiscreteSensor(ctx, sess, record)
+			continue
+		}
+		fsr, ok := record.(*ipmi.FullSensorRecord)
+		if !ok {
+			// compact and event-only records cannot be analog
+			fmt.Printf("\t%-19v not analog\n", record.Name())
+			continue
+		}
 		reader, err := bmc.NewSensorReader(fsr)
 		if err != nil {
-			// e.g. chassis intrusion
 			fmt.Printf("\t%-19v not analog\n", fsr.Identity)
 			continue
 		}
@@ -174,6 +190,31 @@ func run(ctx context.Context) error {
 	return nil
 }
 
+// printDiscreteSensor prints the states currently asserted by a discrete
+// sensor, e.g. chassis intrusion.
+func printDiscreteSensor(ctx context.Context, sess bmc.Session, record ipmi.SensorRecord) {
+	reader, err := bmc.NewDiscreteSensorReader(record)
+	if err != nil {
+		// e.g. event-only
+		fmt.Printf("\t%-19v no reading\n", record.Name())
+		return
+	}
+	states, err := reader.Read(ctx, sess)
+	switch err {
+	case nil:
+		descriptions := make([]string, 0, len(states))
+		for _, state := range states {
+			descriptions = append(descriptions, state.Description)
+		}
+		fmt.Printf("\t%-19v [%v]\n", record.Name(),
+			strings.Join(descriptions, ", "))
+	case bmc.ErrSensorScanningDisabled:
+		fmt.Printf("\t%-19v disabled\n", record.Name())
+	default:
+		fmt.Printf("\t%-19v no reading/missing (%v)\n", record.Name(), err)
+	}
+}
+
 func presencePing(ctx context.Context, t transport.Transport) (*layers.ASFPresencePong, error) {
 	asfRmcp := &layers.RMCP{
 		Version:  layers.RMCPVersion1,
@@ -192,19 +233,23 @@ func presencePing(ctx context.Context, t transport.Transport) (*layers.ASFPresen
 	if err := gopacket.SerializeLayers(buf, opts, asfRmcp, asf); err != nil {
 		return nil, err
 	}
-	bytes, err := t.Send(ctx, buf.Bytes())
-	if err != nil {
+	pong := (*layers.ASFPresencePong)(nil)
+	response := [transport.MaxPacketSize]byte{}
+	if _, err := t.Send(ctx, buf.Bytes(), response[:], func(b []byte) bool {
+		packet := gopacket.NewPacket(b, layers.LayerTypeRMCP, gopacket.DecodeOptions{
+			Lazy:   true,
+			NoCopy: true,
+		})
+		pongLayer := packet.Layer(layers.LayerTypeASFPresencePong)
+		if pongLayer == nil {
+			return false
+		}
+		pong = pongLayer.(*layers.ASFPresencePong)
+		return true
+	}); err != nil {
 		return nil, err
 	}
-	packet := gopacket.NewPacket(bytes, layers.LayerTypeRMCP, gopacket.DecodeOptions{
-		Lazy:   true,
-		NoCopy: true,
-	})
-	pongLayer := packet.Layer(layers.LayerTypeASFPresencePong)
-	if pongLayer == nil {
-		return nil, fmt.Errorf("no presence pong layer in response")
-	}
-	return pongLayer.(*layers.ASFPresencePong), nil
+	return pong, nil
 }
 
 func printPong(p *layers.ASFPresencePong) {
@@ -306,6 +351,17 @@ func printDeviceID(id *ipmi.GetDeviceIDRsp) {
 	fmt.Printf("\tFirmware:           %v\n", bmc.FirmwareVersion(id))
 }
 
+func printSystemInfo(info *bmc.SystemInfo) {
+	fmt.Println("System info:")
+	fmt.Printf("\tSystem name:        %v\n", info.SystemName)
+	fmt.Printf("\tFirmware version:   %v\n", info.FirmwareVersion)
+	fmt.Printf("\tPrimary OS name:    %v\n", info.PrimaryOSName)
+	fmt.Printf("\tOS name:            %v\n", info.OSName)
+	fmt.Printf("\tOS version:         %v\n", info.OSVersion)
+	fmt.Printf("\tBMC URL:            %v\n", info.BMCURL)
+	fmt.Printf("\tHypervisor URL:     %v\n", info.HypervisorURL)
+}
+
 func printChassisStatus(status *ipmi.GetChassisStatusRsp) {
 	fmt.Println("Chassis:")
 	fmt.Printf("\tPowered on:         %v\n", status.PoweredOn)
@@ -329,9 +385,9 @@ func printPowerReading(r *dcmi.GetPowerReadingRsp) {
 
 func printRecords(records []ipmi.RecordID, repo bmc.SDRRepository) {
 	for _, record := range records {
-		fsr, ok := repo[record]
+		sensor, ok := repo[record]
 		if ok {
-			fmt.Printf("\t\t%v (%v)\n", fsr.Identity, record)
+			fmt.Printf("\t\t%v (%v)\n", sensor.Name(), record)
 		} else {
 			fmt.Printf("\t\tUnknown: %v\n", record)
 		}
@@ -0,0 +1,154 @@
+package main
+
+// sol attaches the terminal to a system's serial console using Serial over
+// LAN. Type ~. at the start of a line to disconnect, or ~B to send a break.
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/gebn/bmc"
+	"github.com/gebn/bmc/pkg/ipmi"
+
+	"github.com/alecthomas/kingpin"
+	"golang.org/x/term"
+)
+
+var (
+	argBMCAddr = kingpin.Arg("addr", "IP[:port] of the BMC whose console to attach to.").
+			Required().
+			String()
+	flgUsername = kingpin.Flag("username", "The username to connect as.").
+			Required().
+			String()
+	flgPassword = kingpin.Flag("password", "The password of the user to connect as.").
+			Required().
+			String()
+	flgInstance = kingpin.Flag("instance", "The SOL payload instance to activate.").
+			Default("1").
+			Uint8()
+)
+
+func main() {
+	// call into run() so that we can use log.Fatal() to set a non-zero exit code,
+	// while still allowing run()'s deferred calls to close the session and
+	// transport before exiting
+	if err := run(context.Background()); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(ctx context.Context) error {
+	kingpin.Parse()
+
+	machine, err := bmc.DialV2(*argBMCAddr)
+	if err != nil {
+		return err
+	}
+	defer machine.Close()
+
+	setupCtx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	sess, err := machine.NewV2Session(setupCtx, &bmc.V2SessionOpts{
+		SessionOpts: bmc.SessionOpts{
+			Username:          *flgUsername,
+			Password:          []byte(*flgPassword),
+			MaxPrivilegeLevel: ipmi.PrivilegeLevelUser,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		closeCtx, cancel := context.WithTimeout(ctx, time.Second*5)
+		defer cancel()
+		sess.Close(closeCtx)
+	}()
+
+	console, err := sess.ActivateSOL(setupCtx, *flgInstance)
+	if err != nil {
+		return err
+	}
+	defer console.Close()
+
+	log.Printf("attached to %v; type ~. to disconnect", machine.Address())
+
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		state, err := term.MakeRaw(int(os.Stdin.Fd()))
+		if err != nil {
+			return err
+		}
+		defer term.Restore(int(os.Stdin.Fd()), state)
+	}
+
+	output := make(chan error, 1)
+	go func() {
+		_, err := io.Copy(os.Stdout, console)
+		output <- err
+	}()
+	input := make(chan error, 1)
+	go func() {
+		input <- copyInput(console, os.Stdin)
+	}()
+
+	select {
+	case err := <-output:
+		// the BMC deactivated the console
+		return err
+	case err := <-input:
+		return err
+	}
+}
+
+// copyInput sends keystrokes to the console until ~. is typed at the start of
+// a line, or input ends. ~B sends a break, and ~~ sends a literal tilde.
+func copyInput(console *bmc.SOLConsole, r io.Reader) error {
+	buf := make([]byte, 256)
+	lineStart := true
+	escaped := false
+	for {
+		n, err := r.Read(buf)
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		out := bytes.Buffer{}
+		for _, b := range buf[:n] {
+			if escaped {
+				escaped = false
+				switch b {
+				case '.':
+					_, err := console.Write(out.Bytes())
+					return err
+				case 'B':
+					if _, err := console.Write(out.Bytes()); err != nil {
+						return err
+					}
+					out.Reset()
+					if err := console.Break(); err != nil {
+						return err
+					}
+					continue
+				case '~':
+				default:
+					out.WriteByte('~')
+				}
+			} else if lineStart && b == '~' {
+				escaped = true
+				continue
+			}
+			out.WriteByte(b)
+			lineStart = b == '\r' || b == '\n'
+		}
+		if _, err := console.Write(out.Bytes()); err != nil {
+			return err
+		}
+	}
+}
@@ -7,14 +7,39 @@ import (
 	"github.com/gebn/bmc/pkg/layerexts"
 )
 
-func algorithmCipher(a ipmi.ConfidentialityAlgorithm, g AdditionalKeyMaterialGenerator) (layerexts.SerializableDecodingLayer, error) {
+// algorithmCipher creates n confidentiality layers from the provided IPMI v2.0
+// algorithm, one for each user of a session, e.g. its slots. The layers are
+// nil if the algorithm is ConfidentialityAlgorithmNone.
+func algorithmCipher(a ipmi.ConfidentialityAlgorithm, g AdditionalKeyMaterialGenerator, n int) ([]layerexts.SerializableDecodingLayer, error) {
+	layers := make([]layerexts.SerializableDecodingLayer, n)
+	key := [16]byte{}
+	copy(key[:], g.K(2))
 	switch a {
 	case ipmi.ConfidentialityAlgorithmNone:
-		return nil, nil
+		return layers, nil
 	case ipmi.ConfidentialityAlgorithmAESCBC128:
-		key := [16]byte{}
-		copy(key[:], g.K(2))
-		return ipmi.NewAES128CBC(key)
+		for i := range layers {
+			// AES-CBC-128 is stateless between packets, so the layers are
+			// independent
+			layer, err := ipmi.NewAES128CBC(key)
+			if err != nil {
+				return nil, err
+			}
+			layers[i] = layer
+		}
+		return layers, nil
+	case ipmi.ConfidentialityAlgorithmXRC4128, ipmi.ConfidentialityAlgorithmXRC440:
+		// everything the BMC sends within the session is encrypted using a
+		// single keystream, so the layers must share it
+		first := ipmi.NewXRC4128(key)
+		if a == ipmi.ConfidentialityAlgorithmXRC440 {
+			first = ipmi.NewXRC440(key)
+		}
+		layers[0] = first
+		for i := 1; i < len(layers); i++ {
+			layers[i] = first.Fork()
+		}
+		return layers, nil
 	default:
 		return nil, fmt.Errorf("unsupported confidentiality algorithm: %v", a)
 	}
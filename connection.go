@@ -160,6 +160,15 @@ type Connection interface {
 	// Prometheus exporter. If you don't need this performance, for the sake of
 	// one more allocation per command, it is recommended to use the
 	// higher-level API, e.g. GetSystemGUID(), which wraps this.
+	//
+	// This method is safe for concurrent use, including across a session-less
+	// connection and the sessions created from it. Responses are matched to
+	// requests by session ID, message sequence number, network function and
+	// command; other packets, such as late responses to earlier attempts that
+	// timed out, are discarded while waiting for the response. As BMCs have
+	// very little buffer space, only two requests are in flight to a given BMC
+	// at once; further calls block until one completes or their context
+	// expires.
 	SendCommand(ctx context.Context, cmd ipmi.Command) (ipmi.CompletionCode, error)
 
 	// Version returns the underlying IPMI version of the connection, either
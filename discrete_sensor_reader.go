@@ -0,0 +1,84 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gebn/bmc/pkg/ipmi"
+)
+
+// SensorState is a state asserted by a discrete sensor.
+type SensorState struct {
+
+	// Offset is the position of the state in the Get Sensor Reading response.
+	// Its meaning depends on the sensor's Event/Reading Type Code and, for
+	// sensor-specific codes, its sensor type.
+	Offset uint8
+
+	// Description is a human-readable name for the state, e.g. "Power Supply
+	// Failure detected". This is "Unknown" for OEM and reserved offsets.
+	Description string
+}
+
+func (s SensorState) String() string {
+	return fmt.Sprintf("%v(%v)", s.Offset, s.Description)
+}
+
+// DiscreteSensorReader reads the states asserted by a discrete sensor, e.g. a
+// power supply or chassis intrusion sensor. This is the discrete counterpart
+// of SensorReader.
+type DiscreteSensorReader struct {
+	readingCmd ipmi.GetSensorReadingCmd
+	outputType ipmi.OutputType
+	sensorType ipmi.SensorType
+}
+
+// NewDiscreteSensorReader returns a reader for the sensor described by an SDR.
+// Shared records should be expanded first, otherwise only the first sensor
+// will be read. It returns an error if the sensor is not discrete, or the
+// record is an Event-Only Record, as these sensors cannot be read.
+func NewDiscreteSensorReader(r ipmi.SensorRecord) (*DiscreteSensorReader, error) {
+	if _, ok := r.(*ipmi.EventOnlyRecord); ok {
+		return nil, fmt.Errorf("event-only sensor %v cannot be read", r.Name())
+	}
+	sensorType, outputType := r.Kind()
+	if !outputType.IsDiscrete() {
+		return nil, fmt.Errorf("sensor %v is not discrete: %v", r.Name(),
+			outputType)
+	}
+	key := r.Key()
+	return &DiscreteSensorReader{
+		readingCmd: ipmi.GetSensorReadingCmd{
+			Req: ipmi.GetSensorReadingReq{
+				Number: key.Number,
+			},
+			OwnerLUN: key.OwnerLUN,
+		},
+		outputType: outputType,
+		sensorType: sensorType,
+	}, nil
+}
+
+// Read returns the states currently asserted by the sensor, in ascending order
+// of offset. Like SensorReader, it returns ErrSensorReadingUnavailable or
+// ErrSensorScanningDisabled if the BMC indicates the states should be ignored.
+func (r *DiscreteSensorReader) Read(ctx context.Context, s Session) ([]SensorState, error) {
+	if err := ValidateResponse(s.SendCommand(ctx, &r.readingCmd)); err != nil {
+		return nil, err
+	}
+	if r.readingCmd.Rsp.ReadingUnavailable {
+		return nil, ErrSensorReadingUnavailable
+	}
+	if !r.readingCmd.Rsp.ScanningEnabled {
+		return nil, ErrSensorScanningDisabled
+	}
+	offsets := r.readingCmd.Rsp.AssertedStates()
+	states := make([]SensorState, 0, len(offsets))
+	for _, offset := range offsets {
+		states = append(states, SensorState{
+			Offset:      offset,
+			Description: r.outputType.StateDescription(r.sensorType, offset),
+		})
+	}
+	return states, nil
+}
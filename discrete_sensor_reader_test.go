@@ -0,0 +1,49 @@
+package bmc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gebn/bmc/pkg/ipmi"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiscreteSensorReaderRead(t *testing.T) {
+	record := &ipmi.CompactSensorRecord{
+		SensorType: ipmi.SensorTypePowerSupply,
+		OutputType: ipmi.OutputTypeSensorSpecific,
+		Identity:   "PSU2",
+	}
+	reader, err := NewDiscreteSensorReader(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeSession{}
+	handle(s, func(c *ipmi.GetSensorReadingCmd) (ipmi.CompletionCode, error) {
+		// scanning enabled, presence detected and failure detected
+		return respond(c, ipmi.CompletionCodeNormal,
+			[]byte{0x00, 0x40, 0x03, 0x80})
+	})
+	states, err := reader.Read(context.Background(), s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []SensorState{
+		{0, "Presence detected"},
+		{1, "Power Supply Failure detected"},
+	}
+	if diff := cmp.Diff(want, states); diff != "" {
+		t.Errorf("Read() = %v, want %v: %v", states, want, diff)
+	}
+}
+
+func TestNewDiscreteSensorReaderThreshold(t *testing.T) {
+	record := &ipmi.CompactSensorRecord{
+		SensorType: ipmi.SensorTypeTemperature,
+		OutputType: ipmi.OutputTypeThreshold,
+	}
+	if _, err := NewDiscreteSensorReader(record); err == nil {
+		t.Error("expected error creating reader for threshold sensor")
+	}
+}
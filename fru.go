@@ -0,0 +1,152 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gebn/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket"
+)
+
+const (
+	// fruReadSizeMax is the number of bytes initially requested by each Read
+	// FRU Data command. This is halved each time the BMC indicates it is too
+	// large. 32 bytes is widely supported, and anything larger offers little
+	// benefit given inventory areas are rarely more than a few hundred bytes.
+	fruReadSizeMax = 32
+)
+
+// FRU is a parsed FRU device inventory area, specified in the Platform
+// Management FRU Information Storage Definition v1.0. Areas not present on the
+// device are nil.
+type FRU struct {
+	Header       *ipmi.FRUCommonHeader
+	Chassis      *ipmi.FRUChassisInfoArea
+	Board        *ipmi.FRUBoardInfoArea
+	Product      *ipmi.FRUProductInfoArea
+	MultiRecords []*ipmi.FRUMultiRecord
+}
+
+// RetrieveFRU reads and parses the inventory area of a FRU device. Device 0 is
+// the FRU device of the BMC itself, which typically describes the system.
+func RetrieveFRU(ctx context.Context, s Session, deviceID uint8) (*FRU, error) {
+	data, err := ReadFRUInventory(ctx, s, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeFRU(data)
+}
+
+// ReadFRUInventory reads the entire inventory area of a FRU device, using as
+// many Read FRU Data commands as required. If the BMC indicates a read is too
+// large, the read size is reduced.
+func ReadFRUInventory(ctx context.Context, s Session, deviceID uint8) ([]byte, error) {
+	info, err := s.GetFRUInventoryAreaInfo(ctx, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	// the unit of the offset and count fields in bytes
+	unit := 1
+	if info.WordAccess {
+		unit = 2
+	}
+	size := int(info.Size)
+	data := make([]byte, 0, size+unit)
+	readSize := fruReadSizeMax
+	readFRUDataCmd := &ipmi.ReadFRUDataCmd{
+		Req: ipmi.ReadFRUDataReq{
+			DeviceID: deviceID,
+		},
+	}
+	for len(data) < size {
+		count := min(readSize, size-len(data))
+		readFRUDataCmd.Req.Offset = uint16(len(data) / unit)
+		readFRUDataCmd.Req.Count = uint8((count + unit - 1) / unit)
+		// BMCs typically truncate the response after a non-normal code, so
+		// the code is checked before any decode error
+		code, err := s.SendCommand(ctx, readFRUDataCmd)
+		switch code {
+		case ipmi.CompletionCodeNormal:
+			if err != nil {
+				return nil, err
+			}
+		case ipmi.CompletionCodeCannotReturnRequestedDataBytes,
+			ipmi.CompletionCodeRequestDataFieldLengthLimitExceeded,
+			ipmi.CompletionCodeRequestDataLengthInvalid:
+			if readSize <= unit {
+				return nil, fmt.Errorf("BMC cannot return FRU data even "+
+					"when reading %v bytes: %v", readSize, code)
+			}
+			readSize /= 2
+			continue
+		default:
+			return nil, ValidateResponse(code, nil)
+		}
+		returned := int(readFRUDataCmd.Rsp.Count) * unit
+		if returned == 0 || returned > len(readFRUDataCmd.Rsp.Payload) {
+			return nil, fmt.Errorf("invalid Read FRU Data response: %v",
+				readFRUDataCmd)
+		}
+		data = append(data, readFRUDataCmd.Rsp.Payload[:returned]...)
+	}
+	// with word access, the final read may overshoot by a byte
+	return data[:size], nil
+}
+
+// DecodeFRU parses a FRU device's inventory area, as returned by
+// ReadFRUInventory(). The internal use area is opaque, so is ignored.
+func DecodeFRU(data []byte) (*FRU, error) {
+	fru := &FRU{
+		Header: &ipmi.FRUCommonHeader{},
+	}
+	if err := fru.Header.DecodeFromBytes(data, gopacket.NilDecodeFeedback); err != nil {
+		return nil, err
+	}
+	if offset := fru.Header.ChassisInfoOffset; offset != 0 {
+		fru.Chassis = &ipmi.FRUChassisInfoArea{}
+		if err := decodeFRUArea(data, offset, fru.Chassis); err != nil {
+			return nil, err
+		}
+	}
+	if offset := fru.Header.BoardInfoOffset; offset != 0 {
+		fru.Board = &ipmi.FRUBoardInfoArea{}
+		if err := decodeFRUArea(data, offset, fru.Board); err != nil {
+			return nil, err
+		}
+	}
+	if offset := fru.Header.ProductInfoOffset; offset != 0 {
+		fru.Product = &ipmi.FRUProductInfoArea{}
+		if err := decodeFRUArea(data, offset, fru.Product); err != nil {
+			return nil, err
+		}
+	}
+	if offset := int(fru.Header.MultiRecordOffset); offset != 0 {
+		for {
+			if offset >= len(data) {
+				return nil, fmt.Errorf("MultiRecord offset %v exceeds "+
+					"inventory size %v", offset, len(data))
+			}
+			record := &ipmi.FRUMultiRecord{}
+			if err := record.DecodeFromBytes(data[offset:], gopacket.NilDecodeFeedback); err != nil {
+				return nil, err
+			}
+			fru.MultiRecords = append(fru.MultiRecords, record)
+			if record.EndOfList {
+				break
+			}
+			offset += len(record.Contents) + len(record.Payload)
+		}
+	}
+	return fru, nil
+}
+
+// decodeFRUArea decodes the info area at an offset into the inventory area
+// into the provided layer.
+func decodeFRUArea(data []byte, offset uint16, layer gopacket.DecodingLayer) error {
+	if int(offset) >= len(data) {
+		return fmt.Errorf("info area offset %v exceeds inventory size %v",
+			offset, len(data))
+	}
+	return layer.DecodeFromBytes(data[offset:], gopacket.NilDecodeFeedback)
+}
@@ -0,0 +1,86 @@
+package bmc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gebn/bmc/pkg/ipmi"
+)
+
+var (
+	// testFRUInventory contains a common header, chassis, board and product
+	// info areas, and two MultiRecords.
+	testFRUInventory = []byte{
+		// common header
+		0x01, 0x00, 0x01, 0x05, 0x09, 0x0e, 0x00, 0xe2,
+		// chassis info area
+		0x01, 0x04, 0x17, 0xc6, 0x43, 0x48, 0x53, 0x2d, 0x30, 0x31, 0xc6, 0x43,
+		0x5a, 0x31, 0x32, 0x33, 0x34, 0xc6, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d,
+		0xc1, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x63,
+		// board info area
+		0x01, 0x04, 0x00, 0xb2, 0x9f, 0xc0, 0xc4, 0x41, 0x63, 0x6d, 0x65, 0xc3,
+		0x58, 0x31, 0x31, 0xc4, 0x42, 0x53, 0x4e, 0x31, 0x42, 0x12, 0x34, 0xc0,
+		0xc1, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x52,
+		// product info area
+		0x01, 0x05, 0x00, 0xc4, 0x41, 0x63, 0x6d, 0x65, 0xc6, 0x53, 0x65, 0x72,
+		0x76, 0x65, 0x72, 0xc4, 0x50, 0x4e, 0x2d, 0x39, 0xc3, 0x31, 0x2e, 0x30,
+		0xc4, 0x50, 0x53, 0x4e, 0x31, 0xc0, 0xc0, 0xc1, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x42,
+		// MultiRecords
+		0x00, 0x02, 0x03, 0xfa, 0x01, 0x01, 0x02, 0x03,
+		0xc0, 0x82, 0x01, 0x56, 0x67, 0xaa,
+	}
+)
+
+func TestReadFRUInventoryShrinksReads(t *testing.T) {
+	s := &fakeSession{}
+	handle(s, func(c *ipmi.GetFRUInventoryAreaInfoCmd) (ipmi.CompletionCode, error) {
+		c.Rsp.Size = uint16(len(testFRUInventory))
+		return ipmi.CompletionCodeNormal, nil
+	})
+	// reads larger than 10 bytes are rejected with a truncated response
+	reads := 0
+	handle(s, func(c *ipmi.ReadFRUDataCmd) (ipmi.CompletionCode, error) {
+		if c.Req.Count > 10 {
+			return respond(c, ipmi.CompletionCodeCannotReturnRequestedDataBytes,
+				nil)
+		}
+		reads++
+		data := testFRUInventory[c.Req.Offset:]
+		data = data[:min(len(data), int(c.Req.Count))]
+		return respond(c, ipmi.CompletionCodeNormal,
+			append([]byte{uint8(len(data))}, data...))
+	})
+	got, err := ReadFRUInventory(context.Background(), s, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(testFRUInventory) {
+		t.Errorf("read %v, want %v", got, testFRUInventory)
+	}
+	// 32 and 16 byte reads are rejected, so 8 bytes are read at a time
+	if want := (len(testFRUInventory) + 7) / 8; reads != want {
+		t.Errorf("inventory read in %v commands, want %v", reads, want)
+	}
+}
+
+func TestDecodeFRU(t *testing.T) {
+	fru, err := DecodeFRU(testFRUInventory)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fru.Chassis == nil || fru.Chassis.Type != ipmi.ChassisTypeRackMount ||
+		fru.Chassis.SerialNumber != "CZ1234" {
+		t.Errorf("chassis = %+v, want rack mount with serial CZ1234", fru.Chassis)
+	}
+	if fru.Board == nil || fru.Board.PartNumber != "1234" {
+		t.Errorf("board = %+v, want part number 1234", fru.Board)
+	}
+	if fru.Product == nil || fru.Product.Name != "Server" ||
+		fru.Product.SerialNumber != "PSN1" {
+		t.Errorf("product = %+v, want Server with serial PSN1", fru.Product)
+	}
+	if len(fru.MultiRecords) != 2 || !fru.MultiRecords[1].EndOfList {
+		t.Errorf("MultiRecords = %v, want 2 ending the list", fru.MultiRecords)
+	}
+}
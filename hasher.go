@@ -5,6 +5,7 @@ import (
 	"crypto/md5"
 	"crypto/sha1"
 	"crypto/sha256"
+	"encoding"
 	"fmt"
 	"hash"
 
@@ -13,8 +14,9 @@ import (
 
 // algorithmHasher creates a Hash from the provided IPMI V2.0 algorithm, to be
 // used to sign packets with the Authenticated flag set to true. Note that not
-// all algorithms are authenticated, e.g. MD5-128.
-func algorithmHasher(i ipmi.IntegrityAlgorithm, g AdditionalKeyMaterialGenerator) (hash.Hash, error) {
+// all algorithms are HMACs, e.g. MD5-128, which uses the user's password
+// rather than K_1.
+func algorithmHasher(i ipmi.IntegrityAlgorithm, g AdditionalKeyMaterialGenerator, password []byte) (hash.Hash, error) {
 	switch i {
 	case ipmi.IntegrityAlgorithmNone:
 		return nil, nil
@@ -25,9 +27,8 @@ func algorithmHasher(i ipmi.IntegrityAlgorithm, g AdditionalKeyMaterialGenerator
 		}, nil
 	case ipmi.IntegrityAlgorithmHMACMD5128:
 		return hmac.New(md5.New, g.K(1)), nil
-	//case ipmi.IntegrityAlgorithmMD5128:
-	//    // this is a special case, needing the user password
-	//    // TODO implement if needed
+	case ipmi.IntegrityAlgorithmMD5128:
+		return newMD5128(password), nil
 	case ipmi.IntegrityAlgorithmHMACSHA256128:
 		return truncatedHash{
 			Hash:   hmac.New(sha256.New, g.K(1)),
@@ -37,3 +38,37 @@ func algorithmHasher(i ipmi.IntegrityAlgorithm, g AdditionalKeyMaterialGenerator
 		return nil, fmt.Errorf("unsupported integrity algorithm: %v", i)
 	}
 }
+
+// md5128 implements the MD5-128 integrity algorithm, specified in section
+// 13.28.3 of IPMI v2.0. The AuthCode is the MD5 of the user's password, the
+// data, then the password again, where the password is padded with 0x00 to 20
+// bytes.
+type md5128 struct {
+	hash.Hash
+	password [20]byte
+}
+
+func newMD5128(password []byte) *md5128 {
+	m := &md5128{
+		Hash: md5.New(),
+	}
+	copy(m.password[:], password)
+	m.Hash.Write(m.password[:])
+	return m
+}
+
+// Sum appends the AuthCode of the data written so far to b. As with other
+// hashes, it does not change the underlying state.
+func (m *md5128) Sum(b []byte) []byte {
+	// MD5's state can always be marshalled, so we ignore errors
+	state, _ := m.Hash.(encoding.BinaryMarshaler).MarshalBinary()
+	m.Hash.Write(m.password[:])
+	sum := m.Hash.Sum(b)
+	_ = m.Hash.(encoding.BinaryUnmarshaler).UnmarshalBinary(state)
+	return sum
+}
+
+func (m *md5128) Reset() {
+	m.Hash.Reset()
+	m.Hash.Write(m.password[:])
+}
@@ -0,0 +1,32 @@
+package bmc
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMD5128(t *testing.T) {
+	h := newMD5128([]byte("password"))
+	data := []byte{0x81, 0x1c, 0x63, 0x20, 0x04, 0x37, 0x85}
+	// generated with coreutils' md5sum, over "password" padded with 0x00 to 20
+	// bytes, the data, then the padded password again
+	want := []byte{
+		0xaf, 0x74, 0x37, 0x43, 0x4a, 0xfe, 0xd1, 0x74, 0xab, 0x0b,
+		0xcc, 0x36, 0x72, 0x69, 0xb4, 0x88,
+	}
+
+	h.Write(data)
+	if got := h.Sum(nil); !bytes.Equal(got, want) {
+		t.Errorf("Sum() = %v, want %v", got, want)
+	}
+	// Sum() must not change the state
+	if got := h.Sum(nil); !bytes.Equal(got, want) {
+		t.Errorf("second Sum() = %v, want %v", got, want)
+	}
+
+	h.Reset()
+	h.Write(data)
+	if got := h.Sum(nil); !bytes.Equal(got, want) {
+		t.Errorf("Sum() after Reset() = %v, want %v", got, want)
+	}
+}
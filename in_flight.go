@@ -0,0 +1,81 @@
+package bmc
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/gebn/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket"
+)
+
+// packetBufferDepth is the maximum number of requests we allow to be in flight
+// to a single BMC at once. BMCs are only recommended to have a packet buffer
+// of length 2 (6.10.1, v2.0); sending more risks packets being silently
+// dropped, which we would only discover after the per-attempt timeout.
+const packetBufferDepth = 2
+
+// slotTokens limits the number of requests in flight to a BMC. Each connection
+// has packetBufferDepth slots, containing the state required to send a request
+// and receive its response. A token is the index of a slot; a request must
+// receive a token before using the corresponding slot of its connection, and
+// return it when done. A session-less connection shares its tokens with all
+// sessions created from it, so the limit applies to the BMC as a whole, and a
+// slot is never used by two requests at once.
+type slotTokens chan int
+
+func newSlotTokens() slotTokens {
+	t := make(slotTokens, packetBufferDepth)
+	for i := 0; i < packetBufferDepth; i++ {
+		t <- i
+	}
+	return t
+}
+
+// acquire blocks until a slot is available or the context expires. If no error
+// is returned, the token must be released.
+func (t slotTokens) acquire(ctx context.Context) (int, error) {
+	select {
+	case token := <-t:
+		return token, nil
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
+func (t slotTokens) release(token int) {
+	t <- token
+}
+
+// decodeResponse decodes the payload of a response received into a slot.
+// Layers may refer to the data they were decoded from, e.g. in their Payload
+// or Data fields, and the slot's buffer is reused by the next request once its
+// token is released, so the layer is given a copy it owns.
+func decodeResponse(l gopacket.DecodingLayer, payload []byte) error {
+	return l.DecodeFromBytes(append([]byte(nil), payload...),
+		gopacket.NilDecodeFeedback)
+}
+
+// messageSequence allocates IPMI message sequence numbers (rqSeq) to requests.
+// Each request gets the next number, wrapping at 64 as it is a 6-bit field, so
+// a late response to an earlier request that timed out will not be mistaken
+// for the response to the current one. As at most packetBufferDepth requests
+// are in flight, numbers are unique among them.
+type messageSequence struct {
+	last uint32
+}
+
+// next returns the sequence number to use for a new request. It is safe for
+// concurrent use.
+func (s *messageSequence) next() uint8 {
+	return uint8(atomic.AddUint32(&s.last, 1) & 0x3f)
+}
+
+// isResponseTo returns whether a decoded message is the response to a request
+// with the provided sequence number and operation. The network function of a
+// response is always one greater than that of the request.
+func isResponseTo(m *ipmi.Message, sequence uint8, request *ipmi.Operation) bool {
+	return m.Sequence == sequence &&
+		m.Function == request.Function+1 &&
+		m.Command == request.Command
+}
@@ -0,0 +1,193 @@
+package bmc
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gebn/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestMessageSequence(t *testing.T) {
+	s := messageSequence{}
+	seen := map[uint8]struct{}{}
+	for i := 0; i < 64; i++ {
+		seq := s.next()
+		if seq > 0x3f {
+			t.Fatalf("next() = %v, which does not fit in 6 bits", seq)
+		}
+		seen[seq] = struct{}{}
+	}
+	if len(seen) != 64 {
+		t.Errorf("next() returned %v distinct values in 64 calls, want 64",
+			len(seen))
+	}
+}
+
+// v2SessionlessResponse builds a session-less IPMI v2.0 packet containing a
+// response message.
+func v2SessionlessResponse(t *testing.T, sessionID uint32, sequence uint8, operation ipmi.Operation) []byte {
+	buf := gopacket.NewSerializeBuffer()
+	if err := gopacket.SerializeLayers(buf, serializeOptions,
+		&layers.RMCP{
+			Version:  layers.RMCPVersion1,
+			Sequence: 0xFF,
+			Class:    layers.RMCPClassIPMI,
+		},
+		&ipmi.V2Session{
+			ID:                sessionID,
+			PayloadDescriptor: ipmi.PayloadDescriptorIPMI,
+		},
+		&ipmi.Message{
+			Operation:     operation,
+			RemoteAddress: ipmi.SoftwareIDRemoteConsole1.Address(),
+			LocalAddress:  ipmi.SlaveAddressBMC.Address(),
+			Sequence:      sequence,
+		},
+		gopacket.Payload(make([]byte, 16))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestV2SlotIsMessageResponse(t *testing.T) {
+	slot := &v2Slot{}
+	slot.init(0, nil, nil)
+	slot.sequence = 5
+	slot.operation = ipmi.OperationGetSystemGUIDReq
+
+	tests := []struct {
+		name      string
+		sessionID uint32
+		sequence  uint8
+		operation ipmi.Operation
+		want      bool
+	}{
+		{"match", 0, 5, ipmi.OperationGetSystemGUIDRsp, true},
+		{"stale sequence", 0, 4, ipmi.OperationGetSystemGUIDRsp, false},
+		{"different command", 0, 5, ipmi.OperationGetDeviceIDRsp, false},
+		{"request", 0, 5, ipmi.OperationGetSystemGUIDReq, false},
+		{"different session", 1, 5, ipmi.OperationGetSystemGUIDRsp, false},
+	}
+	for _, test := range tests {
+		packet := v2SessionlessResponse(t, test.sessionID, test.sequence,
+			test.operation)
+		if got := slot.isMessageResponse(packet); got != test.want {
+			t.Errorf("%v: isMessageResponse() = %v, want %v", test.name, got,
+				test.want)
+		}
+	}
+}
+
+func TestV2SlotIsMessageResponseUnauthenticated(t *testing.T) {
+	slot := &v2Slot{}
+	slot.init(0, hmac.New(sha1.New, make([]byte, 20)), nil)
+	slot.sequence = 5
+	slot.operation = ipmi.OperationGetSystemGUIDReq
+
+	packet := v2SessionlessResponse(t, 0, 5, ipmi.OperationGetSystemGUIDRsp)
+	if slot.isMessageResponse(packet) {
+		t.Errorf("isMessageResponse() accepted an unauthenticated packet " +
+			"with an integrity algorithm negotiated")
+	}
+}
+
+func TestV2SessionSendCommandUnauthenticated(t *testing.T) {
+	b := &v2BMC{
+		sequences: map[uint32]uint32{},
+	}
+	sess := newTestV2Session(&v2ConnectionShared{
+		transport: b,
+		tokens:    newSlotTokens(),
+	}, 1)
+
+	for i := 0; i < 2; i++ {
+		if _, err := sess.GetChannelAuthenticationCapabilities(
+			context.Background(),
+			&ipmi.GetChannelAuthenticationCapabilitiesReq{
+				Channel:           ipmi.ChannelPresentInterface,
+				MaxPrivilegeLevel: ipmi.PrivilegeLevelUser,
+			}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.headers) != 2 {
+		t.Fatalf("BMC received %v requests, want 2", len(b.headers))
+	}
+	for i, header := range b.headers {
+		if header.Authenticated || header.Encrypted {
+			t.Errorf("request %v: authenticated = %v, encrypted = %v, want "+
+				"neither", i, header.Authenticated, header.Encrypted)
+		}
+		if want := uint32(i + 1); header.Sequence != want {
+			t.Errorf("request %v: sequence number = %v, want %v", i,
+				header.Sequence, want)
+		}
+	}
+	if sess.UnauthenticatedSequenceNumbers.Inbound != 2 ||
+		sess.UnauthenticatedSequenceNumbers.Outbound != 2 {
+		t.Errorf("unauthenticated sequence numbers = %v/%v, want 2/2",
+			sess.UnauthenticatedSequenceNumbers.Inbound,
+			sess.UnauthenticatedSequenceNumbers.Outbound)
+	}
+	if sess.AuthenticatedSequenceNumbers.Inbound != 0 ||
+		sess.AuthenticatedSequenceNumbers.Outbound != 0 {
+		t.Errorf("authenticated sequence numbers = %v/%v, want 0/0",
+			sess.AuthenticatedSequenceNumbers.Inbound,
+			sess.AuthenticatedSequenceNumbers.Outbound)
+	}
+}
+
+func TestSendCommandConcurrent(t *testing.T) {
+	// each request gets a response full of its set selector
+	b := &v1BMC{
+		respond: func(m *ipmi.Message) (ipmi.CompletionCode, []byte, bool) {
+			return ipmi.CompletionCodeNormal,
+				append([]byte{0x11}, bytes.Repeat(m.LayerPayload()[2:3], 16)...),
+				true
+		},
+	}
+	s := newV1Sessionless(b, time.Second)
+
+	// more commands than slots, so every slot is reused while earlier
+	// responses are still held
+	cmds := make([]*ipmi.GetLANConfigurationParametersCmd, packetBufferDepth*4)
+	sent := sync.WaitGroup{}
+	sent.Add(len(cmds))
+	checked := sync.WaitGroup{}
+	checked.Add(len(cmds))
+	for i := range cmds {
+		cmds[i] = &ipmi.GetLANConfigurationParametersCmd{
+			Req: ipmi.GetLANConfigurationParametersReq{
+				SetSelector: uint8(i),
+			},
+		}
+		go func(cmd *ipmi.GetLANConfigurationParametersCmd) {
+			defer checked.Done()
+			_, err := s.SendCommand(context.Background(), cmd)
+			sent.Done()
+			if err != nil {
+				t.Errorf("selector %v: unexpected error: %v",
+					cmd.Req.SetSelector, err)
+				return
+			}
+			sent.Wait()
+			want := bytes.Repeat([]byte{cmd.Req.SetSelector}, 16)
+			if !bytes.Equal(cmd.Rsp.Data, want) {
+				t.Errorf("selector %v: data = %v, want %v",
+					cmd.Req.SetSelector, cmd.Rsp.Data, want)
+			}
+		}(cmds[i])
+	}
+	checked.Wait()
+}
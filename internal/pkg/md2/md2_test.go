@@ -0,0 +1,42 @@
+package md2
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestMD2(t *testing.T) {
+	// test suite from appendix A.5 of RFC 1319
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", "8350e5a3e24c153df2275c9f80692773"},
+		{"a", "32ec01ec4a6dac72c0ab96fb34c0b5d1"},
+		{"abc", "da853b0d3f88d99b30283a69e6ded6bb"},
+		{"message digest", "ab4f496bfb2a530b219ff33031fe06b0"},
+		{"abcdefghijklmnopqrstuvwxyz", "4e8ddff3650292ab5a4108c3aa47940b"},
+		{"ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789",
+			"da33def2a42df13975352846c30338cd"},
+		{strings.Repeat("1234567890", 8), "d5976f79d83d3a0dc9806c3c66f3efd8"},
+	}
+	for _, test := range tests {
+		h := New()
+		h.Write([]byte(test.in))
+		if got := hex.EncodeToString(h.Sum(nil)); got != test.want {
+			t.Errorf("MD2(%q) = %v, want %v", test.in, got, test.want)
+		}
+	}
+}
+
+func TestMD2Incremental(t *testing.T) {
+	in := []byte(strings.Repeat("1234567890", 8))
+	h := New()
+	for i := range in {
+		h.Write(in[i : i+1])
+	}
+	if got, want := hex.EncodeToString(h.Sum(nil)), "d5976f79d83d3a0dc9806c3c66f3efd8"; got != want {
+		t.Errorf("incremental MD2 = %v, want %v", got, want)
+	}
+}
@@ -4,8 +4,10 @@ package transport
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -47,6 +49,15 @@ var (
 		Buckets: prometheus.ExponentialBuckets(22, 1.17, 10), // 90.38
 	})
 
+	discardedPackets = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "discarded_packets_total",
+		Help: "The number of received UDP packets that were not the response " +
+			"to any request awaiting one, e.g. late responses to requests that " +
+			"already timed out.",
+	})
+
 	responseLatency = promauto.NewHistogram(prometheus.HistogramOpts{
 		Namespace: namespace,
 		Subsystem: subsystem,
@@ -56,20 +67,73 @@ var (
 	})
 )
 
+// MaxPacketSize is the size of the largest packet we expect to receive. It is
+// the minimum length of buffers passed to Send().
+const MaxPacketSize = 512
+
+// Matcher is called for every packet received while a request is awaiting a
+// response. It returns whether the packet is the response to the request, in
+// which case no other request will see it. Matchers are called sequentially
+// from the transport's receive goroutine, and so must not block. The slice is
+// owned by the request that supplied the matcher, so it may be decoded in
+// place, and the decoded layers used once Send() returns.
+type Matcher func([]byte) bool
+
+// Listener is offered every packet that no request awaiting a response
+// accepted, e.g. unsolicited Serial over LAN data. It returns whether it
+// accepted the packet. As with Matcher, it is called from the transport's
+// receive goroutine, so must not block. The slice is only valid for the
+// duration of the call, and may be decoded in place.
+type Listener func([]byte) bool
+
+// listener wraps a Listener so it can be found again to deregister it.
+type listener struct {
+	accept Listener
+}
+
+// waiter is a request awaiting a response.
+type waiter struct {
+	response []byte
+	match    Matcher
+
+	// result receives the outcome of the request. It has capacity 1, so the
+	// receive goroutine never blocks on it.
+	result chan result
+}
+
+// result is the outcome of waiting for a response: either the length of the
+// matched packet, or an error.
+type result struct {
+	n   int
+	err error
+}
+
 type transport struct {
 	conn *net.UDPConn
 
-	// recvBuf is used for reading bytes off the wire. This means we do not
-	// allocate any memory in the hot path, but causes a race condition if the
-	// transport is used concurrently.
-	//
-	// Note that sending session-less commands alongside session-based ones, or
-	// creating multiple Sessions, requires synchronisation over both the
-	// sending and receiving of the response. To maximise throughout for a
-	// single BMC, you can open multiple Connections, however take care not to
-	// overwhelm the BMC - they are only recommended to have a packet buffer of
-	// length 2 (6.10.1, v2.0) and support 4 simultaneous sessions (6.12, v2.0).
-	recvBuf [512]byte
+	// recvBuf is used for reading bytes off the wire. It is only accessed by
+	// the receive goroutine, which copies packets into the buffer of each
+	// waiter in turn until one of them matches.
+	recvBuf [MaxPacketSize]byte
+
+	// mu protects waiters, listeners and err.
+	mu sync.Mutex
+
+	// waiters contains requests awaiting a response, in the order they were
+	// sent. A given packet is offered to each in turn, so an older request
+	// gets first refusal. Note a BMC is only recommended to have a packet
+	// buffer of length 2 (6.10.1, v2.0) and support 4 simultaneous sessions
+	// (6.12, v2.0), so limiting the number of requests in flight is left to
+	// higher layers, which understand the protocol.
+	waiters []*waiter
+
+	// listeners are offered packets not matched by any waiter, in the order
+	// they were registered.
+	listeners []*listener
+
+	// err is the error that caused the receive goroutine to exit, because the
+	// transport was closed. Once set, all sends fail with it.
+	err error
 }
 
 // New establishes a connection to a UDP endpoint. Most implementations should
@@ -92,9 +156,11 @@ func New(addr string) (Transport, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &transport{
+	t := &transport{
 		conn: conn,
-	}, nil
+	}
+	go t.receive()
+	return t, nil
 }
 
 // Address returns the remote IP:port of the endpoint.
@@ -102,51 +168,159 @@ func (t *transport) Address() net.Addr {
 	return t.conn.RemoteAddr()
 }
 
-// Send sends the supplied data to the remote host, blocking until it receives a
-// reply packet, which is then returned. An error is returned if a transport
-// error occurs or the context expires.
-func (t *transport) Send(ctx context.Context, b []byte) ([]byte, error) {
-	// write
-	if deadline, ok := ctx.Deadline(); ok {
-		if err := t.conn.SetWriteDeadline(deadline); err != nil {
-			return nil, err
+// receive reads packets off the wire until the connection is closed, offering
+// each one to the waiters in turn, then the listeners. Packets accepted by
+// neither are dropped.
+func (t *transport) receive() {
+	for {
+		n, _, err := t.conn.ReadFromUDP(t.recvBuf[:])
+		if err != nil {
+			// other errors, e.g. an ICMP port unreachable, are passed to all
+			// current waiters, but do not stop us receiving
+			closed := errors.Is(err, net.ErrClosed)
+			t.mu.Lock()
+			if closed {
+				t.err = err
+			}
+			for _, w := range t.waiters {
+				w.result <- result{err: err}
+			}
+			t.waiters = nil
+			t.mu.Unlock()
+			if closed {
+				return
+			}
+			continue
+		}
+		receiveBytes.Observe(float64(n))
+
+		t.mu.Lock()
+		matched := false
+		for i, w := range t.waiters {
+			if len(w.response) < n {
+				continue
+			}
+			copy(w.response, t.recvBuf[:n])
+			if w.match(w.response[:n]) {
+				t.waiters = append(t.waiters[:i], t.waiters[i+1:]...)
+				w.result <- result{n: n}
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			for _, l := range t.listeners {
+				// waiters decode their own copy, so the buffer is intact
+				if l.accept(t.recvBuf[:n]) {
+					matched = true
+					break
+				}
+			}
+		}
+		t.mu.Unlock()
+		if !matched {
+			// the waiters carry on waiting until their context expires
+			discardedPackets.Inc()
 		}
 	}
-	n, err := t.conn.Write(b)
-	if err != nil {
-		return nil, err
+}
+
+// Send sends the request to the remote host, blocking until it receives a
+// packet accepted by match, which is copied into response and returned. An
+// error is returned if a transport error occurs or the context expires.
+func (t *transport) Send(ctx context.Context, request, response []byte, match Matcher) ([]byte, error) {
+	w := &waiter{
+		response: response,
+		match:    match,
+		result:   make(chan result, 1),
 	}
-	if n != len(b) {
-		return nil, fmt.Errorf("wrote incomplete message (%v/%v bytes)", n,
-			len(b))
+
+	// register before sending, so we cannot miss a fast response
+	t.mu.Lock()
+	if t.err != nil {
+		t.mu.Unlock()
+		return nil, t.err
+	}
+	t.waiters = append(t.waiters, w)
+	t.mu.Unlock()
+
+	if err := t.Write(request); err != nil {
+		t.cancel(w)
+		return nil, err
 	}
 	sent := time.Now()
-	transmitBytes.Observe(float64(len(b)))
 
-	// read
-	if deadline, ok := ctx.Deadline(); ok {
-		if err := t.conn.SetReadDeadline(deadline); err != nil {
-			return nil, err
+	select {
+	case r := <-w.result:
+		if r.err != nil {
+			return nil, r.err
 		}
+		responseLatency.Observe(time.Since(sent).Seconds())
+		return response[:r.n], nil
+	case <-ctx.Done():
+		t.cancel(w)
+		return nil, ctx.Err()
 	}
-	n, _, err = t.conn.ReadFromUDP(t.recvBuf[:])
+}
+
+// Write sends a packet to the remote host without waiting for a response.
+func (t *transport) Write(packet []byte) error {
+	n, err := t.conn.Write(packet)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	responseLatency.Observe(time.Since(sent).Seconds())
-	receiveBytes.Observe(float64(n))
+	if n != len(packet) {
+		return fmt.Errorf("wrote incomplete message (%v/%v bytes)", n,
+			len(packet))
+	}
+	transmitBytes.Observe(float64(len(packet)))
+	return nil
+}
 
-	return t.recvBuf[:n], nil
+// cancel deregisters a waiter. Once this returns, the receive goroutine will
+// no longer touch the waiter's buffer.
+func (t *transport) cancel(w *waiter) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, other := range t.waiters {
+		if other == w {
+			t.waiters = append(t.waiters[:i], t.waiters[i+1:]...)
+			return
+		}
+	}
 }
 
-// Close cleanly shuts down the transport, rendering it unusable.
+// Listen registers a listener for packets no request is awaiting. The returned
+// function deregisters it; once that returns, the listener will not be called
+// again.
+func (t *transport) Listen(accept Listener) func() {
+	l := &listener{
+		accept: accept,
+	}
+	t.mu.Lock()
+	t.listeners = append(t.listeners, l)
+	t.mu.Unlock()
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		for i, other := range t.listeners {
+			if other == l {
+				t.listeners = append(t.listeners[:i], t.listeners[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Close cleanly shuts down the transport, rendering it unusable. Requests
+// awaiting a response fail.
 func (t *transport) Close() error {
 	return t.conn.Close()
 }
 
 // Transport defines an interface capable of sending and receiving data to and
-// from a device. It logically represents a UDP socket and receive buffer.
-// Unless specified otherwise, access must be serialised.
+// from a device. It logically represents a UDP socket. It is safe for
+// concurrent use.
 type Transport interface {
 
 	// Address returns the IP:port of the remote device. This will always have
@@ -154,12 +328,25 @@ type Transport interface {
 	// 623).
 	Address() net.Addr
 
-	// Send encapsulates the provided data in a UDP packet and sends it to the
-	// BMC's address. It then blocks until a packet is received, and returns the
-	// data it contains. If the context expires before all of this is performed,
-	// or there is a network error, the returned slice will be nil and the error
-	// will be returned.
-	Send(context.Context, []byte) ([]byte, error)
+	// Send encapsulates the provided request in a UDP packet and sends it to
+	// the BMC's address. It then blocks until a packet is received that the
+	// matcher accepts, copies it into the response buffer, and returns the
+	// slice of the buffer containing it. The response buffer should be
+	// MaxPacketSize bytes long; shorter packets cannot be received into it.
+	// If the context expires before all of this is performed, or there is a
+	// network error, the returned slice will be nil and the error will be
+	// returned.
+	Send(ctx context.Context, request, response []byte, match Matcher) ([]byte, error)
+
+	// Write sends a packet to the BMC without waiting for a response, e.g. a
+	// Serial over LAN acknowledgement. Any reply must be received via a
+	// listener.
+	Write(packet []byte) error
+
+	// Listen registers a listener to be offered packets that no request is
+	// awaiting, e.g. Serial over LAN data the BMC sends unprompted, and
+	// returns a function to deregister it.
+	Listen(Listener) (cancel func())
 
 	// Close cleanly shuts down the underlying connection, returning any error
 	// that occurs. It is envisaged that this call is deferred as soon as the
@@ -0,0 +1,144 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// reverseServer starts a UDP server that waits for n packets, then echoes them
+// back in reverse order. It returns the server's address.
+func reverseServer(t *testing.T, n int) string {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+	})
+	go func() {
+		packets := [][]byte{}
+		addr := (*net.UDPAddr)(nil)
+		for len(packets) < n {
+			buf := make([]byte, MaxPacketSize)
+			read, from, err := conn.ReadFromUDP(buf)
+			if err != nil {
+				return
+			}
+			packets = append(packets, buf[:read])
+			addr = from
+		}
+		for i := len(packets) - 1; i >= 0; i-- {
+			if _, err := conn.WriteToUDP(packets[i], addr); err != nil {
+				return
+			}
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+func TestSendConcurrent(t *testing.T) {
+	const requests = 4
+	tr, err := New(reverseServer(t, requests))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tr.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(id byte) {
+			defer wg.Done()
+			request := []byte{id, 0xff}
+			response := [MaxPacketSize]byte{}
+			got, err := tr.Send(ctx, request, response[:], func(b []byte) bool {
+				return b[0] == id
+			})
+			if err != nil {
+				t.Errorf("Send(%v) failed: %v", request, err)
+				return
+			}
+			if !bytes.Equal(got, request) {
+				t.Errorf("Send(%v) = %v, want %v", request, got, request)
+			}
+		}(byte(i))
+	}
+	wg.Wait()
+}
+
+func TestSendContextExpiry(t *testing.T) {
+	// the server never responds, as it is waiting for a second packet
+	tr, err := New(reverseServer(t, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tr.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	response := [MaxPacketSize]byte{}
+	if _, err := tr.Send(ctx, []byte{0x1}, response[:], func([]byte) bool {
+		return true
+	}); err != context.DeadlineExceeded {
+		t.Errorf("Send() returned %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestSendClosed(t *testing.T) {
+	tr, err := New(reverseServer(t, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr.Close()
+
+	response := [MaxPacketSize]byte{}
+	if _, err := tr.Send(context.Background(), []byte{0x1}, response[:],
+		func([]byte) bool {
+			return true
+		}); err == nil {
+		t.Errorf("Send() on closed transport succeeded, want error")
+	}
+}
+
+func TestListen(t *testing.T) {
+	// the server echoes our packet, which no request is awaiting
+	tr, err := New(reverseServer(t, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tr.Close()
+
+	received := make(chan []byte, 1)
+	cancel := tr.Listen(func(b []byte) bool {
+		received <- append([]byte(nil), b...)
+		return true
+	})
+	defer cancel()
+
+	// send the packet with a matcher that rejects the echo, leaving it for
+	// the listener
+	ctx, cancelCtx := context.WithTimeout(context.Background(),
+		100*time.Millisecond)
+	defer cancelCtx()
+	response := [MaxPacketSize]byte{}
+	request := []byte{0x1, 0x2}
+	_, _ = tr.Send(ctx, request, response[:], func([]byte) bool {
+		return false
+	})
+
+	select {
+	case got := <-received:
+		if !bytes.Equal(got, request) {
+			t.Errorf("listener received %v, want %v", got, request)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("listener not called")
+	}
+}
@@ -0,0 +1,382 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/gebn/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket"
+)
+
+// LANConfig is the network configuration of a LAN channel, decoded from its
+// LAN configuration parameters.
+type LANConfig struct {
+
+	// IPAddressSource indicates how the BMC obtains its IPv4 address.
+	IPAddressSource ipmi.IPAddressSource
+
+	// IPAddress is the BMC's IPv4 address.
+	IPAddress net.IP
+
+	// SubnetMask is the BMC's IPv4 subnet mask.
+	SubnetMask net.IPMask
+
+	// DefaultGateway is the IPv4 address of the default gateway.
+	DefaultGateway net.IP
+
+	// DefaultGatewayMAC is the MAC address of the default gateway. This is
+	// nil if the BMC does not support the parameter.
+	DefaultGatewayMAC net.HardwareAddr
+
+	// MAC is the BMC's MAC address.
+	MAC net.HardwareAddr
+
+	// VLAN is the 802.1q VLAN the BMC tags its traffic with. This is disabled
+	// if the BMC does not support VLAN tagging.
+	VLAN ipmi.VLAN
+
+	// CipherSuitePrivilegeLevels contains the maximum privilege level that
+	// can be requested with each cipher suite. This is nil if the BMC does not
+	// support RMCP+.
+	CipherSuitePrivilegeLevels *ipmi.CipherSuitePrivilegeLevels
+
+	// IPAddressingMode indicates which IP versions the BMC uses. This is
+	// ipmi.IPAddressingModeIPv4 if the BMC does not support IPv6.
+	IPAddressingMode ipmi.IPAddressingMode
+
+	// IPv6Addresses contains the enabled static IPv6 addresses, followed by
+	// the assigned dynamic addresses. This is empty if the BMC does not
+	// support IPv6.
+	IPv6Addresses []*ipmi.IPv6Address
+}
+
+// GetLANConfig retrieves the network configuration of a LAN channel.
+// ipmi.ChannelPresentInterface can be used to refer to the channel the
+// session is established over. Optional parameters the BMC does not support
+// are left as their zero value.
+func GetLANConfig(ctx context.Context, s Session, channel ipmi.Channel) (*LANConfig, error) {
+	config := &LANConfig{}
+
+	d, err := getLANParameter(ctx, s, channel, ipmi.LANConfigurationParameterIPAddressSource, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+	config.IPAddressSource = ipmi.IPAddressSource(d[0] & 0xf)
+
+	if d, err = getLANParameter(ctx, s, channel, ipmi.LANConfigurationParameterIPAddress, 0, net.IPv4len); err != nil {
+		return nil, err
+	}
+	config.IPAddress = net.IPv4(d[0], d[1], d[2], d[3])
+
+	if d, err = getLANParameter(ctx, s, channel, ipmi.LANConfigurationParameterSubnetMask, 0, net.IPv4len); err != nil {
+		return nil, err
+	}
+	config.SubnetMask = net.IPv4Mask(d[0], d[1], d[2], d[3])
+
+	if d, err = getLANParameter(ctx, s, channel, ipmi.LANConfigurationParameterDefaultGatewayAddress, 0, net.IPv4len); err != nil {
+		return nil, err
+	}
+	config.DefaultGateway = net.IPv4(d[0], d[1], d[2], d[3])
+
+	if d, err = getLANParameter(ctx, s, channel, ipmi.LANConfigurationParameterMACAddress, 0, 6); err != nil {
+		return nil, err
+	}
+	config.MAC = hardwareAddr(d[:6])
+
+	if d, err = getOptionalLANParameter(ctx, s, channel, ipmi.LANConfigurationParameterDefaultGatewayMACAddress, 0, 6); err != nil {
+		return nil, err
+	}
+	if d != nil {
+		config.DefaultGatewayMAC = hardwareAddr(d[:6])
+	}
+
+	if d, err = getOptionalLANParameter(ctx, s, channel, ipmi.LANConfigurationParameterVLANID, 0, 2); err != nil {
+		return nil, err
+	}
+	if d != nil {
+		if _, err := config.VLAN.Deserialise(d, gopacket.NilDecodeFeedback); err != nil {
+			return nil, err
+		}
+	}
+
+	if d, err = getOptionalLANParameter(ctx, s, channel, ipmi.LANConfigurationParameterCipherSuitePrivilegeLevels, 0, 9); err != nil {
+		return nil, err
+	}
+	if d != nil {
+		config.CipherSuitePrivilegeLevels = &ipmi.CipherSuitePrivilegeLevels{}
+		if _, err := config.CipherSuitePrivilegeLevels.Deserialise(d, gopacket.NilDecodeFeedback); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := getIPv6Config(ctx, s, channel, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// getIPv6Config populates the IPv6 fields of a LANConfig. It does nothing if
+// the BMC does not support IPv6.
+func getIPv6Config(ctx context.Context, s Session, channel ipmi.Channel, config *LANConfig) error {
+	status, err := getOptionalLANParameter(ctx, s, channel, ipmi.LANConfigurationParameterIPv6Status, 0, 3)
+	if err != nil || status == nil {
+		return err
+	}
+	d, err := getLANParameter(ctx, s, channel, ipmi.LANConfigurationParameterIPv6IPv4AddressingEnables, 0, 1)
+	if err != nil {
+		return err
+	}
+	config.IPAddressingMode = ipmi.IPAddressingMode(d[0])
+
+	for i := uint8(0); i < status[0]; i++ {
+		address, err := getIPv6Address(ctx, s, channel, ipmi.LANConfigurationParameterIPv6StaticAddresses, i)
+		if err != nil {
+			return err
+		}
+		if address.Enabled {
+			config.IPv6Addresses = append(config.IPv6Addresses, address)
+		}
+	}
+	for i := uint8(0); i < status[1]; i++ {
+		address, err := getIPv6Address(ctx, s, channel, ipmi.LANConfigurationParameterIPv6DynamicAddress, i)
+		if err != nil {
+			return err
+		}
+		if !address.Address.IsUnspecified() {
+			config.IPv6Addresses = append(config.IPv6Addresses, address)
+		}
+	}
+	return nil
+}
+
+func getIPv6Address(ctx context.Context, s Session, channel ipmi.Channel, p ipmi.LANConfigurationParameter, selector uint8) (*ipmi.IPv6Address, error) {
+	d, err := getLANParameter(ctx, s, channel, p, selector, 20)
+	if err != nil {
+		return nil, err
+	}
+	address := &ipmi.IPv6Address{}
+	if _, err := address.Deserialise(d, gopacket.NilDecodeFeedback); err != nil {
+		return nil, err
+	}
+	return address, nil
+}
+
+// getLANParameter retrieves a LAN configuration parameter, returning an error
+// if its data is shorter than length.
+func getLANParameter(ctx context.Context, s Session, channel ipmi.Channel, p ipmi.LANConfigurationParameter, selector uint8, length int) ([]byte, error) {
+	d, err := getOptionalLANParameter(ctx, s, channel, p, selector, length)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, fmt.Errorf("LAN configuration parameter %v is not "+
+			"supported", p)
+	}
+	return d, nil
+}
+
+// getOptionalLANParameter is like getLANParameter, but returns nil data
+// rather than an error if the BMC does not support the parameter.
+func getOptionalLANParameter(ctx context.Context, s Session, channel ipmi.Channel, p ipmi.LANConfigurationParameter, selector uint8, length int) ([]byte, error) {
+	cmd := &ipmi.GetLANConfigurationParametersCmd{
+		Req: ipmi.GetLANConfigurationParametersReq{
+			Channel:     channel,
+			Parameter:   p,
+			SetSelector: selector,
+		},
+	}
+	// BMCs typically truncate the response after a non-normal code, so this
+	// is checked regardless of any decode error
+	code, err := s.SendCommand(ctx, cmd)
+	if code == ipmi.CompletionCodeParameterNotSupported {
+		return nil, nil
+	}
+	if err := ValidateResponse(code, err); err != nil {
+		return nil, err
+	}
+	if len(cmd.Rsp.Data) < length {
+		return nil, fmt.Errorf("LAN configuration parameter %v must be at "+
+			"least %v bytes, got %v", p, length, len(cmd.Rsp.Data))
+	}
+	// the data refers to the packet, so must be copied before the next
+	// command is sent
+	return append([]byte(nil), cmd.Rsp.Data...), nil
+}
+
+func hardwareAddr(d []byte) net.HardwareAddr {
+	mac := make(net.HardwareAddr, len(d))
+	copy(mac, d)
+	return mac
+}
+
+// LANParameter is a LAN configuration parameter to write with SetLANConfig.
+type LANParameter struct {
+
+	// Parameter is the parameter to set.
+	Parameter ipmi.LANConfigurationParameter
+
+	// Data is the new parameter data, whose format depends on the parameter.
+	Data []byte
+}
+
+// StaticIPv4LANParameters returns the parameters to statically assign an IPv4
+// address, subnet mask and default gateway, disabling DHCP. It returns an
+// error if any address is not IPv4.
+func StaticIPv4LANParameters(address net.IP, mask net.IPMask, gateway net.IP) ([]LANParameter, error) {
+	ip := address.To4()
+	if ip == nil {
+		return nil, fmt.Errorf("%v is not an IPv4 address", address)
+	}
+	if len(mask) != net.IPv4len {
+		return nil, fmt.Errorf("%v is not an IPv4 subnet mask", mask)
+	}
+	gw := gateway.To4()
+	if gw == nil {
+		return nil, fmt.Errorf("%v is not an IPv4 address", gateway)
+	}
+	return []LANParameter{
+		{
+			Parameter: ipmi.LANConfigurationParameterIPAddressSource,
+			Data:      []byte{uint8(ipmi.IPAddressSourceStatic)},
+		},
+		{
+			Parameter: ipmi.LANConfigurationParameterIPAddress,
+			Data:      ip,
+		},
+		{
+			Parameter: ipmi.LANConfigurationParameterSubnetMask,
+			Data:      mask,
+		},
+		{
+			Parameter: ipmi.LANConfigurationParameterDefaultGatewayAddress,
+			Data:      gw,
+		},
+	}, nil
+}
+
+// VLANLANParameter returns the parameter to set the 802.1q VLAN the BMC tags
+// its traffic with.
+func VLANLANParameter(v *ipmi.VLAN) (LANParameter, error) {
+	b := gopacket.NewSerializeBuffer()
+	if err := v.Serialise(b); err != nil {
+		return LANParameter{}, err
+	}
+	return LANParameter{
+		Parameter: ipmi.LANConfigurationParameterVLANID,
+		Data:      b.Bytes(),
+	}, nil
+}
+
+// SetLANConfig writes LAN configuration parameters to a channel in order,
+// within the set in progress lock. Once all parameters have been written, it
+// asks the BMC to commit them, then releases the lock. If a write fails, the
+// lock is released without committing, so BMCs that support rollback discard
+// the earlier writes. The lock and commit are optional; if the BMC does not
+// support them, parameters are written without them. Changing the address of
+// the channel the session is established over may cause the session to be
+// lost before this returns.
+func SetLANConfig(ctx context.Context, s Session, channel ipmi.Channel, params ...LANParameter) error {
+	return writeInProgress(func(state ipmi.SetInProgress) (ipmi.CompletionCode, error) {
+		return setLANInProgress(ctx, s, channel, state)
+	}, func() error {
+		return setLANParameters(ctx, s, channel, params)
+	})
+}
+
+func setLANParameters(ctx context.Context, s Session, channel ipmi.Channel, params []LANParameter) error {
+	for _, p := range params {
+		if err := ValidateResponse(s.SendCommand(ctx, &ipmi.SetLANConfigurationParametersCmd{
+			Req: ipmi.SetLANConfigurationParametersReq{
+				Channel:   channel,
+				Parameter: p.Parameter,
+				Data:      p.Data,
+			},
+		})); err != nil {
+			return fmt.Errorf("failed to set %v: %v", p.Parameter, err)
+		}
+	}
+	return nil
+}
+
+func setLANInProgress(ctx context.Context, s Session, channel ipmi.Channel, state ipmi.SetInProgress) (ipmi.CompletionCode, error) {
+	return s.SendCommand(ctx, &ipmi.SetLANConfigurationParametersCmd{
+		Req: ipmi.SetLANConfigurationParametersReq{
+			Channel:   channel,
+			Parameter: ipmi.LANConfigurationParameterSetInProgress,
+			Data:      []byte{uint8(state)},
+		},
+	})
+}
+
+// LANAlertDestination is an alert destination of a LAN channel, decoded from
+// its Destination Type and Destination Addresses LAN configuration
+// parameters. Alert policy entries refer to destinations by selector.
+type LANAlertDestination struct {
+
+	// Destination controls how alerts are delivered. Its selector
+	// identifies the destination.
+	Destination ipmi.AlertDestination
+
+	// Address is where alerts are sent.
+	Address ipmi.AlertDestinationAddress
+}
+
+// GetLANAlertDestinations retrieves the non-volatile alert destinations of a
+// LAN channel, in selector order. The volatile destination 0 is omitted.
+func GetLANAlertDestinations(ctx context.Context, s Session, channel ipmi.Channel) ([]*LANAlertDestination, error) {
+	d, err := getLANParameter(ctx, s, channel, ipmi.LANConfigurationParameterDestinationCount, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+	destinations := []*LANAlertDestination{}
+	for i := uint8(1); i <= d[0]&0xf; i++ {
+		destination := &LANAlertDestination{}
+		d, err := getLANParameter(ctx, s, channel, ipmi.LANConfigurationParameterDestinationType, i, 4)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := destination.Destination.Deserialise(d, gopacket.NilDecodeFeedback); err != nil {
+			return nil, err
+		}
+		// the address parameter's length depends on its format, which the
+		// address checks
+		if d, err = getLANParameter(ctx, s, channel, ipmi.LANConfigurationParameterDestinationAddresses, i, 2); err != nil {
+			return nil, err
+		}
+		if _, err := destination.Address.Deserialise(d, gopacket.NilDecodeFeedback); err != nil {
+			return nil, err
+		}
+		destinations = append(destinations, destination)
+	}
+	return destinations, nil
+}
+
+// AlertDestinationLANParameters returns the parameters to write an alert
+// destination with SetLANConfig. Both are written to the destination's
+// selector, ignoring that of the address.
+func AlertDestinationLANParameters(d *LANAlertDestination) ([]LANParameter, error) {
+	b := gopacket.NewSerializeBuffer()
+	if err := d.Destination.Serialise(b); err != nil {
+		return nil, err
+	}
+	destination := b.Bytes()
+
+	address := d.Address
+	address.Selector = d.Destination.Selector
+	b = gopacket.NewSerializeBuffer()
+	if err := address.Serialise(b); err != nil {
+		return nil, err
+	}
+	return []LANParameter{
+		{
+			Parameter: ipmi.LANConfigurationParameterDestinationType,
+			Data:      destination,
+		},
+		{
+			Parameter: ipmi.LANConfigurationParameterDestinationAddresses,
+			Data:      b.Bytes(),
+		},
+	}, nil
+}
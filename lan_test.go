@@ -0,0 +1,241 @@
+package bmc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/gebn/bmc/pkg/ipmi"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// lanBMC serves LAN configuration parameters, responding with a truncated
+// ipmi.CompletionCodeParameterNotSupported for any not present, as a session
+// would, and records parameter writes. Static and dynamic IPv6 addresses are
+// keyed by parameter and set selector.
+type lanBMC struct {
+	params map[ipmi.LANConfigurationParameter][]byte
+	ipv6   map[ipmi.LANConfigurationParameter]map[uint8][]byte
+
+	// codes overrides the completion code of writes to a parameter, keyed by
+	// its first data byte.
+	codes  map[ipmi.LANConfigurationParameter]map[byte]ipmi.CompletionCode
+	writes []LANParameter
+}
+
+// session returns a session handling Get and Set LAN Configuration
+// Parameters against the BMC.
+func (b *lanBMC) session() *fakeSession {
+	s := &fakeSession{}
+	handle(s, func(c *ipmi.GetLANConfigurationParametersCmd) (ipmi.CompletionCode, error) {
+		data, ok := b.params[c.Req.Parameter]
+		if selectors, ok := b.ipv6[c.Req.Parameter]; ok {
+			data = selectors[c.Req.SetSelector]
+		}
+		if !ok && data == nil {
+			return respond(c, ipmi.CompletionCodeParameterNotSupported, nil)
+		}
+		return respond(c, ipmi.CompletionCodeNormal, append([]byte{0x11}, data...))
+	})
+	handle(s, func(c *ipmi.SetLANConfigurationParametersCmd) (ipmi.CompletionCode, error) {
+		b.writes = append(b.writes, LANParameter{
+			Parameter: c.Req.Parameter,
+			Data:      c.Req.Data,
+		})
+		if code, ok := b.codes[c.Req.Parameter][c.Req.Data[0]]; ok {
+			return code, nil
+		}
+		return ipmi.CompletionCodeNormal, nil
+	})
+	return s
+}
+
+func TestGetLANConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		bmc  *lanBMC
+		want *LANConfig
+	}{
+		{
+			"ipv4 only",
+			&lanBMC{
+				params: map[ipmi.LANConfigurationParameter][]byte{
+					ipmi.LANConfigurationParameterIPAddressSource:       {0x02},
+					ipmi.LANConfigurationParameterIPAddress:             {192, 0, 2, 10},
+					ipmi.LANConfigurationParameterSubnetMask:            {255, 255, 255, 0},
+					ipmi.LANConfigurationParameterDefaultGatewayAddress: {192, 0, 2, 1},
+					ipmi.LANConfigurationParameterMACAddress:            {0x02, 0x00, 0x00, 0x00, 0x00, 0x01},
+				},
+			},
+			&LANConfig{
+				IPAddressSource: ipmi.IPAddressSourceDHCP,
+				IPAddress:       net.IPv4(192, 0, 2, 10),
+				SubnetMask:      net.IPv4Mask(255, 255, 255, 0),
+				DefaultGateway:  net.IPv4(192, 0, 2, 1),
+				MAC:             net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01},
+			},
+		},
+		{
+			"every parameter",
+			&lanBMC{
+				params: map[ipmi.LANConfigurationParameter][]byte{
+					ipmi.LANConfigurationParameterIPAddressSource:            {0x01},
+					ipmi.LANConfigurationParameterIPAddress:                  {192, 0, 2, 10},
+					ipmi.LANConfigurationParameterSubnetMask:                 {255, 255, 255, 0},
+					ipmi.LANConfigurationParameterDefaultGatewayAddress:      {192, 0, 2, 1},
+					ipmi.LANConfigurationParameterDefaultGatewayMACAddress:   {0x02, 0x00, 0x00, 0x00, 0x00, 0x02},
+					ipmi.LANConfigurationParameterMACAddress:                 {0x02, 0x00, 0x00, 0x00, 0x00, 0x01},
+					ipmi.LANConfigurationParameterVLANID:                     {0x64, 0x80},
+					ipmi.LANConfigurationParameterCipherSuitePrivilegeLevels: {0x00, 0x44, 0x04, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+					ipmi.LANConfigurationParameterIPv6Status:                 {0x02, 0x01, 0x03},
+					ipmi.LANConfigurationParameterIPv6IPv4AddressingEnables:  {0x02},
+				},
+				ipv6: map[ipmi.LANConfigurationParameter]map[uint8][]byte{
+					ipmi.LANConfigurationParameterIPv6StaticAddresses: {
+						0: {
+							0x00, 0x80,
+							0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00, 0x00, 0x00,
+							0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x0a,
+							0x40, 0x00,
+						},
+						// unused
+						1: make([]byte, 20),
+					},
+					ipmi.LANConfigurationParameterIPv6DynamicAddress: {
+						0: {
+							0x00, 0x01,
+							0xfe, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+							0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+							0x40, 0x00,
+						},
+					},
+				},
+			},
+			&LANConfig{
+				IPAddressSource:   ipmi.IPAddressSourceStatic,
+				IPAddress:         net.IPv4(192, 0, 2, 10),
+				SubnetMask:        net.IPv4Mask(255, 255, 255, 0),
+				DefaultGateway:    net.IPv4(192, 0, 2, 1),
+				DefaultGatewayMAC: net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x02},
+				MAC:               net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01},
+				VLAN: ipmi.VLAN{
+					Enabled: true,
+					ID:      100,
+				},
+				CipherSuitePrivilegeLevels: &ipmi.CipherSuitePrivilegeLevels{
+					ipmi.PrivilegeLevelAdministrator,
+					ipmi.PrivilegeLevelAdministrator,
+					ipmi.PrivilegeLevelAdministrator,
+				},
+				IPAddressingMode: ipmi.IPAddressingModeDual,
+				IPv6Addresses: []*ipmi.IPv6Address{
+					{
+						Enabled:      true,
+						Source:       ipmi.IPv6AddressSourceStatic,
+						Address:      net.ParseIP("2001:db8::a"),
+						PrefixLength: 64,
+					},
+					{
+						Source:       ipmi.IPv6AddressSourceSLAAC,
+						Address:      net.ParseIP("fe80::1"),
+						PrefixLength: 64,
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config, err := GetLANConfig(context.Background(),
+				test.bmc.session(), ipmi.ChannelPresentInterface)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, config); diff != "" {
+				t.Errorf("GetLANConfig() = %v, want %v: %v", config, test.want,
+					diff)
+			}
+		})
+	}
+}
+
+func TestSetLANConfig(t *testing.T) {
+	params, err := StaticIPv4LANParameters(net.IPv4(192, 0, 2, 10),
+		net.IPv4Mask(255, 255, 255, 0), net.IPv4(192, 0, 2, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	inProgress := LANParameter{
+		Parameter: ipmi.LANConfigurationParameterSetInProgress,
+		Data:      []byte{uint8(ipmi.SetInProgressInProgress)},
+	}
+	commit := LANParameter{
+		Parameter: ipmi.LANConfigurationParameterSetInProgress,
+		Data:      []byte{uint8(ipmi.SetInProgressCommitWrite)},
+	}
+	complete := LANParameter{
+		Parameter: ipmi.LANConfigurationParameterSetInProgress,
+		Data:      []byte{uint8(ipmi.SetInProgressComplete)},
+	}
+	tests := []struct {
+		name    string
+		codes   map[ipmi.LANConfigurationParameter]map[byte]ipmi.CompletionCode
+		wantErr bool
+		want    []LANParameter
+	}{
+		{
+			name: "lock and commit",
+			want: append(append([]LANParameter{inProgress}, params...),
+				commit, complete),
+		},
+		{
+			name: "lock unsupported",
+			codes: map[ipmi.LANConfigurationParameter]map[byte]ipmi.CompletionCode{
+				ipmi.LANConfigurationParameterSetInProgress: {
+					uint8(ipmi.SetInProgressInProgress): ipmi.CompletionCodeParameterNotSupported,
+				},
+			},
+			want: append([]LANParameter{inProgress}, params...),
+		},
+		{
+			name: "lock held",
+			codes: map[ipmi.LANConfigurationParameter]map[byte]ipmi.CompletionCode{
+				ipmi.LANConfigurationParameterSetInProgress: {
+					uint8(ipmi.SetInProgressInProgress): 0x81,
+				},
+			},
+			wantErr: true,
+			want:    []LANParameter{inProgress},
+		},
+		{
+			name: "write fails",
+			codes: map[ipmi.LANConfigurationParameter]map[byte]ipmi.CompletionCode{
+				ipmi.LANConfigurationParameterIPAddress: {
+					192: ipmi.CompletionCodeUnspecified,
+				},
+			},
+			wantErr: true,
+			want: append([]LANParameter{inProgress}, params[0], params[1],
+				complete),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bmc := &lanBMC{
+				codes: test.codes,
+			}
+			err := SetLANConfig(context.Background(), bmc.session(),
+				ipmi.ChannelPresentInterface, params...)
+			switch {
+			case err != nil && !test.wantErr:
+				t.Fatalf("unexpected error: %v", err)
+			case err == nil && test.wantErr:
+				t.Fatal("expected error, got none")
+			}
+			if diff := cmp.Diff(test.want, bmc.writes); diff != "" {
+				t.Errorf("wrote %v, want %v: %v", bmc.writes, test.want, diff)
+			}
+		})
+	}
+}
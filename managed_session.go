@@ -0,0 +1,490 @@
+package bmc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gebn/bmc/pkg/ipmi"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+var (
+	// errReopenBackoff is returned by reopen if the previous attempt to
+	// re-establish the session failed recently.
+	errReopenBackoff = errors.New("not re-establishing session until the " +
+		"previous failed attempt's backoff has elapsed")
+)
+
+// defaultKeepaliveInterval is half the session inactivity timeout BMCs are
+// recommended to use (6.12.15, v2.0).
+const defaultKeepaliveInterval = 30 * time.Second
+
+// ManagedSessionOpts contains parameters for establishing a managed session.
+type ManagedSessionOpts struct {
+	V2SessionOpts
+
+	// KeepaliveInterval is the time between keepalive commands sent to stop
+	// the BMC expiring the session due to inactivity. It defaults to 30
+	// seconds, half of the recommended session inactivity timeout. A negative
+	// value disables keepalives.
+	KeepaliveInterval time.Duration
+}
+
+// ManagedSession is a self-healing IPMI v2.0 session. It sends periodic Get
+// Channel Authentication Capabilities commands as keepalives, and if the BMC
+// reports the session ID is invalid, or a keepalive fails, transparently
+// establishes a new session. If the session ID was invalid, the BMC cannot
+// have executed the command, so it is retried once on the new session. A
+// command timing out does not cause the session to be re-established, as the
+// BMC may simply be slow or unreachable, in which case session setup would
+// only add to its load; some BMCs silently drop packets for sessions they have
+// expired, which the next keepalive will detect. If re-establishing the
+// session fails, further attempts are backed off. It is otherwise identical to
+// the underlying V2Session, and is safe for concurrent use. Note the ID will
+// change each time the session is re-established.
+type ManagedSession struct {
+
+	// open establishes a new underlying session.
+	open func(context.Context) (*V2Session, error)
+
+	// keepaliveInterval is the time between keepalives; see
+	// ManagedSessionOpts.
+	keepaliveInterval time.Duration
+
+	// mu protects session. It is held for writing only to swap the session;
+	// reopening guards the (much slower) establishment of its replacement.
+	mu      sync.RWMutex
+	session *V2Session
+
+	// reopening ensures only one goroutine re-establishes the session at a
+	// time; others wait for it to finish, then use the result. It also
+	// protects backoff and retryAt.
+	reopening sync.Mutex
+
+	// backoff spaces out attempts to re-establish the session after one
+	// fails, so a struggling BMC is not flooded with session setup requests.
+	backoff backoff.BackOff
+
+	// retryAt is the earliest time the session may next be re-established,
+	// following a failed attempt.
+	retryAt time.Time
+
+	// stop is closed to stop the keepalive goroutine, which closes stopped
+	// once it has exited.
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+// NewManagedSession establishes a new RMCP+ session, managed by the library.
+// The session-less transport must remain open for the lifetime of the managed
+// session. This function does not modify the input options.
+func (s *V2SessionlessTransport) NewManagedSession(ctx context.Context, opts *ManagedSessionOpts) (*ManagedSession, error) {
+	sessionOpts := opts.V2SessionOpts
+	open := func(ctx context.Context) (*V2Session, error) {
+		return s.NewV2Session(ctx, &sessionOpts)
+	}
+	sess, err := open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return newManagedSession(sess, open, opts.KeepaliveInterval), nil
+}
+
+// newManagedSession wraps an established session, using open to replace it
+// when it expires, and starts sending keepalives.
+func newManagedSession(sess *V2Session, open func(context.Context) (*V2Session, error), keepaliveInterval time.Duration) *ManagedSession {
+	b := backoff.NewExponentialBackOff()
+	// keep trying for the lifetime of the managed session
+	b.MaxElapsedTime = 0
+	m := &ManagedSession{
+		open:              open,
+		keepaliveInterval: keepaliveInterval,
+		session:           sess,
+		backoff:           b,
+		stop:              make(chan struct{}),
+		stopped:           make(chan struct{}),
+	}
+	if m.keepaliveInterval == 0 {
+		m.keepaliveInterval = defaultKeepaliveInterval
+	}
+	if m.keepaliveInterval > 0 {
+		go m.keepalive()
+	} else {
+		close(m.stopped)
+	}
+	return m
+}
+
+// current returns the session commands should currently be sent over.
+func (m *ManagedSession) current() *V2Session {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.session
+}
+
+// keepalive sends a Get Channel Authentication Capabilities command every
+// keepalive interval until the managed session is closed, re-establishing the
+// session if the keepalive fails.
+func (m *ManagedSession) keepalive() {
+	defer close(m.stopped)
+	ticker := time.NewTicker(m.keepaliveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-ticker.C:
+			m.sendKeepalive()
+		}
+	}
+}
+
+// sendKeepalive sends a single keepalive over the current session,
+// re-establishing it if the keepalive fails for any reason.
+func (m *ManagedSession) sendKeepalive() {
+	// the keepalive must not outlive the interval, otherwise they would pile
+	// up against an unresponsive BMC
+	ctx, cancel := context.WithTimeout(context.Background(),
+		m.keepaliveInterval)
+	defer cancel()
+	sess := m.current()
+	// errors are reflected in command metrics, and the next command sent by
+	// the user will find out anyway
+	if _, err := sess.GetChannelAuthenticationCapabilities(ctx,
+		&ipmi.GetChannelAuthenticationCapabilitiesReq{
+			ExtendedData:      true,
+			Channel:           ipmi.ChannelPresentInterface,
+			MaxPrivilegeLevel: ipmi.PrivilegeLevelUser,
+		}); err == nil {
+		return
+	}
+	ctx, cancel = context.WithTimeout(context.Background(),
+		m.keepaliveInterval)
+	defer cancel()
+	_, _ = m.reopen(ctx, sess)
+}
+
+// reopen establishes a new session to replace the provided one, which is
+// assumed to have expired. If another goroutine has already replaced it, the
+// replacement is returned without establishing another. If the previous
+// attempt failed and its backoff has not elapsed, errReopenBackoff is
+// returned.
+func (m *ManagedSession) reopen(ctx context.Context, expired *V2Session) (*V2Session, error) {
+	m.reopening.Lock()
+	defer m.reopening.Unlock()
+
+	if sess := m.current(); sess != expired {
+		return sess, nil
+	}
+	if time.Now().Before(m.retryAt) {
+		return nil, errReopenBackoff
+	}
+
+	sessionReopenAttempts.Inc()
+	sess, err := m.open(ctx)
+	if err != nil {
+		sessionReopenFailures.Inc()
+		m.retryAt = time.Now().Add(m.backoff.NextBackOff())
+		return nil, err
+	}
+	m.backoff.Reset()
+
+	m.mu.Lock()
+	m.session = sess
+	m.mu.Unlock()
+
+	// the old session is most likely already gone, however if it expired due
+	// to a network issue, it may still occupy one of the BMC's few session
+	// slots, so we make a best-effort attempt to close it without holding up
+	// the caller
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), expired.timeout)
+		defer cancel()
+		_ = expired.Close(ctx)
+	}()
+	return sess, nil
+}
+
+func (m *ManagedSession) Version() string {
+	return "2.0"
+}
+
+// ID returns the remote console's session ID of the current underlying
+// session. This changes each time the session is re-established.
+func (m *ManagedSession) ID() uint32 {
+	return m.current().ID()
+}
+
+// SendCommand sends a command over the current session. If the BMC no longer
+// recognises the session ID, the session is re-established and the command is
+// retried once on the new session; the BMC cannot have executed it.
+func (m *ManagedSession) SendCommand(ctx context.Context, c ipmi.Command) (ipmi.CompletionCode, error) {
+	sess := m.current()
+	code, err := sess.SendCommand(ctx, c)
+	// BMCs typically truncate the response after a non-normal code, so this
+	// may be accompanied by a decode error
+	if code != ipmi.CompletionCodeInvalidSessionID {
+		return code, err
+	}
+	sess, reopenErr := m.reopen(ctx, sess)
+	if reopenErr != nil {
+		// return the original result, which is more useful to the caller
+		// than why we couldn't recover from it
+		return code, err
+	}
+	return sess.SendCommand(ctx, c)
+}
+
+func (m *ManagedSession) GetSystemGUID(ctx context.Context) ([16]byte, error) {
+	return getSystemGUID(ctx, m)
+}
+
+func (m *ManagedSession) GetChannelAuthenticationCapabilities(
+	ctx context.Context,
+	r *ipmi.GetChannelAuthenticationCapabilitiesReq,
+) (*ipmi.GetChannelAuthenticationCapabilitiesRsp, error) {
+	return getChannelAuthenticationCapabilities(ctx, m, r)
+}
+
+func (m *ManagedSession) GetSessionInfo(ctx context.Context, r *ipmi.GetSessionInfoReq) (*ipmi.GetSessionInfoRsp, error) {
+	return getSessionInfo(ctx, m, r)
+}
+
+func (m *ManagedSession) GetDeviceID(ctx context.Context) (*ipmi.GetDeviceIDRsp, error) {
+	return getDeviceID(ctx, m)
+}
+
+func (m *ManagedSession) ColdReset(ctx context.Context) error {
+	return coldReset(ctx, m)
+}
+
+func (m *ManagedSession) WarmReset(ctx context.Context) error {
+	return warmReset(ctx, m)
+}
+
+func (m *ManagedSession) GetSelfTestResults(ctx context.Context) (*ipmi.GetSelfTestResultsRsp, error) {
+	return getSelfTestResults(ctx, m)
+}
+
+func (m *ManagedSession) SetACPIPowerState(ctx context.Context, r *ipmi.SetACPIPowerStateReq) error {
+	return setACPIPowerState(ctx, m, r)
+}
+
+func (m *ManagedSession) GetACPIPowerState(ctx context.Context) (*ipmi.GetACPIPowerStateRsp, error) {
+	return getACPIPowerState(ctx, m)
+}
+
+func (m *ManagedSession) GetSystemInfoParameters(ctx context.Context, r *ipmi.GetSystemInfoParametersReq) (*ipmi.GetSystemInfoParametersRsp, error) {
+	return getSystemInfoParameters(ctx, m, r)
+}
+
+func (m *ManagedSession) SetSystemInfoParameters(ctx context.Context, r *ipmi.SetSystemInfoParametersReq) error {
+	return setSystemInfoParameters(ctx, m, r)
+}
+
+func (m *ManagedSession) GetWatchdogTimer(ctx context.Context) (*ipmi.GetWatchdogTimerRsp, error) {
+	return getWatchdogTimer(ctx, m)
+}
+
+func (m *ManagedSession) SetWatchdogTimer(ctx context.Context, r *ipmi.SetWatchdogTimerReq) error {
+	return setWatchdogTimer(ctx, m, r)
+}
+
+func (m *ManagedSession) ResetWatchdogTimer(ctx context.Context) error {
+	return resetWatchdogTimer(ctx, m)
+}
+
+func (m *ManagedSession) GetChassisStatus(ctx context.Context) (*ipmi.GetChassisStatusRsp, error) {
+	return getChassisStatus(ctx, m)
+}
+
+func (m *ManagedSession) ChassisControl(ctx context.Context, c ipmi.ChassisControl) error {
+	return chassisControl(ctx, m, c)
+}
+
+func (m *ManagedSession) ChassisIdentify(ctx context.Context, interval time.Duration, forceOn bool) error {
+	return chassisIdentify(ctx, m, interval, forceOn)
+}
+
+func (m *ManagedSession) GetSystemBootOptions(ctx context.Context, r *ipmi.GetSystemBootOptionsReq) (*ipmi.GetSystemBootOptionsRsp, error) {
+	return getSystemBootOptions(ctx, m, r)
+}
+
+func (m *ManagedSession) SetSystemBootOptions(ctx context.Context, r *ipmi.SetSystemBootOptionsReq) error {
+	return setSystemBootOptions(ctx, m, r)
+}
+
+func (m *ManagedSession) SetBootDevice(ctx context.Context, f *ipmi.BootFlags) error {
+	return setBootDevice(ctx, m, f)
+}
+
+func (m *ManagedSession) GetLANConfigurationParameters(ctx context.Context, r *ipmi.GetLANConfigurationParametersReq) (*ipmi.GetLANConfigurationParametersRsp, error) {
+	return getLANConfigurationParameters(ctx, m, r)
+}
+
+func (m *ManagedSession) SetLANConfigurationParameters(ctx context.Context, r *ipmi.SetLANConfigurationParametersReq) error {
+	return setLANConfigurationParameters(ctx, m, r)
+}
+
+func (m *ManagedSession) GetChannelInfo(ctx context.Context, channel ipmi.Channel) (*ipmi.GetChannelInfoRsp, error) {
+	return getChannelInfo(ctx, m, channel)
+}
+
+func (m *ManagedSession) GetChannelAccess(ctx context.Context, r *ipmi.GetChannelAccessReq) (*ipmi.GetChannelAccessRsp, error) {
+	return getChannelAccess(ctx, m, r)
+}
+
+func (m *ManagedSession) SetChannelAccess(ctx context.Context, r *ipmi.SetChannelAccessReq) error {
+	return setChannelAccess(ctx, m, r)
+}
+
+func (m *ManagedSession) GetSDRRepositoryInfo(ctx context.Context) (*ipmi.GetSDRRepositoryInfoRsp, error) {
+	return getSDRRepositoryInfo(ctx, m)
+}
+
+func (m *ManagedSession) ReserveSDRRepository(ctx context.Context) (*ipmi.ReserveSDRRepositoryRsp, error) {
+	return reserveSDRRepository(ctx, m)
+}
+
+func (m *ManagedSession) GetSDRRepositoryTime(ctx context.Context) (*ipmi.GetSDRRepositoryTimeRsp, error) {
+	return getSDRRepositoryTime(ctx, m)
+}
+
+func (m *ManagedSession) GetSELInfo(ctx context.Context) (*ipmi.GetSELInfoRsp, error) {
+	return getSELInfo(ctx, m)
+}
+
+func (m *ManagedSession) GetSELAllocationInfo(ctx context.Context) (*ipmi.GetSELAllocationInfoRsp, error) {
+	return getSELAllocationInfo(ctx, m)
+}
+
+func (m *ManagedSession) ReserveSEL(ctx context.Context) (*ipmi.ReserveSELRsp, error) {
+	return reserveSEL(ctx, m)
+}
+
+func (m *ManagedSession) GetSELEntry(ctx context.Context, r *ipmi.GetSELEntryReq) (*ipmi.GetSELEntryRsp, error) {
+	return getSELEntry(ctx, m, r)
+}
+
+func (m *ManagedSession) DeleteSELEntry(ctx context.Context, r *ipmi.DeleteSELEntryReq) (*ipmi.DeleteSELEntryRsp, error) {
+	return deleteSELEntry(ctx, m, r)
+}
+
+func (m *ManagedSession) ClearSEL(ctx context.Context) error {
+	return clearSEL(ctx, m)
+}
+
+func (m *ManagedSession) GetSELTime(ctx context.Context) (*ipmi.GetSELTimeRsp, error) {
+	return getSELTime(ctx, m)
+}
+
+func (m *ManagedSession) SetSELTime(ctx context.Context, t time.Time) error {
+	return setSELTime(ctx, m, t)
+}
+
+func (m *ManagedSession) GetSELTimeUTCOffset(ctx context.Context) (*ipmi.GetSELTimeUTCOffsetRsp, error) {
+	return getSELTimeUTCOffset(ctx, m)
+}
+
+func (m *ManagedSession) SetSELTimeUTCOffset(ctx context.Context, o ipmi.UTCOffset) error {
+	return setSELTimeUTCOffset(ctx, m, o)
+}
+
+func (m *ManagedSession) GetFRUInventoryAreaInfo(ctx context.Context, deviceID uint8) (*ipmi.GetFRUInventoryAreaInfoRsp, error) {
+	return getFRUInventoryAreaInfo(ctx, m, deviceID)
+}
+
+func (m *ManagedSession) ReadFRUData(ctx context.Context, r *ipmi.ReadFRUDataReq) (*ipmi.ReadFRUDataRsp, error) {
+	return readFRUData(ctx, m, r)
+}
+
+func (m *ManagedSession) GetUserAccess(ctx context.Context, r *ipmi.GetUserAccessReq) (*ipmi.GetUserAccessRsp, error) {
+	return getUserAccess(ctx, m, r)
+}
+
+func (m *ManagedSession) SetUserAccess(ctx context.Context, r *ipmi.SetUserAccessReq) error {
+	return setUserAccess(ctx, m, r)
+}
+
+func (m *ManagedSession) GetUserName(ctx context.Context, userID uint8) (string, error) {
+	return getUserName(ctx, m, userID)
+}
+
+func (m *ManagedSession) SetUserName(ctx context.Context, userID uint8, name string) error {
+	return setUserName(ctx, m, userID, name)
+}
+
+func (m *ManagedSession) SetUserPassword(ctx context.Context, r *ipmi.SetUserPasswordReq) error {
+	return setUserPassword(ctx, m, r)
+}
+
+func (m *ManagedSession) GetSensorReading(ctx context.Context, sensor uint8) (*ipmi.GetSensorReadingRsp, error) {
+	return getSensorReading(ctx, m, sensor)
+}
+
+func (m *ManagedSession) GetSensorReadingFactors(ctx context.Context, r *ipmi.GetSensorReadingFactorsReq) (*ipmi.GetSensorReadingFactorsRsp, error) {
+	return getSensorReadingFactors(ctx, m, r)
+}
+
+func (m *ManagedSession) GetSensorThresholds(ctx context.Context, sensor uint8) (*ipmi.GetSensorThresholdsRsp, error) {
+	return getSensorThresholds(ctx, m, sensor)
+}
+
+func (m *ManagedSession) SetSensorThresholds(ctx context.Context, r *ipmi.SetSensorThresholdsReq) error {
+	return setSensorThresholds(ctx, m, r)
+}
+
+func (m *ManagedSession) GetSensorHysteresis(ctx context.Context, sensor uint8) (*ipmi.GetSensorHysteresisRsp, error) {
+	return getSensorHysteresis(ctx, m, sensor)
+}
+
+func (m *ManagedSession) SetSensorHysteresis(ctx context.Context, r *ipmi.SetSensorHysteresisReq) error {
+	return setSensorHysteresis(ctx, m, r)
+}
+
+func (m *ManagedSession) GetPEFCapabilities(ctx context.Context) (*ipmi.GetPEFCapabilitiesRsp, error) {
+	return getPEFCapabilities(ctx, m)
+}
+
+func (m *ManagedSession) ArmPEFPostponeTimer(ctx context.Context, t ipmi.PEFPostponeTimer) (*ipmi.ArmPEFPostponeTimerRsp, error) {
+	return armPEFPostponeTimer(ctx, m, t)
+}
+
+func (m *ManagedSession) GetPEFConfigurationParameters(ctx context.Context, r *ipmi.GetPEFConfigurationParametersReq) (*ipmi.GetPEFConfigurationParametersRsp, error) {
+	return getPEFConfigurationParameters(ctx, m, r)
+}
+
+func (m *ManagedSession) SetPEFConfigurationParameters(ctx context.Context, r *ipmi.SetPEFConfigurationParametersReq) error {
+	return setPEFConfigurationParameters(ctx, m, r)
+}
+
+func (m *ManagedSession) GetSessionPrivilegeLevel(ctx context.Context) (ipmi.PrivilegeLevel, error) {
+	return getSessionPrivilegeLevel(ctx, m)
+}
+
+// SetSessionPrivilegeLevel sets the privilege level of the current underlying
+// session. Note that if the session is re-established, the new session will
+// be at its initial privilege level.
+func (m *ManagedSession) SetSessionPrivilegeLevel(ctx context.Context, level ipmi.PrivilegeLevel) (ipmi.PrivilegeLevel, error) {
+	return setSessionPrivilegeLevel(ctx, m, level)
+}
+
+func (m *ManagedSession) closeSession(ctx context.Context) error {
+	return m.current().closeSession(ctx)
+}
+
+// Close stops sending keepalives, then closes the current underlying session.
+// The managed session must not be used after this is called.
+func (m *ManagedSession) Close(ctx context.Context) error {
+	select {
+	case <-m.stop:
+		// already closed
+	default:
+		close(m.stop)
+	}
+	<-m.stopped
+	return m.closeSession(ctx)
+}
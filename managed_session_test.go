@@ -0,0 +1,325 @@
+package bmc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gebn/bmc/internal/pkg/transport"
+	"github.com/gebn/bmc/pkg/ipmi"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// v2BMC plays the part of a BMC in managed session tests, using integrity and
+// confidentiality algorithms of none. Sessions whose ID is in expired have all
+// commands rejected with an invalid session ID, and those in dropped have all
+// requests dropped, so they time out. The session headers of all requests,
+// and the session IDs of Chassis Control and Get Channel Authentication
+// Capabilities requests received are recorded.
+type v2BMC struct {
+	mu         sync.Mutex
+	headers    []*ipmi.V2Session
+	sequences  map[uint32]uint32
+	expired    map[uint32]bool
+	dropped    map[uint32]bool
+	controls   []uint32
+	keepalives []uint32
+}
+
+func (*v2BMC) Address() net.Addr {
+	return &net.UDPAddr{Port: 623}
+}
+
+func (b *v2BMC) Send(ctx context.Context, request, response []byte, match transport.Matcher) ([]byte, error) {
+	// skip the RMCP header
+	session := &ipmi.V2Session{}
+	if err := session.DecodeFromBytes(request[4:], gopacket.NilDecodeFeedback); err != nil {
+		return nil, err
+	}
+	message := &ipmi.Message{}
+	if err := message.DecodeFromBytes(session.LayerPayload(), gopacket.NilDecodeFeedback); err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	b.headers = append(b.headers, session)
+	code, data, ok := b.respond(session.ID, message)
+	b.sequences[session.ID]++
+	sequence := b.sequences[session.ID]
+	b.mu.Unlock()
+	if !ok {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	buf := gopacket.NewSerializeBuffer()
+	if err := gopacket.SerializeLayers(buf, serializeOptions,
+		&layers.RMCP{
+			Version:  layers.RMCPVersion1,
+			Sequence: 0xFF,
+			Class:    layers.RMCPClassIPMI,
+		},
+		&ipmi.V2Session{
+			ID:                session.ID,
+			Sequence:          sequence,
+			PayloadDescriptor: ipmi.PayloadDescriptorIPMI,
+		},
+		&ipmi.Message{
+			Operation: ipmi.Operation{
+				Function: message.Function + 1,
+				Command:  message.Command,
+			},
+			RemoteAddress:  message.LocalAddress,
+			LocalAddress:   message.RemoteAddress,
+			Sequence:       message.Sequence,
+			CompletionCode: code,
+		},
+		gopacket.Payload(data)); err != nil {
+		return nil, err
+	}
+	n := copy(response, buf.Bytes())
+	if !match(response[:n]) {
+		return nil, errors.New("response rejected")
+	}
+	return response[:n], nil
+}
+
+// respond must be called with mu held.
+func (b *v2BMC) respond(id uint32, m *ipmi.Message) (ipmi.CompletionCode, []byte, bool) {
+	switch m.Operation {
+	case ipmi.OperationChassisControlReq:
+		b.controls = append(b.controls, id)
+	case ipmi.OperationGetChannelAuthenticationCapabilitiesReq:
+		b.keepalives = append(b.keepalives, id)
+	}
+	if b.dropped[id] {
+		return 0, nil, false
+	}
+	if b.expired[id] {
+		return ipmi.CompletionCodeInvalidSessionID, nil, true
+	}
+	switch m.Operation {
+	case ipmi.OperationGetChannelAuthenticationCapabilitiesReq:
+		return ipmi.CompletionCodeNormal, []byte{0x01, 0x80, 0x04, 0x02, 0, 0, 0, 0}, true
+	default:
+		return ipmi.CompletionCodeNormal, nil, true
+	}
+}
+
+func (*v2BMC) Write([]byte) error {
+	return errors.New("not implemented")
+}
+
+func (*v2BMC) Listen(transport.Listener) func() {
+	return func() {}
+}
+
+func (*v2BMC) Close() error {
+	return nil
+}
+
+// newTestV2Session returns a session with the provided ID over shared, using
+// integrity and confidentiality algorithms of none, as though it had been
+// established with cipher suite 0.
+func newTestV2Session(shared *v2ConnectionShared, id uint32) *V2Session {
+	sess := &V2Session{
+		v2ConnectionShared: shared,
+		LocalID:            id,
+		RemoteID:           id,
+		timeout:            time.Millisecond * 50,
+	}
+	for i := range sess.slots {
+		sess.slots[i].init(id, nil, nil)
+		sess.slots[i].authenticatedSequenceNumbers =
+			&sess.AuthenticatedSequenceNumbers
+		sess.slots[i].unauthenticatedSequenceNumbers =
+			&sess.UnauthenticatedSequenceNumbers
+	}
+	return sess
+}
+
+// newTestManagedSession returns a managed session over b. Session IDs start at
+// 1, and increase each time the session is opened.
+func newTestManagedSession(b *v2BMC, keepaliveInterval time.Duration) *ManagedSession {
+	shared := &v2ConnectionShared{
+		transport: b,
+		tokens:    newSlotTokens(),
+	}
+	id := uint32(0)
+	open := func(context.Context) (*V2Session, error) {
+		id++
+		return newTestV2Session(shared, id), nil
+	}
+	sess, _ := open(context.Background())
+	return newManagedSession(sess, open, keepaliveInterval)
+}
+
+func TestManagedSessionSendCommand(t *testing.T) {
+	tests := []struct {
+		name         string
+		expired      map[uint32]bool
+		dropped      map[uint32]bool
+		wantErr      bool
+		wantControls []uint32
+		wantID       uint32
+	}{
+		{
+			name:         "normal",
+			wantControls: []uint32{1},
+			wantID:       1,
+		},
+		{
+			name:         "expired",
+			expired:      map[uint32]bool{1: true},
+			wantControls: []uint32{1, 2},
+			wantID:       2,
+		},
+		{
+			name:         "retried once",
+			expired:      map[uint32]bool{1: true, 2: true, 3: true},
+			wantErr:      true,
+			wantControls: []uint32{1, 2},
+			wantID:       2,
+		},
+		{
+			// the BMC may have executed the command, so it must not be
+			// retried, and the BMC may just be slow, so the session is kept
+			name:         "timeout",
+			dropped:      map[uint32]bool{1: true},
+			wantErr:      true,
+			wantControls: []uint32{1},
+			wantID:       1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := &v2BMC{
+				sequences: map[uint32]uint32{},
+				expired:   test.expired,
+				dropped:   test.dropped,
+			}
+			m := newTestManagedSession(b, -1)
+			defer m.Close(context.Background())
+
+			err := m.ChassisControl(context.Background(),
+				ipmi.ChassisControlPowerCycle)
+			if test.wantErr && err == nil {
+				t.Errorf("expected error")
+			}
+			if !test.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			if diff := cmp.Diff(test.wantControls, b.controls); diff != "" {
+				t.Errorf("Chassis Control sent over sessions %v, want %v: %v",
+					b.controls, test.wantControls, diff)
+			}
+			if id := m.ID(); id != test.wantID {
+				t.Errorf("ID() = %v, want %v", id, test.wantID)
+			}
+		})
+	}
+}
+
+func TestManagedSessionKeepalive(t *testing.T) {
+	tests := []struct {
+		name    string
+		expired map[uint32]bool
+		dropped map[uint32]bool
+	}{
+		{
+			name:    "expired",
+			expired: map[uint32]bool{1: true},
+		},
+		{
+			name:    "timeout",
+			dropped: map[uint32]bool{1: true},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := &v2BMC{
+				sequences: map[uint32]uint32{},
+				expired:   test.expired,
+				dropped:   test.dropped,
+			}
+			m := newTestManagedSession(b, time.Millisecond*10)
+
+			// the first keepalive fails, so the session is re-established;
+			// subsequent ones are sent over the new session
+			deadline := time.Now().Add(time.Second * 5)
+			for {
+				b.mu.Lock()
+				keepalives := append([]uint32(nil), b.keepalives...)
+				b.mu.Unlock()
+				if len(keepalives) >= 3 {
+					if diff := cmp.Diff([]uint32{1, 2, 2}, keepalives[:3]); diff != "" {
+						t.Errorf("keepalives sent over sessions %v, want to "+
+							"start [1 2 2]: %v", keepalives, diff)
+					}
+					break
+				}
+				if time.Now().After(deadline) {
+					t.Fatalf("only %v keepalives sent", len(keepalives))
+				}
+				time.Sleep(time.Millisecond)
+			}
+
+			if err := m.Close(context.Background()); err != nil {
+				t.Fatalf("unexpected error closing: %v", err)
+			}
+			b.mu.Lock()
+			sent := len(b.keepalives)
+			b.mu.Unlock()
+			time.Sleep(time.Millisecond * 50)
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			if len(b.keepalives) != sent {
+				t.Errorf("%v keepalives sent after close",
+					len(b.keepalives)-sent)
+			}
+		})
+	}
+}
+
+func TestManagedSessionReopenBackoff(t *testing.T) {
+	b := &v2BMC{
+		sequences: map[uint32]uint32{},
+		expired:   map[uint32]bool{1: true},
+	}
+	m := newTestManagedSession(b, -1)
+	defer m.Close(context.Background())
+	opens := 0
+	m.open = func(context.Context) (*V2Session, error) {
+		opens++
+		return nil, errors.New("BMC unavailable")
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := m.ChassisControl(context.Background(),
+			ipmi.ChassisControlPowerCycle); err == nil {
+			t.Fatalf("expected error")
+		}
+	}
+	if opens != 1 {
+		t.Errorf("re-established session %v times within backoff, want 1",
+			opens)
+	}
+
+	// the backoff elapsing allows another attempt
+	m.retryAt = time.Time{}
+	if err := m.ChassisControl(context.Background(),
+		ipmi.ChassisControlPowerCycle); err == nil {
+		t.Fatalf("expected error")
+	}
+	if opens != 2 {
+		t.Errorf("re-established session %v times, want 2", opens)
+	}
+}
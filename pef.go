@@ -0,0 +1,226 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/gebn/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket"
+)
+
+// PEFConfig is the Platform Event Filtering configuration of a BMC, decoded
+// from its PEF configuration parameters.
+type PEFConfig struct {
+
+	// Capabilities contains the actions the BMC supports.
+	Capabilities *ipmi.GetPEFCapabilitiesRsp
+
+	// Control indicates whether PEF is enabled.
+	Control ipmi.PEFControl
+
+	// ActionGlobalControl contains the actions PEF is allowed to take. Event
+	// filter actions not in this set are ignored.
+	ActionGlobalControl ipmi.PEFActions
+
+	// EventFilters contains every entry in the event filter table, including
+	// disabled entries, in table order.
+	EventFilters []*ipmi.EventFilter
+
+	// AlertPolicies contains every entry in the alert policy table, including
+	// disabled entries, in table order.
+	AlertPolicies []*ipmi.AlertPolicy
+}
+
+// GetPEFConfig retrieves the Platform Event Filtering configuration of the
+// BMC, including its event filter and alert policy tables. This can be used
+// to audit which events trigger alerts or actions. Alert destinations are
+// configured per channel; see GetLANAlertDestinations().
+func GetPEFConfig(ctx context.Context, s Session) (*PEFConfig, error) {
+	capabilities, err := s.GetPEFCapabilities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	config := &PEFConfig{
+		Capabilities: capabilities,
+	}
+
+	d, err := getPEFParameter(ctx, s, ipmi.PEFConfigurationParameterControl, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := config.Control.Deserialise(d, gopacket.NilDecodeFeedback); err != nil {
+		return nil, err
+	}
+
+	if d, err = getPEFParameter(ctx, s, ipmi.PEFConfigurationParameterActionGlobalControl, 0, 1); err != nil {
+		return nil, err
+	}
+	config.ActionGlobalControl = ipmi.PEFActions(d[0] & 0x3f)
+
+	for i := uint8(1); i <= capabilities.EventFilters; i++ {
+		if d, err = getPEFParameter(ctx, s, ipmi.PEFConfigurationParameterEventFilterTable, i, 21); err != nil {
+			return nil, err
+		}
+		filter := &ipmi.EventFilter{}
+		if _, err := filter.Deserialise(d, gopacket.NilDecodeFeedback); err != nil {
+			return nil, err
+		}
+		config.EventFilters = append(config.EventFilters, filter)
+	}
+
+	if d, err = getPEFParameter(ctx, s, ipmi.PEFConfigurationParameterAlertPolicyCount, 0, 1); err != nil {
+		return nil, err
+	}
+	for i := uint8(1); i <= d[0]&0x7f; i++ {
+		d, err := getPEFParameter(ctx, s, ipmi.PEFConfigurationParameterAlertPolicyTable, i, 4)
+		if err != nil {
+			return nil, err
+		}
+		policy := &ipmi.AlertPolicy{}
+		if _, err := policy.Deserialise(d, gopacket.NilDecodeFeedback); err != nil {
+			return nil, err
+		}
+		config.AlertPolicies = append(config.AlertPolicies, policy)
+	}
+	return config, nil
+}
+
+// getPEFParameter retrieves a PEF configuration parameter, returning an error
+// if its data is shorter than length.
+func getPEFParameter(ctx context.Context, s Session, p ipmi.PEFConfigurationParameter, selector uint8, length int) ([]byte, error) {
+	rsp, err := s.GetPEFConfigurationParameters(ctx, &ipmi.GetPEFConfigurationParametersReq{
+		Parameter:   p,
+		SetSelector: selector,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %v: %v", p, err)
+	}
+	if len(rsp.Data) < length {
+		return nil, fmt.Errorf("PEF configuration parameter %v must be at "+
+			"least %v bytes, got %v", p, length, len(rsp.Data))
+	}
+	// the data refers to the packet, so must be copied before the next
+	// command is sent
+	d := make([]byte, length)
+	copy(d, rsp.Data)
+	return d, nil
+}
+
+// PEFParameter is a PEF configuration parameter to write with SetPEFConfig.
+type PEFParameter struct {
+
+	// Parameter is the parameter to set.
+	Parameter ipmi.PEFConfigurationParameter
+
+	// Data is the new parameter data, whose format depends on the parameter.
+	Data []byte
+}
+
+// ControlPEFParameter returns the parameter to globally enable or disable
+// PEF.
+func ControlPEFParameter(c *ipmi.PEFControl) (PEFParameter, error) {
+	return serialisePEFParameter(ipmi.PEFConfigurationParameterControl, c)
+}
+
+// ActionGlobalControlPEFParameter returns the parameter to set the actions PEF
+// is allowed to take.
+func ActionGlobalControlPEFParameter(a ipmi.PEFActions) PEFParameter {
+	return PEFParameter{
+		Parameter: ipmi.PEFConfigurationParameterActionGlobalControl,
+		Data:      []byte{uint8(a) & 0x3f},
+	}
+}
+
+// EventFilterPEFParameter returns the parameter to write an event filter
+// table entry. The entry written is determined by the filter's number.
+func EventFilterPEFParameter(f *ipmi.EventFilter) (PEFParameter, error) {
+	return serialisePEFParameter(ipmi.PEFConfigurationParameterEventFilterTable, f)
+}
+
+// AlertPolicyPEFParameter returns the parameter to write an alert policy
+// table entry. The entry written is determined by the policy's entry number.
+func AlertPolicyPEFParameter(p *ipmi.AlertPolicy) (PEFParameter, error) {
+	return serialisePEFParameter(ipmi.PEFConfigurationParameterAlertPolicyTable, p)
+}
+
+func serialisePEFParameter(p ipmi.PEFConfigurationParameter, data interface {
+	Serialise(gopacket.SerializeBuffer) error
+}) (PEFParameter, error) {
+	b := gopacket.NewSerializeBuffer()
+	if err := data.Serialise(b); err != nil {
+		return PEFParameter{}, err
+	}
+	return PEFParameter{
+		Parameter: p,
+		Data:      b.Bytes(),
+	}, nil
+}
+
+// SetPEFConfig writes PEF configuration parameters in order, within the set
+// in progress lock. Once all parameters have been written, it asks the BMC to
+// commit them, then releases the lock. If a write fails, the lock is released
+// without committing. The lock and commit are optional; if the BMC does not
+// support them, parameters are written without them. This can be used to push
+// a standard set of event filters and alert policies to many BMCs; note
+// entries not written are left as-is.
+func SetPEFConfig(ctx context.Context, s Session, params ...PEFParameter) error {
+	return writeInProgress(func(state ipmi.SetInProgress) (ipmi.CompletionCode, error) {
+		return s.SendCommand(ctx, &ipmi.SetPEFConfigurationParametersCmd{
+			Req: ipmi.SetPEFConfigurationParametersReq{
+				Parameter: ipmi.PEFConfigurationParameterSetInProgress,
+				Data:      []byte{uint8(state)},
+			},
+		})
+	}, func() error {
+		for _, p := range params {
+			if err := s.SetPEFConfigurationParameters(ctx, &ipmi.SetPEFConfigurationParametersReq{
+				Parameter: p.Parameter,
+				Data:      p.Data,
+			}); err != nil {
+				return fmt.Errorf("failed to set %v: %v", p.Parameter, err)
+			}
+		}
+		return nil
+	})
+}
+
+// EntityEventFilters returns a copy of template for each sensor in the
+// repository monitoring a given entity, matching events from that sensor.
+// This allows filtering by entity, which event filters cannot do directly.
+// The generator, sensor number and sensor type of each copy are set from the
+// sensor's record; all other fields, including the filter number, are those
+// of the template. Filters are returned in sensor owner and number order.
+func (r SDRRepository) EntityEventFilters(entity ipmi.EntityID, template *ipmi.EventFilter) []*ipmi.EventFilter {
+	filters := []*ipmi.EventFilter{}
+	for _, record := range r.expand() {
+		if monitored, _ := record.Monitored(); monitored != entity {
+			continue
+		}
+		key := record.Key()
+		sensorType, _ := record.Kind()
+		filter := *template
+		filter.GeneratorAddress = key.OwnerAddress
+		filter.AnyGeneratorChannel = false
+		filter.GeneratorChannel = key.Channel
+		filter.GeneratorLUN = key.OwnerLUN
+		filter.SensorNumber = key.Number
+		filter.SensorType = sensorType
+		filters = append(filters, &filter)
+	}
+	sort.Slice(filters, func(i, j int) bool {
+		a, b := filters[i], filters[j]
+		if a.GeneratorAddress != b.GeneratorAddress {
+			return a.GeneratorAddress < b.GeneratorAddress
+		}
+		if a.GeneratorChannel != b.GeneratorChannel {
+			return a.GeneratorChannel < b.GeneratorChannel
+		}
+		if a.GeneratorLUN != b.GeneratorLUN {
+			return a.GeneratorLUN < b.GeneratorLUN
+		}
+		return a.SensorNumber < b.SensorNumber
+	})
+	return filters
+}
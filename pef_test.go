@@ -0,0 +1,167 @@
+package bmc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gebn/bmc/pkg/ipmi"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGetPEFConfig(t *testing.T) {
+	capabilities := &ipmi.GetPEFCapabilitiesRsp{
+		Version:      0x51,
+		Actions:      ipmi.PEFActionAlert | ipmi.PEFActionPowerOff,
+		EventFilters: 2,
+	}
+	// parameters are keyed by parameter and set selector
+	params := map[ipmi.PEFConfigurationParameter]map[uint8][]byte{
+		ipmi.PEFConfigurationParameterControl: {
+			0: {0x03},
+		},
+		ipmi.PEFConfigurationParameterActionGlobalControl: {
+			0: {0x01},
+		},
+		ipmi.PEFConfigurationParameterEventFilterTable: {
+			1: {
+				0x01, 0x80, 0x01, 0x01, 0x10, 0xff, 0xff, 0x01, 0xff, 0x01,
+				0xff, 0xff,
+				0x00, 0xff, 0x00,
+				0x00, 0xff, 0x00,
+				0x00, 0xff, 0x00,
+			},
+			2: make([]byte, 21),
+		},
+		ipmi.PEFConfigurationParameterAlertPolicyCount: {
+			0: {0x01},
+		},
+		ipmi.PEFConfigurationParameterAlertPolicyTable: {
+			1: {0x01, 0x18, 0x11, 0x00},
+		},
+	}
+	s := &fakeSession{}
+	handle(s, func(c *ipmi.GetPEFCapabilitiesCmd) (ipmi.CompletionCode, error) {
+		c.Rsp = *capabilities
+		return ipmi.CompletionCodeNormal, nil
+	})
+	handle(s, func(c *ipmi.GetPEFConfigurationParametersCmd) (ipmi.CompletionCode, error) {
+		c.Rsp.Revision = 0x11
+		c.Rsp.Data = params[c.Req.Parameter][c.Req.SetSelector]
+		return ipmi.CompletionCodeNormal, nil
+	})
+	anyData := ipmi.EventDataMatch{
+		Compare1: 0xff,
+	}
+	want := &PEFConfig{
+		Capabilities: capabilities,
+		Control: ipmi.PEFControl{
+			Enabled:       true,
+			EventMessages: true,
+		},
+		ActionGlobalControl: ipmi.PEFActionAlert,
+		EventFilters: []*ipmi.EventFilter{
+			{
+				Number:              1,
+				Enabled:             true,
+				Actions:             ipmi.PEFActionAlert,
+				AlertPolicy:         1,
+				Severity:            ipmi.EventSeverityCritical,
+				GeneratorAddress:    ipmi.EventFilterMatchAny,
+				AnyGeneratorChannel: true,
+				SensorType:          ipmi.SensorTypeTemperature,
+				SensorNumber:        ipmi.EventFilterMatchAny,
+				EventTrigger:        ipmi.OutputTypeThreshold,
+				EventOffsetMask:     0xffff,
+				EventData1:          anyData,
+				EventData2:          anyData,
+				EventData3:          anyData,
+			},
+			{},
+		},
+		AlertPolicies: []*ipmi.AlertPolicy{
+			{
+				Entry:       1,
+				Policy:      1,
+				Enabled:     true,
+				Channel:     1,
+				Destination: 1,
+			},
+		},
+	}
+
+	config, err := GetPEFConfig(context.Background(), s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(want, config); diff != "" {
+		t.Errorf("GetPEFConfig() = %v, want %v: %v", config, want, diff)
+	}
+}
+
+func TestEntityEventFilters(t *testing.T) {
+	repo := SDRRepository{
+		1: &ipmi.FullSensorRecord{
+			SensorRecordKey: ipmi.SensorRecordKey{
+				OwnerAddress: 0x20,
+				Number:       0x31,
+			},
+			Entity:     ipmi.EntityIDProcessor,
+			SensorType: ipmi.SensorTypeTemperature,
+		},
+		2: &ipmi.FullSensorRecord{
+			SensorRecordKey: ipmi.SensorRecordKey{
+				OwnerAddress: 0x20,
+				Number:       0x30,
+			},
+			Entity:     ipmi.EntityIDProcessor,
+			SensorType: ipmi.SensorTypeProcessor,
+		},
+		3: &ipmi.FullSensorRecord{
+			SensorRecordKey: ipmi.SensorRecordKey{
+				OwnerAddress: 0x20,
+				Number:       0x01,
+			},
+			Entity:     ipmi.EntityIDSystemChassis,
+			SensorType: ipmi.SensorTypeTemperature,
+		},
+	}
+	template := &ipmi.EventFilter{
+		Number:              4,
+		Enabled:             true,
+		Actions:             ipmi.PEFActionAlert,
+		Severity:            ipmi.EventSeverityCritical,
+		AnyGeneratorChannel: true,
+		EventTrigger:        ipmi.EventFilterMatchAny,
+		EventOffsetMask:     0xffff,
+	}
+	want := []*ipmi.EventFilter{
+		{
+			Number:           4,
+			Enabled:          true,
+			Actions:          ipmi.PEFActionAlert,
+			Severity:         ipmi.EventSeverityCritical,
+			GeneratorAddress: 0x20,
+			SensorType:       ipmi.SensorTypeProcessor,
+			SensorNumber:     0x30,
+			EventTrigger:     ipmi.EventFilterMatchAny,
+			EventOffsetMask:  0xffff,
+		},
+		{
+			Number:           4,
+			Enabled:          true,
+			Actions:          ipmi.PEFActionAlert,
+			Severity:         ipmi.EventSeverityCritical,
+			GeneratorAddress: 0x20,
+			SensorType:       ipmi.SensorTypeTemperature,
+			SensorNumber:     0x31,
+			EventTrigger:     ipmi.EventFilterMatchAny,
+			EventOffsetMask:  0xffff,
+		},
+	}
+
+	filters := repo.EntityEventFilters(ipmi.EntityIDProcessor, template)
+	if diff := cmp.Diff(want, filters); diff != "" {
+		t.Errorf("EntityEventFilters() = %v, want %v: %v", filters, want, diff)
+	}
+}
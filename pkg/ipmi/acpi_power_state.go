@@ -0,0 +1,145 @@
+package ipmi
+
+import (
+	"fmt"
+)
+
+// SystemPowerState is an ACPI system power state, as recorded by the BMC. It
+// is specified alongside the Set ACPI Power State command, in 20.6 of IPMI
+// v2.0, and is a 7-bit uint on the wire.
+type SystemPowerState uint8
+
+const (
+	// SystemPowerStateS0 is S0/G0, working.
+	SystemPowerStateS0 SystemPowerState = iota
+
+	// SystemPowerStateS1 is S1, hardware context maintained, typically
+	// equating to processor and chipset clocks stopped.
+	SystemPowerStateS1
+
+	// SystemPowerStateS2 is S2, stopped clocks with processor and cache
+	// context lost.
+	SystemPowerStateS2
+
+	// SystemPowerStateS3 is S3, suspend-to-RAM.
+	SystemPowerStateS3
+
+	// SystemPowerStateS4 is S4, suspend-to-disk.
+	SystemPowerStateS4
+
+	// SystemPowerStateS5 is S5/G2, soft off.
+	SystemPowerStateS5
+
+	// SystemPowerStateS4S5 is sent when the system cannot differentiate
+	// between S4 and S5.
+	SystemPowerStateS4S5
+
+	// SystemPowerStateG3 is G3, mechanical off.
+	SystemPowerStateG3
+
+	// SystemPowerStateSleeping means the system is in S1, S2 or S3, but
+	// cannot differentiate between them.
+	SystemPowerStateSleeping
+
+	// SystemPowerStateG1 means the system is in S1, S2, S3 or S4, but cannot
+	// differentiate between them.
+	SystemPowerStateG1
+
+	// SystemPowerStateOverride means the system was powered off by a power
+	// button override.
+	SystemPowerStateOverride
+)
+
+const (
+	SystemPowerStateLegacyOn  SystemPowerState = 0x20
+	SystemPowerStateLegacyOff SystemPowerState = 0x21
+	SystemPowerStateUnknown   SystemPowerState = 0x2a
+
+	// SystemPowerStateNoChange is used in Set ACPI Power State requests to
+	// leave the system power state unchanged.
+	SystemPowerStateNoChange SystemPowerState = 0x7f
+)
+
+func (s SystemPowerState) Description() string {
+	switch s {
+	case SystemPowerStateS0:
+		return "S0/G0"
+	case SystemPowerStateS1:
+		return "S1"
+	case SystemPowerStateS2:
+		return "S2"
+	case SystemPowerStateS3:
+		return "S3"
+	case SystemPowerStateS4:
+		return "S4"
+	case SystemPowerStateS5:
+		return "S5/G2"
+	case SystemPowerStateS4S5:
+		return "S4/S5"
+	case SystemPowerStateG3:
+		return "G3"
+	case SystemPowerStateSleeping:
+		return "Sleeping"
+	case SystemPowerStateG1:
+		return "G1"
+	case SystemPowerStateOverride:
+		return "Override"
+	case SystemPowerStateLegacyOn:
+		return "Legacy On"
+	case SystemPowerStateLegacyOff:
+		return "Legacy Off"
+	case SystemPowerStateUnknown:
+		return "Unknown"
+	case SystemPowerStateNoChange:
+		return "No Change"
+	default:
+		return "Reserved"
+	}
+}
+
+func (s SystemPowerState) String() string {
+	return fmt.Sprintf("%#x(%v)", uint8(s), s.Description())
+}
+
+// DevicePowerState is an ACPI device power state, as recorded by the BMC. It
+// is specified alongside the Set ACPI Power State command, in 20.6 of IPMI
+// v2.0, and is a 7-bit uint on the wire.
+type DevicePowerState uint8
+
+const (
+	DevicePowerStateD0 DevicePowerState = iota
+	DevicePowerStateD1
+	DevicePowerStateD2
+	DevicePowerStateD3
+)
+
+const (
+	DevicePowerStateUnknown DevicePowerState = 0x2a
+
+	// DevicePowerStateNoChange is used in Set ACPI Power State requests to
+	// leave the device power state unchanged.
+	DevicePowerStateNoChange DevicePowerState = 0x7f
+)
+
+func (s DevicePowerState) Description() string {
+	switch s {
+	case DevicePowerStateD0:
+		return "D0"
+	case DevicePowerStateD1:
+		return "D1"
+	case DevicePowerStateD2:
+		return "D2"
+	case DevicePowerStateD3:
+		return "D3"
+	case DevicePowerStateUnknown:
+		return "Unknown"
+	case DevicePowerStateNoChange:
+		return "No Change"
+	default:
+		return "Reserved"
+	}
+}
+
+func (s DevicePowerState) String() string {
+	return fmt.Sprintf("%#x(%v)", uint8(s), s.Description())
+}
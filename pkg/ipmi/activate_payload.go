@@ -0,0 +1,189 @@
+package ipmi
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// SerialAlertBehaviour determines what happens to serial/modem alerts while
+// SOL is active on a serial port shared with the baseboard. It is specified
+// in table 24-2 of IPMI v2.0, and is a 2-bit uint on the wire.
+type SerialAlertBehaviour uint8
+
+const (
+	// SerialAlertBehaviourFail means serial/modem alerts fail while SOL is
+	// active.
+	SerialAlertBehaviourFail SerialAlertBehaviour = iota
+
+	// SerialAlertBehaviourDefer means serial/modem alerts are deferred until
+	// SOL is deactivated.
+	SerialAlertBehaviourDefer
+
+	// SerialAlertBehaviourSucceed means serial/modem alerts succeed while SOL
+	// is active, which may interrupt the SOL session.
+	SerialAlertBehaviourSucceed
+)
+
+func (b SerialAlertBehaviour) String() string {
+	switch b {
+	case SerialAlertBehaviourFail:
+		return "Fail"
+	case SerialAlertBehaviourDefer:
+		return "Defer"
+	case SerialAlertBehaviourSucceed:
+		return "Succeed"
+	default:
+		return fmt.Sprintf("Unknown(%#x)", uint8(b))
+	}
+}
+
+// ActivatePayloadReq implements the Activate Payload command, specified in
+// section 24.1 of IPMI v2.0. It is used to start a payload other than IPMI
+// messages within the session it is sent over, e.g. SOL. The auxiliary data
+// fields are those specified for SOL; they are sent as zeroes for other
+// payload types.
+type ActivatePayloadReq struct {
+	layers.BaseLayer
+
+	// PayloadType is the type of payload to activate. This is a 6-bit uint on
+	// the wire.
+	PayloadType PayloadType
+
+	// Instance is the instance of the payload type to activate, starting at
+	// 1. This is a 4-bit uint on the wire.
+	Instance uint8
+
+	// Encrypted asks the BMC to encrypt SOL packets it sends, and to require
+	// encryption of packets it receives. This requires a confidentiality
+	// algorithm to have been negotiated for the session.
+	Encrypted bool
+
+	// Authenticated asks the BMC to authenticate SOL packets it sends, and to
+	// require authentication of packets it receives. This requires an
+	// integrity algorithm to have been negotiated for the session.
+	Authenticated bool
+
+	// SerialAlertBehaviour determines what happens to serial/modem alerts
+	// while SOL is active.
+	SerialAlertBehaviour SerialAlertBehaviour
+
+	// DeassertHandshake asks the BMC to deassert CTS and DCD/DSR to the
+	// baseboard serial controller until the remote console sends a packet
+	// asserting them, allowing the remote console to finish setting up before
+	// any characters are transferred.
+	DeassertHandshake bool
+}
+
+func (*ActivatePayloadReq) LayerType() gopacket.LayerType {
+	return LayerTypeActivatePayloadReq
+}
+
+func (a *ActivatePayloadReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(6)
+	if err != nil {
+		return err
+	}
+	bytes[0] = uint8(a.PayloadType) & 0x3f
+	bytes[1] = a.Instance & 0xf
+	bytes[2] = 0
+	if a.PayloadType == PayloadTypeSOL {
+		if a.Encrypted {
+			bytes[2] |= 1 << 7
+		}
+		if a.Authenticated {
+			bytes[2] |= 1 << 6
+		}
+		bytes[2] |= (uint8(a.SerialAlertBehaviour) & 0x3) << 4
+		if a.DeassertHandshake {
+			bytes[2] |= 1 << 1
+		}
+	}
+	bytes[3] = 0
+	bytes[4] = 0
+	bytes[5] = 0
+	return nil
+}
+
+type ActivatePayloadRsp struct {
+	layers.BaseLayer
+
+	// AuxiliaryData is payload-specific data returned by the BMC. It is
+	// reserved for SOL.
+	AuxiliaryData uint32
+
+	// InboundPayloadSize is the largest payload the BMC will accept from the
+	// remote console, in bytes. For SOL, this includes the 4-byte header.
+	InboundPayloadSize uint16
+
+	// OutboundPayloadSize is the largest payload the BMC will send the remote
+	// console, in bytes.
+	OutboundPayloadSize uint16
+
+	// Port is the UDP port over which the payload will be transferred. This is
+	// usually the port of the session, 623.
+	Port uint16
+
+	// VLAN is the VLAN number the payload will be transferred over, or 0xffff
+	// if VLANs are not in use.
+	VLAN uint16
+}
+
+func (*ActivatePayloadRsp) LayerType() gopacket.LayerType {
+	return LayerTypeActivatePayloadRsp
+}
+
+func (a *ActivatePayloadRsp) CanDecode() gopacket.LayerClass {
+	return a.LayerType()
+}
+
+func (*ActivatePayloadRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (a *ActivatePayloadRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 12 {
+		df.SetTruncated()
+		return fmt.Errorf("Activate Payload responses must be 12 bytes, got %v",
+			len(data))
+	}
+
+	a.AuxiliaryData = binary.LittleEndian.Uint32(data[0:4])
+	a.InboundPayloadSize = binary.LittleEndian.Uint16(data[4:6])
+	a.OutboundPayloadSize = binary.LittleEndian.Uint16(data[6:8])
+	a.Port = binary.LittleEndian.Uint16(data[8:10])
+	a.VLAN = binary.LittleEndian.Uint16(data[10:12])
+
+	a.BaseLayer.Contents = data[:12]
+	a.BaseLayer.Payload = data[12:]
+	return nil
+}
+
+type ActivatePayloadCmd struct {
+	Req ActivatePayloadReq
+	Rsp ActivatePayloadRsp
+}
+
+// Name returns "Activate Payload".
+func (*ActivatePayloadCmd) Name() string {
+	return "Activate Payload"
+}
+
+// Operation returns OperationActivatePayloadReq.
+func (*ActivatePayloadCmd) Operation() *Operation {
+	return &OperationActivatePayloadReq
+}
+
+func (c *ActivatePayloadCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (c *ActivatePayloadCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *ActivatePayloadCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
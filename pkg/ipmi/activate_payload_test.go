@@ -0,0 +1,88 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestActivatePayloadReqSerializeTo(t *testing.T) {
+	tests := []struct {
+		layer *ActivatePayloadReq
+		want  []byte
+	}{
+		{
+			&ActivatePayloadReq{
+				PayloadType: PayloadTypeSOL,
+				Instance:    1,
+			},
+			[]byte{0x01, 0x01, 0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			&ActivatePayloadReq{
+				PayloadType:          PayloadTypeSOL,
+				Instance:             2,
+				Encrypted:            true,
+				Authenticated:        true,
+				SerialAlertBehaviour: SerialAlertBehaviourDefer,
+				DeassertHandshake:    true,
+			},
+			[]byte{0x01, 0x02, 0xd2, 0x00, 0x00, 0x00},
+		},
+	}
+	opts := gopacket.SerializeOptions{}
+	for _, test := range tests {
+		sb := gopacket.NewSerializeBuffer()
+		if err := test.layer.SerializeTo(sb, opts); err != nil {
+			t.Errorf("serialize %v = error %v, want %v", test.layer, err,
+				test.want)
+			continue
+		}
+		if got := sb.Bytes(); !bytes.Equal(got, test.want) {
+			t.Errorf("serialize %v = %v, want %v", test.layer, got, test.want)
+		}
+	}
+}
+
+func TestActivatePayloadRspDecodeFromBytes(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want *ActivatePayloadRsp
+	}{
+		{
+			// too short
+			[]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x01, 0x6f, 0x02, 0xff},
+			nil,
+		},
+		{
+			[]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x01, 0x6f, 0x02, 0xff, 0xff},
+			&ActivatePayloadRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x01, 0x6f, 0x02, 0xff, 0xff},
+					Payload:  []byte{},
+				},
+				InboundPayloadSize:  256,
+				OutboundPayloadSize: 256,
+				Port:                623,
+				VLAN:                0xffff,
+			},
+		},
+	}
+	for _, test := range tests {
+		rsp := &ActivatePayloadRsp{}
+		err := rsp.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("expected error decoding %v, got none", test.in)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, rsp); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, rsp, test.want, diff)
+			}
+		case err != nil && test.want != nil:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package ipmi
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// ActivateSessionReq represents an Activate Session request, specified in
+// section 18.15 and 22.17 of IPMI v1.5 and v2.0 respectively. It is the second
+// and final step in establishing an IPMI v1.5 session, and is sent with the
+// temporary session ID returned by Get Session Challenge, a session sequence
+// number of 0, and an auth code calculated using the chosen authentication
+// type.
+type ActivateSessionReq struct {
+	layers.BaseLayer
+
+	// AuthenticationType is the algorithm to use for the remainder of the
+	// session. It must match the authentication type used in the session
+	// header of this request.
+	AuthenticationType AuthenticationType
+
+	// MaxPrivilegeLevel is the highest privilege level the remote console
+	// will request for the session. The session itself starts at the User
+	// level (or lower, if this is Callback); Set Session Privilege Level must
+	// be used to raise it. PrivilegeLevelHighest is invalid in IPMI v1.5.
+	MaxPrivilegeLevel PrivilegeLevel
+
+	// Challenge is the challenge string returned by Get Session Challenge.
+	Challenge [16]byte
+
+	// InitialOutboundSequence is the sequence number the BMC should use for
+	// the first packet it sends in the session, chosen by the remote console.
+	// It must be non-null.
+	InitialOutboundSequence uint32
+}
+
+func (*ActivateSessionReq) LayerType() gopacket.LayerType {
+	return LayerTypeActivateSessionReq
+}
+
+func (r *ActivateSessionReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(22)
+	if err != nil {
+		return err
+	}
+	bytes[0] = uint8(r.AuthenticationType) & 0xf
+	bytes[1] = uint8(r.MaxPrivilegeLevel) & 0xf
+	copy(bytes[2:18], r.Challenge[:])
+	binary.LittleEndian.PutUint32(bytes[18:22], r.InitialOutboundSequence)
+	return nil
+}
+
+// ActivateSessionRsp represents the managed system's response to an Activate
+// Session request.
+type ActivateSessionRsp struct {
+	layers.BaseLayer
+
+	// AuthenticationType is the authentication type the BMC will use for the
+	// remainder of the session. If per-message authentication is disabled,
+	// this may be AuthenticationTypeNone.
+	AuthenticationType AuthenticationType
+
+	// SessionID is the ID of the now active session, used in all subsequent
+	// session headers by both the remote console and managed system.
+	SessionID uint32
+
+	// InitialInboundSequence is the sequence number the remote console should
+	// use for the first packet it sends to the BMC within the session.
+	InitialInboundSequence uint32
+
+	// MaxPrivilegeLevel is the highest privilege level allowed for the
+	// session, which may be lower than requested.
+	MaxPrivilegeLevel PrivilegeLevel
+}
+
+func (*ActivateSessionRsp) LayerType() gopacket.LayerType {
+	return LayerTypeActivateSessionRsp
+}
+
+func (r *ActivateSessionRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*ActivateSessionRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *ActivateSessionRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 10 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be 10 bytes, got %v", len(data))
+	}
+
+	r.BaseLayer.Contents = data[:10]
+	r.BaseLayer.Payload = data[10:]
+	r.AuthenticationType = AuthenticationType(data[0] & 0xf)
+	r.SessionID = binary.LittleEndian.Uint32(data[1:5])
+	r.InitialInboundSequence = binary.LittleEndian.Uint32(data[5:9])
+	r.MaxPrivilegeLevel = PrivilegeLevel(data[9] & 0xf)
+	return nil
+}
+
+type ActivateSessionCmd struct {
+	Req ActivateSessionReq
+	Rsp ActivateSessionRsp
+}
+
+// Name returns "Activate Session".
+func (*ActivateSessionCmd) Name() string {
+	return "Activate Session"
+}
+
+// Operation returns &OperationActivateSessionReq.
+func (*ActivateSessionCmd) Operation() *Operation {
+	return &OperationActivateSessionReq
+}
+
+func (*ActivateSessionCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (c *ActivateSessionCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *ActivateSessionCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
@@ -0,0 +1,70 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestActivateSessionReqSerializeTo(t *testing.T) {
+	layer := &ActivateSessionReq{
+		AuthenticationType: AuthenticationTypeMD5,
+		MaxPrivilegeLevel:  PrivilegeLevelAdministrator,
+		Challenge: [16]byte{0x1, 0x2, 0x3, 0x4, 0x5, 0x6, 0x7, 0x8, 0x9, 0xa,
+			0xb, 0xc, 0xd, 0xe, 0xf, 0x10},
+		InitialOutboundSequence: 0x01020304,
+	}
+	want := []byte{0x02, 0x04, 0x1, 0x2, 0x3, 0x4, 0x5, 0x6, 0x7, 0x8, 0x9,
+		0xa, 0xb, 0xc, 0xd, 0xe, 0xf, 0x10, 0x04, 0x03, 0x02, 0x01}
+
+	sb := gopacket.NewSerializeBuffer()
+	if err := layer.SerializeTo(sb, gopacket.SerializeOptions{}); err != nil {
+		t.Fatalf("serialize %v failed with %v, wanted %v", layer, err, want)
+	}
+	if got := sb.Bytes(); !bytes.Equal(got, want) {
+		t.Errorf("serialize %v = %v, want %v", layer, got, want)
+	}
+}
+
+func TestActivateSessionRspDecodeFromBytes(t *testing.T) {
+	table := []struct {
+		in   []byte
+		want *ActivateSessionRsp
+	}{
+		{
+			[]byte{0x02, 0x01, 0x02},
+			nil,
+		},
+		{
+			[]byte{0x02, 0x78, 0x56, 0x34, 0x12, 0x10, 0x00, 0x00, 0x00, 0x04},
+			&ActivateSessionRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x02, 0x78, 0x56, 0x34, 0x12, 0x10, 0x00,
+						0x00, 0x00, 0x04},
+					Payload: []byte{},
+				},
+				AuthenticationType:     AuthenticationTypeMD5,
+				SessionID:              0x12345678,
+				InitialInboundSequence: 16,
+				MaxPrivilegeLevel:      PrivilegeLevelAdministrator,
+			},
+		},
+	}
+	for _, test := range table {
+		rsp := &ActivateSessionRsp{}
+		err := rsp.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("expected error decoding %v, got none", test.in)
+		case err != nil && test.want != nil:
+			t.Errorf("unexpected error decoding %v: %v", test.in, err)
+		case err == nil:
+			if diff := cmp.Diff(test.want, rsp); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, rsp, test.want, diff)
+			}
+		}
+	}
+}
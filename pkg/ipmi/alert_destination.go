@@ -0,0 +1,198 @@
+package ipmi
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/google/gopacket"
+)
+
+// AlertDestinationType is the kind of alert sent to a LAN alert destination.
+// This is a 3-bit uint on the wire.
+type AlertDestinationType uint8
+
+const (
+	// AlertDestinationTypePETTrap sends an SNMP Platform Event Trap.
+	AlertDestinationTypePETTrap AlertDestinationType = 0x0
+
+	AlertDestinationTypeOEM1 AlertDestinationType = 0x6
+	AlertDestinationTypeOEM2 AlertDestinationType = 0x7
+)
+
+func (t AlertDestinationType) Description() string {
+	switch t {
+	case AlertDestinationTypePETTrap:
+		return "PET Trap"
+	case AlertDestinationTypeOEM1:
+		return "OEM 1"
+	case AlertDestinationTypeOEM2:
+		return "OEM 2"
+	default:
+		return "Unknown"
+	}
+}
+
+func (t AlertDestinationType) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(t), t.Description())
+}
+
+// AlertDestination is the data of the Destination Type LAN configuration
+// parameter, parameter 18 in table 23-4 of IPMI v2.0. It controls how alerts
+// are delivered to a destination, whose address is configured separately.
+type AlertDestination struct {
+
+	// Selector identifies the destination. This is the set selector used to
+	// retrieve it, and a 4-bit uint on the wire. Destination 0 is volatile,
+	// and is typically used for one-off alerts.
+	Selector uint8
+
+	// Type is the kind of alert to send.
+	Type AlertDestinationType
+
+	// Acknowledged indicates the destination acknowledges alerts, so they
+	// are retried until acknowledged. Otherwise, alerts are sent once.
+	Acknowledged bool
+
+	// Timeout is how long to wait for an acknowledgement before retrying,
+	// truncated to the second. It is also the interval between retries of
+	// unacknowledged alerts. The maximum is 255 seconds.
+	Timeout time.Duration
+
+	// Retries is the number of times to retry sending the alert. This is a
+	// 3-bit uint on the wire.
+	Retries uint8
+}
+
+// Serialise encodes the destination onto the end of a buffer, returning an
+// error if one occurs.
+func (a *AlertDestination) Serialise(b gopacket.SerializeBuffer) error {
+	d, err := b.AppendBytes(4)
+	if err != nil {
+		return err
+	}
+	d[0] = a.Selector & 0xf
+	d[1] = uint8(a.Type) & 0x7
+	if a.Acknowledged {
+		d[1] |= 1 << 7
+	}
+	d[2] = uint8(min(a.Timeout/time.Second, 0xff))
+	d[3] = a.Retries & 0x7
+	return nil
+}
+
+// Deserialise reads a destination from the supplied byte slice, returning
+// unconsumed remaining bytes.
+func (a *AlertDestination) Deserialise(d []byte, df gopacket.DecodeFeedback) ([]byte, error) {
+	if len(d) < 4 {
+		df.SetTruncated()
+		return nil, fmt.Errorf("alert destinations are 4 bytes, only %v "+
+			"remaining", len(d))
+	}
+	a.Selector = d[0] & 0xf
+	a.Acknowledged = d[1]&(1<<7) != 0
+	a.Type = AlertDestinationType(d[1] & 0x7)
+	a.Timeout = time.Duration(d[2]) * time.Second
+	a.Retries = d[3] & 0x7
+	return d[4:], nil
+}
+
+// AlertDestinationAddress is the data of the Destination Addresses LAN
+// configuration parameter, parameter 19 in table 23-4 of IPMI v2.0. It is the
+// address alerts to a destination are sent to.
+type AlertDestinationAddress struct {
+
+	// Selector identifies the destination. This is the set selector used to
+	// retrieve it, and a 4-bit uint on the wire.
+	Selector uint8
+
+	// IP is the IPv4 or IPv6 address of the destination. An IPv4 address is
+	// encoded in the IPv4 format, which includes the gateway and MAC;
+	// anything else is encoded in the IPv6 format.
+	IP net.IP
+
+	// UseBackupGateway indicates IPv4 alerts are routed via the backup
+	// gateway rather than the default gateway. This is ignored for IPv6.
+	UseBackupGateway bool
+
+	// MAC is the MAC address of the destination, or of the gateway if the
+	// destination is on another subnet. This is only used for IPv4; BMCs
+	// that resolve addresses via ARP ignore it.
+	MAC net.HardwareAddr
+}
+
+func (a *AlertDestinationAddress) String() string {
+	return a.IP.String()
+}
+
+// Serialise encodes the address onto the end of a buffer, returning an error
+// if one occurs. An address that is not a valid IPv4 or IPv6 address is
+// encoded as the unspecified IPv6 address.
+func (a *AlertDestinationAddress) Serialise(b gopacket.SerializeBuffer) error {
+	if ip := a.IP.To4(); ip != nil {
+		d, err := b.AppendBytes(13)
+		if err != nil {
+			return err
+		}
+		d[0] = a.Selector & 0xf
+		d[1] = 0
+		d[2] = 0
+		if a.UseBackupGateway {
+			d[2] = 1
+		}
+		copy(d[3:7], ip)
+		clear(d[7:13])
+		copy(d[7:13], a.MAC)
+		return nil
+	}
+	d, err := b.AppendBytes(18)
+	if err != nil {
+		return err
+	}
+	d[0] = a.Selector & 0xf
+	d[1] = 1 << 4
+	ip := a.IP.To16()
+	if ip == nil {
+		ip = net.IPv6unspecified
+	}
+	copy(d[2:18], ip)
+	return nil
+}
+
+// Deserialise reads an address from the supplied byte slice, returning
+// unconsumed remaining bytes.
+func (a *AlertDestinationAddress) Deserialise(d []byte, df gopacket.DecodeFeedback) ([]byte, error) {
+	if len(d) < 2 {
+		df.SetTruncated()
+		return nil, fmt.Errorf("alert destination addresses are at least 2 "+
+			"bytes, only %v remaining", len(d))
+	}
+	a.Selector = d[0] & 0xf
+	switch format := d[1] >> 4; format {
+	case 0:
+		if len(d) < 13 {
+			df.SetTruncated()
+			return nil, fmt.Errorf("IPv4 alert destination addresses are 13 "+
+				"bytes, only %v remaining", len(d))
+		}
+		a.UseBackupGateway = d[2]&1 != 0
+		a.IP = net.IPv4(d[3], d[4], d[5], d[6])
+		a.MAC = make(net.HardwareAddr, 6)
+		copy(a.MAC, d[7:13])
+		return d[13:], nil
+	case 1:
+		if len(d) < 18 {
+			df.SetTruncated()
+			return nil, fmt.Errorf("IPv6 alert destination addresses are 18 "+
+				"bytes, only %v remaining", len(d))
+		}
+		a.UseBackupGateway = false
+		a.IP = make(net.IP, net.IPv6len)
+		copy(a.IP, d[2:18])
+		a.MAC = nil
+		return d[18:], nil
+	default:
+		return nil, fmt.Errorf("unknown alert destination address format %v",
+			format)
+	}
+}
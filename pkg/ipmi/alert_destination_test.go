@@ -0,0 +1,99 @@
+package ipmi
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+)
+
+func TestAlertDestination(t *testing.T) {
+	destination := &AlertDestination{
+		Selector:     1,
+		Type:         AlertDestinationTypePETTrap,
+		Acknowledged: true,
+		Timeout:      time.Second * 3,
+		Retries:      2,
+	}
+	wire := []byte{0x01, 0x80, 0x03, 0x02}
+
+	b := gopacket.NewSerializeBuffer()
+	if err := destination.Serialise(b); err != nil {
+		t.Fatalf("serialise %v = error %v", destination, err)
+	}
+	if got := b.Bytes(); !bytes.Equal(got, wire) {
+		t.Errorf("serialise %v = %v, want %v", destination, got, wire)
+	}
+
+	got := &AlertDestination{}
+	if _, err := got.Deserialise(wire, gopacket.NilDecodeFeedback); err != nil {
+		t.Fatalf("deserialise %v = error %v", wire, err)
+	}
+	if diff := cmp.Diff(destination, got); diff != "" {
+		t.Errorf("deserialise %v = %v, want %v: %v", wire, got, destination,
+			diff)
+	}
+}
+
+func TestAlertDestinationAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		address *AlertDestinationAddress
+		wire    []byte
+	}{
+		{
+			"ipv4",
+			&AlertDestinationAddress{
+				Selector:         2,
+				IP:               net.IPv4(192, 0, 2, 10),
+				UseBackupGateway: true,
+				MAC:              net.HardwareAddr{0x00, 0x11, 0x22, 0x33, 0x44, 0x55},
+			},
+			[]byte{
+				0x02, 0x00, 0x01,
+				0xc0, 0x00, 0x02, 0x0a,
+				0x00, 0x11, 0x22, 0x33, 0x44, 0x55,
+			},
+		},
+		{
+			"ipv6",
+			&AlertDestinationAddress{
+				Selector: 3,
+				IP:       net.ParseIP("2001:db8::a"),
+			},
+			[]byte{
+				0x03, 0x10,
+				0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x0a,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := gopacket.NewSerializeBuffer()
+			if err := test.address.Serialise(b); err != nil {
+				t.Fatalf("serialise %v = error %v", test.address, err)
+			}
+			if got := b.Bytes(); !bytes.Equal(got, test.wire) {
+				t.Errorf("serialise %v = %v, want %v", test.address, got,
+					test.wire)
+			}
+
+			address := &AlertDestinationAddress{}
+			remaining, err := address.Deserialise(test.wire, gopacket.NilDecodeFeedback)
+			if err != nil {
+				t.Fatalf("deserialise %v = error %v", test.wire, err)
+			}
+			if len(remaining) != 0 {
+				t.Errorf("deserialise %v left %v bytes", test.wire, len(remaining))
+			}
+			if diff := cmp.Diff(test.address, address); diff != "" {
+				t.Errorf("deserialise %v = %v, want %v: %v", test.wire, address,
+					test.address, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+)
+
+// AlertPolicyMode determines whether an alert policy entry is processed,
+// based on the outcome of the previous entry in the same policy. Modes are
+// specified in table 17-4 of IPMI v2.0. This is a 3-bit uint on the wire.
+type AlertPolicyMode uint8
+
+const (
+	// AlertPolicyModeAlways always sends an alert to the entry's
+	// destination.
+	AlertPolicyModeAlways AlertPolicyMode = iota
+
+	// AlertPolicyModeNextEntry skips the entry if the alert to the previous
+	// destination succeeded, proceeding to the next entry in the policy.
+	AlertPolicyModeNextEntry
+
+	// AlertPolicyModeStop skips the entry if the alert to the previous
+	// destination succeeded, and stops processing the policy.
+	AlertPolicyModeStop
+
+	// AlertPolicyModeNextChannel skips the entry if the alert to the previous
+	// destination succeeded, proceeding to the next entry in the policy on a
+	// different channel.
+	AlertPolicyModeNextChannel
+
+	// AlertPolicyModeNextDestinationType skips the entry if the alert to the
+	// previous destination succeeded, proceeding to the next entry in the
+	// policy with a different destination type.
+	AlertPolicyModeNextDestinationType
+)
+
+func (m AlertPolicyMode) Description() string {
+	switch m {
+	case AlertPolicyModeAlways:
+		return "Always"
+	case AlertPolicyModeNextEntry:
+		return "Next entry"
+	case AlertPolicyModeStop:
+		return "Stop"
+	case AlertPolicyModeNextChannel:
+		return "Next channel"
+	case AlertPolicyModeNextDestinationType:
+		return "Next destination type"
+	default:
+		return "Unknown"
+	}
+}
+
+func (m AlertPolicyMode) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(m), m.Description())
+}
+
+// AlertPolicy is an entry in the PEF alert policy table, specified in table
+// 17-4 of IPMI v2.0. It is the data of the Alert Policy Table PEF
+// configuration parameter, parameter 9 in table 30-6. A policy is the set of
+// entries sharing a policy number, processed in entry order; event filters
+// refer to policies by number. Each entry sends an alert to a destination on
+// a channel, e.g. a LAN alert destination.
+type AlertPolicy struct {
+
+	// Entry is the entry's position in the table, starting at 1. This is the
+	// set selector used to retrieve it, and a 7-bit uint on the wire.
+	Entry uint8
+
+	// Policy is the policy number the entry belongs to. This is a 4-bit uint
+	// on the wire.
+	Policy uint8
+
+	// Enabled indicates the entry is processed.
+	Enabled bool
+
+	// Mode determines whether the entry is processed, given the outcome of
+	// the previous entry.
+	Mode AlertPolicyMode
+
+	// Channel is the channel to send the alert over.
+	Channel Channel
+
+	// Destination selects the channel's alert destination, e.g. the set
+	// selector of the LAN Destination Type and Destination Addresses
+	// parameters. This is a 4-bit uint on the wire.
+	Destination uint8
+
+	// EventSpecificAlertString indicates the alert string is selected by
+	// the number of the event filter that matched, as well as
+	// AlertStringSet.
+	EventSpecificAlertString bool
+
+	// AlertStringSet selects the alert string sent with the alert. This is a
+	// 7-bit uint on the wire.
+	AlertStringSet uint8
+}
+
+// Serialise encodes the entry onto the end of a buffer, returning an error if
+// one occurs.
+func (p *AlertPolicy) Serialise(b gopacket.SerializeBuffer) error {
+	d, err := b.AppendBytes(4)
+	if err != nil {
+		return err
+	}
+	d[0] = p.Entry & 0x7f
+	d[1] = (p.Policy&0xf)<<4 | uint8(p.Mode)&0x7
+	if p.Enabled {
+		d[1] |= 1 << 3
+	}
+	d[2] = uint8(p.Channel)<<4 | p.Destination&0xf
+	d[3] = p.AlertStringSet & 0x7f
+	if p.EventSpecificAlertString {
+		d[3] |= 1 << 7
+	}
+	return nil
+}
+
+// Deserialise reads an entry from the supplied byte slice, returning
+// unconsumed remaining bytes.
+func (p *AlertPolicy) Deserialise(d []byte, df gopacket.DecodeFeedback) ([]byte, error) {
+	if len(d) < 4 {
+		df.SetTruncated()
+		return nil, fmt.Errorf("alert policy entries are 4 bytes, only %v "+
+			"remaining", len(d))
+	}
+	p.Entry = d[0] & 0x7f
+	p.Policy = d[1] >> 4
+	p.Enabled = d[1]&(1<<3) != 0
+	p.Mode = AlertPolicyMode(d[1] & 0x7)
+	p.Channel = Channel(d[2] >> 4)
+	p.Destination = d[2] & 0xf
+	p.EventSpecificAlertString = d[3]&(1<<7) != 0
+	p.AlertStringSet = d[3] & 0x7f
+	return d[4:], nil
+}
@@ -0,0 +1,39 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+)
+
+func TestAlertPolicy(t *testing.T) {
+	policy := &AlertPolicy{
+		Entry:                    1,
+		Policy:                   2,
+		Enabled:                  true,
+		Mode:                     AlertPolicyModeNextEntry,
+		Channel:                  1,
+		Destination:              3,
+		EventSpecificAlertString: true,
+		AlertStringSet:           4,
+	}
+	wire := []byte{0x01, 0x29, 0x13, 0x84}
+
+	b := gopacket.NewSerializeBuffer()
+	if err := policy.Serialise(b); err != nil {
+		t.Fatalf("serialise %v = error %v", policy, err)
+	}
+	if got := b.Bytes(); !bytes.Equal(got, wire) {
+		t.Errorf("serialise %v = %v, want %v", policy, got, wire)
+	}
+
+	got := &AlertPolicy{}
+	if _, err := got.Deserialise(wire, gopacket.NilDecodeFeedback); err != nil {
+		t.Fatalf("deserialise %v = error %v", wire, err)
+	}
+	if diff := cmp.Diff(policy, got); diff != "" {
+		t.Errorf("deserialise %v = %v, want %v: %v", wire, got, policy, diff)
+	}
+}
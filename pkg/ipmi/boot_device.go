@@ -0,0 +1,88 @@
+package ipmi
+
+import (
+	"fmt"
+)
+
+// BootDevice is the device the BIOS is asked to boot from, overriding its
+// configured boot order. Values are specified in the boot flags parameter of
+// table 28-14 of IPMI v2.0. This is a 4-bit uint on the wire.
+type BootDevice uint8
+
+const (
+	// BootDeviceNone means the BIOS boot order is not overridden.
+	BootDeviceNone BootDevice = 0x0
+
+	// BootDevicePXE forces a network boot.
+	BootDevicePXE BootDevice = 0x1
+
+	// BootDeviceDisk forces a boot from the default hard drive.
+	BootDeviceDisk BootDevice = 0x2
+
+	// BootDeviceDiskSafeMode forces a boot from the default hard drive,
+	// requesting safe mode.
+	BootDeviceDiskSafeMode BootDevice = 0x3
+
+	// BootDeviceDiagnosticPartition forces a boot from the default diagnostic
+	// partition.
+	BootDeviceDiagnosticPartition BootDevice = 0x4
+
+	// BootDeviceCDROM forces a boot from the default CD/DVD drive.
+	BootDeviceCDROM BootDevice = 0x5
+
+	// BootDeviceBIOSSetup forces the system into BIOS setup.
+	BootDeviceBIOSSetup BootDevice = 0x6
+
+	// BootDeviceRemoteFloppy forces a boot from remotely connected floppy or
+	// primary removable media.
+	BootDeviceRemoteFloppy BootDevice = 0x7
+
+	// BootDeviceRemoteCDROM forces a boot from remotely connected CD/DVD
+	// media.
+	BootDeviceRemoteCDROM BootDevice = 0x8
+
+	// BootDeviceRemotePrimaryMedia forces a boot from primary remote media.
+	BootDeviceRemotePrimaryMedia BootDevice = 0x9
+
+	// BootDeviceRemoteDisk forces a boot from a remotely connected hard
+	// drive.
+	BootDeviceRemoteDisk BootDevice = 0xb
+
+	// BootDeviceFloppy forces a boot from floppy or primary removable media.
+	BootDeviceFloppy BootDevice = 0xf
+)
+
+func (d BootDevice) Description() string {
+	switch d {
+	case BootDeviceNone:
+		return "No override"
+	case BootDevicePXE:
+		return "PXE"
+	case BootDeviceDisk:
+		return "Disk"
+	case BootDeviceDiskSafeMode:
+		return "Disk (safe mode)"
+	case BootDeviceDiagnosticPartition:
+		return "Diagnostic partition"
+	case BootDeviceCDROM:
+		return "CD/DVD"
+	case BootDeviceBIOSSetup:
+		return "BIOS setup"
+	case BootDeviceRemoteFloppy:
+		return "Remote floppy/primary removable media"
+	case BootDeviceRemoteCDROM:
+		return "Remote CD/DVD"
+	case BootDeviceRemotePrimaryMedia:
+		return "Primary remote media"
+	case BootDeviceRemoteDisk:
+		return "Remote disk"
+	case BootDeviceFloppy:
+		return "Floppy/primary removable media"
+	default:
+		return "Unknown"
+	}
+}
+
+func (d BootDevice) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(d), d.Description())
+}
@@ -0,0 +1,245 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+)
+
+// BootType indicates whether the BIOS should perform a legacy (PC compatible)
+// or EFI boot. This is a 1-bit uint on the wire.
+type BootType uint8
+
+const (
+	BootTypeLegacy BootType = iota
+	BootTypeEFI
+)
+
+func (t BootType) Description() string {
+	switch t {
+	case BootTypeLegacy:
+		return "Legacy"
+	case BootTypeEFI:
+		return "EFI"
+	default:
+		return "Unknown"
+	}
+}
+
+func (t BootType) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(t), t.Description())
+}
+
+// ConsoleRedirection controls whether the BIOS redirects its console to the
+// serial port, e.g. so it can be seen over SOL. This is a 2-bit uint on the
+// wire.
+type ConsoleRedirection uint8
+
+const (
+	// ConsoleRedirectionDefault leaves console redirection as configured in
+	// the BIOS.
+	ConsoleRedirectionDefault ConsoleRedirection = iota
+
+	// ConsoleRedirectionSuppress disables console redirection, if enabled.
+	ConsoleRedirectionSuppress
+
+	// ConsoleRedirectionEnable requests console redirection.
+	ConsoleRedirectionEnable
+)
+
+func (r ConsoleRedirection) Description() string {
+	switch r {
+	case ConsoleRedirectionDefault:
+		return "BIOS default"
+	case ConsoleRedirectionSuppress:
+		return "Suppress"
+	case ConsoleRedirectionEnable:
+		return "Enable"
+	default:
+		return "Unknown"
+	}
+}
+
+func (r ConsoleRedirection) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(r), r.Description())
+}
+
+// FirmwareVerbosity controls how much the BIOS displays while booting. This
+// is a 2-bit uint on the wire.
+type FirmwareVerbosity uint8
+
+const (
+	FirmwareVerbosityDefault FirmwareVerbosity = iota
+	FirmwareVerbosityQuiet
+	FirmwareVerbosityVerbose
+)
+
+func (v FirmwareVerbosity) Description() string {
+	switch v {
+	case FirmwareVerbosityDefault:
+		return "Default"
+	case FirmwareVerbosityQuiet:
+		return "Quiet"
+	case FirmwareVerbosityVerbose:
+		return "Verbose"
+	default:
+		return "Unknown"
+	}
+}
+
+func (v FirmwareVerbosity) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(v), v.Description())
+}
+
+// BootFlags is the data of the boot flags system boot option, parameter 5 in
+// table 28-14 of IPMI v2.0. It tells the BIOS how to boot the system next
+// time, or every time if persistent. The BMC clears Valid 60 seconds after it
+// is set unless the system restarts, so the flags should be set shortly
+// before a power cycle.
+type BootFlags struct {
+
+	// Valid indicates the flags should be acted upon. This must be set for
+	// any of the other fields to have an effect.
+	Valid bool
+
+	// Persistent applies the flags to all future boots, rather than only the
+	// next one.
+	Persistent bool
+
+	// BootType indicates whether a legacy or EFI boot should be performed.
+	BootType BootType
+
+	// ClearCMOS asks the BIOS to clear its CMOS settings.
+	ClearCMOS bool
+
+	// LockKeyboard locks the keyboard during boot.
+	LockKeyboard bool
+
+	// Device is the device to boot from.
+	Device BootDevice
+
+	// BlankScreen blanks the screen during boot.
+	BlankScreen bool
+
+	// LockResetButton disables the reset button during boot.
+	LockResetButton bool
+
+	// LockPowerButton disables the power button during boot.
+	LockPowerButton bool
+
+	// FirmwareVerbosity controls how much the BIOS displays while booting.
+	FirmwareVerbosity FirmwareVerbosity
+
+	// ForceProgressEventTraps asks the BIOS to send progress event traps.
+	ForceProgressEventTraps bool
+
+	// BypassUserPassword asks the BIOS to skip its user password prompt.
+	BypassUserPassword bool
+
+	// LockSleepButton disables the sleep button during boot.
+	LockSleepButton bool
+
+	// ConsoleRedirection controls whether the BIOS redirects its console to
+	// the serial port.
+	ConsoleRedirection ConsoleRedirection
+
+	// BIOSSharedModeOverride asks the BIOS to override the shared mode of the
+	// serial port.
+	BIOSSharedModeOverride bool
+
+	// BIOSMuxControlOverride controls the serial port multiplexer during
+	// boot. 0 leaves it as recommended by the BIOS. This is a 3-bit uint on
+	// the wire.
+	BIOSMuxControlOverride uint8
+
+	// DeviceInstance selects between several devices of the same type, e.g.
+	// multiple NICs for PXE. 0 means the BIOS default. This is a 5-bit uint
+	// on the wire.
+	DeviceInstance uint8
+}
+
+// Serialise encodes the boot flags onto the end of a buffer, returning an
+// error if one occurs.
+func (f *BootFlags) Serialise(b gopacket.SerializeBuffer) error {
+	d, err := b.AppendBytes(5)
+	if err != nil {
+		return err
+	}
+	d[0] = 0
+	if f.Valid {
+		d[0] |= 1 << 7
+	}
+	if f.Persistent {
+		d[0] |= 1 << 6
+	}
+	d[0] |= (uint8(f.BootType) & 1) << 5
+
+	d[1] = (uint8(f.Device) & 0xf) << 2
+	if f.ClearCMOS {
+		d[1] |= 1 << 7
+	}
+	if f.LockKeyboard {
+		d[1] |= 1 << 6
+	}
+	if f.BlankScreen {
+		d[1] |= 1 << 1
+	}
+	if f.LockResetButton {
+		d[1] |= 1
+	}
+
+	d[2] = (uint8(f.FirmwareVerbosity)&0x3)<<5 |
+		uint8(f.ConsoleRedirection)&0x3
+	if f.LockPowerButton {
+		d[2] |= 1 << 7
+	}
+	if f.ForceProgressEventTraps {
+		d[2] |= 1 << 4
+	}
+	if f.BypassUserPassword {
+		d[2] |= 1 << 3
+	}
+	if f.LockSleepButton {
+		d[2] |= 1 << 2
+	}
+
+	d[3] = f.BIOSMuxControlOverride & 0x7
+	if f.BIOSSharedModeOverride {
+		d[3] |= 1 << 3
+	}
+
+	d[4] = f.DeviceInstance & 0x1f
+	return nil
+}
+
+// Deserialise reads boot flags from the supplied byte slice, returning
+// unconsumed remaining bytes.
+func (f *BootFlags) Deserialise(d []byte, df gopacket.DecodeFeedback) ([]byte, error) {
+	if len(d) < 5 {
+		df.SetTruncated()
+		return nil, fmt.Errorf("boot flags are 5 bytes, only %v remaining",
+			len(d))
+	}
+	f.Valid = d[0]&(1<<7) != 0
+	f.Persistent = d[0]&(1<<6) != 0
+	f.BootType = BootType((d[0] >> 5) & 1)
+
+	f.ClearCMOS = d[1]&(1<<7) != 0
+	f.LockKeyboard = d[1]&(1<<6) != 0
+	f.Device = BootDevice((d[1] >> 2) & 0xf)
+	f.BlankScreen = d[1]&(1<<1) != 0
+	f.LockResetButton = d[1]&1 != 0
+
+	f.LockPowerButton = d[2]&(1<<7) != 0
+	f.FirmwareVerbosity = FirmwareVerbosity((d[2] >> 5) & 0x3)
+	f.ForceProgressEventTraps = d[2]&(1<<4) != 0
+	f.BypassUserPassword = d[2]&(1<<3) != 0
+	f.LockSleepButton = d[2]&(1<<2) != 0
+	f.ConsoleRedirection = ConsoleRedirection(d[2] & 0x3)
+
+	f.BIOSSharedModeOverride = d[3]&(1<<3) != 0
+	f.BIOSMuxControlOverride = d[3] & 0x7
+
+	f.DeviceInstance = d[4] & 0x1f
+	return d[5:], nil
+}
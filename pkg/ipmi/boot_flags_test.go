@@ -0,0 +1,93 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+)
+
+func TestBootFlags(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags *BootFlags
+		wire  []byte
+	}{
+		{
+			"no override",
+			&BootFlags{},
+			[]byte{0x00, 0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			"pxe next boot efi",
+			&BootFlags{
+				Valid:    true,
+				BootType: BootTypeEFI,
+				Device:   BootDevicePXE,
+			},
+			[]byte{0xa0, 0x04, 0x00, 0x00, 0x00},
+		},
+		{
+			"persistent bios setup with console redirection",
+			&BootFlags{
+				Valid:              true,
+				Persistent:         true,
+				Device:             BootDeviceBIOSSetup,
+				LockResetButton:    true,
+				FirmwareVerbosity:  FirmwareVerbosityVerbose,
+				ConsoleRedirection: ConsoleRedirectionEnable,
+				DeviceInstance:     0x11,
+			},
+			[]byte{0xc0, 0x19, 0x42, 0x00, 0x11},
+		},
+		{
+			"every other field",
+			&BootFlags{
+				ClearCMOS:               true,
+				LockKeyboard:            true,
+				Device:                  BootDeviceFloppy,
+				BlankScreen:             true,
+				LockPowerButton:         true,
+				ForceProgressEventTraps: true,
+				BypassUserPassword:      true,
+				LockSleepButton:         true,
+				BIOSSharedModeOverride:  true,
+				BIOSMuxControlOverride:  0x7,
+			},
+			[]byte{0x00, 0xfe, 0x9c, 0x0f, 0x00},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := gopacket.NewSerializeBuffer()
+			if err := test.flags.Serialise(b); err != nil {
+				t.Fatalf("serialise %v = error %v", test.flags, err)
+			}
+			if got := b.Bytes(); !bytes.Equal(got, test.wire) {
+				t.Errorf("serialise %v = %v, want %v", test.flags, got, test.wire)
+			}
+
+			flags := &BootFlags{}
+			remaining, err := flags.Deserialise(test.wire, gopacket.NilDecodeFeedback)
+			if err != nil {
+				t.Fatalf("deserialise %v = error %v", test.wire, err)
+			}
+			if len(remaining) != 0 {
+				t.Errorf("deserialise %v left %v bytes", test.wire, len(remaining))
+			}
+			if diff := cmp.Diff(test.flags, flags); diff != "" {
+				t.Errorf("deserialise %v = %v, want %v: %v", test.wire, flags,
+					test.flags, diff)
+			}
+		})
+	}
+}
+
+func TestBootFlagsDeserialiseTruncated(t *testing.T) {
+	flags := &BootFlags{}
+	if _, err := flags.Deserialise([]byte{0x80, 0x04, 0x00, 0x00},
+		gopacket.NilDecodeFeedback); err == nil {
+		t.Error("expected error deserialising 4 bytes, got none")
+	}
+}
@@ -0,0 +1,90 @@
+package ipmi
+
+import (
+	"fmt"
+)
+
+// BootOptionParameter identifies a system boot option, used in the Get and Set
+// System Boot Options commands. Parameters are specified in table 28-14 of
+// IPMI v2.0. This is a 7-bit uint on the wire.
+type BootOptionParameter uint8
+
+const (
+	// BootOptionParameterSetInProgress is used to indicate that boot options
+	// are being updated. Its data is 1 byte, the lower 2 bits of which are a
+	// SetInProgress value.
+	BootOptionParameterSetInProgress BootOptionParameter = iota
+
+	// BootOptionParameterServicePartitionSelector identifies the service
+	// partition to boot. Its data is 1 byte.
+	BootOptionParameterServicePartitionSelector
+
+	// BootOptionParameterServicePartitionScan asks the BIOS to scan for a
+	// service partition. Its data is 1 byte.
+	BootOptionParameterServicePartitionScan
+
+	// BootOptionParameterBootFlagValidBitClearing controls which events cause
+	// the BMC to clear the boot flags valid bit, which otherwise happens 60
+	// seconds after the boot flags are set. Its data is 1 byte.
+	BootOptionParameterBootFlagValidBitClearing
+
+	// BootOptionParameterBootInfoAcknowledge tracks which parts of the boot
+	// process have handled the boot flags. Its data is 2 bytes: a write mask,
+	// then a BootInitiators value, whose set bits indicate the boot info has
+	// not been handled by that initiator.
+	BootOptionParameterBootInfoAcknowledge
+
+	// BootOptionParameterBootFlags instructs the BIOS how to boot the system
+	// next time. Its data is 5 bytes; see BootFlags.
+	BootOptionParameterBootFlags
+
+	// BootOptionParameterBootInitiatorInfo identifies who initiated the last
+	// boot, and when. Its data is 9 bytes.
+	BootOptionParameterBootInitiatorInfo
+
+	// BootOptionParameterBootInitiatorMailbox is an area for passing data
+	// between the remote console and the boot initiator. Its data is a block
+	// selector followed by 16 bytes.
+	BootOptionParameterBootInitiatorMailbox
+)
+
+func (p BootOptionParameter) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(p), p.name())
+}
+
+func (p BootOptionParameter) name() string {
+	switch p {
+	case BootOptionParameterSetInProgress:
+		return "Set In Progress"
+	case BootOptionParameterServicePartitionSelector:
+		return "Service partition selector"
+	case BootOptionParameterServicePartitionScan:
+		return "Service partition scan"
+	case BootOptionParameterBootFlagValidBitClearing:
+		return "BMC boot flag valid bit clearing"
+	case BootOptionParameterBootInfoAcknowledge:
+		return "Boot info acknowledge"
+	case BootOptionParameterBootFlags:
+		return "Boot flags"
+	case BootOptionParameterBootInitiatorInfo:
+		return "Boot initiator info"
+	case BootOptionParameterBootInitiatorMailbox:
+		return "Boot initiator mailbox"
+	}
+	if p >= 96 {
+		return "OEM"
+	}
+	return "Unknown"
+}
+
+// BootInitiators is a bitfield of the parts of the boot process that can
+// acknowledge boot info, used in the Boot Info Acknowledge parameter.
+type BootInitiators uint8
+
+const (
+	BootInitiatorBIOS BootInitiators = 1 << iota
+	BootInitiatorOSLoader
+	BootInitiatorOSServicePartition
+	BootInitiatorSMS
+	BootInitiatorOEM
+)
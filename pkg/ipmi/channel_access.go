@@ -0,0 +1,107 @@
+package ipmi
+
+import (
+	"fmt"
+)
+
+// ChannelAccess contains the access settings of a channel. It is used by the
+// Get and Set Channel Access commands.
+type ChannelAccess struct {
+
+	// PEFAlertingDisabled indicates PEF alerts are not sent over the channel.
+	PEFAlertingDisabled bool
+
+	// PerMessageAuthenticationDisabled indicates only the Activate Session
+	// request needs to be authenticated, rather than every message. This
+	// only applies to IPMI v1.5 sessions.
+	PerMessageAuthenticationDisabled bool
+
+	// UserLevelAuthenticationDisabled indicates commands requiring only the
+	// User privilege level do not have to be authenticated.
+	UserLevelAuthenticationDisabled bool
+
+	// Mode indicates when the channel is available.
+	Mode ChannelAccessMode
+
+	// PrivilegeLimit is the maximum privilege level that can be requested on
+	// the channel, regardless of the user's privilege limit.
+	PrivilegeLimit PrivilegeLevel
+}
+
+// ChannelAccessMode indicates when a channel can be used for IPMI messaging.
+// It is specified in table 22-28 of IPMI v2.0, and is a 3-bit uint on the
+// wire.
+type ChannelAccessMode uint8
+
+const (
+	// ChannelAccessModeDisabled means the channel cannot be used for IPMI
+	// messaging.
+	ChannelAccessModeDisabled ChannelAccessMode = iota
+
+	// ChannelAccessModePreBoot means the channel is only available while
+	// the system is powered down or in BIOS.
+	ChannelAccessModePreBoot
+
+	// ChannelAccessModeAlwaysAvailable means the channel is always available
+	// for IPMI messaging.
+	ChannelAccessModeAlwaysAvailable
+
+	// ChannelAccessModeShared means the channel is always available, and
+	// shared with the system software, e.g. a serial port also used as a
+	// console.
+	ChannelAccessModeShared
+)
+
+func (m ChannelAccessMode) Description() string {
+	switch m {
+	case ChannelAccessModeDisabled:
+		return "Disabled"
+	case ChannelAccessModePreBoot:
+		return "Pre-boot only"
+	case ChannelAccessModeAlwaysAvailable:
+		return "Always available"
+	case ChannelAccessModeShared:
+		return "Shared"
+	default:
+		return "Unknown"
+	}
+}
+
+func (m ChannelAccessMode) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(m), m.Description())
+}
+
+// ChannelAccessType selects between the non-volatile channel access
+// settings, which are restored when the BMC resets, and the volatile
+// settings, which are currently in effect. This is a 2-bit uint on the wire.
+type ChannelAccessType uint8
+
+const (
+	// ChannelAccessTypeUnchanged is used in Set Channel Access requests to
+	// leave settings unchanged. It is invalid in Get Channel Access requests.
+	ChannelAccessTypeUnchanged ChannelAccessType = iota
+
+	// ChannelAccessTypeNonVolatile refers to the settings restored when the
+	// BMC resets.
+	ChannelAccessTypeNonVolatile
+
+	// ChannelAccessTypeVolatile refers to the settings currently in effect.
+	ChannelAccessTypeVolatile
+)
+
+func (t ChannelAccessType) Description() string {
+	switch t {
+	case ChannelAccessTypeUnchanged:
+		return "Unchanged"
+	case ChannelAccessTypeNonVolatile:
+		return "Non-volatile"
+	case ChannelAccessTypeVolatile:
+		return "Volatile"
+	default:
+		return "Unknown"
+	}
+}
+
+func (t ChannelAccessType) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(t), t.Description())
+}
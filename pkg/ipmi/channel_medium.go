@@ -0,0 +1,91 @@
+package ipmi
+
+import (
+	"fmt"
+)
+
+// ChannelMedium identifies the physical transport of a channel. Channel
+// medium types are specified in table 6-3 of IPMI v1.5 and v2.0, and
+// returned by the Get Channel Info command. This is a 7-bit uint on the wire.
+type ChannelMedium uint8
+
+const (
+	ChannelMediumIPMB ChannelMedium = iota + 1
+
+	// ChannelMediumICMB10 is ICMB v1.0.
+	ChannelMediumICMB10
+
+	// ChannelMediumICMB09 is ICMB v0.9.
+	ChannelMediumICMB09
+
+	// ChannelMediumLAN is an 802.3 LAN channel, over which sessions can be
+	// established.
+	ChannelMediumLAN
+
+	// ChannelMediumSerial is an RS-232 serial or modem channel.
+	ChannelMediumSerial
+
+	// ChannelMediumOtherLAN is a LAN channel that is not 802.3.
+	ChannelMediumOtherLAN
+
+	ChannelMediumPCISMBus
+
+	// ChannelMediumSMBus1 is SMBus v1.0 or v1.1.
+	ChannelMediumSMBus1
+
+	// ChannelMediumSMBus2 is SMBus v2.0.
+	ChannelMediumSMBus2
+
+	// ChannelMediumUSB1 is USB 1.x.
+	ChannelMediumUSB1
+
+	// ChannelMediumUSB2 is USB 2.x.
+	ChannelMediumUSB2
+
+	// ChannelMediumSystemInterface is the system interface, e.g. KCS, SMIC or
+	// BT.
+	ChannelMediumSystemInterface
+)
+
+// IsLAN returns whether the medium is a LAN, over which sessions can be
+// established.
+func (m ChannelMedium) IsLAN() bool {
+	return m == ChannelMediumLAN || m == ChannelMediumOtherLAN
+}
+
+func (m ChannelMedium) Description() string {
+	switch m {
+	case ChannelMediumIPMB:
+		return "IPMB (I2C)"
+	case ChannelMediumICMB10:
+		return "ICMB v1.0"
+	case ChannelMediumICMB09:
+		return "ICMB v0.9"
+	case ChannelMediumLAN:
+		return "802.3 LAN"
+	case ChannelMediumSerial:
+		return "Asynch. Serial/Modem (RS-232)"
+	case ChannelMediumOtherLAN:
+		return "Other LAN"
+	case ChannelMediumPCISMBus:
+		return "PCI SMBus"
+	case ChannelMediumSMBus1:
+		return "SMBus v1.0/1.1"
+	case ChannelMediumSMBus2:
+		return "SMBus v2.0"
+	case ChannelMediumUSB1:
+		return "USB 1.x"
+	case ChannelMediumUSB2:
+		return "USB 2.x"
+	case ChannelMediumSystemInterface:
+		return "System Interface (KCS, SMIC, or BT)"
+	}
+	if 0x60 <= m && m <= 0x7f {
+		return "OEM"
+	}
+	return "Unknown"
+}
+
+func (m ChannelMedium) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(m), m.Description())
+}
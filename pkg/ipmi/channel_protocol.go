@@ -0,0 +1,66 @@
+package ipmi
+
+import (
+	"fmt"
+)
+
+// ChannelProtocol identifies the protocol used to carry IPMI messages over a
+// channel. Channel protocol types are specified in table 6-2 of IPMI v1.5 and
+// v2.0, and returned by the Get Channel Info command. This is a 5-bit uint on
+// the wire.
+type ChannelProtocol uint8
+
+const (
+	// ChannelProtocolIPMB is IPMB-1.0, used for IPMI messaging over IPMB,
+	// serial and LAN channels.
+	ChannelProtocolIPMB ChannelProtocol = iota + 1
+
+	// ChannelProtocolICMB is ICMB-1.0.
+	ChannelProtocolICMB
+
+	_
+
+	// ChannelProtocolSMBus is IPMI over SMBus.
+	ChannelProtocolSMBus
+
+	ChannelProtocolKCS
+	ChannelProtocolSMIC
+
+	// ChannelProtocolBT10 is the Block Transfer protocol of IPMI v1.0.
+	ChannelProtocolBT10
+
+	// ChannelProtocolBT15 is the Block Transfer protocol of IPMI v1.5.
+	ChannelProtocolBT15
+
+	// ChannelProtocolTMode is the terminal mode of serial channels.
+	ChannelProtocolTMode
+)
+
+func (p ChannelProtocol) Description() string {
+	switch p {
+	case ChannelProtocolIPMB:
+		return "IPMB-1.0"
+	case ChannelProtocolICMB:
+		return "ICMB-1.0"
+	case ChannelProtocolSMBus:
+		return "IPMI-SMBus"
+	case ChannelProtocolKCS:
+		return "KCS"
+	case ChannelProtocolSMIC:
+		return "SMIC"
+	case ChannelProtocolBT10:
+		return "BT-10"
+	case ChannelProtocolBT15:
+		return "BT-15"
+	case ChannelProtocolTMode:
+		return "TMode"
+	}
+	if 0x1c <= p && p <= 0x1f {
+		return "OEM"
+	}
+	return "Unknown"
+}
+
+func (p ChannelProtocol) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(p), p.Description())
+}
@@ -0,0 +1,47 @@
+package ipmi
+
+import (
+	"fmt"
+)
+
+// ChannelSessionSupport indicates whether sessions can be established over a
+// channel, and if so how many. It is returned by the Get Channel Info
+// command, specified in 18.24 and 22.24 of IPMI v1.5 and v2.0 respectively.
+// This is a 2-bit uint on the wire.
+type ChannelSessionSupport uint8
+
+const (
+	ChannelSessionSupportSessionless ChannelSessionSupport = iota
+	ChannelSessionSupportSingleSession
+	ChannelSessionSupportMultiSession
+
+	// ChannelSessionSupportSessionBased means the channel can run in single
+	// or multi-session mode, e.g. a serial channel that can be switched
+	// between basic and terminal mode.
+	ChannelSessionSupportSessionBased
+)
+
+// SupportsSessions returns whether sessions can be established over the
+// channel.
+func (s ChannelSessionSupport) SupportsSessions() bool {
+	return s != ChannelSessionSupportSessionless
+}
+
+func (s ChannelSessionSupport) Description() string {
+	switch s {
+	case ChannelSessionSupportSessionless:
+		return "Session-less"
+	case ChannelSessionSupportSingleSession:
+		return "Single-session"
+	case ChannelSessionSupportMultiSession:
+		return "Multi-session"
+	case ChannelSessionSupportSessionBased:
+		return "Session-based"
+	default:
+		return "Unknown"
+	}
+}
+
+func (s ChannelSessionSupport) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(s), s.Description())
+}
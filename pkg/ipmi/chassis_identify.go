@@ -0,0 +1,75 @@
+package ipmi
+
+import (
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// ChassisIdentifyReq represents a Chassis Identify command, specified in
+// section 22.5 and 28.5 of IPMI v1.5 and 2.0 respectively. It controls the
+// chassis identification mechanism, usually a flashing light on the front
+// panel, to help locate a system. Its state can be read back in the Get
+// Chassis Status command.
+type ChassisIdentifyReq struct {
+	layers.BaseLayer
+
+	// Interval is how long the mechanism should remain active for. A value of
+	// 0 turns it off. It is sent as a whole number of seconds, so is
+	// truncated, and clamped to 0-255 seconds. Some BMCs impose a shorter
+	// limit.
+	Interval time.Duration
+
+	// ForceOn activates the mechanism indefinitely, overriding Interval. It is
+	// only sent when true, as IPMI v1.5 BMCs do not understand it; this also
+	// means an indefinite identify can only be turned off by an Interval of
+	// 0.
+	ForceOn bool
+}
+
+func (*ChassisIdentifyReq) LayerType() gopacket.LayerType {
+	return LayerTypeChassisIdentifyReq
+}
+
+func (c *ChassisIdentifyReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	length := 1
+	if c.ForceOn {
+		length++
+	}
+	bytes, err := b.PrependBytes(length)
+	if err != nil {
+		return err
+	}
+	bytes[0] = uint8(max(min(c.Interval/time.Second, 0xff), 0))
+	if c.ForceOn {
+		bytes[1] = 1
+	}
+	return nil
+}
+
+type ChassisIdentifyCmd struct {
+	Req ChassisIdentifyReq
+}
+
+// Name returns "Chassis Identify".
+func (*ChassisIdentifyCmd) Name() string {
+	return "Chassis Identify"
+}
+
+// Operation returns OperationChassisIdentifyReq.
+func (*ChassisIdentifyCmd) Operation() *Operation {
+	return &OperationChassisIdentifyReq
+}
+
+func (c *ChassisIdentifyCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (c *ChassisIdentifyCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *ChassisIdentifyCmd) Response() gopacket.DecodingLayer {
+	return nil
+}
@@ -0,0 +1,57 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+)
+
+func TestChassisIdentifyReqSerializeTo(t *testing.T) {
+	tests := []struct {
+		layer *ChassisIdentifyReq
+		want  []byte
+	}{
+		{
+			&ChassisIdentifyReq{},
+			[]byte{0x00},
+		},
+		{
+			&ChassisIdentifyReq{
+				Interval: 15*time.Second + 999*time.Millisecond,
+			},
+			[]byte{0x0f},
+		},
+		{
+			&ChassisIdentifyReq{
+				Interval: time.Hour,
+			},
+			[]byte{0xff},
+		},
+		{
+			&ChassisIdentifyReq{
+				Interval: -time.Minute,
+			},
+			[]byte{0x00},
+		},
+		{
+			&ChassisIdentifyReq{
+				ForceOn: true,
+			},
+			[]byte{0x00, 0x01},
+		},
+	}
+	opts := gopacket.SerializeOptions{}
+	for _, test := range tests {
+		sb := gopacket.NewSerializeBuffer()
+		if err := test.layer.SerializeTo(sb, opts); err != nil {
+			t.Errorf("serialize %v = error %v, want %v", test.layer, err,
+				test.want)
+			continue
+		}
+		if got := sb.Bytes(); !bytes.Equal(got, test.want) {
+			t.Errorf("serialize %v = %v, want %v", test.layer, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package ipmi
+
+import (
+	"fmt"
+)
+
+// ChassisType describes the physical form of a chassis, e.g. tower or rack
+// mount. It is used in the FRU Chassis Info Area, and its values are defined
+// in the SMBIOS specification's System Enclosure or Chassis Types table.
+type ChassisType uint8
+
+const (
+	_ ChassisType = iota
+	ChassisTypeOther
+	ChassisTypeUnknown
+	ChassisTypeDesktop
+	ChassisTypeLowProfileDesktop
+	ChassisTypePizzaBox
+	ChassisTypeMiniTower
+	ChassisTypeTower
+	ChassisTypePortable
+	ChassisTypeLaptop
+	ChassisTypeNotebook
+	ChassisTypeHandHeld
+	ChassisTypeDockingStation
+	ChassisTypeAllInOne
+	ChassisTypeSubNotebook
+	ChassisTypeSpaceSaving
+	ChassisTypeLunchBox
+	ChassisTypeMainServer
+	ChassisTypeExpansion
+	ChassisTypeSubChassis
+	ChassisTypeBusExpansion
+	ChassisTypePeripheral
+	ChassisTypeRAID
+	ChassisTypeRackMount
+	ChassisTypeSealedCasePC
+	ChassisTypeMultiSystem
+	ChassisTypeCompactPCI
+	ChassisTypeAdvancedTCA
+	ChassisTypeBlade
+	ChassisTypeBladeEnclosure
+	ChassisTypeTablet
+	ChassisTypeConvertible
+	ChassisTypeDetachable
+	ChassisTypeIoTGateway
+	ChassisTypeEmbeddedPC
+	ChassisTypeMiniPC
+	ChassisTypeStickPC
+)
+
+var (
+	chassisTypeDescriptions = map[ChassisType]string{
+		ChassisTypeOther:             "Other",
+		ChassisTypeUnknown:           "Unknown",
+		ChassisTypeDesktop:           "Desktop",
+		ChassisTypeLowProfileDesktop: "Low Profile Desktop",
+		ChassisTypePizzaBox:          "Pizza Box",
+		ChassisTypeMiniTower:         "Mini Tower",
+		ChassisTypeTower:             "Tower",
+		ChassisTypePortable:          "Portable",
+		ChassisTypeLaptop:            "Laptop",
+		ChassisTypeNotebook:          "Notebook",
+		ChassisTypeHandHeld:          "Hand Held",
+		ChassisTypeDockingStation:    "Docking Station",
+		ChassisTypeAllInOne:          "All in One",
+		ChassisTypeSubNotebook:       "Sub Notebook",
+		ChassisTypeSpaceSaving:       "Space-saving",
+		ChassisTypeLunchBox:          "Lunch Box",
+		ChassisTypeMainServer:        "Main Server Chassis",
+		ChassisTypeExpansion:         "Expansion Chassis",
+		ChassisTypeSubChassis:        "SubChassis",
+		ChassisTypeBusExpansion:      "Bus Expansion Chassis",
+		ChassisTypePeripheral:        "Peripheral Chassis",
+		ChassisTypeRAID:              "RAID Chassis",
+		ChassisTypeRackMount:         "Rack Mount Chassis",
+		ChassisTypeSealedCasePC:      "Sealed-case PC",
+		ChassisTypeMultiSystem:       "Multi-system Chassis",
+		ChassisTypeCompactPCI:        "Compact PCI",
+		ChassisTypeAdvancedTCA:       "Advanced TCA",
+		ChassisTypeBlade:             "Blade",
+		ChassisTypeBladeEnclosure:    "Blade Enclosure",
+		ChassisTypeTablet:            "Tablet",
+		ChassisTypeConvertible:       "Convertible",
+		ChassisTypeDetachable:        "Detachable",
+		ChassisTypeIoTGateway:        "IoT Gateway",
+		ChassisTypeEmbeddedPC:        "Embedded PC",
+		ChassisTypeMiniPC:            "Mini PC",
+		ChassisTypeStickPC:           "Stick PC",
+	}
+)
+
+func (t ChassisType) Description() string {
+	if desc, ok := chassisTypeDescriptions[t]; ok {
+		return desc
+	}
+	return "Unknown"
+}
+
+func (t ChassisType) String() string {
+	return fmt.Sprintf("%#x(%v)", uint8(t), t.Description())
+}
@@ -27,6 +27,29 @@ var (
 		ConfidentialityAlgorithmAESCBC128,
 	}
 
+	// CipherSuite5 represents Cipher Suite 5 (RAKP-HMAC-SHA1/HMAC-SHA1-96/xRC4-40).
+	// Its 40-bit confidentiality key can be brute-forced.
+	CipherSuite5 = CipherSuite{
+		AuthenticationAlgorithmHMACSHA1,
+		IntegrityAlgorithmHMACSHA196,
+		ConfidentialityAlgorithmXRC440,
+	}
+
+	// CipherSuite9 represents Cipher Suite 9 (RAKP-HMAC-MD5/HMAC-MD5-128/xRC4-128).
+	CipherSuite9 = CipherSuite{
+		AuthenticationAlgorithmHMACMD5,
+		IntegrityAlgorithmHMACMD5128,
+		ConfidentialityAlgorithmXRC4128,
+	}
+
+	// CipherSuite10 represents Cipher Suite 10 (RAKP-HMAC-MD5/HMAC-MD5-128/xRC4-40).
+	// Its 40-bit confidentiality key can be brute-forced.
+	CipherSuite10 = CipherSuite{
+		AuthenticationAlgorithmHMACMD5,
+		IntegrityAlgorithmHMACMD5128,
+		ConfidentialityAlgorithmXRC440,
+	}
+
 	// CipherSuite17 represents Cipher Suite 17 (RAKP-HMAC-SHA256/HMAC-SHA256-128/AES-CBC-128),
 	// which is supported by newer BMCs.
 	CipherSuite17 = CipherSuite{
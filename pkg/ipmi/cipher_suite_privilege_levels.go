@@ -0,0 +1,49 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+)
+
+// CipherSuitePrivilegeLevels is the data of the RMCP+ messaging cipher suite
+// privilege levels LAN configuration parameter, parameter 24 in table 23-4 of
+// IPMI v2.0. It contains the maximum privilege level a session can be
+// established with using each cipher suite. Each element corresponds to the
+// cipher suite ID at the same index in the RMCP+ messaging cipher suite
+// entries parameter, rather than the ID itself. Elements beyond the number
+// of supported cipher suites are PrivilegeLevelHighest, which is reserved in
+// this context and indicates no restriction is configured. This is a 4-bit
+// uint per entry on the wire.
+type CipherSuitePrivilegeLevels [16]PrivilegeLevel
+
+// Serialise encodes the privilege levels onto the end of a buffer, returning
+// an error if one occurs.
+func (l *CipherSuitePrivilegeLevels) Serialise(b gopacket.SerializeBuffer) error {
+	d, err := b.AppendBytes(1 + len(l)/2)
+	if err != nil {
+		return err
+	}
+	d[0] = 0 // reserved
+	for i := 0; i < len(l); i += 2 {
+		d[1+i/2] = uint8(l[i+1]&0xf)<<4 | uint8(l[i]&0xf)
+	}
+	return nil
+}
+
+// Deserialise reads privilege levels from the supplied byte slice, returning
+// unconsumed remaining bytes.
+func (l *CipherSuitePrivilegeLevels) Deserialise(d []byte, df gopacket.DecodeFeedback) ([]byte, error) {
+	length := 1 + len(l)/2
+	if len(d) < length {
+		df.SetTruncated()
+		return nil, fmt.Errorf("cipher suite privilege levels are %v "+
+			"bytes, only %v remaining", length, len(d))
+	}
+	for i := 0; i < len(l); i += 2 {
+		b := d[1+i/2]
+		l[i] = PrivilegeLevel(b & 0xf)
+		l[i+1] = PrivilegeLevel(b >> 4)
+	}
+	return d[length:], nil
+}
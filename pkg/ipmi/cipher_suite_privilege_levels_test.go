@@ -0,0 +1,44 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+)
+
+func TestCipherSuitePrivilegeLevels(t *testing.T) {
+	levels := &CipherSuitePrivilegeLevels{
+		PrivilegeLevelCallback,
+		PrivilegeLevelUser,
+		PrivilegeLevelOperator,
+		PrivilegeLevelAdministrator,
+		PrivilegeLevelOEM,
+	}
+	wire := []byte{0x00, 0x21, 0x43, 0x05, 0x00, 0x00, 0x00, 0x00, 0x00}
+
+	b := gopacket.NewSerializeBuffer()
+	if err := levels.Serialise(b); err != nil {
+		t.Fatalf("serialise %v = error %v", levels, err)
+	}
+	if got := b.Bytes(); !bytes.Equal(got, wire) {
+		t.Errorf("serialise %v = %v, want %v", levels, got, wire)
+	}
+
+	got := &CipherSuitePrivilegeLevels{}
+	remaining, err := got.Deserialise(append(wire, 0xff), gopacket.NilDecodeFeedback)
+	if err != nil {
+		t.Fatalf("deserialise %v = error %v", wire, err)
+	}
+	if len(remaining) != 1 {
+		t.Errorf("deserialise %v left %v bytes, want 1", wire, len(remaining))
+	}
+	if diff := cmp.Diff(levels, got); diff != "" {
+		t.Errorf("deserialise %v = %v, want %v: %v", wire, got, levels, diff)
+	}
+
+	if _, err := got.Deserialise(wire[:8], gopacket.NilDecodeFeedback); err == nil {
+		t.Error("expected error deserialising 8 bytes, got none")
+	}
+}
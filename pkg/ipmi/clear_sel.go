@@ -0,0 +1,107 @@
+package ipmi
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// ClearSELReq represents a request to erase the entire System Event Log, or
+// to find out whether a previously initiated erasure has completed. This
+// command is specified in 25.9 and 31.9 of IPMI v1.5 and v2.0 respectively.
+// Erasure can take some time, so the status must be polled until it is
+// complete.
+type ClearSELReq struct {
+	layers.BaseLayer
+
+	// ReservationID must be a current reservation obtained via Reserve SEL.
+	ReservationID ReservationID
+
+	// Initiate indicates whether to start erasing the SEL. If false, the
+	// request only retrieves the erasure status.
+	Initiate bool
+}
+
+func (*ClearSELReq) LayerType() gopacket.LayerType {
+	return LayerTypeClearSELReq
+}
+
+func (c *ClearSELReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(6)
+	if err != nil {
+		return err
+	}
+	binary.LittleEndian.PutUint16(bytes[0:2], uint16(c.ReservationID))
+	// required to guard against accidental erasure
+	bytes[2] = 'C'
+	bytes[3] = 'L'
+	bytes[4] = 'R'
+	if c.Initiate {
+		bytes[5] = 0xaa
+	} else {
+		bytes[5] = 0x00
+	}
+	return nil
+}
+
+// ClearSELRsp contains the progress of a SEL erasure.
+type ClearSELRsp struct {
+	layers.BaseLayer
+
+	// Complete indicates whether the erasure has finished. If false, it is in
+	// progress.
+	Complete bool
+}
+
+func (*ClearSELRsp) LayerType() gopacket.LayerType {
+	return LayerTypeClearSELRsp
+}
+
+func (c *ClearSELRsp) CanDecode() gopacket.LayerClass {
+	return c.LayerType()
+}
+
+func (*ClearSELRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (c *ClearSELRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 1 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be at least 1 byte, got %v", len(data))
+	}
+
+	c.BaseLayer.Contents = data[:1]
+	c.BaseLayer.Payload = data[1:]
+	c.Complete = data[0]&0xf == 1
+	return nil
+}
+
+type ClearSELCmd struct {
+	Req ClearSELReq
+	Rsp ClearSELRsp
+}
+
+// Name returns "Clear SEL".
+func (*ClearSELCmd) Name() string {
+	return "Clear SEL"
+}
+
+// Operation returns &OperationClearSELReq.
+func (*ClearSELCmd) Operation() *Operation {
+	return &OperationClearSELReq
+}
+
+func (c *ClearSELCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (c *ClearSELCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *ClearSELCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
@@ -0,0 +1,87 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestClearSELReqSerializeTo(t *testing.T) {
+	table := []struct {
+		layer *ClearSELReq
+		want  []byte
+	}{
+		{
+			&ClearSELReq{
+				ReservationID: 0x1234,
+				Initiate:      true,
+			},
+			[]byte{0x34, 0x12, 'C', 'L', 'R', 0xaa},
+		},
+		{
+			&ClearSELReq{
+				ReservationID: 0x1234,
+			},
+			[]byte{0x34, 0x12, 'C', 'L', 'R', 0x00},
+		},
+	}
+	for _, test := range table {
+		sb := gopacket.NewSerializeBuffer()
+		if err := test.layer.SerializeTo(sb, gopacket.SerializeOptions{}); err != nil {
+			t.Errorf("serialize %v failed: %v", test.layer, err)
+			continue
+		}
+		if got := sb.Bytes(); !bytes.Equal(got, test.want) {
+			t.Errorf("serialize %v = %v, want %v", test.layer, got, test.want)
+		}
+	}
+}
+
+func TestClearSELRspDecodeFromBytes(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want *ClearSELRsp
+	}{
+		// too short
+		{
+			[]byte{},
+			nil,
+		},
+		{
+			[]byte{0x00},
+			&ClearSELRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x00},
+					Payload:  []byte{},
+				},
+			},
+		},
+		{
+			[]byte{0x01},
+			&ClearSELRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x01},
+					Payload:  []byte{},
+				},
+				Complete: true,
+			},
+		},
+	}
+	for _, test := range tests {
+		rsp := &ClearSELRsp{}
+		err := rsp.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("expected error decoding %v, got none", test.in)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, rsp); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, rsp, test.want, diff)
+			}
+		case err != nil && test.want != nil:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
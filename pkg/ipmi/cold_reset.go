@@ -0,0 +1,35 @@
+package ipmi
+
+import (
+	"github.com/google/gopacket"
+)
+
+// ColdResetCmd implements the Cold Reset command, specified in 17.2 and 20.2
+// of IPMI v1.5 and v2.0 respectively. It resets the BMC as if it had been
+// power cycled, re-initialising its hardware, and ending all sessions. Sensor
+// scanning restarts, so readings may be unavailable for a while afterwards.
+// The BMC may reset before responding. Neither the request nor response
+// contain data.
+type ColdResetCmd struct{}
+
+// Name returns "Cold Reset".
+func (*ColdResetCmd) Name() string {
+	return "Cold Reset"
+}
+
+// Operation returns &OperationColdResetReq.
+func (*ColdResetCmd) Operation() *Operation {
+	return &OperationColdResetReq
+}
+
+func (*ColdResetCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (*ColdResetCmd) Request() gopacket.SerializableLayer {
+	return nil
+}
+
+func (*ColdResetCmd) Response() gopacket.DecodingLayer {
+	return nil
+}
@@ -0,0 +1,128 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// CompactSensorRecord is specified in 37.2 and 43.2 of v1.5 and v2.0
+// respectively. It describes a sensor without analogue readings, e.g. a
+// presence or redundancy sensor, and can be shared by several sensors with
+// consecutive numbers. This layer represents the record key and record body
+// sections.
+type CompactSensorRecord struct {
+	layers.BaseLayer
+	SensorRecordKey
+	SensorRecordSharing
+
+	// IsContainerEntity indicates whether we should treat the entity as a
+	// logical container entity, as opposed to a physical entity.
+	IsContainerEntity bool
+
+	// Entity describes the type of component that the sensor monitors.
+	Entity EntityID
+
+	// Instance distinguishes between multiple occurrences of the entity.
+	Instance EntityInstance
+
+	// Ignore indicates whether we should ignore the sensor if its entity is
+	// absent or disabled.
+	Ignore bool
+
+	// SensorType indicates what is being monitored. For discrete sensors,
+	// this determines the meaning of sensor-specific states.
+	SensorType SensorType
+
+	// OutputType contains the Event/Reading Type Code of the underlying sensor.
+	OutputType OutputType
+
+	// Direction indicates whether the sensor is monitoring input or output of
+	// the entity.
+	Direction SensorDirection
+
+	// Identity is a descriptive string for the sensor, excluding the
+	// modifier added for shared records; see Expand().
+	Identity string
+}
+
+func (*CompactSensorRecord) LayerType() gopacket.LayerType {
+	return LayerTypeCompactSensorRecord
+}
+
+func (r *CompactSensorRecord) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*CompactSensorRecord) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *CompactSensorRecord) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 27 {
+		df.SetTruncated()
+		return fmt.Errorf("Compact Sensor Records are at least 27 bytes long, got %v",
+			len(data))
+	}
+
+	// as with Full Sensor Records, add 6 to get the byte number in the
+	// specification
+
+	r.OwnerAddress = Address(data[0])
+	r.Channel = Channel(data[1] >> 4)
+	r.OwnerLUN = LUN(data[1] & 0x3)
+	r.Number = uint8(data[2])
+
+	r.Entity = EntityID(data[3])
+	r.IsContainerEntity = data[4]&(1<<7) != 0
+	r.Instance = EntityInstance(data[4] & 0x7f)
+
+	r.Ignore = data[6]&(1<<7) != 0
+
+	r.SensorType = SensorType(data[7])
+	r.OutputType = OutputType(data[8])
+
+	r.Direction = SensorDirection(data[18] >> 6)
+	r.SensorRecordSharing.decode(data[18:20])
+
+	identity, consumed, err := decodeIDString(data[26:])
+	if err != nil {
+		return err
+	}
+	r.Identity = identity
+	r.BaseLayer.Contents = data[:26+consumed]
+	r.BaseLayer.Payload = data[26+consumed:]
+	return nil
+}
+
+// Key returns the record key of the first sensor sharing the record.
+func (r *CompactSensorRecord) Key() SensorRecordKey {
+	return r.SensorRecordKey
+}
+
+// Name returns the Identity of the first sensor sharing the record.
+func (r *CompactSensorRecord) Name() string {
+	return r.Identity
+}
+
+func (r *CompactSensorRecord) Kind() (SensorType, OutputType) {
+	return r.SensorType, r.OutputType
+}
+
+func (r *CompactSensorRecord) Monitored() (EntityID, EntityInstance) {
+	return r.Entity, r.Instance
+}
+
+// Expand returns a record for each sensor sharing this one, with the sensor
+// number, entity instance and ID String modified accordingly. If the record
+// is not shared, it is returned as the sole element.
+func (r *CompactSensorRecord) Expand() []*CompactSensorRecord {
+	records := make([]*CompactSensorRecord, r.Sensors())
+	for i := range records {
+		shared := *r
+		r.share(i, &shared.Number, &shared.Instance, &shared.Identity)
+		records[i] = &shared
+	}
+	return records
+}
@@ -0,0 +1,144 @@
+package ipmi
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestCompactSensorRecordDecodeFromBytes(t *testing.T) {
+	record := []byte{
+		// key
+		0x20, // owned by the BMC
+		0x00, // channel 0, LUN 0
+		0x40, // sensor number 0x40
+
+		// body
+		0x0a,                               // power supply entity ID
+		0x01,                               // physical entity, instance 1
+		0x7f,                               // sensor initialisation
+		0x40,                               // sensor capabilities; don't ignore
+		0x08,                               // sensor type: power supply
+		0x6f,                               // sensor-specific Event/Reading Type Code
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // masks
+		0xc0,       // units 1: not analog
+		0x00, 0x00, // units 2 and 3
+		0x52,       // input, alpha modifier, shared by 2 sensors
+		0x80,       // entity instance increments, modifier offset 0
+		0x00, 0x00, // hysteresis
+		0x00, 0x00, 0x00, // reserved
+		0x00,                         // OEM
+		0xc4, 0x50, 0x53, 0x55, 0x20, // "PSU "
+	}
+	tests := []struct {
+		in   []byte
+		want *CompactSensorRecord
+	}{
+		// too short
+		{
+			record[:26],
+			nil,
+		},
+		{
+			append(record, 0xff),
+			&CompactSensorRecord{
+				BaseLayer: layers.BaseLayer{
+					Contents: record,
+					Payload:  []byte{0xff},
+				},
+				SensorRecordKey: SensorRecordKey{
+					OwnerAddress: 0x20,
+					Number:       0x40,
+				},
+				SensorRecordSharing: SensorRecordSharing{
+					Count:              2,
+					ModifierType:       IDStringModifierTypeAlpha,
+					InstanceIncrements: true,
+				},
+				Entity:     EntityIDPowerSupply,
+				Instance:   1,
+				SensorType: SensorTypePowerSupply,
+				OutputType: 0x6f,
+				Direction:  SensorDirectionInput,
+				Identity:   "PSU ",
+			},
+		},
+	}
+	for _, test := range tests {
+		rsp := &CompactSensorRecord{}
+		err := rsp.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("expected error decoding %v, got none", test.in)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, rsp); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, rsp, test.want, diff)
+			}
+		case err != nil && test.want != nil:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestCompactSensorRecordExpand(t *testing.T) {
+	type sensor struct {
+		Identity string
+		Number   uint8
+		Instance EntityInstance
+	}
+	tests := []struct {
+		name     string
+		identity string
+		sharing  SensorRecordSharing
+		want     []sensor
+	}{
+		{
+			"not shared",
+			"Fan",
+			SensorRecordSharing{},
+			[]sensor{{"Fan", 0x30, 3}},
+		},
+		{
+			"numeric",
+			"Fan ",
+			SensorRecordSharing{
+				Count:          3,
+				ModifierOffset: 1,
+			},
+			[]sensor{{"Fan 1", 0x30, 3}, {"Fan 2", 0x31, 3}, {"Fan 3", 0x32, 3}},
+		},
+		{
+			"alpha",
+			"Fan ",
+			SensorRecordSharing{
+				Count:              2,
+				ModifierType:       IDStringModifierTypeAlpha,
+				ModifierOffset:     25,
+				InstanceIncrements: true,
+			},
+			[]sensor{{"Fan Z", 0x30, 3}, {"Fan AA", 0x31, 4}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &CompactSensorRecord{
+				SensorRecordKey: SensorRecordKey{
+					Number: 0x30,
+				},
+				SensorRecordSharing: test.sharing,
+				Instance:            3,
+				Identity:            test.identity,
+			}
+			got := []sensor{}
+			for _, shared := range r.Expand() {
+				got = append(got, sensor{shared.Name(), shared.Number,
+					shared.Instance})
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("Expand() = %v, want %v: %v", got, test.want, diff)
+			}
+		})
+	}
+}
@@ -16,6 +16,19 @@ type CompletionCode uint8
 const (
 	CompletionCodeNormal CompletionCode = 0x0
 
+	// CompletionCodeParameterNotSupported is returned by the Get and Set
+	// configuration parameter commands, e.g. Set System Boot Options, if the
+	// BMC does not support the requested parameter. Many parameters are
+	// optional, including Set In Progress, so this is often expected. As with
+	// other command-specific codes, 0x80 means something else for other
+	// commands.
+	CompletionCodeParameterNotSupported CompletionCode = 0x80
+
+	// CompletionCodeRequestedPrivilegeLevelExceedsLimit is returned by
+	// Activate Session if the requested maximum privilege level exceeds the
+	// channel or user privilege limit.
+	CompletionCodeRequestedPrivilegeLevelExceedsLimit CompletionCode = 0x86
+
 	// CompletionCodeInvalidSessionID is returned by Close Session if the
 	// specified session ID does not match one the BMC knows about. Whether
 	// this is also returned if the used doesn't have the required privileges
@@ -35,6 +48,19 @@ const (
 	// you forget to add the final request data layer?
 	CompletionCodeRequestTruncated CompletionCode = 0xc6
 
+	// CompletionCodeRequestDataLengthInvalid means the request was the wrong
+	// length for the command.
+	CompletionCodeRequestDataLengthInvalid CompletionCode = 0xc7
+
+	// CompletionCodeRequestDataFieldLengthLimitExceeded means a field in the
+	// request was too long, e.g. the number of bytes to read.
+	CompletionCodeRequestDataFieldLengthLimitExceeded CompletionCode = 0xc8
+
+	// CompletionCodeCannotReturnRequestedDataBytes means the response to a
+	// read would not fit in the BMC's buffer, so fewer bytes should be
+	// requested.
+	CompletionCodeCannotReturnRequestedDataBytes CompletionCode = 0xca
+
 	// CompletionCodeInsufficientPrivileges indicates the channel or effective
 	// user privilege level is insufficient to execute the command, or the
 	// request was blocked by the firmware firewall.
@@ -45,14 +71,18 @@ const (
 
 var (
 	completionCodeDescriptions = map[CompletionCode]string{
-		CompletionCodeNormal:                 "Normal",
-		CompletionCodeInvalidSessionID:       "Invalid Session ID",
-		CompletionCodeNodeBusy:               "Node Busy",
-		CompletionCodeUnrecognisedCommand:    "Unrecognised Command",
-		CompletionCodeTimeout:                "Timeout",
-		CompletionCodeRequestTruncated:       "Request Truncated",
-		CompletionCodeInsufficientPrivileges: "Insufficient Privileges",
-		CompletionCodeUnspecified:            "Unspecified Error",
+		CompletionCodeNormal:                              "Normal",
+		CompletionCodeRequestedPrivilegeLevelExceedsLimit: "Requested Privilege Level Exceeds Limit",
+		CompletionCodeInvalidSessionID:                    "Invalid Session ID",
+		CompletionCodeNodeBusy:                            "Node Busy",
+		CompletionCodeUnrecognisedCommand:                 "Unrecognised Command",
+		CompletionCodeTimeout:                             "Timeout",
+		CompletionCodeRequestTruncated:                    "Request Truncated",
+		CompletionCodeRequestDataLengthInvalid:            "Request Data Length Invalid",
+		CompletionCodeRequestDataFieldLengthLimitExceeded: "Request Data Field Length Limit Exceeded",
+		CompletionCodeCannotReturnRequestedDataBytes:      "Cannot Return Requested Data Bytes",
+		CompletionCodeInsufficientPrivileges:              "Insufficient Privileges",
+		CompletionCodeUnspecified:                         "Unspecified Error",
 	}
 )
 
@@ -20,7 +20,14 @@ const (
 	// mandatory.
 	ConfidentialityAlgorithmAESCBC128
 
+	// ConfidentialityAlgorithmXRC4128 specifies the use of RC4 with a 128-bit
+	// key derived from K2 and an IV sent when the keystream is initialised.
+	// The confidentiality header contains the offset into the sender's
+	// keystream, and there is no confidentiality trailer.
 	ConfidentialityAlgorithmXRC4128
+
+	// ConfidentialityAlgorithmXRC440 is identical to xRC4-128, except the key
+	// is truncated to 40 bits.
 	ConfidentialityAlgorithmXRC440
 )
 
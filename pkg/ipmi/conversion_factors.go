@@ -2,6 +2,8 @@ package ipmi
 
 import (
 	"math"
+
+	"github.com/gebn/bmc/internal/pkg/complement"
 )
 
 // ConversionFactors contains inputs to the linear formula in 30.3 and 36.3 of
@@ -52,3 +54,30 @@ func (f *ConversionFactors) ConvertReading(raw int16) float64 {
 	b10k1 := float64(f.B) * math.Pow10(int(f.BExp))
 	return (float64(mX) + b10k1) * math.Pow10(int(f.RExp))
 }
+
+// decode reads M, B and their exponents from the 6-byte block shared by the
+// Full Sensor Record and Get Sensor Reading Factors response, which starts with
+// the LS 8 bits of M. The tolerance and accuracy fields interleaved with these
+// are left to the caller.
+func (f *ConversionFactors) decode(b []byte) {
+	buf := [...]byte{b[1] >> 6, b[0]}
+	f.M = complement.Twos(buf, 10)
+	buf[1] = b[2]
+	buf[0] = b[3] >> 6
+	f.B = complement.Twos(buf, 10)
+	buf[0] = 0
+	buf[1] = b[5] >> 4
+	f.RExp = int8(complement.Twos(buf, 4))
+	buf[1] = b[5] & 0xf
+	f.BExp = int8(complement.Twos(buf, 4))
+}
+
+// decodeTolerance reads the tolerance, accuracy and accuracy exponent from the
+// same 6-byte block as ConversionFactors.decode().
+func decodeTolerance(b []byte) (tolerance uint8, accuracy int16, accuracyExp uint8) {
+	tolerance = b[1] & 0x3f
+	buf := [...]byte{(b[4] & 0xf0) >> 6, b[3]&0x3f | ((b[4] & 0xf0) << 2)}
+	accuracy = complement.Twos(buf, 10)
+	accuracyExp = (b[4] & 0xc) >> 2
+	return tolerance, accuracy, accuracyExp
+}
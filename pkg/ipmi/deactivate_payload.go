@@ -0,0 +1,74 @@
+package ipmi
+
+import (
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+const (
+	// CompletionCodePayloadAlreadyDeactivated is returned in response to a
+	// Deactivate Payload command if the payload instance is not active.
+	CompletionCodePayloadAlreadyDeactivated CompletionCode = 0x80
+)
+
+// DeactivatePayloadReq implements the Deactivate Payload command, specified in
+// section 24.2 of IPMI v2.0. It stops a payload previously started with
+// Activate Payload. The BMC responds with
+// CompletionCodePayloadAlreadyDeactivated if the payload was already
+// deactivated.
+type DeactivatePayloadReq struct {
+	layers.BaseLayer
+
+	// PayloadType is the type of payload to deactivate. This is a 6-bit uint
+	// on the wire.
+	PayloadType PayloadType
+
+	// Instance is the instance of the payload type to deactivate, starting at
+	// 1. This is a 4-bit uint on the wire.
+	Instance uint8
+}
+
+func (*DeactivatePayloadReq) LayerType() gopacket.LayerType {
+	return LayerTypeDeactivatePayloadReq
+}
+
+func (d *DeactivatePayloadReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(6)
+	if err != nil {
+		return err
+	}
+	bytes[0] = uint8(d.PayloadType) & 0x3f
+	bytes[1] = d.Instance & 0xf
+	// auxiliary data is reserved for all standard payload types
+	bytes[2] = 0
+	bytes[3] = 0
+	bytes[4] = 0
+	bytes[5] = 0
+	return nil
+}
+
+type DeactivatePayloadCmd struct {
+	Req DeactivatePayloadReq
+}
+
+// Name returns "Deactivate Payload".
+func (*DeactivatePayloadCmd) Name() string {
+	return "Deactivate Payload"
+}
+
+// Operation returns OperationDeactivatePayloadReq.
+func (*DeactivatePayloadCmd) Operation() *Operation {
+	return &OperationDeactivatePayloadReq
+}
+
+func (c *DeactivatePayloadCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (c *DeactivatePayloadCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *DeactivatePayloadCmd) Response() gopacket.DecodingLayer {
+	return nil
+}
@@ -0,0 +1,98 @@
+package ipmi
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// DeleteSELEntryReq represents a request to delete a single record from the
+// System Event Log. This command is specified in 25.8 and 31.8 of IPMI v1.5
+// and v2.0 respectively. Support for it is optional, and indicated by
+// GetSELInfoRsp.SupportsDelete.
+type DeleteSELEntryReq struct {
+	layers.BaseLayer
+
+	// ReservationID must be a current reservation obtained via Reserve SEL.
+	ReservationID ReservationID
+
+	// RecordID is the record to delete. RecordIDFirst and RecordIDLast can be
+	// used to delete the oldest and newest records respectively.
+	RecordID RecordID
+}
+
+func (*DeleteSELEntryReq) LayerType() gopacket.LayerType {
+	return LayerTypeDeleteSELEntryReq
+}
+
+func (d *DeleteSELEntryReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(4)
+	if err != nil {
+		return err
+	}
+	binary.LittleEndian.PutUint16(bytes[0:2], uint16(d.ReservationID))
+	binary.LittleEndian.PutUint16(bytes[2:4], uint16(d.RecordID))
+	return nil
+}
+
+// DeleteSELEntryRsp contains the ID of the record that was deleted.
+type DeleteSELEntryRsp struct {
+	layers.BaseLayer
+
+	// RecordID is the ID of the deleted record. This will differ from the
+	// requested ID if RecordIDFirst or RecordIDLast was specified.
+	RecordID RecordID
+}
+
+func (*DeleteSELEntryRsp) LayerType() gopacket.LayerType {
+	return LayerTypeDeleteSELEntryRsp
+}
+
+func (d *DeleteSELEntryRsp) CanDecode() gopacket.LayerClass {
+	return d.LayerType()
+}
+
+func (*DeleteSELEntryRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (d *DeleteSELEntryRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 2 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be at least 2 bytes, got %v", len(data))
+	}
+
+	d.BaseLayer.Contents = data[:2]
+	d.BaseLayer.Payload = data[2:]
+	d.RecordID = RecordID(binary.LittleEndian.Uint16(data[0:2]))
+	return nil
+}
+
+type DeleteSELEntryCmd struct {
+	Req DeleteSELEntryReq
+	Rsp DeleteSELEntryRsp
+}
+
+// Name returns "Delete SEL Entry".
+func (*DeleteSELEntryCmd) Name() string {
+	return "Delete SEL Entry"
+}
+
+// Operation returns &OperationDeleteSELEntryReq.
+func (*DeleteSELEntryCmd) Operation() *Operation {
+	return &OperationDeleteSELEntryReq
+}
+
+func (c *DeleteSELEntryCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (c *DeleteSELEntryCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *DeleteSELEntryCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
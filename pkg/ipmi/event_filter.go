@@ -0,0 +1,178 @@
+package ipmi
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/google/gopacket"
+)
+
+const (
+	// EventFilterMatchAny is used in the generator address, sensor type,
+	// sensor number and event trigger fields of an event filter to match
+	// any value.
+	EventFilterMatchAny = 0xff
+)
+
+// EventDataMatch matches a byte of event data in an event filter. The byte is
+// first masked with AND. Each bit set in Compare1 must then match the
+// corresponding bit of Compare2 exactly, and if any bits in Compare1 are
+// unset, at least one of the corresponding bits must match Compare2. An AND
+// mask of 0 with Compare1 set to 0xff and Compare2 set to 0 matches any
+// value.
+type EventDataMatch struct {
+	AND      uint8
+	Compare1 uint8
+	Compare2 uint8
+}
+
+// EventDataMatchAny matches any event data byte.
+var EventDataMatchAny = EventDataMatch{
+	Compare1: 0xff,
+}
+
+// EventFilter is an entry in the PEF event filter table, specified in table
+// 17-2 of IPMI v2.0. It is the data of the Event Filter Table PEF
+// configuration parameter, parameter 6 in table 30-6. Filters match events by
+// their generator, sensor and event data, and specify the actions to take on
+// a match. They cannot match on entity; see SDRRepository.EntityEventFilters()
+// to create a filter for each of an entity's sensors.
+type EventFilter struct {
+
+	// Number is the filter's position in the table, starting at 1. This is
+	// the set selector used to retrieve it, and a 7-bit uint on the wire.
+	Number uint8
+
+	// Enabled indicates events are matched against the filter.
+	Enabled bool
+
+	// Preconfigured indicates the filter was configured by the manufacturer.
+	// Such filters can be enabled or disabled, but should not otherwise be
+	// modified.
+	Preconfigured bool
+
+	// Actions are the actions to take when an event matches the filter.
+	Actions PEFActions
+
+	// AlertPolicy is the alert policy number to use if Actions contains
+	// PEFActionAlert. This is a 4-bit uint on the wire.
+	AlertPolicy uint8
+
+	// GroupControlSelector selects the group control table entry to use if
+	// Actions contains PEFActionGroupControl. This is a 3-bit uint on the
+	// wire.
+	GroupControlSelector uint8
+
+	// Severity is the severity of matching events, reported in alerts.
+	Severity EventSeverity
+
+	// GeneratorAddress is the slave address or software ID of the event
+	// generator to match, or EventFilterMatchAny.
+	GeneratorAddress Address
+
+	// AnyGeneratorChannel indicates the filter matches events from any
+	// channel and LUN, so GeneratorChannel and GeneratorLUN are ignored.
+	AnyGeneratorChannel bool
+
+	// GeneratorChannel is the channel of the event generator to match.
+	GeneratorChannel Channel
+
+	// GeneratorLUN is the LUN of the event generator to match.
+	GeneratorLUN LUN
+
+	// SensorType is the type of sensor to match, or EventFilterMatchAny.
+	SensorType SensorType
+
+	// SensorNumber is the number of the sensor to match, or
+	// EventFilterMatchAny.
+	SensorNumber uint8
+
+	// EventTrigger is the Event/Reading Type Code to match, or
+	// EventFilterMatchAny.
+	EventTrigger OutputType
+
+	// EventOffsetMask selects which event offsets (the lower nibble of event
+	// data 1) match. Bit n corresponds to offset n. 0xffff matches any offset.
+	EventOffsetMask uint16
+
+	// EventData1 matches the first byte of event data.
+	EventData1 EventDataMatch
+
+	// EventData2 matches the second byte of event data.
+	EventData2 EventDataMatch
+
+	// EventData3 matches the third byte of event data.
+	EventData3 EventDataMatch
+}
+
+// Serialise encodes the filter onto the end of a buffer, returning an error
+// if one occurs.
+func (f *EventFilter) Serialise(b gopacket.SerializeBuffer) error {
+	d, err := b.AppendBytes(21)
+	if err != nil {
+		return err
+	}
+	d[0] = f.Number & 0x7f
+	d[1] = 0
+	if f.Enabled {
+		d[1] |= 1 << 7
+	}
+	if f.Preconfigured {
+		d[1] |= 1 << 6
+	}
+	d[2] = uint8(f.Actions) & 0x7f
+	d[3] = (f.GroupControlSelector&0x7)<<4 | f.AlertPolicy&0xf
+	d[4] = uint8(f.Severity)
+	d[5] = uint8(f.GeneratorAddress)
+	if f.AnyGeneratorChannel {
+		d[6] = EventFilterMatchAny
+	} else {
+		d[6] = uint8(f.GeneratorChannel)<<4 | uint8(f.GeneratorLUN)&0x3
+	}
+	d[7] = uint8(f.SensorType)
+	d[8] = f.SensorNumber
+	d[9] = uint8(f.EventTrigger)
+	binary.LittleEndian.PutUint16(d[10:12], f.EventOffsetMask)
+	for i, m := range []*EventDataMatch{&f.EventData1, &f.EventData2, &f.EventData3} {
+		d[12+i*3] = m.AND
+		d[13+i*3] = m.Compare1
+		d[14+i*3] = m.Compare2
+	}
+	return nil
+}
+
+// Deserialise reads a filter from the supplied byte slice, returning
+// unconsumed remaining bytes.
+func (f *EventFilter) Deserialise(d []byte, df gopacket.DecodeFeedback) ([]byte, error) {
+	if len(d) < 21 {
+		df.SetTruncated()
+		return nil, fmt.Errorf("event filters are 21 bytes, only %v "+
+			"remaining", len(d))
+	}
+	f.Number = d[0] & 0x7f
+	f.Enabled = d[1]&(1<<7) != 0
+	f.Preconfigured = d[1]&(1<<6) != 0
+	f.Actions = PEFActions(d[2] & 0x7f)
+	f.GroupControlSelector = (d[3] >> 4) & 0x7
+	f.AlertPolicy = d[3] & 0xf
+	f.Severity = EventSeverity(d[4])
+	f.GeneratorAddress = Address(d[5])
+	f.AnyGeneratorChannel = d[6] == EventFilterMatchAny
+	if f.AnyGeneratorChannel {
+		f.GeneratorChannel = 0
+		f.GeneratorLUN = 0
+	} else {
+		f.GeneratorChannel = Channel(d[6] >> 4)
+		f.GeneratorLUN = LUN(d[6] & 0x3)
+	}
+	f.SensorType = SensorType(d[7])
+	f.SensorNumber = d[8]
+	f.EventTrigger = OutputType(d[9])
+	f.EventOffsetMask = binary.LittleEndian.Uint16(d[10:12])
+	for i, m := range []*EventDataMatch{&f.EventData1, &f.EventData2, &f.EventData3} {
+		m.AND = d[12+i*3]
+		m.Compare1 = d[13+i*3]
+		m.Compare2 = d[14+i*3]
+	}
+	return d[21:], nil
+}
@@ -0,0 +1,102 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+)
+
+func TestEventFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter *EventFilter
+		wire   []byte
+	}{
+		{
+			"any critical temperature",
+			&EventFilter{
+				Number:              1,
+				Enabled:             true,
+				Actions:             PEFActionAlert,
+				AlertPolicy:         1,
+				Severity:            EventSeverityCritical,
+				GeneratorAddress:    EventFilterMatchAny,
+				AnyGeneratorChannel: true,
+				SensorType:          SensorTypeTemperature,
+				SensorNumber:        EventFilterMatchAny,
+				EventTrigger:        OutputTypeThreshold,
+				EventOffsetMask:     0x0a10,
+				EventData1:          EventDataMatchAny,
+				EventData2:          EventDataMatchAny,
+				EventData3:          EventDataMatchAny,
+			},
+			[]byte{
+				0x01, 0x80, 0x01, 0x01, 0x10, 0xff, 0xff, 0x01, 0xff, 0x01,
+				0x10, 0x0a,
+				0x00, 0xff, 0x00,
+				0x00, 0xff, 0x00,
+				0x00, 0xff, 0x00,
+			},
+		},
+		{
+			"preconfigured",
+			&EventFilter{
+				Number:               0x7f,
+				Preconfigured:        true,
+				Actions:              PEFActionPowerCycle | PEFActionGroupControl,
+				AlertPolicy:          0xf,
+				GroupControlSelector: 0x7,
+				Severity:             EventSeverityNonRecoverable,
+				GeneratorAddress:     0x20,
+				GeneratorChannel:     ChannelPrimaryIPMB,
+				GeneratorLUN:         LUNBMC,
+				SensorType:           SensorTypeProcessor,
+				SensorNumber:         0x42,
+				EventTrigger:         OutputType(0x6f),
+				EventOffsetMask:      0x0001,
+				EventData1: EventDataMatch{
+					AND:      0x0f,
+					Compare1: 0x0f,
+					Compare2: 0x01,
+				},
+				EventData3: EventDataMatch{
+					AND: 0xaa,
+				},
+			},
+			[]byte{
+				0x7f, 0x40, 0x48, 0x7f, 0x20, 0x20, 0x00, 0x07, 0x42, 0x6f,
+				0x01, 0x00,
+				0x0f, 0x0f, 0x01,
+				0x00, 0x00, 0x00,
+				0xaa, 0x00, 0x00,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := gopacket.NewSerializeBuffer()
+			if err := test.filter.Serialise(b); err != nil {
+				t.Fatalf("serialise %v = error %v", test.filter, err)
+			}
+			if got := b.Bytes(); !bytes.Equal(got, test.wire) {
+				t.Errorf("serialise %v = %v, want %v", test.filter, got,
+					test.wire)
+			}
+
+			filter := &EventFilter{}
+			remaining, err := filter.Deserialise(test.wire, gopacket.NilDecodeFeedback)
+			if err != nil {
+				t.Fatalf("deserialise %v = error %v", test.wire, err)
+			}
+			if len(remaining) != 0 {
+				t.Errorf("deserialise %v left %v bytes", test.wire, len(remaining))
+			}
+			if diff := cmp.Diff(test.filter, filter); diff != "" {
+				t.Errorf("deserialise %v = %v, want %v: %v", test.wire, filter,
+					test.filter, diff)
+			}
+		})
+	}
+}
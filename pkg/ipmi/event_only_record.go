@@ -0,0 +1,120 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// EventOnlyRecord is specified in 37.3 and 43.3 of v1.5 and v2.0
+// respectively. It describes a sensor that generates events but cannot be
+// read with Get Sensor Reading, e.g. one implemented by system software. Like
+// Compact Sensor Records, it can be shared by several sensors with
+// consecutive numbers. This layer represents the record key and record body
+// sections.
+type EventOnlyRecord struct {
+	layers.BaseLayer
+	SensorRecordKey
+	SensorRecordSharing
+
+	// IsContainerEntity indicates whether we should treat the entity as a
+	// logical container entity, as opposed to a physical entity.
+	IsContainerEntity bool
+
+	// Entity describes the type of component that the sensor monitors.
+	Entity EntityID
+
+	// Instance distinguishes between multiple occurrences of the entity.
+	Instance EntityInstance
+
+	// SensorType indicates what is being monitored, which determines the
+	// meaning of sensor-specific event offsets.
+	SensorType SensorType
+
+	// OutputType contains the Event/Reading Type Code of the underlying sensor.
+	OutputType OutputType
+
+	// Direction indicates whether the sensor is monitoring input or output of
+	// the entity.
+	Direction SensorDirection
+
+	// Identity is a descriptive string for the sensor, excluding the
+	// modifier added for shared records; see Expand().
+	Identity string
+}
+
+func (*EventOnlyRecord) LayerType() gopacket.LayerType {
+	return LayerTypeEventOnlyRecord
+}
+
+func (r *EventOnlyRecord) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*EventOnlyRecord) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *EventOnlyRecord) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 12 {
+		df.SetTruncated()
+		return fmt.Errorf("Event-Only Records are at least 12 bytes long, got %v",
+			len(data))
+	}
+
+	r.OwnerAddress = Address(data[0])
+	r.Channel = Channel(data[1] >> 4)
+	r.OwnerLUN = LUN(data[1] & 0x3)
+	r.Number = uint8(data[2])
+
+	r.Entity = EntityID(data[3])
+	r.IsContainerEntity = data[4]&(1<<7) != 0
+	r.Instance = EntityInstance(data[4] & 0x7f)
+
+	r.SensorType = SensorType(data[5])
+	r.OutputType = OutputType(data[6])
+
+	r.Direction = SensorDirection(data[7] >> 6)
+	r.SensorRecordSharing.decode(data[7:9])
+
+	identity, consumed, err := decodeIDString(data[11:])
+	if err != nil {
+		return err
+	}
+	r.Identity = identity
+	r.BaseLayer.Contents = data[:11+consumed]
+	r.BaseLayer.Payload = data[11+consumed:]
+	return nil
+}
+
+// Key returns the record key of the first sensor sharing the record.
+func (r *EventOnlyRecord) Key() SensorRecordKey {
+	return r.SensorRecordKey
+}
+
+// Name returns the Identity of the first sensor sharing the record.
+func (r *EventOnlyRecord) Name() string {
+	return r.Identity
+}
+
+func (r *EventOnlyRecord) Kind() (SensorType, OutputType) {
+	return r.SensorType, r.OutputType
+}
+
+func (r *EventOnlyRecord) Monitored() (EntityID, EntityInstance) {
+	return r.Entity, r.Instance
+}
+
+// Expand returns a record for each sensor sharing this one, with the sensor
+// number, entity instance and ID String modified accordingly. If the record
+// is not shared, it is returned as the sole element.
+func (r *EventOnlyRecord) Expand() []*EventOnlyRecord {
+	records := make([]*EventOnlyRecord, r.Sensors())
+	for i := range records {
+		shared := *r
+		r.share(i, &shared.Number, &shared.Instance, &shared.Identity)
+		records[i] = &shared
+	}
+	return records
+}
@@ -0,0 +1,80 @@
+package ipmi
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestEventOnlyRecordDecodeFromBytes(t *testing.T) {
+	record := []byte{
+		// key
+		0x41, // system software ID 0x20
+		0x21, // channel 2, LUN 1
+		0x07, // sensor number 7
+
+		// body
+		0x17,                                           // system chassis entity ID
+		0x81,                                           // logical entity, instance 1
+		0x05,                                           // sensor type: physical security
+		0x6f,                                           // sensor-specific Event/Reading Type Code
+		0x00,                                           // no sharing
+		0x00,                                           // modifier offset 0
+		0x00,                                           // reserved
+		0x00,                                           // OEM
+		0xc8,                                           // 8-bit ASCII, 8 characters
+		0x49, 0x6e, 0x74, 0x72, 0x75, 0x73, 0x69, 0x6f, // "Intrusio"
+	}
+	tests := []struct {
+		in   []byte
+		want *EventOnlyRecord
+	}{
+		// too short
+		{
+			record[:11],
+			nil,
+		},
+		// ID String truncated
+		{
+			record[:15],
+			nil,
+		},
+		{
+			record,
+			&EventOnlyRecord{
+				BaseLayer: layers.BaseLayer{
+					Contents: record,
+					Payload:  []byte{},
+				},
+				SensorRecordKey: SensorRecordKey{
+					OwnerAddress: 0x41,
+					Channel:      2,
+					OwnerLUN:     1,
+					Number:       7,
+				},
+				IsContainerEntity: true,
+				Entity:            EntityIDSystemChassis,
+				Instance:          1,
+				SensorType:        SensorTypePhysicalSecurity,
+				OutputType:        0x6f,
+				Identity:          "Intrusio",
+			},
+		},
+	}
+	for _, test := range tests {
+		rsp := &EventOnlyRecord{}
+		err := rsp.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("expected error decoding %v, got none", test.in)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, rsp); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, rsp, test.want, diff)
+			}
+		case err != nil && test.want != nil:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package ipmi
+
+import (
+	"fmt"
+)
+
+// EventSeverity is the severity of an event that matches a PEF event filter,
+// specified in table 17-2 of IPMI v2.0. It is reported in PET alerts, and
+// used to select which alert strings and destinations are used. Values are
+// mutually exclusive, despite being defined as bits.
+type EventSeverity uint8
+
+const (
+	EventSeverityUnspecified    EventSeverity = 0x00
+	EventSeverityMonitor        EventSeverity = 0x01
+	EventSeverityInformation    EventSeverity = 0x02
+	EventSeverityOK             EventSeverity = 0x04
+	EventSeverityNonCritical    EventSeverity = 0x08
+	EventSeverityCritical       EventSeverity = 0x10
+	EventSeverityNonRecoverable EventSeverity = 0x20
+)
+
+func (s EventSeverity) Description() string {
+	switch s {
+	case EventSeverityUnspecified:
+		return "Unspecified"
+	case EventSeverityMonitor:
+		return "Monitor"
+	case EventSeverityInformation:
+		return "Information"
+	case EventSeverityOK:
+		return "OK"
+	case EventSeverityNonCritical:
+		return "Non-critical"
+	case EventSeverityCritical:
+		return "Critical"
+	case EventSeverityNonRecoverable:
+		return "Non-recoverable"
+	default:
+		return "Unknown"
+	}
+}
+
+func (s EventSeverity) String() string {
+	return fmt.Sprintf("%#x(%v)", uint8(s), s.Description())
+}
@@ -0,0 +1,76 @@
+package ipmi
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// FRUBoardInfoArea describes a board, e.g. the motherboard of a system. It is
+// specified in section 11 of the Platform Management FRU Information Storage
+// Definition v1.0.
+type FRUBoardInfoArea struct {
+	layers.BaseLayer
+
+	// Language is the language code of the area's strings. 0 and 25 mean
+	// English.
+	Language uint8
+
+	// ManufacturedAt is the time the board was manufactured, to the minute.
+	// This will be the zero value if unspecified.
+	ManufacturedAt time.Time
+
+	Manufacturer string
+	ProductName  string
+	SerialNumber string
+	PartNumber   string
+
+	// FRUFileID identifies the file used to program the FRU data, to help
+	// verify it.
+	FRUFileID string
+
+	// Custom contains any additional manufacturer-defined fields.
+	Custom []string
+}
+
+func (*FRUBoardInfoArea) LayerType() gopacket.LayerType {
+	return LayerTypeFRUBoardInfoArea
+}
+
+func (a *FRUBoardInfoArea) CanDecode() gopacket.LayerClass {
+	return a.LayerType()
+}
+
+func (*FRUBoardInfoArea) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (a *FRUBoardInfoArea) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	area, err := fruArea(data)
+	if err != nil {
+		return err
+	}
+	if len(area) < 7 {
+		df.SetTruncated()
+		return fmt.Errorf("board info area must be at least 7 bytes, got %v",
+			len(area))
+	}
+	a.Language = area[2]
+	minutes := uint32(area[3]) | uint32(area[4])<<8 | uint32(area[5])<<16
+	if minutes == 0 {
+		a.ManufacturedAt = time.Time{}
+	} else {
+		a.ManufacturedAt = fruEpoch.Add(time.Duration(minutes) * time.Minute)
+	}
+	a.Custom, err = decodeFRUFields(area[6:len(area)-1], &a.Manufacturer,
+		&a.ProductName, &a.SerialNumber, &a.PartNumber, &a.FRUFileID)
+	if err != nil {
+		return err
+	}
+
+	a.BaseLayer.Contents = area
+	a.BaseLayer.Payload = data[len(area):]
+	return nil
+}
@@ -0,0 +1,85 @@
+package ipmi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestFRUBoardInfoAreaDecodeFromBytes(t *testing.T) {
+	area := []byte{
+		0x01, 0x04, // version, length
+		0x00,             // language
+		0xb2, 0x9f, 0xc0, // manufacturing date
+		0xc4, 0x41, 0x63, 0x6d, 0x65, // manufacturer
+		0xc3, 0x58, 0x31, 0x31, // product name
+		0xc4, 0x42, 0x53, 0x4e, 0x31, // serial number
+		0x42, 0x12, 0x34, // part number, BCD plus
+		0xc0, // FRU file ID
+		0xc1, // end of fields
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x52, // checksum
+	}
+	corrupted := append([]byte(nil), area...)
+	corrupted[len(corrupted)-1]++
+	unterminated := append([]byte(nil), area...)
+	unterminated[24] = 0x00
+	unterminated[31]++
+
+	tests := []struct {
+		name string
+		in   []byte
+		want *FRUBoardInfoArea
+	}{
+		{
+			"truncated",
+			area[:16],
+			nil,
+		},
+		{
+			"invalid checksum",
+			corrupted,
+			nil,
+		},
+		{
+			"missing end of fields",
+			unterminated,
+			nil,
+		},
+		{
+			"valid",
+			append(area, 0xff),
+			&FRUBoardInfoArea{
+				BaseLayer: layers.BaseLayer{
+					Contents: area,
+					Payload:  []byte{0xff},
+				},
+				ManufacturedAt: time.Date(2020, time.January, 1, 12, 34, 0, 0, time.UTC),
+				Manufacturer:   "Acme",
+				ProductName:    "X11",
+				SerialNumber:   "BSN1",
+				PartNumber:     "1234",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := &FRUBoardInfoArea{}
+			err := got.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+			switch {
+			case err == nil && test.want == nil:
+				t.Errorf("expected error decoding %v, got none", test.in)
+			case err == nil && test.want != nil:
+				if diff := cmp.Diff(test.want, got, cmpopts.EquateEmpty()); diff != "" {
+					t.Errorf("decode %v = %v, want %v: %v", test.in, got, test.want, diff)
+				}
+			case err != nil && test.want != nil:
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// FRUChassisInfoArea describes the chassis of a system. It is specified in
+// section 10 of the Platform Management FRU Information Storage Definition
+// v1.0.
+type FRUChassisInfoArea struct {
+	layers.BaseLayer
+
+	// Type is the SMBIOS chassis type, e.g. rack mount chassis.
+	Type ChassisType
+
+	PartNumber   string
+	SerialNumber string
+
+	// Custom contains any additional manufacturer-defined fields.
+	Custom []string
+}
+
+func (*FRUChassisInfoArea) LayerType() gopacket.LayerType {
+	return LayerTypeFRUChassisInfoArea
+}
+
+func (a *FRUChassisInfoArea) CanDecode() gopacket.LayerClass {
+	return a.LayerType()
+}
+
+func (*FRUChassisInfoArea) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (a *FRUChassisInfoArea) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	area, err := fruArea(data)
+	if err != nil {
+		return err
+	}
+	if len(area) < 4 {
+		df.SetTruncated()
+		return fmt.Errorf("chassis info area must be at least 4 bytes, got %v",
+			len(area))
+	}
+	a.Type = ChassisType(area[2])
+	a.Custom, err = decodeFRUFields(area[3:len(area)-1], &a.PartNumber,
+		&a.SerialNumber)
+	if err != nil {
+		return err
+	}
+
+	a.BaseLayer.Contents = area
+	a.BaseLayer.Payload = data[len(area):]
+	return nil
+}
@@ -0,0 +1,66 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// FRUCommonHeader is the first 8 bytes of a FRU device's inventory area,
+// specified in section 8 of the Platform Management FRU Information Storage
+// Definition v1.0. It locates the info areas that follow it. Offsets are in
+// bytes from the start of the inventory area; 0 indicates the area is not
+// present.
+type FRUCommonHeader struct {
+	layers.BaseLayer
+
+	// FormatVersion is the version of the common header format. Only 1 is
+	// defined.
+	FormatVersion uint8
+
+	InternalUseOffset uint16
+	ChassisInfoOffset uint16
+	BoardInfoOffset   uint16
+	ProductInfoOffset uint16
+	MultiRecordOffset uint16
+}
+
+func (*FRUCommonHeader) LayerType() gopacket.LayerType {
+	return LayerTypeFRUCommonHeader
+}
+
+func (h *FRUCommonHeader) CanDecode() gopacket.LayerClass {
+	return h.LayerType()
+}
+
+func (*FRUCommonHeader) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (h *FRUCommonHeader) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 8 {
+		df.SetTruncated()
+		return fmt.Errorf("FRU common header must be 8 bytes, got %v", len(data))
+	}
+	if want := checksum(data[:7]); data[7] != want {
+		return fmt.Errorf("invalid FRU common header checksum: got %v, want %v",
+			data[7], want)
+	}
+
+	h.FormatVersion = data[0] & 0xf
+	if h.FormatVersion != fruAreaFormatVersion {
+		return fmt.Errorf("unsupported FRU common header format version %v",
+			h.FormatVersion)
+	}
+	// offsets are in multiples of 8 bytes on the wire
+	h.InternalUseOffset = uint16(data[1]) * 8
+	h.ChassisInfoOffset = uint16(data[2]) * 8
+	h.BoardInfoOffset = uint16(data[3]) * 8
+	h.ProductInfoOffset = uint16(data[4]) * 8
+	h.MultiRecordOffset = uint16(data[5]) * 8
+
+	h.BaseLayer.Contents = data[:8]
+	h.BaseLayer.Payload = data[8:]
+	return nil
+}
@@ -0,0 +1,118 @@
+package ipmi
+
+import (
+	"fmt"
+	"time"
+)
+
+// This file implements functions common to the FRU info areas, specified in
+// the Platform Management FRU Information Storage Definition v1.0.
+
+const (
+	// fruFieldsEnd is the type/length byte that follows the last field in an
+	// info area.
+	fruFieldsEnd = 0xc1
+
+	// fruAreaFormatVersion is the only defined version of the common header
+	// and info areas.
+	fruAreaFormatVersion = 0x01
+)
+
+var (
+	// fruEpoch is the reference point of the board manufacturing date, which
+	// is stored as a number of minutes since this time.
+	fruEpoch = time.Date(1996, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// fruArea validates the version, length and checksum of an info area, which
+// begin with a version byte and a length in multiples of 8 bytes, and end in
+// a zero checksum. It returns the area.
+func fruArea(data []byte) ([]byte, error) {
+	if len(data) < 2 {
+		return nil, fmt.Errorf("info area must be at least 2 bytes, got %v",
+			len(data))
+	}
+	if version := data[0] & 0xf; version != fruAreaFormatVersion {
+		return nil, fmt.Errorf("unsupported info area format version %v",
+			version)
+	}
+	length := int(data[1]) * 8
+	if len(data) < length || length < 3 {
+		return nil, fmt.Errorf("info area length %v invalid for %v bytes",
+			length, len(data))
+	}
+	area := data[:length]
+	if want := checksum(area[:length-1]); area[length-1] != want {
+		return nil, fmt.Errorf("invalid info area checksum: got %v, want %v",
+			area[length-1], want)
+	}
+	return area, nil
+}
+
+// decodeFRUField parses a single type/length byte and the data that follows
+// it, returning the string and the number of bytes consumed. The type bits are
+// interpreted as a StringEncoding; unlike SDR ID Strings, the length is always
+// in bytes.
+func decodeFRUField(b []byte) (string, int, error) {
+	if len(b) == 0 {
+		return "", 0, fmt.Errorf("missing type/length byte")
+	}
+	encoding := StringEncoding(b[0] >> 6)
+	length := int(b[0] & 0x3f)
+	if length == 0 {
+		return "", 1, nil
+	}
+	if len(b) < 1+length {
+		return "", 0, fmt.Errorf("field is %v bytes, only %v remain", length,
+			len(b)-1)
+	}
+	decoder, err := encoding.Decoder()
+	if err != nil {
+		return "", 0, err
+	}
+	chars := length
+	switch encoding {
+	case StringEncodingBCDPlus:
+		chars = length * 2
+	case StringEncodingPacked6BitAscii:
+		chars = length * 4 / 3
+	}
+	// pass the remainder of the area rather than only the field, as the
+	// 8-bit decoder requires at least 2 bytes; the end marker and checksum
+	// ensure it will always receive this
+	s, _, err := decoder.Decode(b[1:], chars)
+	if err != nil {
+		return "", 0, err
+	}
+	return s, 1 + length, nil
+}
+
+// decodeFRUFields parses the fields of an info area into the provided
+// strings in order, followed by any custom fields until the end marker. It
+// returns the custom fields.
+func decodeFRUFields(b []byte, fields ...*string) ([]string, error) {
+	offset := 0
+	for _, field := range fields {
+		s, n, err := decodeFRUField(b[offset:])
+		if err != nil {
+			return nil, err
+		}
+		*field = s
+		offset += n
+	}
+	custom := []string{}
+	for {
+		if offset >= len(b) {
+			return nil, fmt.Errorf("info area is missing end of fields marker")
+		}
+		if b[offset] == fruFieldsEnd {
+			return custom, nil
+		}
+		s, n, err := decodeFRUField(b[offset:])
+		if err != nil {
+			return nil, err
+		}
+		custom = append(custom, s)
+		offset += n
+	}
+}
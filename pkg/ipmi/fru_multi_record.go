@@ -0,0 +1,110 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// FRUMultiRecordType identifies the format of a record in the FRU MultiRecord
+// Area. It is specified in section 18 of the Platform Management FRU
+// Information Storage Definition v1.0.
+type FRUMultiRecordType uint8
+
+const (
+	FRUMultiRecordTypePowerSupplyInformation FRUMultiRecordType = iota
+	FRUMultiRecordTypeDCOutput
+	FRUMultiRecordTypeDCLoad
+	FRUMultiRecordTypeManagementAccess
+	FRUMultiRecordTypeBaseCompatibility
+	FRUMultiRecordTypeExtendedCompatibility
+
+	// 0xc0-0xff are OEM record types
+)
+
+var (
+	fruMultiRecordTypeDescriptions = map[FRUMultiRecordType]string{
+		FRUMultiRecordTypePowerSupplyInformation: "Power Supply Information",
+		FRUMultiRecordTypeDCOutput:               "DC Output",
+		FRUMultiRecordTypeDCLoad:                 "DC Load",
+		FRUMultiRecordTypeManagementAccess:       "Management Access Record",
+		FRUMultiRecordTypeBaseCompatibility:      "Base Compatibility Record",
+		FRUMultiRecordTypeExtendedCompatibility:  "Extended Compatibility Record",
+	}
+)
+
+func (t FRUMultiRecordType) Description() string {
+	if desc, ok := fruMultiRecordTypeDescriptions[t]; ok {
+		return desc
+	}
+	if t >= 0xc0 {
+		return "OEM"
+	}
+	return "Unknown"
+}
+
+func (t FRUMultiRecordType) String() string {
+	return fmt.Sprintf("%#x(%v)", uint8(t), t.Description())
+}
+
+// FRUMultiRecord is a single record in the FRU MultiRecord Area, specified in
+// section 16 of the Platform Management FRU Information Storage Definition
+// v1.0. The area is a list of these records, which run until one has
+// EndOfList set. This layer represents the record header; its payload is the
+// record data, whose format depends on the Type.
+type FRUMultiRecord struct {
+	layers.BaseLayer
+
+	// Type indicates how the record data should be interpreted.
+	Type FRUMultiRecordType
+
+	// EndOfList indicates whether this is the last record in the area.
+	EndOfList bool
+
+	// FormatVersion is the version of the record format. This is 2 for
+	// records defined by the current specification.
+	FormatVersion uint8
+}
+
+func (*FRUMultiRecord) LayerType() gopacket.LayerType {
+	return LayerTypeFRUMultiRecord
+}
+
+func (r *FRUMultiRecord) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*FRUMultiRecord) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *FRUMultiRecord) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 5 {
+		df.SetTruncated()
+		return fmt.Errorf("MultiRecord header must be 5 bytes, got %v", len(data))
+	}
+	if want := checksum(data[:4]); data[4] != want {
+		return fmt.Errorf("invalid MultiRecord header checksum: got %v, want %v",
+			data[4], want)
+	}
+	length := int(data[2])
+	if len(data) < 5+length {
+		df.SetTruncated()
+		return fmt.Errorf("MultiRecord data is %v bytes, only %v remain",
+			length, len(data)-5)
+	}
+	record := data[5 : 5+length]
+	if want := checksum(record); data[3] != want {
+		return fmt.Errorf("invalid MultiRecord data checksum: got %v, want %v",
+			data[3], want)
+	}
+
+	r.Type = FRUMultiRecordType(data[0])
+	r.EndOfList = data[1]&(1<<7) != 0
+	r.FormatVersion = data[1] & 0xf
+
+	r.BaseLayer.Contents = data[:5]
+	r.BaseLayer.Payload = record
+	return nil
+}
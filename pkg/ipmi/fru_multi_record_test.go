@@ -0,0 +1,74 @@
+package ipmi
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestFRUMultiRecordDecodeFromBytes(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want *FRUMultiRecord
+	}{
+		// too short
+		{
+			[]byte{0x00, 0x02, 0x03, 0xfa},
+			nil,
+		},
+		// invalid header checksum
+		{
+			[]byte{0x00, 0x02, 0x03, 0xfa, 0x00, 0x01, 0x02, 0x03},
+			nil,
+		},
+		// invalid record checksum
+		{
+			[]byte{0x00, 0x02, 0x03, 0xfb, 0x00, 0x01, 0x02, 0x03},
+			nil,
+		},
+		// data truncated
+		{
+			[]byte{0x00, 0x02, 0x03, 0xfa, 0x01, 0x01, 0x02},
+			nil,
+		},
+		{
+			[]byte{0x00, 0x02, 0x03, 0xfa, 0x01, 0x01, 0x02, 0x03, 0xc0},
+			&FRUMultiRecord{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x00, 0x02, 0x03, 0xfa, 0x01},
+					Payload:  []byte{0x01, 0x02, 0x03},
+				},
+				Type:          FRUMultiRecordTypePowerSupplyInformation,
+				FormatVersion: 2,
+			},
+		},
+		{
+			[]byte{0xc0, 0x82, 0x01, 0x56, 0x67, 0xaa},
+			&FRUMultiRecord{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0xc0, 0x82, 0x01, 0x56, 0x67},
+					Payload:  []byte{0xaa},
+				},
+				Type:          0xc0,
+				EndOfList:     true,
+				FormatVersion: 2,
+			},
+		},
+	}
+	for _, test := range tests {
+		rsp := &FRUMultiRecord{}
+		err := rsp.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("expected error decoding %v, got none", test.in)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, rsp); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, rsp, test.want, diff)
+			}
+		case err != nil && test.want != nil:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
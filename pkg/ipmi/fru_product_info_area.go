@@ -0,0 +1,70 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// FRUProductInfoArea describes a product, e.g. the system as sold. It is
+// specified in section 12 of the Platform Management FRU Information Storage
+// Definition v1.0.
+type FRUProductInfoArea struct {
+	layers.BaseLayer
+
+	// Language is the language code of the area's strings. 0 and 25 mean
+	// English.
+	Language uint8
+
+	Manufacturer string
+	Name         string
+
+	// PartNumber is the part or model number of the product.
+	PartNumber   string
+	Version      string
+	SerialNumber string
+	AssetTag     string
+
+	// FRUFileID identifies the file used to program the FRU data, to help
+	// verify it.
+	FRUFileID string
+
+	// Custom contains any additional manufacturer-defined fields.
+	Custom []string
+}
+
+func (*FRUProductInfoArea) LayerType() gopacket.LayerType {
+	return LayerTypeFRUProductInfoArea
+}
+
+func (a *FRUProductInfoArea) CanDecode() gopacket.LayerClass {
+	return a.LayerType()
+}
+
+func (*FRUProductInfoArea) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (a *FRUProductInfoArea) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	area, err := fruArea(data)
+	if err != nil {
+		return err
+	}
+	if len(area) < 4 {
+		df.SetTruncated()
+		return fmt.Errorf("product info area must be at least 4 bytes, got %v",
+			len(area))
+	}
+	a.Language = area[2]
+	a.Custom, err = decodeFRUFields(area[3:len(area)-1], &a.Manufacturer,
+		&a.Name, &a.PartNumber, &a.Version, &a.SerialNumber, &a.AssetTag,
+		&a.FRUFileID)
+	if err != nil {
+		return err
+	}
+
+	a.BaseLayer.Contents = area
+	a.BaseLayer.Payload = data[len(area):]
+	return nil
+}
@@ -3,8 +3,6 @@ package ipmi
 import (
 	"fmt"
 
-	"github.com/gebn/bmc/internal/pkg/complement"
-
 	"github.com/google/gopacket"
 	"github.com/google/gopacket/layers"
 )
@@ -55,6 +53,14 @@ type FullSensorRecord struct {
 	// entity's status can be obtained via an Entity Presence sensor.
 	Ignore bool
 
+	// HysteresisAccess indicates whether the hysteresis values can be
+	// retrieved and changed with Get and Set Sensor Hysteresis.
+	HysteresisAccess SensorAccess
+
+	// ThresholdAccess indicates whether the thresholds can be retrieved and
+	// changed with Get and Set Sensor Thresholds.
+	ThresholdAccess SensorAccess
+
 	// SensorType indicates what is being measured. For analogue sensors, this
 	// is the dimension, e.g. temperature. For discrete sensors, there are many
 	// values to pinpoint exactly what is being exposed.
@@ -145,6 +151,35 @@ type FullSensorRecord struct {
 	// higher than this should be given as this value (this is not enforced).
 	SensorMax uint8
 
+	// ComparedThresholds contains the thresholds for which the BMC returns a
+	// comparison status in Get Sensor Reading responses. This is the Lower
+	// and Upper Threshold Reading Mask.
+	ComparedThresholds ThresholdMask
+
+	// ReadableThresholds contains the thresholds returned by Get Sensor
+	// Thresholds.
+	ReadableThresholds ThresholdMask
+
+	// SettableThresholds contains the thresholds that can be changed with Set
+	// Sensor Thresholds.
+	SettableThresholds ThresholdMask
+
+	// Thresholds contains the initial raw threshold values. These are only
+	// meaningful for thresholds in ReadableThresholds, and may have been
+	// changed since the SDR was written; if ThresholdAccess allows, Get Sensor
+	// Thresholds returns the current values.
+	Thresholds Thresholds
+
+	// PositiveHysteresis is the raw amount a reading must fall below an upper
+	// threshold (or rise above a lower threshold, for NegativeHysteresis)
+	// before the threshold is considered deasserted. It is in the same units
+	// as a raw reading, but is always unsigned. 0 means none or unspecified.
+	PositiveHysteresis uint8
+
+	// NegativeHysteresis is the negative-going counterpart of
+	// PositiveHysteresis.
+	NegativeHysteresis uint8
+
 	// Identity is a descriptive string for the sensor. This can be up to 16
 	// bytes long, which translates into 16-32 characters depending on the
 	// format used. There are no conventions around this, and it is provided for
@@ -187,6 +222,8 @@ func (r *FullSensorRecord) DecodeFromBytes(data []byte, df gopacket.DecodeFeedba
 	r.Instance = EntityInstance(data[4] & 0x7f)
 
 	r.Ignore = data[6]&(1<<7) != 0
+	r.HysteresisAccess = SensorAccess((data[6] >> 4) & 0x3)
+	r.ThresholdAccess = SensorAccess((data[6] >> 2) & 0x3)
 
 	r.SensorType = SensorType(data[7])
 	r.OutputType = OutputType(data[8])
@@ -201,22 +238,9 @@ func (r *FullSensorRecord) DecodeFromBytes(data []byte, df gopacket.DecodeFeedba
 
 	r.Linearisation = Linearisation(data[18] & 0x7f)
 
-	buf := [...]byte{data[20] >> 6, data[19]}
-	r.M = complement.Twos(buf, 10)
-	r.Tolerance = uint8(data[20] & 0x3f)
-	buf[1] = data[21]
-	buf[0] = data[22] >> 6
-	r.B = complement.Twos(buf, 10)
-	buf[1] = data[22]&0x3f | ((data[23] & 0xf0) << 2)
-	buf[0] = (data[23] & 0xf0) >> 6
-	r.Accuracy = complement.Twos(buf, 10)
-	r.AccuracyExp = uint8(data[23]&0xc) >> 2
+	r.ConversionFactors.decode(data[19:25])
+	r.Tolerance, r.Accuracy, r.AccuracyExp = decodeTolerance(data[19:25])
 	r.Direction = SensorDirection(data[23] & 0x3)
-	buf[0] = 0
-	buf[1] = data[24] >> 4
-	r.RExp = int8(complement.Twos(buf, 4))
-	buf[1] = data[24] & 0xf
-	r.BExp = int8(complement.Twos(buf, 4))
 
 	r.NominalReadingSpecified = data[25]&1 != 0
 	r.NormalMaxSpecified = data[25]&(1<<1) != 0
@@ -229,20 +253,47 @@ func (r *FullSensorRecord) DecodeFromBytes(data []byte, df gopacket.DecodeFeedba
 	r.SensorMax = uint8(data[29])
 	r.SensorMin = uint8(data[30])
 
-	encoding := StringEncoding(data[42] >> 6)
-	decoder, err := encoding.Decoder()
-	if err != nil {
-		// unsupported encoding; fail loudly so we can fix this
-		return err
-	}
-	characters := int(data[42] & 0x1f)
-	identity, consumed, err := decoder.Decode(data[43:], characters)
+	// bits 12-14 of the assertion and deassertion event masks
+	r.ComparedThresholds = ThresholdMask((data[10]>>4)&0x7 |
+		((data[12]>>4)&0x7)<<3)
+	r.ReadableThresholds = ThresholdMask(data[13] & 0x3f)
+	r.SettableThresholds = ThresholdMask(data[14] & 0x3f)
+
+	r.Thresholds.UpperNonRecoverable = data[31]
+	r.Thresholds.UpperCritical = data[32]
+	r.Thresholds.UpperNonCritical = data[33]
+	r.Thresholds.LowerNonRecoverable = data[34]
+	r.Thresholds.LowerCritical = data[35]
+	r.Thresholds.LowerNonCritical = data[36]
+
+	r.PositiveHysteresis = data[37]
+	r.NegativeHysteresis = data[38]
+
+	identity, consumed, err := decodeIDString(data[42:])
 	if err != nil {
-		// invalid bytes
+		// unsupported encoding or invalid bytes; fail loudly so we can fix this
 		return err
 	}
 	r.Identity = identity
-	r.BaseLayer.Contents = data[:43+consumed]
-	r.BaseLayer.Payload = data[43+consumed:]
+	r.BaseLayer.Contents = data[:42+consumed]
+	r.BaseLayer.Payload = data[42+consumed:]
 	return nil
 }
+
+// Key returns the record key of the sensor.
+func (r *FullSensorRecord) Key() SensorRecordKey {
+	return r.SensorRecordKey
+}
+
+// Name returns the Identity of the sensor.
+func (r *FullSensorRecord) Name() string {
+	return r.Identity
+}
+
+func (r *FullSensorRecord) Kind() (SensorType, OutputType) {
+	return r.SensorType, r.OutputType
+}
+
+func (r *FullSensorRecord) Monitored() (EntityID, EntityInstance) {
+	return r.Entity, r.Instance
+}
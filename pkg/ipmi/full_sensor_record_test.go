@@ -86,6 +86,8 @@ func TestFullSensorRecordDecodeFromBytes(t *testing.T) {
 				IsContainerEntity:       false,
 				Instance:                1,
 				Ignore:                  false,
+				HysteresisAccess:        SensorAccessSettable,
+				ThresholdAccess:         SensorAccessSettable,
 				SensorType:              SensorTypeTemperature,
 				OutputType:              OutputTypeThreshold,
 				AnalogDataFormat:        AnalogDataFormatTwosComplement,
@@ -106,7 +108,17 @@ func TestFullSensorRecordDecodeFromBytes(t *testing.T) {
 				NormalMax:               0x59,
 				SensorMin:               0x80,
 				SensorMax:               0x7f,
-				Identity:                "CPU Temp",
+				ComparedThresholds:      ThresholdMaskAll,
+				ReadableThresholds:      ThresholdMaskAll,
+				SettableThresholds:      ThresholdMaskAll,
+				Thresholds: Thresholds{
+					UpperNonCritical:    0x5f,
+					UpperCritical:       0x64,
+					UpperNonRecoverable: 0x64,
+				},
+				PositiveHysteresis: 2,
+				NegativeHysteresis: 2,
+				Identity:           "CPU Temp",
 			},
 		},
 		{
@@ -195,6 +207,8 @@ func TestFullSensorRecordDecodeFromBytes(t *testing.T) {
 				IsContainerEntity:       true,
 				Instance:                96,
 				Ignore:                  true,
+				HysteresisAccess:        SensorAccessSettable,
+				ThresholdAccess:         SensorAccessSettable,
 				SensorType:              SensorTypeCurrent,
 				OutputType:              OutputTypeThreshold,
 				AnalogDataFormat:        AnalogDataFormatUnsigned,
@@ -215,7 +229,17 @@ func TestFullSensorRecordDecodeFromBytes(t *testing.T) {
 				NormalMax:               0x11,
 				SensorMin:               0x80,
 				SensorMax:               0x7b,
-				Identity:                `8$ ='[\V_`,
+				ComparedThresholds:      ThresholdMaskAll,
+				ReadableThresholds:      ThresholdMaskAll,
+				SettableThresholds:      ThresholdMaskAll,
+				Thresholds: Thresholds{
+					UpperNonCritical:    0x5f,
+					UpperCritical:       0x64,
+					UpperNonRecoverable: 0x64,
+				},
+				PositiveHysteresis: 2,
+				NegativeHysteresis: 2,
+				Identity:           `8$ ='[\V_`,
 			},
 		},
 		{
@@ -290,6 +314,8 @@ func TestFullSensorRecordDecodeFromBytes(t *testing.T) {
 				IsContainerEntity:       false,
 				Instance:                5,
 				Ignore:                  false,
+				HysteresisAccess:        SensorAccessSettable,
+				ThresholdAccess:         SensorAccessSettable,
 				SensorType:              SensorTypeVoltage,
 				OutputType:              OutputTypeThreshold,
 				AnalogDataFormat:        AnalogDataFormatUnsigned,
@@ -310,7 +336,17 @@ func TestFullSensorRecordDecodeFromBytes(t *testing.T) {
 				NormalMax:               0x00,
 				SensorMin:               0x00,
 				SensorMax:               0x0a,
-				Identity:                "Voltage",
+				ComparedThresholds:      ThresholdMaskAll,
+				ReadableThresholds:      ThresholdMaskAll,
+				SettableThresholds:      ThresholdMaskAll,
+				Thresholds: Thresholds{
+					UpperNonCritical:    0x5f,
+					UpperCritical:       0x64,
+					UpperNonRecoverable: 0x64,
+				},
+				PositiveHysteresis: 2,
+				NegativeHysteresis: 2,
+				Identity:           "Voltage",
 			},
 		},
 	}
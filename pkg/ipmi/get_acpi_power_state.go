@@ -0,0 +1,75 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetACPIPowerStateRsp represents the response to a Get ACPI Power State
+// command, specified in 17.7 and 20.7 of IPMI v1.5 and v2.0 respectively. It
+// contains the power states last recorded with Set ACPI Power State, so may
+// not reflect reality if system software does not send it. The request has
+// no data.
+type GetACPIPowerStateRsp struct {
+	layers.BaseLayer
+
+	// SystemState is the recorded system power state.
+	SystemState SystemPowerState
+
+	// DeviceState is the recorded device power state.
+	DeviceState DevicePowerState
+}
+
+func (*GetACPIPowerStateRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetACPIPowerStateRsp
+}
+
+func (r *GetACPIPowerStateRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*GetACPIPowerStateRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *GetACPIPowerStateRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 2 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be at least 2 bytes, got %v", len(data))
+	}
+
+	r.SystemState = SystemPowerState(data[0] & 0x7f)
+	r.DeviceState = DevicePowerState(data[1] & 0x7f)
+
+	r.BaseLayer.Contents = data[:2]
+	r.BaseLayer.Payload = data[2:]
+	return nil
+}
+
+type GetACPIPowerStateCmd struct {
+	Rsp GetACPIPowerStateRsp
+}
+
+// Name returns "Get ACPI Power State".
+func (*GetACPIPowerStateCmd) Name() string {
+	return "Get ACPI Power State"
+}
+
+// Operation returns &OperationGetACPIPowerStateReq.
+func (*GetACPIPowerStateCmd) Operation() *Operation {
+	return &OperationGetACPIPowerStateReq
+}
+
+func (*GetACPIPowerStateCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (*GetACPIPowerStateCmd) Request() gopacket.SerializableLayer {
+	return nil
+}
+
+func (c *GetACPIPowerStateCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
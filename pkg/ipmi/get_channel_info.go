@@ -0,0 +1,124 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/gebn/bmc/pkg/iana"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetChannelInfoReq represents a Get Channel Info command, specified in 18.24
+// and 22.24 of IPMI v1.5 and v2.0 respectively. The BMC responds with a
+// non-normal completion code, typically 0xcc, if the channel does not exist.
+type GetChannelInfoReq struct {
+	layers.BaseLayer
+
+	// Channel is the channel to retrieve information about.
+	// ChannelPresentInterface refers to the channel the request is sent over.
+	Channel Channel
+}
+
+func (*GetChannelInfoReq) LayerType() gopacket.LayerType {
+	return LayerTypeGetChannelInfoReq
+}
+
+func (r *GetChannelInfoReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(1)
+	if err != nil {
+		return err
+	}
+	bytes[0] = uint8(r.Channel) & 0xf
+	return nil
+}
+
+// GetChannelInfoRsp describes a channel's medium and protocol.
+type GetChannelInfoRsp struct {
+	layers.BaseLayer
+
+	// Channel is the channel the information corresponds to. This will never
+	// be ChannelPresentInterface.
+	Channel Channel
+
+	// Medium is the physical transport of the channel.
+	Medium ChannelMedium
+
+	// Protocol is the protocol used to carry IPMI messages over the channel.
+	Protocol ChannelProtocol
+
+	// SessionSupport indicates whether sessions can be established over the
+	// channel.
+	SessionSupport ChannelSessionSupport
+
+	// ActiveSessions is the number of sessions currently established over the
+	// channel. This is a 6-bit uint on the wire.
+	ActiveSessions uint8
+
+	// Vendor is the enterprise number of the organisation that defined the
+	// protocol. This is IPMI's own number, 7154, for protocols in the spec.
+	Vendor iana.Enterprise
+
+	// AuxiliaryInfo contains additional information whose meaning depends on
+	// the channel. For the system interface, it contains the SMS and event
+	// message buffer interrupts.
+	AuxiliaryInfo [2]byte
+}
+
+func (*GetChannelInfoRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetChannelInfoRsp
+}
+
+func (r *GetChannelInfoRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*GetChannelInfoRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *GetChannelInfoRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 9 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be at least 9 bytes, got %v", len(data))
+	}
+
+	r.Channel = Channel(data[0] & 0xf)
+	r.Medium = ChannelMedium(data[1] & 0x7f)
+	r.Protocol = ChannelProtocol(data[2] & 0x1f)
+	r.SessionSupport = ChannelSessionSupport(data[3] >> 6)
+	r.ActiveSessions = data[3] & 0x3f
+	r.Vendor = iana.Enterprise(uint32(data[4]) | uint32(data[5])<<8 | uint32(data[6])<<16)
+	copy(r.AuxiliaryInfo[:], data[7:9])
+
+	r.BaseLayer.Contents = data[:9]
+	r.BaseLayer.Payload = data[9:]
+	return nil
+}
+
+type GetChannelInfoCmd struct {
+	Req GetChannelInfoReq
+	Rsp GetChannelInfoRsp
+}
+
+// Name returns "Get Channel Info".
+func (*GetChannelInfoCmd) Name() string {
+	return "Get Channel Info"
+}
+
+// Operation returns &OperationGetChannelInfoReq.
+func (*GetChannelInfoCmd) Operation() *Operation {
+	return &OperationGetChannelInfoReq
+}
+
+func (*GetChannelInfoCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (c *GetChannelInfoCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *GetChannelInfoCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
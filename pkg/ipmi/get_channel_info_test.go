@@ -0,0 +1,67 @@
+package ipmi
+
+import (
+	"testing"
+
+	"github.com/gebn/bmc/pkg/iana"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestGetChannelInfoRspDecodeFromBytes(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want *GetChannelInfoRsp
+	}{
+		{
+			make([]byte, 8),
+			nil,
+		},
+		{
+			[]byte{0x01, 0x04, 0x01, 0x82, 0xf2, 0x1b, 0x00, 0x00, 0x00},
+			&GetChannelInfoRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x01, 0x04, 0x01, 0x82, 0xf2, 0x1b, 0x00, 0x00, 0x00},
+					Payload:  []byte{},
+				},
+				Channel:        1,
+				Medium:         ChannelMediumLAN,
+				Protocol:       ChannelProtocolIPMB,
+				SessionSupport: ChannelSessionSupportMultiSession,
+				ActiveSessions: 2,
+				Vendor:         iana.Enterprise(7154),
+			},
+		},
+		{
+			[]byte{0x0f, 0x0c, 0x05, 0x00, 0xf2, 0x1b, 0x00, 0x01, 0xff},
+			&GetChannelInfoRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x0f, 0x0c, 0x05, 0x00, 0xf2, 0x1b, 0x00, 0x01, 0xff},
+					Payload:  []byte{},
+				},
+				Channel:        ChannelSystemInterface,
+				Medium:         ChannelMediumSystemInterface,
+				Protocol:       ChannelProtocolKCS,
+				SessionSupport: ChannelSessionSupportSessionless,
+				Vendor:         iana.Enterprise(7154),
+				AuxiliaryInfo:  [2]byte{0x01, 0xff},
+			},
+		},
+	}
+	for _, test := range tests {
+		rsp := &GetChannelInfoRsp{}
+		err := rsp.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("expected error decoding %v, got none", test.in)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, rsp); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, rsp, test.want, diff)
+			}
+		case err != nil && test.want != nil:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
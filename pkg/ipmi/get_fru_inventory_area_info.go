@@ -0,0 +1,99 @@
+package ipmi
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetFRUInventoryAreaInfoReq represents a request for the size of a FRU
+// device's inventory area. It is specified in 28.1 and 34.1 of IPMI v1.5 and
+// v2.0 respectively.
+type GetFRUInventoryAreaInfoReq struct {
+	layers.BaseLayer
+
+	// DeviceID identifies the FRU device behind the BMC. 0 is the FRU device
+	// of the BMC itself, which typically describes the system.
+	DeviceID uint8
+}
+
+func (*GetFRUInventoryAreaInfoReq) LayerType() gopacket.LayerType {
+	return LayerTypeGetFRUInventoryAreaInfoReq
+}
+
+func (r *GetFRUInventoryAreaInfoReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(1)
+	if err != nil {
+		return err
+	}
+	bytes[0] = r.DeviceID
+	return nil
+}
+
+// GetFRUInventoryAreaInfoRsp contains the size of a FRU device's inventory
+// area, and how it is addressed.
+type GetFRUInventoryAreaInfoRsp struct {
+	layers.BaseLayer
+
+	// Size is the size of the inventory area in bytes.
+	Size uint16
+
+	// WordAccess indicates whether the device is accessed in words rather
+	// than bytes. If true, the offset and count of Read FRU Data are in
+	// 16-bit words.
+	WordAccess bool
+}
+
+func (*GetFRUInventoryAreaInfoRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetFRUInventoryAreaInfoRsp
+}
+
+func (r *GetFRUInventoryAreaInfoRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*GetFRUInventoryAreaInfoRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *GetFRUInventoryAreaInfoRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 3 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be 3 bytes, got %v", len(data))
+	}
+
+	r.BaseLayer.Contents = data[:3]
+	r.BaseLayer.Payload = data[3:]
+	r.Size = binary.LittleEndian.Uint16(data[0:2])
+	r.WordAccess = data[2]&1 != 0
+	return nil
+}
+
+type GetFRUInventoryAreaInfoCmd struct {
+	Req GetFRUInventoryAreaInfoReq
+	Rsp GetFRUInventoryAreaInfoRsp
+}
+
+// Name returns "Get FRU Inventory Area Info".
+func (*GetFRUInventoryAreaInfoCmd) Name() string {
+	return "Get FRU Inventory Area Info"
+}
+
+// Operation returns &OperationGetFRUInventoryAreaInfoReq.
+func (*GetFRUInventoryAreaInfoCmd) Operation() *Operation {
+	return &OperationGetFRUInventoryAreaInfoReq
+}
+
+func (*GetFRUInventoryAreaInfoCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (c *GetFRUInventoryAreaInfoCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *GetFRUInventoryAreaInfoCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
@@ -0,0 +1,122 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetLANConfigurationParametersReq implements the Get LAN Configuration
+// Parameters command, specified in section 23.2 of IPMI v2.0. The BMC
+// responds with completion code 0x80 if the parameter is not supported.
+type GetLANConfigurationParametersReq struct {
+	layers.BaseLayer
+
+	// RevisionOnly asks the BMC to only return the parameter revision,
+	// omitting the data.
+	RevisionOnly bool
+
+	// Channel is the channel whose LAN configuration to retrieve.
+	// ChannelPresentInterface can be used to refer to the channel the request
+	// is sent over.
+	Channel Channel
+
+	// Parameter is the parameter to retrieve.
+	Parameter LANConfigurationParameter
+
+	// SetSelector selects a given set of parameters under a given parameter.
+	// It is 0x00 for parameters that do not require it.
+	SetSelector uint8
+
+	// BlockSelector selects a block of data under a given parameter. It is
+	// 0x00 for parameters that do not require it.
+	BlockSelector uint8
+}
+
+func (*GetLANConfigurationParametersReq) LayerType() gopacket.LayerType {
+	return LayerTypeGetLANConfigurationParametersReq
+}
+
+func (g *GetLANConfigurationParametersReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(4)
+	if err != nil {
+		return err
+	}
+	bytes[0] = uint8(g.Channel) & 0xf
+	if g.RevisionOnly {
+		bytes[0] |= 1 << 7
+	}
+	bytes[1] = uint8(g.Parameter)
+	bytes[2] = g.SetSelector
+	bytes[3] = g.BlockSelector
+	return nil
+}
+
+type GetLANConfigurationParametersRsp struct {
+	layers.BaseLayer
+
+	// Revision is the parameter revision. The upper nibble is the present
+	// revision, and the lower nibble is the oldest revision the present
+	// revision is backwards compatible with. This is 0x11 for IPMI v2.0.
+	Revision uint8
+
+	// Data is the parameter data, whose format depends on the parameter
+	// requested. It is empty if only the revision was requested. This is the
+	// layer payload, so refers to the packet.
+	Data []byte
+}
+
+func (*GetLANConfigurationParametersRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetLANConfigurationParametersRsp
+}
+
+func (g *GetLANConfigurationParametersRsp) CanDecode() gopacket.LayerClass {
+	return g.LayerType()
+}
+
+func (*GetLANConfigurationParametersRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (g *GetLANConfigurationParametersRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 1 {
+		df.SetTruncated()
+		return fmt.Errorf("Get LAN Configuration Parameters responses must "+
+			"be at least 1 byte, got %v", len(data))
+	}
+
+	g.Revision = data[0]
+	g.Data = data[1:]
+
+	g.BaseLayer.Contents = data[:1]
+	g.BaseLayer.Payload = data[1:]
+	return nil
+}
+
+type GetLANConfigurationParametersCmd struct {
+	Req GetLANConfigurationParametersReq
+	Rsp GetLANConfigurationParametersRsp
+}
+
+// Name returns "Get LAN Configuration Parameters".
+func (*GetLANConfigurationParametersCmd) Name() string {
+	return "Get LAN Configuration Parameters"
+}
+
+// Operation returns OperationGetLANConfigurationParametersReq.
+func (*GetLANConfigurationParametersCmd) Operation() *Operation {
+	return &OperationGetLANConfigurationParametersReq
+}
+
+func (c *GetLANConfigurationParametersCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (c *GetLANConfigurationParametersCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *GetLANConfigurationParametersCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
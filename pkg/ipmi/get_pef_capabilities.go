@@ -0,0 +1,83 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetPEFCapabilitiesRsp represents the response to a Get PEF Capabilities
+// command, specified in 30.1 of IPMI v2.0. The request has no data.
+type GetPEFCapabilitiesRsp struct {
+	layers.BaseLayer
+
+	// Version is the PEF specification version implemented, in
+	// little-endian packed BCD. This is 0x51 for IPMI v1.5 and v2.0.
+	Version uint8
+
+	// SupportsOEMEventFiltering indicates whether OEM event records are
+	// passed through PEF.
+	SupportsOEMEventFiltering bool
+
+	// Actions contains the actions PEF can take. Group control is never
+	// reported here.
+	Actions PEFActions
+
+	// EventFilters is the number of entries in the event filter table.
+	EventFilters uint8
+}
+
+func (*GetPEFCapabilitiesRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetPEFCapabilitiesRsp
+}
+
+func (r *GetPEFCapabilitiesRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*GetPEFCapabilitiesRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *GetPEFCapabilitiesRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 3 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be at least 3 bytes, got %v", len(data))
+	}
+
+	r.Version = data[0]
+	r.SupportsOEMEventFiltering = data[1]&(1<<7) != 0
+	r.Actions = PEFActions(data[1] & 0x3f)
+	r.EventFilters = data[2]
+
+	r.BaseLayer.Contents = data[:3]
+	r.BaseLayer.Payload = data[3:]
+	return nil
+}
+
+type GetPEFCapabilitiesCmd struct {
+	Rsp GetPEFCapabilitiesRsp
+}
+
+// Name returns "Get PEF Capabilities".
+func (*GetPEFCapabilitiesCmd) Name() string {
+	return "Get PEF Capabilities"
+}
+
+// Operation returns &OperationGetPEFCapabilitiesReq.
+func (*GetPEFCapabilitiesCmd) Operation() *Operation {
+	return &OperationGetPEFCapabilitiesReq
+}
+
+func (*GetPEFCapabilitiesCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (*GetPEFCapabilitiesCmd) Request() gopacket.SerializableLayer {
+	return nil
+}
+
+func (c *GetPEFCapabilitiesCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
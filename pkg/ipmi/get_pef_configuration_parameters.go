@@ -0,0 +1,118 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetPEFConfigurationParametersReq implements the Get PEF Configuration
+// Parameters command, specified in section 30.4 of IPMI v2.0. The BMC
+// responds with completion code 0x80 if the parameter is not supported.
+type GetPEFConfigurationParametersReq struct {
+	layers.BaseLayer
+
+	// RevisionOnly asks the BMC to only return the parameter revision,
+	// omitting the data.
+	RevisionOnly bool
+
+	// Parameter is the parameter to retrieve. This is a 7-bit uint on the
+	// wire.
+	Parameter PEFConfigurationParameter
+
+	// SetSelector selects a given set of parameters under a given parameter,
+	// e.g. the event filter number. It is 0x00 for parameters that do not
+	// require it.
+	SetSelector uint8
+
+	// BlockSelector selects a block of data under a given parameter. It is
+	// 0x00 for parameters that do not require it.
+	BlockSelector uint8
+}
+
+func (*GetPEFConfigurationParametersReq) LayerType() gopacket.LayerType {
+	return LayerTypeGetPEFConfigurationParametersReq
+}
+
+func (g *GetPEFConfigurationParametersReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(3)
+	if err != nil {
+		return err
+	}
+	bytes[0] = uint8(g.Parameter) & 0x7f
+	if g.RevisionOnly {
+		bytes[0] |= 1 << 7
+	}
+	bytes[1] = g.SetSelector
+	bytes[2] = g.BlockSelector
+	return nil
+}
+
+type GetPEFConfigurationParametersRsp struct {
+	layers.BaseLayer
+
+	// Revision is the parameter revision. The upper nibble is the present
+	// revision, and the lower nibble is the oldest revision the present
+	// revision is backwards compatible with. This is 0x11 for IPMI v2.0.
+	Revision uint8
+
+	// Data is the parameter data, whose format depends on the parameter
+	// requested. It is empty if only the revision was requested. This is the
+	// layer payload, so refers to the packet.
+	Data []byte
+}
+
+func (*GetPEFConfigurationParametersRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetPEFConfigurationParametersRsp
+}
+
+func (g *GetPEFConfigurationParametersRsp) CanDecode() gopacket.LayerClass {
+	return g.LayerType()
+}
+
+func (*GetPEFConfigurationParametersRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (g *GetPEFConfigurationParametersRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 1 {
+		df.SetTruncated()
+		return fmt.Errorf("Get PEF Configuration Parameters responses must "+
+			"be at least 1 byte, got %v", len(data))
+	}
+
+	g.Revision = data[0]
+	g.Data = data[1:]
+
+	g.BaseLayer.Contents = data[:1]
+	g.BaseLayer.Payload = data[1:]
+	return nil
+}
+
+type GetPEFConfigurationParametersCmd struct {
+	Req GetPEFConfigurationParametersReq
+	Rsp GetPEFConfigurationParametersRsp
+}
+
+// Name returns "Get PEF Configuration Parameters".
+func (*GetPEFConfigurationParametersCmd) Name() string {
+	return "Get PEF Configuration Parameters"
+}
+
+// Operation returns OperationGetPEFConfigurationParametersReq.
+func (*GetPEFConfigurationParametersCmd) Operation() *Operation {
+	return &OperationGetPEFConfigurationParametersReq
+}
+
+func (c *GetPEFConfigurationParametersCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (c *GetPEFConfigurationParametersCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *GetPEFConfigurationParametersCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
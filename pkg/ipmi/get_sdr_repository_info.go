@@ -97,8 +97,8 @@ func (i *GetSDRRepositoryInfoRsp) DecodeFromBytes(data []byte, df gopacket.Decod
 	i.Version = bcd.Decode(data[0]&0xf)*10 + bcd.Decode(data[0]>>4)
 	i.Records = binary.LittleEndian.Uint16(data[1:3])
 	i.FreeSpace = binary.LittleEndian.Uint16(data[3:5])
-	i.LastAddition = time.Unix(int64(binary.LittleEndian.Uint32(data[5:9])), 0)
-	i.LastErase = time.Unix(int64(binary.LittleEndian.Uint32(data[9:13])), 0)
+	i.LastAddition = decodeTimestamp(data[5:9])
+	i.LastErase = decodeTimestamp(data[9:13])
 	i.Overflow = data[13]&(1<<7) != 0
 	i.SupportsModalUpdate = data[13]&(1<<6) != 0
 	i.SupportsNonModalUpdate = data[13]&(1<<5) != 0
@@ -0,0 +1,72 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetSDRRepositoryTimeRsp represents the response to a Get SDR Repository
+// Time command, specified in 27.17 and 33.17 of IPMI v1.5 and v2.0
+// respectively. The SDR Repository Device's clock is used to record when the
+// repository was last modified. It is often shared with the SEL Device. The
+// request has no data.
+type GetSDRRepositoryTimeRsp struct {
+	layers.BaseLayer
+
+	// ClockReading is the device's current time. This is relative to the
+	// device's initialisation if its clock has not been set.
+	ClockReading
+}
+
+func (*GetSDRRepositoryTimeRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetSDRRepositoryTimeRsp
+}
+
+func (r *GetSDRRepositoryTimeRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*GetSDRRepositoryTimeRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *GetSDRRepositoryTimeRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 4 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be at least 4 bytes, got %v", len(data))
+	}
+
+	r.ClockReading = decodeClockReading(data[0:4])
+
+	r.BaseLayer.Contents = data[:4]
+	r.BaseLayer.Payload = data[4:]
+	return nil
+}
+
+type GetSDRRepositoryTimeCmd struct {
+	Rsp GetSDRRepositoryTimeRsp
+}
+
+// Name returns "Get SDR Repository Time".
+func (*GetSDRRepositoryTimeCmd) Name() string {
+	return "Get SDR Repository Time"
+}
+
+// Operation returns &OperationGetSDRRepositoryTimeReq.
+func (*GetSDRRepositoryTimeCmd) Operation() *Operation {
+	return &OperationGetSDRRepositoryTimeReq
+}
+
+func (*GetSDRRepositoryTimeCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (*GetSDRRepositoryTimeCmd) Request() gopacket.SerializableLayer {
+	return nil
+}
+
+func (c *GetSDRRepositoryTimeCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
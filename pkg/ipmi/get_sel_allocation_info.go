@@ -0,0 +1,89 @@
+package ipmi
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetSELAllocationInfoRsp represents the response to a Get SEL Allocation Info
+// command, specified in 25.3 and 31.3 of IPMI v1.5 and v2.0 respectively. It
+// describes how the SEL's non-volatile storage is divided up. Support for the
+// command is indicated by GetSELInfoRsp.SupportsGetAllocationInformation.
+type GetSELAllocationInfoRsp struct {
+	layers.BaseLayer
+
+	// Units is the total number of allocation units in the SEL.
+	Units uint16
+
+	// UnitSize is the size of each allocation unit in bytes. 0 means
+	// unspecified.
+	UnitSize uint16
+
+	// FreeUnits is the number of allocation units that are unused.
+	FreeUnits uint16
+
+	// LargestFreeBlock is the size of the largest contiguous free region, in
+	// allocation units.
+	LargestFreeBlock uint16
+
+	// MaxRecordSize is the maximum size of a record, in allocation units.
+	MaxRecordSize uint8
+}
+
+func (*GetSELAllocationInfoRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetSELAllocationInfoRsp
+}
+
+func (i *GetSELAllocationInfoRsp) CanDecode() gopacket.LayerClass {
+	return i.LayerType()
+}
+
+func (*GetSELAllocationInfoRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (i *GetSELAllocationInfoRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 9 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be 9 bytes, got %v", len(data))
+	}
+
+	i.BaseLayer.Contents = data[:9]
+	i.BaseLayer.Payload = data[9:]
+
+	i.Units = binary.LittleEndian.Uint16(data[0:2])
+	i.UnitSize = binary.LittleEndian.Uint16(data[2:4])
+	i.FreeUnits = binary.LittleEndian.Uint16(data[4:6])
+	i.LargestFreeBlock = binary.LittleEndian.Uint16(data[6:8])
+	i.MaxRecordSize = data[8]
+	return nil
+}
+
+type GetSELAllocationInfoCmd struct {
+	Rsp GetSELAllocationInfoRsp
+}
+
+// Name returns "Get SEL Allocation Info".
+func (*GetSELAllocationInfoCmd) Name() string {
+	return "Get SEL Allocation Info"
+}
+
+// Operation returns OperationGetSELAllocationInfoReq.
+func (*GetSELAllocationInfoCmd) Operation() *Operation {
+	return &OperationGetSELAllocationInfoReq
+}
+
+func (*GetSELAllocationInfoCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (*GetSELAllocationInfoCmd) Request() gopacket.SerializableLayer {
+	return nil
+}
+
+func (c *GetSELAllocationInfoCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
@@ -0,0 +1,110 @@
+package ipmi
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetSELEntryReq represents a request to retrieve a single record from the
+// System Event Log. This command is specified in 25.5 and 31.5 of IPMI v1.5
+// and v2.0 respectively. Unlike SDRs, SEL records are almost always 16 bytes,
+// so there is rarely a need to read them in parts.
+type GetSELEntryReq struct {
+	layers.BaseLayer
+
+	// ReservationID is a consistency token, only required if Offset > 0.
+	ReservationID ReservationID
+
+	// RecordID is the record to read. To read the first record, specify
+	// RecordIDFirst; RecordIDLast can be used to read the most recent record.
+	RecordID RecordID
+
+	// Offset is the number of bytes into the record to start reading from. If
+	// >0, ReservationID must be non-zero.
+	Offset uint8
+
+	// Length is the number of bytes to read starting at the offset. 0xff means
+	// the entire record.
+	Length uint8
+}
+
+func (*GetSELEntryReq) LayerType() gopacket.LayerType {
+	return LayerTypeGetSELEntryReq
+}
+
+func (s *GetSELEntryReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(6)
+	if err != nil {
+		return err
+	}
+	binary.LittleEndian.PutUint16(bytes[0:2], uint16(s.ReservationID))
+	binary.LittleEndian.PutUint16(bytes[2:4], uint16(s.RecordID))
+	bytes[4] = s.Offset
+	bytes[5] = s.Length
+	return nil
+}
+
+// GetSELEntryRsp contains the next Record ID in the SEL, and wraps the record
+// data requested.
+type GetSELEntryRsp struct {
+	layers.BaseLayer
+
+	// Next is the Record ID of the next record in the SEL. This is
+	// RecordIDLast if the current record is the last one.
+	Next RecordID
+}
+
+func (*GetSELEntryRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetSELEntryRsp
+}
+
+func (s *GetSELEntryRsp) CanDecode() gopacket.LayerClass {
+	return s.LayerType()
+}
+
+func (*GetSELEntryRsp) NextLayerType() gopacket.LayerType {
+	return LayerTypeSELRecord
+}
+
+func (s *GetSELEntryRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 2 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be at least 2 bytes for the record ID, got %v",
+			len(data))
+	}
+
+	s.BaseLayer.Contents = data[:2]
+	s.BaseLayer.Payload = data[2:]
+	s.Next = RecordID(binary.LittleEndian.Uint16(data[:2]))
+	return nil
+}
+
+type GetSELEntryCmd struct {
+	Req GetSELEntryReq
+	Rsp GetSELEntryRsp
+}
+
+// Name returns "Get SEL Entry".
+func (*GetSELEntryCmd) Name() string {
+	return "Get SEL Entry"
+}
+
+// Operation returns &OperationGetSELEntryReq.
+func (*GetSELEntryCmd) Operation() *Operation {
+	return &OperationGetSELEntryReq
+}
+
+func (c *GetSELEntryCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (c *GetSELEntryCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *GetSELEntryCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
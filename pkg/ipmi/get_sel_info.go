@@ -0,0 +1,119 @@
+package ipmi
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/gebn/bmc/internal/pkg/bcd"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetSELInfoRsp represents the response to a Get SEL Info command, specified
+// in 25.2 and 31.2 of IPMI v1.5 and v2.0 respectively. Like Get SDR Repository
+// Info, this is useful for finding out how many entries are in the System
+// Event Log, and whether any changes were made during enumeration.
+type GetSELInfoRsp struct {
+	layers.BaseLayer
+
+	// Version indicates the command set supported by the SEL Device. This is
+	// little-endian packed BCD, and has been 0x51 (i.e. IPMI v1.5) since
+	// IPMI-over-LAN was introduced.
+	Version uint8
+
+	// Entries is the number of records in the SEL.
+	Entries uint16
+
+	// FreeSpace is the space remaining in the SEL in bytes. Each record is 16
+	// bytes.
+	FreeSpace uint16
+
+	// LastAddition is the time when the last record was added to the SEL.
+	// This will be the zero value if never.
+	LastAddition time.Time
+
+	// LastErase is the time when the last record was deleted from the SEL, or
+	// the entire SEL was cleared. This will be the zero value if never.
+	LastErase time.Time
+
+	// Overflow indicates whether an event could not be logged due to lack of
+	// space.
+	Overflow bool
+
+	// SupportsDelete indicates whether the Delete SEL Entry command is
+	// supported.
+	SupportsDelete bool
+
+	// SupportsPartialAdd indicates whether the Partial Add SEL Entry command
+	// is supported.
+	SupportsPartialAdd bool
+
+	// SupportsReserve indicates whether the Reserve SEL command is supported.
+	SupportsReserve bool
+
+	// SupportsGetAllocationInformation indicates whether the Get SEL
+	// Allocation Info command is supported.
+	SupportsGetAllocationInformation bool
+}
+
+func (*GetSELInfoRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetSELInfoRsp
+}
+
+func (i *GetSELInfoRsp) CanDecode() gopacket.LayerClass {
+	return i.LayerType()
+}
+
+func (*GetSELInfoRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (i *GetSELInfoRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 14 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be 14 bytes, got %v", len(data))
+	}
+
+	i.BaseLayer.Contents = data[:14]
+	i.BaseLayer.Payload = data[14:]
+
+	i.Version = bcd.Decode(data[0]&0xf)*10 + bcd.Decode(data[0]>>4)
+	i.Entries = binary.LittleEndian.Uint16(data[1:3])
+	i.FreeSpace = binary.LittleEndian.Uint16(data[3:5])
+	i.LastAddition = decodeTimestamp(data[5:9])
+	i.LastErase = decodeTimestamp(data[9:13])
+	i.Overflow = data[13]&(1<<7) != 0
+	i.SupportsDelete = data[13]&(1<<3) != 0
+	i.SupportsPartialAdd = data[13]&(1<<2) != 0
+	i.SupportsReserve = data[13]&(1<<1) != 0
+	i.SupportsGetAllocationInformation = data[13]&1 != 0
+	return nil
+}
+
+type GetSELInfoCmd struct {
+	Rsp GetSELInfoRsp
+}
+
+// Name returns "Get SEL Info".
+func (*GetSELInfoCmd) Name() string {
+	return "Get SEL Info"
+}
+
+// Operation returns OperationGetSELInfoReq.
+func (*GetSELInfoCmd) Operation() *Operation {
+	return &OperationGetSELInfoReq
+}
+
+func (*GetSELInfoCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (*GetSELInfoCmd) Request() gopacket.SerializableLayer {
+	return nil
+}
+
+func (c *GetSELInfoCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
@@ -0,0 +1,69 @@
+package ipmi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestGetSELInfoRspDecodeFromBytes(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want *GetSELInfoRsp
+	}{
+		// too short
+		{
+			make([]byte, 13),
+			nil,
+		},
+		{
+			[]byte{
+				0x51,
+				0x2a, 0x00,
+				0x60, 0x3b,
+				0x00, 0xf1, 0x53, 0x65,
+				0xff, 0xff, 0xff, 0xff,
+				0x8a,
+			},
+			&GetSELInfoRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{
+						0x51,
+						0x2a, 0x00,
+						0x60, 0x3b,
+						0x00, 0xf1, 0x53, 0x65,
+						0xff, 0xff, 0xff, 0xff,
+						0x8a,
+					},
+					Payload: []byte{},
+				},
+				Version:                          15,
+				Entries:                          42,
+				FreeSpace:                        15200,
+				LastAddition:                     time.Unix(1700000000, 0),
+				Overflow:                         true,
+				SupportsDelete:                   true,
+				SupportsPartialAdd:               false,
+				SupportsReserve:                  true,
+				SupportsGetAllocationInformation: false,
+			},
+		},
+	}
+	for _, test := range tests {
+		rsp := &GetSELInfoRsp{}
+		err := rsp.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("expected error decoding %v, got none", test.in)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, rsp); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, rsp, test.want, diff)
+			}
+		case err != nil && test.want != nil:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
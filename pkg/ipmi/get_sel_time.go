@@ -0,0 +1,70 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetSELTimeRsp represents the response to a Get SEL Time command, specified
+// in 25.10 and 31.10 of IPMI v1.5 and v2.0 respectively. The SEL Device's clock
+// is used to timestamp SEL entries. The request has no data.
+type GetSELTimeRsp struct {
+	layers.BaseLayer
+
+	// ClockReading is the device's current time. This is relative to the
+	// device's initialisation if its clock has not been set.
+	ClockReading
+}
+
+func (*GetSELTimeRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetSELTimeRsp
+}
+
+func (r *GetSELTimeRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*GetSELTimeRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *GetSELTimeRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 4 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be at least 4 bytes, got %v", len(data))
+	}
+
+	r.ClockReading = decodeClockReading(data[0:4])
+
+	r.BaseLayer.Contents = data[:4]
+	r.BaseLayer.Payload = data[4:]
+	return nil
+}
+
+type GetSELTimeCmd struct {
+	Rsp GetSELTimeRsp
+}
+
+// Name returns "Get SEL Time".
+func (*GetSELTimeCmd) Name() string {
+	return "Get SEL Time"
+}
+
+// Operation returns &OperationGetSELTimeReq.
+func (*GetSELTimeCmd) Operation() *Operation {
+	return &OperationGetSELTimeReq
+}
+
+func (*GetSELTimeCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (*GetSELTimeCmd) Request() gopacket.SerializableLayer {
+	return nil
+}
+
+func (c *GetSELTimeCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
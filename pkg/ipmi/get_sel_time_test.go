@@ -0,0 +1,75 @@
+package ipmi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestGetSELTimeRspDecodeFromBytes(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want *GetSELTimeRsp
+	}{
+		// too short
+		{
+			make([]byte, 3),
+			nil,
+		},
+		{
+			[]byte{0x00, 0xf1, 0x53, 0x65},
+			&GetSELTimeRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x00, 0xf1, 0x53, 0x65},
+					Payload:  []byte{},
+				},
+				ClockReading: ClockReading{
+					Time: time.Unix(1700000000, 0),
+				},
+			},
+		},
+		// clock not set
+		{
+			[]byte{0x3a, 0x0e, 0x00, 0x00},
+			&GetSELTimeRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x3a, 0x0e, 0x00, 0x00},
+					Payload:  []byte{},
+				},
+				ClockReading: ClockReading{
+					SinceInit: time.Hour + time.Second*42,
+				},
+			},
+		},
+		// upper bound of the pre-initialisation range
+		{
+			[]byte{0x00, 0x00, 0x00, 0x20},
+			&GetSELTimeRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x00, 0x00, 0x00, 0x20},
+					Payload:  []byte{},
+				},
+				ClockReading: ClockReading{
+					SinceInit: time.Second * 0x20000000,
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		rsp := &GetSELTimeRsp{}
+		err := rsp.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("expected error decoding %v, got none", test.in)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, rsp); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, rsp, test.want, diff)
+			}
+		case err != nil && test.want != nil:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
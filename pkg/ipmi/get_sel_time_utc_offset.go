@@ -0,0 +1,70 @@
+package ipmi
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetSELTimeUTCOffsetRsp represents the response to a Get SEL Time UTC Offset
+// command, specified in 31.11a of IPMI v2.0. The request has no data.
+type GetSELTimeUTCOffsetRsp struct {
+	layers.BaseLayer
+
+	// Offset is the BMC's local time offset from UTC. This may be
+	// UTCOffsetUnspecified.
+	Offset UTCOffset
+}
+
+func (*GetSELTimeUTCOffsetRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetSELTimeUTCOffsetRsp
+}
+
+func (r *GetSELTimeUTCOffsetRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*GetSELTimeUTCOffsetRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *GetSELTimeUTCOffsetRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 2 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be at least 2 bytes, got %v", len(data))
+	}
+
+	r.Offset = UTCOffset(binary.LittleEndian.Uint16(data[0:2]))
+
+	r.BaseLayer.Contents = data[:2]
+	r.BaseLayer.Payload = data[2:]
+	return nil
+}
+
+type GetSELTimeUTCOffsetCmd struct {
+	Rsp GetSELTimeUTCOffsetRsp
+}
+
+// Name returns "Get SEL Time UTC Offset".
+func (*GetSELTimeUTCOffsetCmd) Name() string {
+	return "Get SEL Time UTC Offset"
+}
+
+// Operation returns &OperationGetSELTimeUTCOffsetReq.
+func (*GetSELTimeUTCOffsetCmd) Operation() *Operation {
+	return &OperationGetSELTimeUTCOffsetReq
+}
+
+func (*GetSELTimeUTCOffsetCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (*GetSELTimeUTCOffsetCmd) Request() gopacket.SerializableLayer {
+	return nil
+}
+
+func (c *GetSELTimeUTCOffsetCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
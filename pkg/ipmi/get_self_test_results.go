@@ -0,0 +1,87 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetSelfTestResultsRsp represents the response to a Get Self Test Results
+// command, specified in 17.4 and 20.4 of IPMI v1.5 and v2.0 respectively. The
+// request has no data.
+type GetSelfTestResultsRsp struct {
+	layers.BaseLayer
+
+	// Result is the overall outcome of the self test.
+	Result SelfTestResult
+
+	// Failures details the failures found if Result is
+	// SelfTestResultCorrupted. If Result is device-specific, its meaning is
+	// also device-specific; use Detail to access it. Otherwise, it is 0.
+	Failures SelfTestFailures
+
+	// Detail is the raw second byte of the response.
+	Detail uint8
+}
+
+// Passed returns whether the self test found no errors.
+func (r *GetSelfTestResultsRsp) Passed() bool {
+	return r.Result == SelfTestResultPassed
+}
+
+func (*GetSelfTestResultsRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetSelfTestResultsRsp
+}
+
+func (r *GetSelfTestResultsRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*GetSelfTestResultsRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *GetSelfTestResultsRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 2 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be at least 2 bytes, got %v", len(data))
+	}
+
+	r.Result = SelfTestResult(data[0])
+	r.Detail = data[1]
+	r.Failures = 0
+	if r.Result == SelfTestResultCorrupted {
+		r.Failures = SelfTestFailures(data[1])
+	}
+
+	r.BaseLayer.Contents = data[:2]
+	r.BaseLayer.Payload = data[2:]
+	return nil
+}
+
+type GetSelfTestResultsCmd struct {
+	Rsp GetSelfTestResultsRsp
+}
+
+// Name returns "Get Self Test Results".
+func (*GetSelfTestResultsCmd) Name() string {
+	return "Get Self Test Results"
+}
+
+// Operation returns &OperationGetSelfTestResultsReq.
+func (*GetSelfTestResultsCmd) Operation() *Operation {
+	return &OperationGetSelfTestResultsReq
+}
+
+func (*GetSelfTestResultsCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (*GetSelfTestResultsCmd) Request() gopacket.SerializableLayer {
+	return nil
+}
+
+func (c *GetSelfTestResultsCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
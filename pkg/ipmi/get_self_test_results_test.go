@@ -0,0 +1,84 @@
+package ipmi
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestGetSelfTestResultsRspDecodeFromBytes(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want *GetSelfTestResultsRsp
+	}{
+		{
+			[]byte{0x55},
+			nil,
+		},
+		{
+			[]byte{0x55, 0x00},
+			&GetSelfTestResultsRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x55, 0x00},
+					Payload:  []byte{},
+				},
+				Result: SelfTestResultPassed,
+			},
+		},
+		{
+			[]byte{0x57, 0x88},
+			&GetSelfTestResultsRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x57, 0x88},
+					Payload:  []byte{},
+				},
+				Result:   SelfTestResultCorrupted,
+				Failures: SelfTestFailureSEL | SelfTestFailureSDRRepositoryEmpty,
+				Detail:   0x88,
+			},
+		},
+		{
+			[]byte{0x01, 0x88},
+			&GetSelfTestResultsRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x01, 0x88},
+					Payload:  []byte{},
+				},
+				Result: 0x01,
+				Detail: 0x88,
+			},
+		},
+	}
+	for _, test := range tests {
+		rsp := &GetSelfTestResultsRsp{}
+		err := rsp.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("expected error decoding %v, got none", test.in)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, rsp); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, rsp, test.want, diff)
+			}
+		case err != nil && test.want != nil:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestSelfTestFailuresString(t *testing.T) {
+	tests := []struct {
+		failures SelfTestFailures
+		want     string
+	}{
+		{0, "None"},
+		{SelfTestFailureOperationalFirmware, "Controller operational firmware corrupted"},
+		{0x88, "SDR Repository empty, Cannot access SEL device"},
+	}
+	for _, test := range tests {
+		if got := test.failures.String(); got != test.want {
+			t.Errorf("%#x.String() = %v, want %v", uint8(test.failures), got, test.want)
+		}
+	}
+}
@@ -51,6 +51,34 @@ type GetSensorReadingRsp struct {
 	// progress, or that the entity is not present. If set, the reading should
 	// be ignored.
 	ReadingUnavailable bool
+
+	// States contains the state bits of the response, with bit n set if state
+	// offset n is asserted. For discrete sensors, offsets are interpreted
+	// using OutputType.StateDescription(). For threshold sensors, bits 0
+	// through 5 instead indicate threshold comparison status. Offsets 8
+	// through 14 are only present if the BMC returns the optional fourth
+	// byte.
+	States uint16
+}
+
+// ThresholdComparison returns the thresholds the reading of a threshold-based
+// sensor is at or beyond, from the threshold comparison status bits. Only
+// thresholds in the SDR's ComparedThresholds are meaningful. This should not be
+// used for discrete sensors.
+func (r *GetSensorReadingRsp) ThresholdComparison() ThresholdMask {
+	return ThresholdMask(r.States) & ThresholdMaskAll
+}
+
+// AssertedStates returns the offsets of the states set in States, in ascending
+// order.
+func (r *GetSensorReadingRsp) AssertedStates() []uint8 {
+	var offsets []uint8
+	for offset := uint8(0); offset < 15; offset++ {
+		if r.States&(1<<offset) != 0 {
+			offsets = append(offsets, offset)
+		}
+	}
+	return offsets
 }
 
 func (*GetSensorReadingRsp) LayerType() gopacket.LayerType {
@@ -76,9 +104,11 @@ func (r *GetSensorReadingRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFee
 	r.EventMessagesEnabled = data[1]&(1<<7) != 0
 	r.ScanningEnabled = data[1]&(1<<6) != 0
 	r.ReadingUnavailable = data[1]&(1<<5) != 0
+	r.States = uint16(data[2])
 
 	if len(data) > 3 {
-		// discrete reading sensors only section
+		// discrete reading sensors only section; the top bit is reserved
+		r.States |= uint16(data[3]&0x7f) << 8
 		r.BaseLayer.Contents = data[:4]
 		r.BaseLayer.Payload = data[4:]
 	} else {
@@ -0,0 +1,116 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetSensorReadingFactorsReq represents a Get Sensor Reading Factors command,
+// specified in 29.5 and 35.5 of v1.5 and v2.0 respectively. This is needed to
+// convert the readings of non-linear sensors, whose conversion factors vary by
+// raw reading.
+type GetSensorReadingFactorsReq struct {
+	layers.BaseLayer
+
+	// Number is the number of the sensor whose factors to retrieve.
+	Number uint8
+
+	// Reading is the raw reading to retrieve the factors for.
+	Reading uint8
+}
+
+func (*GetSensorReadingFactorsReq) LayerType() gopacket.LayerType {
+	return LayerTypeGetSensorReadingFactorsReq
+}
+
+func (r *GetSensorReadingFactorsReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(2)
+	if err != nil {
+		return err
+	}
+	bytes[0] = r.Number
+	bytes[1] = r.Reading
+	return nil
+}
+
+// GetSensorReadingFactorsRsp contains the conversion factors applicable to a
+// single raw reading.
+type GetSensorReadingFactorsRsp struct {
+	layers.BaseLayer
+	ConversionFactors
+
+	// NextReading is the next raw reading for which the factors differ. A BMC
+	// may return the requested reading if it does not track this.
+	NextReading uint8
+
+	// Tolerance gives the absolute accuracy of the reading in +/- half raw
+	// counts. This is a 6-bit uint on the wire.
+	Tolerance uint8
+
+	// Accuracy gives the accuracy in 0.01% increments when raised to
+	// AccuracyExp. This is a 10-bit int on the wire.
+	Accuracy int16
+
+	// AccuracyExp is the quantity Accuracy is raised to the power of to give
+	// the final accuracy.
+	AccuracyExp uint8
+}
+
+func (*GetSensorReadingFactorsRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetSensorReadingFactorsRsp
+}
+
+func (r *GetSensorReadingFactorsRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*GetSensorReadingFactorsRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *GetSensorReadingFactorsRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 7 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be at least 7 bytes, got %v", len(data))
+	}
+
+	r.NextReading = data[0]
+	r.ConversionFactors.decode(data[1:7])
+	r.Tolerance, r.Accuracy, r.AccuracyExp = decodeTolerance(data[1:7])
+
+	r.BaseLayer.Contents = data[:7]
+	r.BaseLayer.Payload = data[7:]
+	return nil
+}
+
+type GetSensorReadingFactorsCmd struct {
+	Req GetSensorReadingFactorsReq
+	Rsp GetSensorReadingFactorsRsp
+
+	// OwnerLUN is the remote LUN of the sensor, which we learn from the SDR.
+	OwnerLUN LUN
+}
+
+// Name returns "Get Sensor Reading Factors".
+func (*GetSensorReadingFactorsCmd) Name() string {
+	return "Get Sensor Reading Factors"
+}
+
+// Operation returns &OperationGetSensorReadingFactorsReq.
+func (*GetSensorReadingFactorsCmd) Operation() *Operation {
+	return &OperationGetSensorReadingFactorsReq
+}
+
+func (c *GetSensorReadingFactorsCmd) RemoteLUN() LUN {
+	return c.OwnerLUN
+}
+
+func (c *GetSensorReadingFactorsCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *GetSensorReadingFactorsCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
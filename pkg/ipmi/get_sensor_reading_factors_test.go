@@ -0,0 +1,60 @@
+package ipmi
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestGetSensorReadingFactorsRspDecodeFromBytes(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want *GetSensorReadingFactorsRsp
+	}{
+		{
+			make([]byte, 6),
+			nil,
+		},
+		{
+			[]byte{
+				0x21,       // next reading
+				0xff,       // LS 8 bits of M
+				0b10110101, // MS 2 bits of M (M = -257), tolerance: 53
+				0xf0,       // LS 8 bits of B
+				0x00,       // MS 2 bits of B (B = 240), LS 6 bits of accuracy
+				0x00,       // MS 4 bits of accuracy, accuracy exp: 0
+				0b10100101, // R exp: -6, B exp: 5
+			},
+			&GetSensorReadingFactorsRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x21, 0xff, 0xb5, 0xf0, 0x00, 0x00, 0xa5},
+					Payload:  []byte{},
+				},
+				ConversionFactors: ConversionFactors{
+					M:    -257,
+					B:    240,
+					BExp: 5,
+					RExp: -6,
+				},
+				NextReading: 0x21,
+				Tolerance:   53,
+			},
+		},
+	}
+	for _, test := range tests {
+		rsp := &GetSensorReadingFactorsRsp{}
+		err := rsp.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("expected error decoding %v, got none", test.in)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, rsp); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, rsp, test.want, diff)
+			}
+		case err != nil && test.want != nil:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
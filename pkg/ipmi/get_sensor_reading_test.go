@@ -80,6 +80,19 @@ func TestGetSensorReadingRspDecodeFromBytes(t *testing.T) {
 				EventMessagesEnabled: false,
 				ScanningEnabled:      true,
 				ReadingUnavailable:   false,
+				States:               0x0100,
+			},
+		},
+		{
+			[]byte{0x00, 0b11000000, 0b00000010, 0b10000001},
+			&GetSensorReadingRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x00, 0b11000000, 0b00000010, 0b10000001},
+					Payload:  []byte{},
+				},
+				EventMessagesEnabled: true,
+				ScanningEnabled:      true,
+				States:               0x0102,
 			},
 		},
 	}
@@ -98,3 +111,14 @@ func TestGetSensorReadingRspDecodeFromBytes(t *testing.T) {
 		}
 	}
 }
+
+func TestGetSensorReadingRspAssertedStates(t *testing.T) {
+	rsp := &GetSensorReadingRsp{
+		States: 0x4102,
+	}
+	want := []uint8{1, 8, 14}
+	if diff := cmp.Diff(want, rsp.AssertedStates()); diff != "" {
+		t.Errorf("AssertedStates() = %v, want %v: %v", rsp.AssertedStates(),
+			want, diff)
+	}
+}
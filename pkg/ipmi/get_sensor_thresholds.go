@@ -0,0 +1,103 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetSensorThresholdsReq represents a Get Sensor Thresholds command, specified
+// in 29.9 and 35.9 of v1.5 and v2.0 respectively.
+type GetSensorThresholdsReq struct {
+	layers.BaseLayer
+
+	// Number is the number of the sensor whose thresholds to retrieve.
+	Number uint8
+}
+
+func (*GetSensorThresholdsReq) LayerType() gopacket.LayerType {
+	return LayerTypeGetSensorThresholdsReq
+}
+
+func (r *GetSensorThresholdsReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(1)
+	if err != nil {
+		return err
+	}
+	bytes[0] = r.Number
+	return nil
+}
+
+// GetSensorThresholdsRsp contains the current thresholds of a sensor.
+type GetSensorThresholdsRsp struct {
+	layers.BaseLayer
+
+	// Readable indicates which of the values in Thresholds are meaningful.
+	Readable ThresholdMask
+
+	// Thresholds contains the raw threshold values, which must be converted
+	// in the same way as a reading.
+	Thresholds Thresholds
+}
+
+func (*GetSensorThresholdsRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetSensorThresholdsRsp
+}
+
+func (r *GetSensorThresholdsRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*GetSensorThresholdsRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *GetSensorThresholdsRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 7 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be at least 7 bytes, got %v", len(data))
+	}
+
+	r.Readable = ThresholdMask(data[0] & 0x3f)
+	r.Thresholds.LowerNonCritical = data[1]
+	r.Thresholds.LowerCritical = data[2]
+	r.Thresholds.LowerNonRecoverable = data[3]
+	r.Thresholds.UpperNonCritical = data[4]
+	r.Thresholds.UpperCritical = data[5]
+	r.Thresholds.UpperNonRecoverable = data[6]
+
+	r.BaseLayer.Contents = data[:7]
+	r.BaseLayer.Payload = data[7:]
+	return nil
+}
+
+type GetSensorThresholdsCmd struct {
+	Req GetSensorThresholdsReq
+	Rsp GetSensorThresholdsRsp
+
+	// OwnerLUN is the remote LUN of the sensor, which we learn from the SDR.
+	OwnerLUN LUN
+}
+
+// Name returns "Get Sensor Thresholds".
+func (*GetSensorThresholdsCmd) Name() string {
+	return "Get Sensor Thresholds"
+}
+
+// Operation returns &OperationGetSensorThresholdsReq.
+func (*GetSensorThresholdsCmd) Operation() *Operation {
+	return &OperationGetSensorThresholdsReq
+}
+
+func (c *GetSensorThresholdsCmd) RemoteLUN() LUN {
+	return c.OwnerLUN
+}
+
+func (c *GetSensorThresholdsCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *GetSensorThresholdsCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
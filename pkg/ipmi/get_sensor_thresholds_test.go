@@ -0,0 +1,56 @@
+package ipmi
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestGetSensorThresholdsRspDecodeFromBytes(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want *GetSensorThresholdsRsp
+	}{
+		{
+			make([]byte, 6),
+			nil,
+		},
+		{
+			[]byte{0xdb, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07},
+			&GetSensorThresholdsRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0xdb, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06},
+					Payload:  []byte{0x07},
+				},
+				Readable: ThresholdMaskLowerNonCritical |
+					ThresholdMaskLowerCritical |
+					ThresholdMaskUpperNonCritical |
+					ThresholdMaskUpperCritical,
+				Thresholds: Thresholds{
+					LowerNonCritical:    1,
+					LowerCritical:       2,
+					LowerNonRecoverable: 3,
+					UpperNonCritical:    4,
+					UpperCritical:       5,
+					UpperNonRecoverable: 6,
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		rsp := &GetSensorThresholdsRsp{}
+		err := rsp.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("expected error decoding %v, got none", test.in)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, rsp); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, rsp, test.want, diff)
+			}
+		case err != nil && test.want != nil:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package ipmi
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetSessionChallengeReq represents a Get Session Challenge request, specified
+// in section 18.14 and 22.16 of IPMI v1.5 and v2.0 respectively. This is the
+// first step in establishing an IPMI v1.5 session, and is sent outside of a
+// session. It is not used to establish IPMI v2.0/RMCP+ sessions.
+type GetSessionChallengeReq struct {
+	layers.BaseLayer
+
+	// AuthenticationType is the algorithm the remote console intends to use to
+	// activate the session. It must be one of the types indicated as supported
+	// by Get Channel Authentication Capabilities. AuthenticationTypeRMCPPlus is
+	// invalid.
+	AuthenticationType AuthenticationType
+
+	// Username is the user to establish the session as. It is null-padded to
+	// 16 bytes on the wire, so cannot be longer than this. An empty username
+	// selects the null user.
+	Username string
+}
+
+func (*GetSessionChallengeReq) LayerType() gopacket.LayerType {
+	return LayerTypeGetSessionChallengeReq
+}
+
+func (r *GetSessionChallengeReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	if len(r.Username) > 16 {
+		return fmt.Errorf("username must be at most 16 bytes, got %v",
+			len(r.Username))
+	}
+	bytes, err := b.PrependBytes(17)
+	if err != nil {
+		return err
+	}
+	bytes[0] = uint8(r.AuthenticationType) & 0xf
+	copy(bytes[1:], r.Username)
+	for i := 1 + len(r.Username); i < 17; i++ {
+		// the buffer may be reused
+		bytes[i] = 0
+	}
+	return nil
+}
+
+// GetSessionChallengeRsp represents the managed system's response to a Get
+// Session Challenge request.
+type GetSessionChallengeRsp struct {
+	layers.BaseLayer
+
+	// TemporarySessionID is the session ID to use in the Activate Session
+	// request. It is only valid for that command; the BMC will assign the
+	// real session ID in the Activate Session response.
+	TemporarySessionID uint32
+
+	// Challenge is a random string generated by the BMC, which the remote
+	// console must echo back in the Activate Session request. Its
+	// authentication code proves knowledge of the password.
+	Challenge [16]byte
+}
+
+func (*GetSessionChallengeRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetSessionChallengeRsp
+}
+
+func (r *GetSessionChallengeRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*GetSessionChallengeRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *GetSessionChallengeRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 20 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be 20 bytes, got %v", len(data))
+	}
+
+	r.BaseLayer.Contents = data[:20]
+	r.BaseLayer.Payload = data[20:]
+	r.TemporarySessionID = binary.LittleEndian.Uint32(data[0:4])
+	copy(r.Challenge[:], data[4:20])
+	return nil
+}
+
+type GetSessionChallengeCmd struct {
+	Req GetSessionChallengeReq
+	Rsp GetSessionChallengeRsp
+}
+
+// Name returns "Get Session Challenge".
+func (*GetSessionChallengeCmd) Name() string {
+	return "Get Session Challenge"
+}
+
+// Operation returns &OperationGetSessionChallengeReq.
+func (*GetSessionChallengeCmd) Operation() *Operation {
+	return &OperationGetSessionChallengeReq
+}
+
+func (*GetSessionChallengeCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (c *GetSessionChallengeCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *GetSessionChallengeCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
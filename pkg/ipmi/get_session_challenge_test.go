@@ -0,0 +1,93 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestGetSessionChallengeReqSerializeTo(t *testing.T) {
+	table := []struct {
+		layer *GetSessionChallengeReq
+		want  []byte
+	}{
+		{
+			&GetSessionChallengeReq{
+				AuthenticationType: AuthenticationTypeMD5,
+			},
+			[]byte{0x02, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			&GetSessionChallengeReq{
+				AuthenticationType: AuthenticationTypeMD2,
+				Username:           "ADMIN",
+			},
+			[]byte{0x01, 'A', 'D', 'M', 'I', 'N', 0, 0, 0, 0, 0, 0, 0, 0, 0,
+				0, 0},
+		},
+		{
+			&GetSessionChallengeReq{
+				Username: "abcdefghijklmnopq",
+			},
+			nil, // username too long
+		},
+	}
+	for _, test := range table {
+		sb := gopacket.NewSerializeBuffer()
+		err := test.layer.SerializeTo(sb, gopacket.SerializeOptions{})
+		got := sb.Bytes()
+
+		switch {
+		case err != nil && test.want != nil:
+			t.Errorf("serialize %v failed with %v, wanted %v", test.layer, err, test.want)
+		case err == nil && test.want == nil:
+			t.Errorf("serialize %v succeeded with %v, wanted error", test.layer, got)
+		case err == nil && !bytes.Equal(got, test.want):
+			t.Errorf("serialize %v = %v, want %v", test.layer, got, test.want)
+		}
+	}
+}
+
+func TestGetSessionChallengeRspDecodeFromBytes(t *testing.T) {
+	table := []struct {
+		in   []byte
+		want *GetSessionChallengeRsp
+	}{
+		{
+			[]byte{0x01, 0x02, 0x03},
+			nil,
+		},
+		{
+			[]byte{0x78, 0x56, 0x34, 0x12, 0x1, 0x2, 0x3, 0x4, 0x5, 0x6, 0x7,
+				0x8, 0x9, 0xa, 0xb, 0xc, 0xd, 0xe, 0xf, 0x10},
+			&GetSessionChallengeRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x78, 0x56, 0x34, 0x12, 0x1, 0x2, 0x3,
+						0x4, 0x5, 0x6, 0x7, 0x8, 0x9, 0xa, 0xb, 0xc, 0xd, 0xe,
+						0xf, 0x10},
+					Payload: []byte{},
+				},
+				TemporarySessionID: 0x12345678,
+				Challenge: [16]byte{0x1, 0x2, 0x3, 0x4, 0x5, 0x6, 0x7, 0x8,
+					0x9, 0xa, 0xb, 0xc, 0xd, 0xe, 0xf, 0x10},
+			},
+		},
+	}
+	for _, test := range table {
+		rsp := &GetSessionChallengeRsp{}
+		err := rsp.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("expected error decoding %v, got none", test.in)
+		case err != nil && test.want != nil:
+			t.Errorf("unexpected error decoding %v: %v", test.in, err)
+		case err == nil:
+			if diff := cmp.Diff(test.want, rsp); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, rsp, test.want, diff)
+			}
+		}
+	}
+}
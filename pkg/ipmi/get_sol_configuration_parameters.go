@@ -0,0 +1,122 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetSOLConfigurationParametersReq implements the Get SOL Configuration
+// Parameters command, specified in section 26.3 of IPMI v2.0. The BMC
+// responds with completion code 0x80 if the parameter is not supported.
+type GetSOLConfigurationParametersReq struct {
+	layers.BaseLayer
+
+	// RevisionOnly asks the BMC to only return the parameter revision,
+	// omitting the data.
+	RevisionOnly bool
+
+	// Channel is the channel whose SOL configuration to retrieve.
+	// ChannelPresentInterface can be used to refer to the channel the request
+	// is sent over.
+	Channel Channel
+
+	// Parameter is the parameter to retrieve.
+	Parameter SOLConfigurationParameter
+
+	// SetSelector selects a given set of parameters under a given parameter.
+	// It is 0x00 for parameters that do not require it.
+	SetSelector uint8
+
+	// BlockSelector selects a block of data under a given parameter. It is
+	// 0x00 for parameters that do not require it.
+	BlockSelector uint8
+}
+
+func (*GetSOLConfigurationParametersReq) LayerType() gopacket.LayerType {
+	return LayerTypeGetSOLConfigurationParametersReq
+}
+
+func (g *GetSOLConfigurationParametersReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(4)
+	if err != nil {
+		return err
+	}
+	bytes[0] = uint8(g.Channel) & 0xf
+	if g.RevisionOnly {
+		bytes[0] |= 1 << 7
+	}
+	bytes[1] = uint8(g.Parameter)
+	bytes[2] = g.SetSelector
+	bytes[3] = g.BlockSelector
+	return nil
+}
+
+type GetSOLConfigurationParametersRsp struct {
+	layers.BaseLayer
+
+	// Revision is the parameter revision. The upper nibble is the present
+	// revision, and the lower nibble is the oldest revision the present
+	// revision is backwards compatible with. This is 0x11 for IPMI v2.0.
+	Revision uint8
+
+	// Data is the parameter data, whose format depends on the parameter
+	// requested. It is empty if only the revision was requested. This is the
+	// layer payload, so refers to the packet.
+	Data []byte
+}
+
+func (*GetSOLConfigurationParametersRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetSOLConfigurationParametersRsp
+}
+
+func (g *GetSOLConfigurationParametersRsp) CanDecode() gopacket.LayerClass {
+	return g.LayerType()
+}
+
+func (*GetSOLConfigurationParametersRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (g *GetSOLConfigurationParametersRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 1 {
+		df.SetTruncated()
+		return fmt.Errorf("Get SOL Configuration Parameters responses must "+
+			"be at least 1 byte, got %v", len(data))
+	}
+
+	g.Revision = data[0]
+	g.Data = data[1:]
+
+	g.BaseLayer.Contents = data[:1]
+	g.BaseLayer.Payload = data[1:]
+	return nil
+}
+
+type GetSOLConfigurationParametersCmd struct {
+	Req GetSOLConfigurationParametersReq
+	Rsp GetSOLConfigurationParametersRsp
+}
+
+// Name returns "Get SOL Configuration Parameters".
+func (*GetSOLConfigurationParametersCmd) Name() string {
+	return "Get SOL Configuration Parameters"
+}
+
+// Operation returns OperationGetSOLConfigurationParametersReq.
+func (*GetSOLConfigurationParametersCmd) Operation() *Operation {
+	return &OperationGetSOLConfigurationParametersReq
+}
+
+func (c *GetSOLConfigurationParametersCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (c *GetSOLConfigurationParametersCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *GetSOLConfigurationParametersCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
@@ -0,0 +1,116 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetSystemBootOptionsReq implements the Get System Boot Options command,
+// specified in section 28.13 of IPMI v2.0. The BMC responds with completion
+// code 0x80 if the parameter is not supported.
+type GetSystemBootOptionsReq struct {
+	layers.BaseLayer
+
+	// Parameter is the boot option to retrieve.
+	Parameter BootOptionParameter
+
+	// SetSelector selects a given set of parameters under a given parameter.
+	// It is 0x00 for parameters that do not require it.
+	SetSelector uint8
+
+	// BlockSelector selects a block of data under a given parameter. It is
+	// 0x00 for parameters that do not require it.
+	BlockSelector uint8
+}
+
+func (*GetSystemBootOptionsReq) LayerType() gopacket.LayerType {
+	return LayerTypeGetSystemBootOptionsReq
+}
+
+func (g *GetSystemBootOptionsReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(3)
+	if err != nil {
+		return err
+	}
+	bytes[0] = uint8(g.Parameter) & 0x7f
+	bytes[1] = g.SetSelector
+	bytes[2] = g.BlockSelector
+	return nil
+}
+
+type GetSystemBootOptionsRsp struct {
+	layers.BaseLayer
+
+	// Version is the parameter version. This is 1 for IPMI v2.0. This is a
+	// 4-bit uint on the wire.
+	Version uint8
+
+	// Invalid indicates the parameter has been marked invalid or locked, e.g.
+	// by the BIOS once it has acted on it.
+	Invalid bool
+
+	// Parameter is the boot option returned.
+	Parameter BootOptionParameter
+
+	// Data is the parameter data, whose format depends on the parameter. This
+	// is the layer payload, so refers to the packet.
+	Data []byte
+}
+
+func (*GetSystemBootOptionsRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetSystemBootOptionsRsp
+}
+
+func (g *GetSystemBootOptionsRsp) CanDecode() gopacket.LayerClass {
+	return g.LayerType()
+}
+
+func (*GetSystemBootOptionsRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (g *GetSystemBootOptionsRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 2 {
+		df.SetTruncated()
+		return fmt.Errorf("Get System Boot Options responses must be at "+
+			"least 2 bytes, got %v", len(data))
+	}
+
+	g.Version = data[0] & 0xf
+	g.Invalid = data[1]&(1<<7) != 0
+	g.Parameter = BootOptionParameter(data[1] & 0x7f)
+	g.Data = data[2:]
+
+	g.BaseLayer.Contents = data[:2]
+	g.BaseLayer.Payload = data[2:]
+	return nil
+}
+
+type GetSystemBootOptionsCmd struct {
+	Req GetSystemBootOptionsReq
+	Rsp GetSystemBootOptionsRsp
+}
+
+// Name returns "Get System Boot Options".
+func (*GetSystemBootOptionsCmd) Name() string {
+	return "Get System Boot Options"
+}
+
+// Operation returns OperationGetSystemBootOptionsReq.
+func (*GetSystemBootOptionsCmd) Operation() *Operation {
+	return &OperationGetSystemBootOptionsReq
+}
+
+func (c *GetSystemBootOptionsCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (c *GetSystemBootOptionsCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *GetSystemBootOptionsCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
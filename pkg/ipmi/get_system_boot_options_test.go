@@ -0,0 +1,61 @@
+package ipmi
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestGetSystemBootOptionsRspDecodeFromBytes(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want *GetSystemBootOptionsRsp
+	}{
+		{
+			// too short
+			[]byte{0x01},
+			nil,
+		},
+		{
+			[]byte{0x01, 0x05, 0x80, 0x04, 0x00, 0x00, 0x00},
+			&GetSystemBootOptionsRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x01, 0x05},
+					Payload:  []byte{0x80, 0x04, 0x00, 0x00, 0x00},
+				},
+				Version:   1,
+				Parameter: BootOptionParameterBootFlags,
+				Data:      []byte{0x80, 0x04, 0x00, 0x00, 0x00},
+			},
+		},
+		{
+			[]byte{0x01, 0x80},
+			&GetSystemBootOptionsRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x01, 0x80},
+					Payload:  []byte{},
+				},
+				Version:   1,
+				Invalid:   true,
+				Parameter: BootOptionParameterSetInProgress,
+				Data:      []byte{},
+			},
+		},
+	}
+	for _, test := range tests {
+		rsp := &GetSystemBootOptionsRsp{}
+		err := rsp.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("expected error decoding %v, got none", test.in)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, rsp); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, rsp, test.want, diff)
+			}
+		case err != nil && test.want != nil:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetSystemInfoParametersReq implements the Get System Info Parameters
+// command, specified in section 22.14b of IPMI v2.0. The BMC responds with
+// completion code 0x80 if the parameter is not supported.
+type GetSystemInfoParametersReq struct {
+	layers.BaseLayer
+
+	// RevisionOnly asks the BMC to only return the parameter revision,
+	// omitting the data.
+	RevisionOnly bool
+
+	// Parameter is the parameter to retrieve.
+	Parameter SystemInfoParameter
+
+	// SetSelector selects a given set of parameters under a given parameter,
+	// e.g. the block number of a string. It is 0x00 for parameters that do not
+	// require it.
+	SetSelector uint8
+
+	// BlockSelector selects a block of data under a given parameter. It is
+	// 0x00 for parameters that do not require it.
+	BlockSelector uint8
+}
+
+func (*GetSystemInfoParametersReq) LayerType() gopacket.LayerType {
+	return LayerTypeGetSystemInfoParametersReq
+}
+
+func (g *GetSystemInfoParametersReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(4)
+	if err != nil {
+		return err
+	}
+	bytes[0] = 0
+	if g.RevisionOnly {
+		bytes[0] |= 1 << 7
+	}
+	bytes[1] = uint8(g.Parameter)
+	bytes[2] = g.SetSelector
+	bytes[3] = g.BlockSelector
+	return nil
+}
+
+type GetSystemInfoParametersRsp struct {
+	layers.BaseLayer
+
+	// Revision is the parameter revision. The upper nibble is the present
+	// revision, and the lower nibble is the oldest revision the present
+	// revision is backwards compatible with. This is 0x11 for IPMI v2.0.
+	Revision uint8
+
+	// Data is the parameter data, whose format depends on the parameter
+	// requested. It is empty if only the revision was requested. This is the
+	// layer payload, so refers to the packet.
+	Data []byte
+}
+
+func (*GetSystemInfoParametersRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetSystemInfoParametersRsp
+}
+
+func (g *GetSystemInfoParametersRsp) CanDecode() gopacket.LayerClass {
+	return g.LayerType()
+}
+
+func (*GetSystemInfoParametersRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (g *GetSystemInfoParametersRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 1 {
+		df.SetTruncated()
+		return fmt.Errorf("Get System Info Parameters responses must "+
+			"be at least 1 byte, got %v", len(data))
+	}
+
+	g.Revision = data[0]
+	g.Data = data[1:]
+
+	g.BaseLayer.Contents = data[:1]
+	g.BaseLayer.Payload = data[1:]
+	return nil
+}
+
+type GetSystemInfoParametersCmd struct {
+	Req GetSystemInfoParametersReq
+	Rsp GetSystemInfoParametersRsp
+}
+
+// Name returns "Get System Info Parameters".
+func (*GetSystemInfoParametersCmd) Name() string {
+	return "Get System Info Parameters"
+}
+
+// Operation returns OperationGetSystemInfoParametersReq.
+func (*GetSystemInfoParametersCmd) Operation() *Operation {
+	return &OperationGetSystemInfoParametersReq
+}
+
+func (c *GetSystemInfoParametersCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (c *GetSystemInfoParametersCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *GetSystemInfoParametersCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
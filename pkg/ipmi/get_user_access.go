@@ -0,0 +1,116 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetUserAccessReq represents a Get User Access command, specified in 18.27
+// and 22.27 of IPMI v1.5 and v2.0 respectively.
+type GetUserAccessReq struct {
+	layers.BaseLayer
+
+	// Channel is the channel to retrieve the user's access for.
+	// ChannelPresentInterface refers to the channel the request is sent over.
+	Channel Channel
+
+	// UserID identifies the user. User IDs start at 1, which is typically the
+	// anonymous or "null" user. This is a 6-bit uint on the wire.
+	UserID uint8
+}
+
+func (*GetUserAccessReq) LayerType() gopacket.LayerType {
+	return LayerTypeGetUserAccessReq
+}
+
+func (r *GetUserAccessReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(2)
+	if err != nil {
+		return err
+	}
+	bytes[0] = uint8(r.Channel) & 0xf
+	bytes[1] = r.UserID & 0x3f
+	return nil
+}
+
+// GetUserAccessRsp contains a user's access on a channel, along with summary
+// information about the BMC's users.
+type GetUserAccessRsp struct {
+	layers.BaseLayer
+	UserAccess
+
+	// MaxUsers is the number of user IDs the BMC supports. Users can be
+	// enumerated from ID 1 to this value inclusive.
+	MaxUsers uint8
+
+	// Status indicates whether the requested user is enabled.
+	Status UserStatus
+
+	// EnabledUsers is the number of currently enabled user IDs.
+	EnabledUsers uint8
+
+	// FixedNameUsers is the number of user IDs, starting at 1, whose names
+	// cannot be changed.
+	FixedNameUsers uint8
+}
+
+func (*GetUserAccessRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetUserAccessRsp
+}
+
+func (r *GetUserAccessRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*GetUserAccessRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *GetUserAccessRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 4 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be at least 4 bytes, got %v", len(data))
+	}
+
+	r.MaxUsers = data[0] & 0x3f
+	r.Status = UserStatus(data[1] >> 6)
+	r.EnabledUsers = data[1] & 0x3f
+	r.FixedNameUsers = data[2] & 0x3f
+	r.CallbackOnly = data[3]&(1<<6) != 0
+	r.LinkAuthentication = data[3]&(1<<5) != 0
+	r.IPMIMessaging = data[3]&(1<<4) != 0
+	r.PrivilegeLimit = PrivilegeLevel(data[3] & 0xf)
+
+	r.BaseLayer.Contents = data[:4]
+	r.BaseLayer.Payload = data[4:]
+	return nil
+}
+
+type GetUserAccessCmd struct {
+	Req GetUserAccessReq
+	Rsp GetUserAccessRsp
+}
+
+// Name returns "Get User Access".
+func (*GetUserAccessCmd) Name() string {
+	return "Get User Access"
+}
+
+// Operation returns &OperationGetUserAccessReq.
+func (*GetUserAccessCmd) Operation() *Operation {
+	return &OperationGetUserAccessReq
+}
+
+func (*GetUserAccessCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (c *GetUserAccessCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *GetUserAccessCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
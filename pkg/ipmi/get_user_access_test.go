@@ -0,0 +1,53 @@
+package ipmi
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestGetUserAccessRspDecodeFromBytes(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want *GetUserAccessRsp
+	}{
+		{
+			make([]byte, 3),
+			nil,
+		},
+		{
+			[]byte{0x0a, 0x42, 0x01, 0x34},
+			&GetUserAccessRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x0a, 0x42, 0x01, 0x34},
+					Payload:  []byte{},
+				},
+				UserAccess: UserAccess{
+					LinkAuthentication: true,
+					IPMIMessaging:      true,
+					PrivilegeLimit:     PrivilegeLevelAdministrator,
+				},
+				MaxUsers:       10,
+				Status:         UserStatusEnabled,
+				EnabledUsers:   2,
+				FixedNameUsers: 1,
+			},
+		},
+	}
+	for _, test := range tests {
+		rsp := &GetUserAccessRsp{}
+		err := rsp.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("expected error decoding %v, got none", test.in)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, rsp); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, rsp, test.want, diff)
+			}
+		case err != nil && test.want != nil:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package ipmi
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+const (
+	// userNameLength is the size of the user name field in Get and Set User
+	// Name. Shorter names are padded with 0x00.
+	userNameLength = 16
+)
+
+// GetUserNameReq represents a Get User Name command, specified in 18.29 and
+// 22.29 of IPMI v1.5 and v2.0 respectively.
+type GetUserNameReq struct {
+	layers.BaseLayer
+
+	// UserID identifies the user. This is a 6-bit uint on the wire.
+	UserID uint8
+}
+
+func (*GetUserNameReq) LayerType() gopacket.LayerType {
+	return LayerTypeGetUserNameReq
+}
+
+func (r *GetUserNameReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(1)
+	if err != nil {
+		return err
+	}
+	bytes[0] = r.UserID & 0x3f
+	return nil
+}
+
+type GetUserNameRsp struct {
+	layers.BaseLayer
+
+	// Name is the user's name, without padding. This is empty for the
+	// anonymous user, and unused user IDs.
+	Name string
+}
+
+func (*GetUserNameRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetUserNameRsp
+}
+
+func (r *GetUserNameRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*GetUserNameRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *GetUserNameRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < userNameLength {
+		df.SetTruncated()
+		return fmt.Errorf("response must be at least %v bytes, got %v",
+			userNameLength, len(data))
+	}
+
+	name := data[:userNameLength]
+	if end := bytes.IndexByte(name, 0); end != -1 {
+		name = name[:end]
+	}
+	r.Name = string(name)
+
+	r.BaseLayer.Contents = data[:userNameLength]
+	r.BaseLayer.Payload = data[userNameLength:]
+	return nil
+}
+
+type GetUserNameCmd struct {
+	Req GetUserNameReq
+	Rsp GetUserNameRsp
+}
+
+// Name returns "Get User Name".
+func (*GetUserNameCmd) Name() string {
+	return "Get User Name"
+}
+
+// Operation returns &OperationGetUserNameReq.
+func (*GetUserNameCmd) Operation() *Operation {
+	return &OperationGetUserNameReq
+}
+
+func (*GetUserNameCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (c *GetUserNameCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *GetUserNameCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
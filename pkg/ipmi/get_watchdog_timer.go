@@ -0,0 +1,90 @@
+package ipmi
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// GetWatchdogTimerRsp represents the response to a Get Watchdog Timer command,
+// specified in 21.7 and 27.7 of IPMI v1.5 and v2.0 respectively. The request
+// has no data.
+type GetWatchdogTimerRsp struct {
+	layers.BaseLayer
+	WatchdogTimer
+
+	// Running indicates the timer is counting down.
+	Running bool
+
+	// ExpirationFlags contains the timer uses that have expired since the
+	// flags were last cleared.
+	ExpirationFlags WatchdogExpirationFlags
+
+	// PresentCountdown is the time remaining until the timer expires. This is
+	// InitialCountdown if the timer has been set but not started.
+	PresentCountdown time.Duration
+}
+
+func (*GetWatchdogTimerRsp) LayerType() gopacket.LayerType {
+	return LayerTypeGetWatchdogTimerRsp
+}
+
+func (r *GetWatchdogTimerRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*GetWatchdogTimerRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *GetWatchdogTimerRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 8 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be at least 8 bytes, got %v", len(data))
+	}
+
+	r.DontLog = data[0]&(1<<7) != 0
+	r.Running = data[0]&(1<<6) != 0
+	r.Use = WatchdogTimerUse(data[0] & 0x7)
+	r.PreTimeoutInterrupt = WatchdogPreTimeoutInterrupt((data[1] >> 4) & 0x7)
+	r.TimeoutAction = WatchdogTimeoutAction(data[1] & 0x7)
+	r.PreTimeoutInterval = time.Duration(data[2]) * time.Second
+	r.ExpirationFlags = WatchdogExpirationFlags(data[3]) & WatchdogExpirationFlagsAll
+	r.InitialCountdown = time.Duration(binary.LittleEndian.Uint16(data[4:6])) *
+		time.Millisecond * 100
+	r.PresentCountdown = time.Duration(binary.LittleEndian.Uint16(data[6:8])) *
+		time.Millisecond * 100
+
+	r.BaseLayer.Contents = data[:8]
+	r.BaseLayer.Payload = data[8:]
+	return nil
+}
+
+type GetWatchdogTimerCmd struct {
+	Rsp GetWatchdogTimerRsp
+}
+
+// Name returns "Get Watchdog Timer".
+func (*GetWatchdogTimerCmd) Name() string {
+	return "Get Watchdog Timer"
+}
+
+// Operation returns &OperationGetWatchdogTimerReq.
+func (*GetWatchdogTimerCmd) Operation() *Operation {
+	return &OperationGetWatchdogTimerReq
+}
+
+func (*GetWatchdogTimerCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (*GetWatchdogTimerCmd) Request() gopacket.SerializableLayer {
+	return nil
+}
+
+func (c *GetWatchdogTimerCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
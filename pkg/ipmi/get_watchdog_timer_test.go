@@ -0,0 +1,72 @@
+package ipmi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestGetWatchdogTimerRspDecodeFromBytes(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want *GetWatchdogTimerRsp
+	}{
+		{
+			make([]byte, 7),
+			nil,
+		},
+		{
+			[]byte{0x44, 0x21, 0x0a, 0x10, 0x58, 0x02, 0x2c, 0x01},
+			&GetWatchdogTimerRsp{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x44, 0x21, 0x0a, 0x10, 0x58, 0x02, 0x2c, 0x01},
+					Payload:  []byte{},
+				},
+				WatchdogTimer: WatchdogTimer{
+					Use:                 WatchdogTimerUseSMSOS,
+					TimeoutAction:       WatchdogTimeoutActionHardReset,
+					PreTimeoutInterrupt: WatchdogPreTimeoutInterruptNMI,
+					PreTimeoutInterval:  time.Second * 10,
+					InitialCountdown:    time.Minute,
+				},
+				Running:          true,
+				ExpirationFlags:  WatchdogExpirationFlag(WatchdogTimerUseSMSOS),
+				PresentCountdown: time.Second * 30,
+			},
+		},
+	}
+	for _, test := range tests {
+		rsp := &GetWatchdogTimerRsp{}
+		err := rsp.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("expected error decoding %v, got none", test.in)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, rsp); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, rsp, test.want, diff)
+			}
+		case err != nil && test.want != nil:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestWatchdogExpirationFlagsString(t *testing.T) {
+	tests := []struct {
+		flags WatchdogExpirationFlags
+		want  string
+	}{
+		{0, "None"},
+		{0x01, "None"},
+		{0x12, "BIOS FRB2, SMS/OS"},
+		{WatchdogExpirationFlagsAll, "BIOS FRB2, BIOS/POST, OS Load, SMS/OS, OEM"},
+	}
+	for _, test := range tests {
+		if got := test.flags.String(); got != test.want {
+			t.Errorf("%#x.String() = %v, want %v", uint8(test.flags), got, test.want)
+		}
+	}
+}
@@ -6,7 +6,8 @@ import (
 )
 
 // This file implements functions to decode the final "ID String" field in full
-// and compact sensor records.
+// and compact sensor records. The same encodings are used by the type/length
+// fields of FRU info areas.
 
 var (
 	// bcdPlus defines the mappings of BCD plus nibbles to runes, specified in
@@ -0,0 +1,52 @@
+package ipmi
+
+import (
+	"fmt"
+)
+
+// IPAddressSource indicates how the BMC obtains its IPv4 address. It is the
+// data of the IP address source LAN configuration parameter, specified in
+// table 23-4 of IPMI v2.0. This is a 4-bit uint on the wire.
+type IPAddressSource uint8
+
+const (
+	// IPAddressSourceUnspecified means the BMC does not say how its address
+	// was obtained.
+	IPAddressSourceUnspecified IPAddressSource = iota
+
+	// IPAddressSourceStatic means the address was configured manually, e.g.
+	// via the IP address LAN configuration parameter.
+	IPAddressSourceStatic
+
+	// IPAddressSourceDHCP means the BMC runs its own DHCP client.
+	IPAddressSourceDHCP
+
+	// IPAddressSourceBIOS means the address is loaded by the BIOS or system
+	// software.
+	IPAddressSourceBIOS
+
+	// IPAddressSourceOther means the address is obtained by the BMC via a
+	// protocol other than DHCP.
+	IPAddressSourceOther
+)
+
+func (s IPAddressSource) Description() string {
+	switch s {
+	case IPAddressSourceUnspecified:
+		return "Unspecified"
+	case IPAddressSourceStatic:
+		return "Static"
+	case IPAddressSourceDHCP:
+		return "DHCP"
+	case IPAddressSourceBIOS:
+		return "BIOS or system software"
+	case IPAddressSourceOther:
+		return "Other"
+	default:
+		return "Unknown"
+	}
+}
+
+func (s IPAddressSource) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(s), s.Description())
+}
@@ -0,0 +1,39 @@
+package ipmi
+
+import (
+	"fmt"
+)
+
+// IPAddressingMode controls which IP versions the BMC uses on a LAN channel.
+// It is the data of the IPv6/IPv4 addressing enables LAN configuration
+// parameter, specified in table 23-4 of IPMI v2.0.
+type IPAddressingMode uint8
+
+const (
+	// IPAddressingModeIPv4 disables IPv6. This is the behaviour of BMCs
+	// without IPv6 support.
+	IPAddressingModeIPv4 IPAddressingMode = iota
+
+	// IPAddressingModeIPv6 disables IPv4.
+	IPAddressingModeIPv6
+
+	// IPAddressingModeDual enables both IPv4 and IPv6.
+	IPAddressingModeDual
+)
+
+func (m IPAddressingMode) Description() string {
+	switch m {
+	case IPAddressingModeIPv4:
+		return "IPv4 only"
+	case IPAddressingModeIPv6:
+		return "IPv6 only"
+	case IPAddressingModeDual:
+		return "IPv4 and IPv6"
+	default:
+		return "Unknown"
+	}
+}
+
+func (m IPAddressingMode) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(m), m.Description())
+}
@@ -0,0 +1,150 @@
+package ipmi
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/google/gopacket"
+)
+
+// IPv6AddressSource indicates how the BMC obtained an IPv6 address. This is a
+// 4-bit uint on the wire.
+type IPv6AddressSource uint8
+
+const (
+	// IPv6AddressSourceStatic means the address was configured manually. All
+	// addresses in the IPv6 static addresses parameter have this source.
+	IPv6AddressSourceStatic IPv6AddressSource = iota
+
+	// IPv6AddressSourceSLAAC means the address was obtained via stateless
+	// address autoconfiguration.
+	IPv6AddressSourceSLAAC
+
+	// IPv6AddressSourceDHCPv6 means the address was leased via DHCPv6.
+	IPv6AddressSourceDHCPv6
+)
+
+func (s IPv6AddressSource) Description() string {
+	switch s {
+	case IPv6AddressSourceStatic:
+		return "Static"
+	case IPv6AddressSourceSLAAC:
+		return "SLAAC"
+	case IPv6AddressSourceDHCPv6:
+		return "DHCPv6"
+	default:
+		return "Unknown"
+	}
+}
+
+func (s IPv6AddressSource) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(s), s.Description())
+}
+
+// IPv6AddressStatus is the state of an IPv6 address. It is read-only.
+type IPv6AddressStatus uint8
+
+const (
+	IPv6AddressStatusActive IPv6AddressStatus = iota
+	IPv6AddressStatusDisabled
+	IPv6AddressStatusPending
+	IPv6AddressStatusFailed
+	IPv6AddressStatusDeprecated
+	IPv6AddressStatusInvalid
+)
+
+func (s IPv6AddressStatus) Description() string {
+	switch s {
+	case IPv6AddressStatusActive:
+		return "Active"
+	case IPv6AddressStatusDisabled:
+		return "Disabled"
+	case IPv6AddressStatusPending:
+		return "Pending"
+	case IPv6AddressStatusFailed:
+		return "Failed"
+	case IPv6AddressStatusDeprecated:
+		return "Deprecated"
+	case IPv6AddressStatusInvalid:
+		return "Invalid"
+	default:
+		return "Unknown"
+	}
+}
+
+func (s IPv6AddressStatus) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(s), s.Description())
+}
+
+// IPv6Address is the data of the IPv6 static addresses and IPv6 dynamic
+// address LAN configuration parameters, parameters 56 and 59 in table 23-4 of
+// IPMI v2.0. The BMC has a fixed number of slots for each kind of address,
+// given by the IPv6 status parameter.
+type IPv6Address struct {
+
+	// Selector is the slot containing the address. This is the set selector
+	// used to retrieve it.
+	Selector uint8
+
+	// Enabled indicates the address is in use. This is only meaningful for
+	// static addresses; dynamic addresses are in use if Address is non-zero.
+	Enabled bool
+
+	// Source indicates how the address was obtained.
+	Source IPv6AddressSource
+
+	// Address is the IPv6 address. This is 16 bytes on the wire.
+	Address net.IP
+
+	// PrefixLength is the length of the network prefix in bits, e.g. 64.
+	PrefixLength uint8
+
+	// Status is the state of the address. It is ignored when setting a static
+	// address.
+	Status IPv6AddressStatus
+}
+
+func (a *IPv6Address) String() string {
+	return fmt.Sprintf("%v/%v", a.Address, a.PrefixLength)
+}
+
+// Serialise encodes the address onto the end of a buffer, returning an error
+// if one occurs. An address that is not a valid IPv6 address is encoded as
+// the unspecified address.
+func (a *IPv6Address) Serialise(b gopacket.SerializeBuffer) error {
+	d, err := b.AppendBytes(20)
+	if err != nil {
+		return err
+	}
+	d[0] = a.Selector
+	d[1] = uint8(a.Source) & 0xf
+	if a.Enabled {
+		d[1] |= 1 << 7
+	}
+	ip := a.Address.To16()
+	if ip == nil {
+		ip = net.IPv6unspecified
+	}
+	copy(d[2:18], ip)
+	d[18] = a.PrefixLength
+	d[19] = uint8(a.Status)
+	return nil
+}
+
+// Deserialise reads an address from the supplied byte slice, returning
+// unconsumed remaining bytes.
+func (a *IPv6Address) Deserialise(d []byte, df gopacket.DecodeFeedback) ([]byte, error) {
+	if len(d) < 20 {
+		df.SetTruncated()
+		return nil, fmt.Errorf("IPv6 addresses are 20 bytes, only %v "+
+			"remaining", len(d))
+	}
+	a.Selector = d[0]
+	a.Enabled = d[1]&(1<<7) != 0
+	a.Source = IPv6AddressSource(d[1] & 0xf)
+	a.Address = make(net.IP, net.IPv6len)
+	copy(a.Address, d[2:18])
+	a.PrefixLength = d[18]
+	a.Status = IPv6AddressStatus(d[19])
+	return d[20:], nil
+}
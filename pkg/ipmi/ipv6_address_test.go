@@ -0,0 +1,76 @@
+package ipmi
+
+import (
+	"bytes"
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+)
+
+func TestIPv6Address(t *testing.T) {
+	tests := []struct {
+		name    string
+		address *IPv6Address
+		wire    []byte
+	}{
+		{
+			"static",
+			&IPv6Address{
+				Selector:     1,
+				Enabled:      true,
+				Source:       IPv6AddressSourceStatic,
+				Address:      net.ParseIP("2001:db8::1"),
+				PrefixLength: 64,
+				Status:       IPv6AddressStatusActive,
+			},
+			[]byte{
+				0x01, 0x80,
+				0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+				0x40, 0x00,
+			},
+		},
+		{
+			"dhcpv6",
+			&IPv6Address{
+				Source:       IPv6AddressSourceDHCPv6,
+				Address:      net.ParseIP("fe80::2"),
+				PrefixLength: 128,
+				Status:       IPv6AddressStatusDeprecated,
+			},
+			[]byte{
+				0x00, 0x02,
+				0xfe, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02,
+				0x80, 0x04,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := gopacket.NewSerializeBuffer()
+			if err := test.address.Serialise(b); err != nil {
+				t.Fatalf("serialise %v = error %v", test.address, err)
+			}
+			if got := b.Bytes(); !bytes.Equal(got, test.wire) {
+				t.Errorf("serialise %v = %v, want %v", test.address, got,
+					test.wire)
+			}
+
+			address := &IPv6Address{}
+			remaining, err := address.Deserialise(test.wire, gopacket.NilDecodeFeedback)
+			if err != nil {
+				t.Fatalf("deserialise %v = error %v", test.wire, err)
+			}
+			if len(remaining) != 0 {
+				t.Errorf("deserialise %v left %v bytes", test.wire, len(remaining))
+			}
+			if diff := cmp.Diff(test.address, address); diff != "" {
+				t.Errorf("deserialise %v = %v, want %v: %v", test.wire, address,
+					test.address, diff)
+			}
+		})
+	}
+}
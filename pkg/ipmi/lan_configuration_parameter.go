@@ -0,0 +1,271 @@
+package ipmi
+
+import (
+	"fmt"
+)
+
+// LANConfigurationParameter identifies a LAN configuration parameter, used in
+// the Get and Set LAN Configuration Parameters commands. Parameters are
+// specified in table 23-4 of IPMI v2.0. The format of the data of common
+// parameters is specified alongside them; addresses are in network byte
+// order.
+type LANConfigurationParameter uint8
+
+const (
+	// LANConfigurationParameterSetInProgress is used to indicate that
+	// parameters are being updated. Its data is 1 byte, the lower 2 bits of
+	// which are a SetInProgress value.
+	LANConfigurationParameterSetInProgress LANConfigurationParameter = iota
+
+	// LANConfigurationParameterAuthenticationTypeSupport is a read-only
+	// bitfield of the IPMI v1.5 authentication types the BMC supports.
+	LANConfigurationParameterAuthenticationTypeSupport
+
+	// LANConfigurationParameterAuthenticationTypeEnables contains the
+	// authentication types enabled for each privilege level. Its data is 5
+	// bytes, one per privilege level from Callback to OEM.
+	LANConfigurationParameterAuthenticationTypeEnables
+
+	// LANConfigurationParameterIPAddress is the BMC's IPv4 address. Its data
+	// is 4 bytes.
+	LANConfigurationParameterIPAddress
+
+	// LANConfigurationParameterIPAddressSource is how the BMC obtains its
+	// IPv4 address. Its data is 1 byte, the lower 4 bits of which are an
+	// IPAddressSource value.
+	LANConfigurationParameterIPAddressSource
+
+	// LANConfigurationParameterMACAddress is the BMC's MAC address. Its data
+	// is 6 bytes.
+	LANConfigurationParameterMACAddress
+
+	// LANConfigurationParameterSubnetMask is the BMC's IPv4 subnet mask. Its
+	// data is 4 bytes.
+	LANConfigurationParameterSubnetMask
+
+	// LANConfigurationParameterIPv4HeaderParameters contains the TTL, flags
+	// and type of service of IPv4 packets sent by the BMC. Its data is 3
+	// bytes.
+	LANConfigurationParameterIPv4HeaderParameters
+
+	// LANConfigurationParameterPrimaryRMCPPort is the UDP port the BMC
+	// listens on for RMCP. Its data is 2 bytes, least significant first.
+	LANConfigurationParameterPrimaryRMCPPort
+
+	// LANConfigurationParameterSecondaryRMCPPort is the UDP port the BMC
+	// listens on for secure RMCP. Its format is identical to the primary
+	// port.
+	LANConfigurationParameterSecondaryRMCPPort
+
+	// LANConfigurationParameterARPControl controls whether the BMC responds
+	// to ARP requests and sends gratuitous ARPs. Its data is 1 byte.
+	LANConfigurationParameterARPControl
+
+	// LANConfigurationParameterGratuitousARPInterval is the interval between
+	// gratuitous ARPs in 500ms increments. Its data is 1 byte.
+	LANConfigurationParameterGratuitousARPInterval
+
+	// LANConfigurationParameterDefaultGatewayAddress is the IPv4 address of
+	// the default gateway. Its data is 4 bytes.
+	LANConfigurationParameterDefaultGatewayAddress
+
+	// LANConfigurationParameterDefaultGatewayMACAddress is the MAC address of
+	// the default gateway. Its data is 6 bytes.
+	LANConfigurationParameterDefaultGatewayMACAddress
+
+	// LANConfigurationParameterBackupGatewayAddress is the IPv4 address of
+	// the backup gateway. Its data is 4 bytes.
+	LANConfigurationParameterBackupGatewayAddress
+
+	// LANConfigurationParameterBackupGatewayMACAddress is the MAC address of
+	// the backup gateway. Its data is 6 bytes.
+	LANConfigurationParameterBackupGatewayMACAddress
+
+	// LANConfigurationParameterCommunityString is the SNMP community string
+	// used in PET traps. Its data is 18 bytes, padded with NULs.
+	LANConfigurationParameterCommunityString
+
+	// LANConfigurationParameterDestinationCount is the number of alert
+	// destinations the BMC supports. It is read-only, and its data is 1 byte.
+	LANConfigurationParameterDestinationCount
+
+	// LANConfigurationParameterDestinationType is the type of an alert
+	// destination, selected by the set selector. Its data is 4 bytes; see
+	// AlertDestination.
+	LANConfigurationParameterDestinationType
+
+	// LANConfigurationParameterDestinationAddresses is the address of an
+	// alert destination, selected by the set selector. Its data is 13 bytes
+	// for IPv4 destinations and 18 bytes for IPv6; see
+	// AlertDestinationAddress.
+	LANConfigurationParameterDestinationAddresses
+
+	// LANConfigurationParameterVLANID is the 802.1q VLAN the BMC tags its
+	// traffic with. Its data is 2 bytes; see VLAN.
+	LANConfigurationParameterVLANID
+
+	// LANConfigurationParameterVLANPriority is the 802.1q priority of the
+	// BMC's traffic. Its data is 1 byte, the lower 3 bits of which are the
+	// priority.
+	LANConfigurationParameterVLANPriority
+
+	// LANConfigurationParameterCipherSuiteEntrySupport is the number of cipher
+	// suites the BMC supports over RMCP+. It is read-only, and its data is 1
+	// byte, the lower 5 bits of which are the count.
+	LANConfigurationParameterCipherSuiteEntrySupport
+
+	// LANConfigurationParameterCipherSuiteEntries contains the IDs of the
+	// cipher suites the BMC supports over RMCP+. It is read-only, and its
+	// data is 1 reserved byte followed by up to 16 CipherSuiteID values.
+	LANConfigurationParameterCipherSuiteEntries
+
+	// LANConfigurationParameterCipherSuitePrivilegeLevels contains the
+	// maximum privilege level that can be requested with each cipher suite.
+	// Its data is 9 bytes; see CipherSuitePrivilegeLevels.
+	LANConfigurationParameterCipherSuitePrivilegeLevels
+
+	// LANConfigurationParameterDestinationAddressVLANTags is the VLAN tag of
+	// an alert destination, selected by the set selector.
+	LANConfigurationParameterDestinationAddressVLANTags
+
+	// LANConfigurationParameterBadPasswordThreshold controls how many failed
+	// logins cause a user to be locked out, and for how long. Its data is 6
+	// bytes.
+	LANConfigurationParameterBadPasswordThreshold
+)
+
+const (
+	// LANConfigurationParameterIPv6IPv4Support indicates which IP versions
+	// the BMC supports. It is read-only, and its data is 1 byte: bit 0 is set
+	// if the BMC can be IPv6-only, bit 1 if it can use both IPv4 and IPv6, and
+	// bit 2 if it can send alerts over IPv6.
+	LANConfigurationParameterIPv6IPv4Support LANConfigurationParameter = 50 + iota
+
+	// LANConfigurationParameterIPv6IPv4AddressingEnables controls which IP
+	// versions the BMC uses. Its data is 1 byte, an IPAddressingMode value.
+	LANConfigurationParameterIPv6IPv4AddressingEnables
+
+	// LANConfigurationParameterIPv6HeaderTrafficClass is the traffic class of
+	// IPv6 packets sent by the BMC. Its data is 1 byte.
+	LANConfigurationParameterIPv6HeaderTrafficClass
+
+	// LANConfigurationParameterIPv6HeaderHopLimit is the hop limit of IPv6
+	// packets sent by the BMC. Its data is 1 byte.
+	LANConfigurationParameterIPv6HeaderHopLimit
+
+	// LANConfigurationParameterIPv6HeaderFlowLabel is the flow label of IPv6
+	// packets sent by the BMC. Its data is 3 bytes, most significant first.
+	LANConfigurationParameterIPv6HeaderFlowLabel
+
+	// LANConfigurationParameterIPv6Status describes the BMC's IPv6 address
+	// capacity. It is read-only, and its data is 3 bytes: the maximum number
+	// of static addresses, the maximum number of dynamic addresses, and a
+	// bitfield where bit 0 indicates SLAAC support and bit 1 DHCPv6 support.
+	LANConfigurationParameterIPv6Status
+
+	// LANConfigurationParameterIPv6StaticAddresses contains a static IPv6
+	// address, selected by the set selector. Its data is 20 bytes; see
+	// IPv6Address.
+	LANConfigurationParameterIPv6StaticAddresses
+
+	// LANConfigurationParameterIPv6DHCPv6StaticDUIDStorageLength is the
+	// number of 16-byte blocks available to store each static DUID. It is
+	// read-only, and its data is 1 byte.
+	LANConfigurationParameterIPv6DHCPv6StaticDUIDStorageLength
+
+	// LANConfigurationParameterIPv6DHCPv6StaticDUIDs contains a block of a
+	// static DUID, selected by the set and block selectors.
+	LANConfigurationParameterIPv6DHCPv6StaticDUIDs
+
+	// LANConfigurationParameterIPv6DynamicAddress contains an IPv6 address
+	// obtained via SLAAC or DHCPv6, selected by the set selector. It is
+	// read-only, and its format is identical to static addresses.
+	LANConfigurationParameterIPv6DynamicAddress
+)
+
+func (p LANConfigurationParameter) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(p), p.name())
+}
+
+func (p LANConfigurationParameter) name() string {
+	switch p {
+	case LANConfigurationParameterSetInProgress:
+		return "Set In Progress"
+	case LANConfigurationParameterAuthenticationTypeSupport:
+		return "Authentication Type Support"
+	case LANConfigurationParameterAuthenticationTypeEnables:
+		return "Authentication Type Enables"
+	case LANConfigurationParameterIPAddress:
+		return "IP Address"
+	case LANConfigurationParameterIPAddressSource:
+		return "IP Address Source"
+	case LANConfigurationParameterMACAddress:
+		return "MAC Address"
+	case LANConfigurationParameterSubnetMask:
+		return "Subnet Mask"
+	case LANConfigurationParameterIPv4HeaderParameters:
+		return "IPv4 Header Parameters"
+	case LANConfigurationParameterPrimaryRMCPPort:
+		return "Primary RMCP Port Number"
+	case LANConfigurationParameterSecondaryRMCPPort:
+		return "Secondary RMCP Port Number"
+	case LANConfigurationParameterARPControl:
+		return "BMC-generated ARP control"
+	case LANConfigurationParameterGratuitousARPInterval:
+		return "Gratuitous ARP interval"
+	case LANConfigurationParameterDefaultGatewayAddress:
+		return "Default Gateway Address"
+	case LANConfigurationParameterDefaultGatewayMACAddress:
+		return "Default Gateway MAC Address"
+	case LANConfigurationParameterBackupGatewayAddress:
+		return "Backup Gateway Address"
+	case LANConfigurationParameterBackupGatewayMACAddress:
+		return "Backup Gateway MAC Address"
+	case LANConfigurationParameterCommunityString:
+		return "Community String"
+	case LANConfigurationParameterDestinationCount:
+		return "Number of Destinations"
+	case LANConfigurationParameterDestinationType:
+		return "Destination Type"
+	case LANConfigurationParameterDestinationAddresses:
+		return "Destination Addresses"
+	case LANConfigurationParameterVLANID:
+		return "802.1q VLAN ID"
+	case LANConfigurationParameterVLANPriority:
+		return "802.1q VLAN Priority"
+	case LANConfigurationParameterCipherSuiteEntrySupport:
+		return "RMCP+ Messaging Cipher Suite Entry Support"
+	case LANConfigurationParameterCipherSuiteEntries:
+		return "RMCP+ Messaging Cipher Suite Entries"
+	case LANConfigurationParameterCipherSuitePrivilegeLevels:
+		return "RMCP+ Messaging Cipher Suite Privilege Levels"
+	case LANConfigurationParameterDestinationAddressVLANTags:
+		return "Destination Address VLAN TAGs"
+	case LANConfigurationParameterBadPasswordThreshold:
+		return "Bad Password Threshold"
+	case LANConfigurationParameterIPv6IPv4Support:
+		return "IPv6/IPv4 Support"
+	case LANConfigurationParameterIPv6IPv4AddressingEnables:
+		return "IPv6/IPv4 Addressing Enables"
+	case LANConfigurationParameterIPv6HeaderTrafficClass:
+		return "IPv6 Header Static Traffic Class"
+	case LANConfigurationParameterIPv6HeaderHopLimit:
+		return "IPv6 Header Static Hop Limit"
+	case LANConfigurationParameterIPv6HeaderFlowLabel:
+		return "IPv6 Header Flow Label"
+	case LANConfigurationParameterIPv6Status:
+		return "IPv6 Status"
+	case LANConfigurationParameterIPv6StaticAddresses:
+		return "IPv6 Static Addresses"
+	case LANConfigurationParameterIPv6DHCPv6StaticDUIDStorageLength:
+		return "IPv6 DHCPv6 Static DUID Storage Length"
+	case LANConfigurationParameterIPv6DHCPv6StaticDUIDs:
+		return "IPv6 DHCPv6 Static DUIDs"
+	case LANConfigurationParameterIPv6DynamicAddress:
+		return "IPv6 Dynamic Address"
+	}
+	if p >= 0xc0 {
+		return "OEM"
+	}
+	return "Unknown"
+}
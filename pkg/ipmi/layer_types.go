@@ -107,6 +107,13 @@ var (
 			// decoder not specified here as default struct not usable
 		},
 	)
+	layerTypeXRC4 = gopacket.RegisterLayerType(
+		1036,
+		gopacket.LayerTypeMetadata{
+			Name: "xRC4 Encrypted IPMI Message",
+			// decoder not specified here as default struct not usable
+		},
+	)
 	LayerTypeMessage = gopacket.RegisterLayerType(
 		1012,
 		gopacket.LayerTypeMetadata{
@@ -266,4 +273,601 @@ var (
 			}),
 		},
 	)
+	LayerTypeGetSessionChallengeReq = gopacket.RegisterLayerType(
+		1032,
+		gopacket.LayerTypeMetadata{
+			Name: "Get Session Challenge Request",
+		},
+	)
+	LayerTypeGetSessionChallengeRsp = gopacket.RegisterLayerType(
+		1033,
+		gopacket.LayerTypeMetadata{
+			Name: "Get Session Challenge Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetSessionChallengeRsp{}
+			}),
+		},
+	)
+	LayerTypeActivateSessionReq = gopacket.RegisterLayerType(
+		1034,
+		gopacket.LayerTypeMetadata{
+			Name: "Activate Session Request",
+		},
+	)
+	LayerTypeActivateSessionRsp = gopacket.RegisterLayerType(
+		1035,
+		gopacket.LayerTypeMetadata{
+			Name: "Activate Session Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &ActivateSessionRsp{}
+			}),
+		},
+	)
+	LayerTypeSOL = gopacket.RegisterLayerType(
+		1037,
+		gopacket.LayerTypeMetadata{
+			Name: "SOL",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &SOL{}
+			}),
+		},
+	)
+	LayerTypeActivatePayloadReq = gopacket.RegisterLayerType(
+		1038,
+		gopacket.LayerTypeMetadata{
+			Name: "Activate Payload Request",
+		},
+	)
+	LayerTypeActivatePayloadRsp = gopacket.RegisterLayerType(
+		1039,
+		gopacket.LayerTypeMetadata{
+			Name: "Activate Payload Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &ActivatePayloadRsp{}
+			}),
+		},
+	)
+	LayerTypeDeactivatePayloadReq = gopacket.RegisterLayerType(
+		1040,
+		gopacket.LayerTypeMetadata{
+			Name: "Deactivate Payload Request",
+		},
+	)
+	LayerTypeGetSOLConfigurationParametersReq = gopacket.RegisterLayerType(
+		1041,
+		gopacket.LayerTypeMetadata{
+			Name: "Get SOL Configuration Parameters Request",
+		},
+	)
+	LayerTypeGetSOLConfigurationParametersRsp = gopacket.RegisterLayerType(
+		1042,
+		gopacket.LayerTypeMetadata{
+			Name: "Get SOL Configuration Parameters Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetSOLConfigurationParametersRsp{}
+			}),
+		},
+	)
+	LayerTypeSetSOLConfigurationParametersReq = gopacket.RegisterLayerType(
+		1043,
+		gopacket.LayerTypeMetadata{
+			Name: "Set SOL Configuration Parameters Request",
+		},
+	)
+	LayerTypeGetSystemBootOptionsReq = gopacket.RegisterLayerType(
+		1044,
+		gopacket.LayerTypeMetadata{
+			Name: "Get System Boot Options Request",
+		},
+	)
+	LayerTypeGetSystemBootOptionsRsp = gopacket.RegisterLayerType(
+		1045,
+		gopacket.LayerTypeMetadata{
+			Name: "Get System Boot Options Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetSystemBootOptionsRsp{}
+			}),
+		},
+	)
+	LayerTypeSetSystemBootOptionsReq = gopacket.RegisterLayerType(
+		1046,
+		gopacket.LayerTypeMetadata{
+			Name: "Set System Boot Options Request",
+		},
+	)
+	LayerTypeChassisIdentifyReq = gopacket.RegisterLayerType(
+		1047,
+		gopacket.LayerTypeMetadata{
+			Name: "Chassis Identify Request",
+		},
+	)
+	LayerTypeGetSELInfoRsp = gopacket.RegisterLayerType(
+		1048,
+		gopacket.LayerTypeMetadata{
+			Name: "Get SEL Info Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetSELInfoRsp{}
+			}),
+		},
+	)
+	LayerTypeGetSELAllocationInfoRsp = gopacket.RegisterLayerType(
+		1049,
+		gopacket.LayerTypeMetadata{
+			Name: "Get SEL Allocation Info Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetSELAllocationInfoRsp{}
+			}),
+		},
+	)
+	LayerTypeReserveSELRsp = gopacket.RegisterLayerType(
+		1050,
+		gopacket.LayerTypeMetadata{
+			Name: "Reserve SEL Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &ReserveSELRsp{}
+			}),
+		},
+	)
+	LayerTypeGetSELEntryReq = gopacket.RegisterLayerType(
+		1051,
+		gopacket.LayerTypeMetadata{
+			Name: "Get SEL Entry Request",
+		},
+	)
+	LayerTypeGetSELEntryRsp = gopacket.RegisterLayerType(
+		1052,
+		gopacket.LayerTypeMetadata{
+			Name: "Get SEL Entry Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetSELEntryRsp{}
+			}),
+		},
+	)
+	LayerTypeSELRecord = gopacket.RegisterLayerType(
+		1053,
+		gopacket.LayerTypeMetadata{
+			Name: "SEL Record Header",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &SELRecord{}
+			}),
+		},
+	)
+	LayerTypeSystemEventRecord = gopacket.RegisterLayerType(
+		1054,
+		gopacket.LayerTypeMetadata{
+			Name: "System Event Record",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &SystemEventRecord{}
+			}),
+		},
+	)
+	LayerTypeDeleteSELEntryReq = gopacket.RegisterLayerType(
+		1055,
+		gopacket.LayerTypeMetadata{
+			Name: "Delete SEL Entry Request",
+		},
+	)
+	LayerTypeDeleteSELEntryRsp = gopacket.RegisterLayerType(
+		1056,
+		gopacket.LayerTypeMetadata{
+			Name: "Delete SEL Entry Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &DeleteSELEntryRsp{}
+			}),
+		},
+	)
+	LayerTypeClearSELReq = gopacket.RegisterLayerType(
+		1057,
+		gopacket.LayerTypeMetadata{
+			Name: "Clear SEL Request",
+		},
+	)
+	LayerTypeClearSELRsp = gopacket.RegisterLayerType(
+		1058,
+		gopacket.LayerTypeMetadata{
+			Name: "Clear SEL Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &ClearSELRsp{}
+			}),
+		},
+	)
+	LayerTypeGetFRUInventoryAreaInfoReq = gopacket.RegisterLayerType(
+		1059,
+		gopacket.LayerTypeMetadata{
+			Name: "Get FRU Inventory Area Info Request",
+		},
+	)
+	LayerTypeGetFRUInventoryAreaInfoRsp = gopacket.RegisterLayerType(
+		1060,
+		gopacket.LayerTypeMetadata{
+			Name: "Get FRU Inventory Area Info Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetFRUInventoryAreaInfoRsp{}
+			}),
+		},
+	)
+	LayerTypeReadFRUDataReq = gopacket.RegisterLayerType(
+		1061,
+		gopacket.LayerTypeMetadata{
+			Name: "Read FRU Data Request",
+		},
+	)
+	LayerTypeReadFRUDataRsp = gopacket.RegisterLayerType(
+		1062,
+		gopacket.LayerTypeMetadata{
+			Name: "Read FRU Data Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &ReadFRUDataRsp{}
+			}),
+		},
+	)
+	LayerTypeFRUCommonHeader = gopacket.RegisterLayerType(
+		1063,
+		gopacket.LayerTypeMetadata{
+			Name: "FRU Common Header",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &FRUCommonHeader{}
+			}),
+		},
+	)
+	LayerTypeFRUChassisInfoArea = gopacket.RegisterLayerType(
+		1064,
+		gopacket.LayerTypeMetadata{
+			Name: "FRU Chassis Info Area",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &FRUChassisInfoArea{}
+			}),
+		},
+	)
+	LayerTypeFRUBoardInfoArea = gopacket.RegisterLayerType(
+		1065,
+		gopacket.LayerTypeMetadata{
+			Name: "FRU Board Info Area",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &FRUBoardInfoArea{}
+			}),
+		},
+	)
+	LayerTypeFRUProductInfoArea = gopacket.RegisterLayerType(
+		1066,
+		gopacket.LayerTypeMetadata{
+			Name: "FRU Product Info Area",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &FRUProductInfoArea{}
+			}),
+		},
+	)
+	LayerTypeFRUMultiRecord = gopacket.RegisterLayerType(
+		1067,
+		gopacket.LayerTypeMetadata{
+			Name: "FRU MultiRecord",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &FRUMultiRecord{}
+			}),
+		},
+	)
+	LayerTypeCompactSensorRecord = gopacket.RegisterLayerType(
+		1068,
+		gopacket.LayerTypeMetadata{
+			Name: "Compact Sensor Record",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &CompactSensorRecord{}
+			}),
+		},
+	)
+	LayerTypeEventOnlyRecord = gopacket.RegisterLayerType(
+		1069,
+		gopacket.LayerTypeMetadata{
+			Name: "Event-Only Record",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &EventOnlyRecord{}
+			}),
+		},
+	)
+	LayerTypeSetSensorHysteresisReq = gopacket.RegisterLayerType(
+		1070,
+		gopacket.LayerTypeMetadata{
+			Name: "Set Sensor Hysteresis Request",
+		},
+	)
+	LayerTypeGetSensorHysteresisReq = gopacket.RegisterLayerType(
+		1071,
+		gopacket.LayerTypeMetadata{
+			Name: "Get Sensor Hysteresis Request",
+		},
+	)
+	LayerTypeGetSensorHysteresisRsp = gopacket.RegisterLayerType(
+		1072,
+		gopacket.LayerTypeMetadata{
+			Name: "Get Sensor Hysteresis Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetSensorHysteresisRsp{}
+			}),
+		},
+	)
+	LayerTypeSetSensorThresholdsReq = gopacket.RegisterLayerType(
+		1073,
+		gopacket.LayerTypeMetadata{
+			Name: "Set Sensor Thresholds Request",
+		},
+	)
+	LayerTypeGetSensorThresholdsReq = gopacket.RegisterLayerType(
+		1074,
+		gopacket.LayerTypeMetadata{
+			Name: "Get Sensor Thresholds Request",
+		},
+	)
+	LayerTypeGetSensorThresholdsRsp = gopacket.RegisterLayerType(
+		1075,
+		gopacket.LayerTypeMetadata{
+			Name: "Get Sensor Thresholds Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetSensorThresholdsRsp{}
+			}),
+		},
+	)
+	LayerTypeGetSensorReadingFactorsReq = gopacket.RegisterLayerType(
+		1076,
+		gopacket.LayerTypeMetadata{
+			Name: "Get Sensor Reading Factors Request",
+		},
+	)
+	LayerTypeGetSensorReadingFactorsRsp = gopacket.RegisterLayerType(
+		1077,
+		gopacket.LayerTypeMetadata{
+			Name: "Get Sensor Reading Factors Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetSensorReadingFactorsRsp{}
+			}),
+		},
+	)
+	LayerTypeSetUserAccessReq = gopacket.RegisterLayerType(
+		1078,
+		gopacket.LayerTypeMetadata{
+			Name: "Set User Access Request",
+		},
+	)
+	LayerTypeGetUserAccessReq = gopacket.RegisterLayerType(
+		1079,
+		gopacket.LayerTypeMetadata{
+			Name: "Get User Access Request",
+		},
+	)
+	LayerTypeGetUserAccessRsp = gopacket.RegisterLayerType(
+		1080,
+		gopacket.LayerTypeMetadata{
+			Name: "Get User Access Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetUserAccessRsp{}
+			}),
+		},
+	)
+	LayerTypeSetUserNameReq = gopacket.RegisterLayerType(
+		1081,
+		gopacket.LayerTypeMetadata{
+			Name: "Set User Name Request",
+		},
+	)
+	LayerTypeGetUserNameReq = gopacket.RegisterLayerType(
+		1082,
+		gopacket.LayerTypeMetadata{
+			Name: "Get User Name Request",
+		},
+	)
+	LayerTypeGetUserNameRsp = gopacket.RegisterLayerType(
+		1083,
+		gopacket.LayerTypeMetadata{
+			Name: "Get User Name Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetUserNameRsp{}
+			}),
+		},
+	)
+	LayerTypeSetUserPasswordReq = gopacket.RegisterLayerType(
+		1084,
+		gopacket.LayerTypeMetadata{
+			Name: "Set User Password Request",
+		},
+	)
+	LayerTypeSetLANConfigurationParametersReq = gopacket.RegisterLayerType(
+		1085,
+		gopacket.LayerTypeMetadata{
+			Name: "Set LAN Configuration Parameters Request",
+		},
+	)
+	LayerTypeGetLANConfigurationParametersReq = gopacket.RegisterLayerType(
+		1086,
+		gopacket.LayerTypeMetadata{
+			Name: "Get LAN Configuration Parameters Request",
+		},
+	)
+	LayerTypeGetLANConfigurationParametersRsp = gopacket.RegisterLayerType(
+		1087,
+		gopacket.LayerTypeMetadata{
+			Name: "Get LAN Configuration Parameters Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetLANConfigurationParametersRsp{}
+			}),
+		},
+	)
+	LayerTypeSetChannelAccessReq = gopacket.RegisterLayerType(
+		1088,
+		gopacket.LayerTypeMetadata{
+			Name: "Set Channel Access Request",
+		},
+	)
+	LayerTypeGetChannelAccessReq = gopacket.RegisterLayerType(
+		1089,
+		gopacket.LayerTypeMetadata{
+			Name: "Get Channel Access Request",
+		},
+	)
+	LayerTypeGetChannelAccessRsp = gopacket.RegisterLayerType(
+		1090,
+		gopacket.LayerTypeMetadata{
+			Name: "Get Channel Access Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetChannelAccessRsp{}
+			}),
+		},
+	)
+	LayerTypeGetChannelInfoReq = gopacket.RegisterLayerType(
+		1091,
+		gopacket.LayerTypeMetadata{
+			Name: "Get Channel Info Request",
+		},
+	)
+	LayerTypeGetChannelInfoRsp = gopacket.RegisterLayerType(
+		1092,
+		gopacket.LayerTypeMetadata{
+			Name: "Get Channel Info Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetChannelInfoRsp{}
+			}),
+		},
+	)
+	LayerTypeSetWatchdogTimerReq = gopacket.RegisterLayerType(
+		1093,
+		gopacket.LayerTypeMetadata{
+			Name: "Set Watchdog Timer Request",
+		},
+	)
+	LayerTypeGetWatchdogTimerRsp = gopacket.RegisterLayerType(
+		1094,
+		gopacket.LayerTypeMetadata{
+			Name: "Get Watchdog Timer Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetWatchdogTimerRsp{}
+			}),
+		},
+	)
+	LayerTypeGetSelfTestResultsRsp = gopacket.RegisterLayerType(
+		1095,
+		gopacket.LayerTypeMetadata{
+			Name: "Get Self Test Results Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetSelfTestResultsRsp{}
+			}),
+		},
+	)
+	LayerTypeSetACPIPowerStateReq = gopacket.RegisterLayerType(
+		1096,
+		gopacket.LayerTypeMetadata{
+			Name: "Set ACPI Power State Request",
+		},
+	)
+	LayerTypeGetACPIPowerStateRsp = gopacket.RegisterLayerType(
+		1097,
+		gopacket.LayerTypeMetadata{
+			Name: "Get ACPI Power State Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetACPIPowerStateRsp{}
+			}),
+		},
+	)
+	LayerTypeGetSDRRepositoryTimeRsp = gopacket.RegisterLayerType(
+		1098,
+		gopacket.LayerTypeMetadata{
+			Name: "Get SDR Repository Time Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetSDRRepositoryTimeRsp{}
+			}),
+		},
+	)
+	LayerTypeGetSELTimeRsp = gopacket.RegisterLayerType(
+		1099,
+		gopacket.LayerTypeMetadata{
+			Name: "Get SEL Time Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetSELTimeRsp{}
+			}),
+		},
+	)
+	LayerTypeSetSELTimeReq = gopacket.RegisterLayerType(
+		1100,
+		gopacket.LayerTypeMetadata{
+			Name: "Set SEL Time Request",
+		},
+	)
+	LayerTypeGetSELTimeUTCOffsetRsp = gopacket.RegisterLayerType(
+		1101,
+		gopacket.LayerTypeMetadata{
+			Name: "Get SEL Time UTC Offset Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetSELTimeUTCOffsetRsp{}
+			}),
+		},
+	)
+	LayerTypeSetSELTimeUTCOffsetReq = gopacket.RegisterLayerType(
+		1102,
+		gopacket.LayerTypeMetadata{
+			Name: "Set SEL Time UTC Offset Request",
+		},
+	)
+	LayerTypeGetPEFCapabilitiesRsp = gopacket.RegisterLayerType(
+		1103,
+		gopacket.LayerTypeMetadata{
+			Name: "Get PEF Capabilities Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetPEFCapabilitiesRsp{}
+			}),
+		},
+	)
+	LayerTypeArmPEFPostponeTimerReq = gopacket.RegisterLayerType(
+		1104,
+		gopacket.LayerTypeMetadata{
+			Name: "Arm PEF Postpone Timer Request",
+		},
+	)
+	LayerTypeArmPEFPostponeTimerRsp = gopacket.RegisterLayerType(
+		1105,
+		gopacket.LayerTypeMetadata{
+			Name: "Arm PEF Postpone Timer Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &ArmPEFPostponeTimerRsp{}
+			}),
+		},
+	)
+	LayerTypeSetPEFConfigurationParametersReq = gopacket.RegisterLayerType(
+		1106,
+		gopacket.LayerTypeMetadata{
+			Name: "Set PEF Configuration Parameters Request",
+		},
+	)
+	LayerTypeGetPEFConfigurationParametersReq = gopacket.RegisterLayerType(
+		1107,
+		gopacket.LayerTypeMetadata{
+			Name: "Get PEF Configuration Parameters Request",
+		},
+	)
+	LayerTypeGetPEFConfigurationParametersRsp = gopacket.RegisterLayerType(
+		1108,
+		gopacket.LayerTypeMetadata{
+			Name: "Get PEF Configuration Parameters Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetPEFConfigurationParametersRsp{}
+			}),
+		},
+	)
+	LayerTypeSetSystemInfoParametersReq = gopacket.RegisterLayerType(
+		1109,
+		gopacket.LayerTypeMetadata{
+			Name: "Set System Info Parameters Request",
+		},
+	)
+	LayerTypeGetSystemInfoParametersReq = gopacket.RegisterLayerType(
+		1110,
+		gopacket.LayerTypeMetadata{
+			Name: "Get System Info Parameters Request",
+		},
+	)
+	LayerTypeGetSystemInfoParametersRsp = gopacket.RegisterLayerType(
+		1111,
+		gopacket.LayerTypeMetadata{
+			Name: "Get System Info Parameters Response",
+			Decoder: layerexts.BuildDecoder(func() layerexts.LayerDecodingLayer {
+				return &GetSystemInfoParametersRsp{}
+			}),
+		},
+	)
 )
@@ -42,6 +42,22 @@ var (
 		Function: NetworkFunctionChassisReq,
 		Command:  0x02,
 	}
+	OperationChassisIdentifyReq = Operation{
+		Function: NetworkFunctionChassisReq,
+		Command:  0x04,
+	}
+	OperationSetSystemBootOptionsReq = Operation{
+		Function: NetworkFunctionChassisReq,
+		Command:  0x08,
+	}
+	OperationGetSystemBootOptionsReq = Operation{
+		Function: NetworkFunctionChassisReq,
+		Command:  0x09,
+	}
+	OperationGetSystemBootOptionsRsp = Operation{
+		Function: NetworkFunctionChassisRsp,
+		Command:  0x09,
+	}
 	OperationGetDeviceIDReq = Operation{
 		Function: NetworkFunctionAppReq,
 		Command:  0x01,
@@ -50,6 +66,34 @@ var (
 		Function: NetworkFunctionAppRsp,
 		Command:  0x01,
 	}
+	OperationColdResetReq = Operation{
+		Function: NetworkFunctionAppReq,
+		Command:  0x02,
+	}
+	OperationWarmResetReq = Operation{
+		Function: NetworkFunctionAppReq,
+		Command:  0x03,
+	}
+	OperationGetSelfTestResultsReq = Operation{
+		Function: NetworkFunctionAppReq,
+		Command:  0x04,
+	}
+	OperationGetSelfTestResultsRsp = Operation{
+		Function: NetworkFunctionAppRsp,
+		Command:  0x04,
+	}
+	OperationSetACPIPowerStateReq = Operation{
+		Function: NetworkFunctionAppReq,
+		Command:  0x06,
+	}
+	OperationGetACPIPowerStateReq = Operation{
+		Function: NetworkFunctionAppReq,
+		Command:  0x07,
+	}
+	OperationGetACPIPowerStateRsp = Operation{
+		Function: NetworkFunctionAppRsp,
+		Command:  0x07,
+	}
 	OperationGetSystemGUIDReq = Operation{
 		Function: NetworkFunctionAppReq,
 		Command:  0x37,
@@ -66,6 +110,22 @@ var (
 		Function: NetworkFunctionAppRsp,
 		Command:  0x38,
 	}
+	OperationGetSessionChallengeReq = Operation{
+		Function: NetworkFunctionAppReq,
+		Command:  0x39,
+	}
+	OperationGetSessionChallengeRsp = Operation{
+		Function: NetworkFunctionAppRsp,
+		Command:  0x39,
+	}
+	OperationActivateSessionReq = Operation{
+		Function: NetworkFunctionAppReq,
+		Command:  0x3a,
+	}
+	OperationActivateSessionRsp = Operation{
+		Function: NetworkFunctionAppRsp,
+		Command:  0x3a,
+	}
 	OperationSetSessionPrivilegeLevelReq = Operation{
 		Function: NetworkFunctionAppReq,
 		Command:  0x3b,
@@ -126,6 +186,274 @@ var (
 		Function: NetworkFunctionAppRsp,
 		Command:  0x54,
 	}
+	OperationActivatePayloadReq = Operation{
+		Function: NetworkFunctionAppReq,
+		Command:  0x48,
+	}
+	OperationActivatePayloadRsp = Operation{
+		Function: NetworkFunctionAppRsp,
+		Command:  0x48,
+	}
+	OperationDeactivatePayloadReq = Operation{
+		Function: NetworkFunctionAppReq,
+		Command:  0x49,
+	}
+	OperationSetSOLConfigurationParametersReq = Operation{
+		Function: NetworkFunctionTransportReq,
+		Command:  0x21,
+	}
+	OperationGetSOLConfigurationParametersReq = Operation{
+		Function: NetworkFunctionTransportReq,
+		Command:  0x22,
+	}
+	OperationGetSOLConfigurationParametersRsp = Operation{
+		Function: NetworkFunctionTransportRsp,
+		Command:  0x22,
+	}
+	OperationGetSELInfoReq = Operation{
+		Function: NetworkFunctionStorageReq,
+		Command:  0x40,
+	}
+	OperationGetSELInfoRsp = Operation{
+		Function: NetworkFunctionStorageRsp,
+		Command:  0x40,
+	}
+	OperationGetSELAllocationInfoReq = Operation{
+		Function: NetworkFunctionStorageReq,
+		Command:  0x41,
+	}
+	OperationGetSELAllocationInfoRsp = Operation{
+		Function: NetworkFunctionStorageRsp,
+		Command:  0x41,
+	}
+	OperationReserveSELReq = Operation{
+		Function: NetworkFunctionStorageReq,
+		Command:  0x42,
+	}
+	OperationReserveSELRsp = Operation{
+		Function: NetworkFunctionStorageRsp,
+		Command:  0x42,
+	}
+	OperationGetSELEntryReq = Operation{
+		Function: NetworkFunctionStorageReq,
+		Command:  0x43,
+	}
+	OperationGetSELEntryRsp = Operation{
+		Function: NetworkFunctionStorageRsp,
+		Command:  0x43,
+	}
+	OperationDeleteSELEntryReq = Operation{
+		Function: NetworkFunctionStorageReq,
+		Command:  0x46,
+	}
+	OperationDeleteSELEntryRsp = Operation{
+		Function: NetworkFunctionStorageRsp,
+		Command:  0x46,
+	}
+	OperationClearSELReq = Operation{
+		Function: NetworkFunctionStorageReq,
+		Command:  0x47,
+	}
+	OperationClearSELRsp = Operation{
+		Function: NetworkFunctionStorageRsp,
+		Command:  0x47,
+	}
+	OperationGetFRUInventoryAreaInfoReq = Operation{
+		Function: NetworkFunctionStorageReq,
+		Command:  0x10,
+	}
+	OperationGetFRUInventoryAreaInfoRsp = Operation{
+		Function: NetworkFunctionStorageRsp,
+		Command:  0x10,
+	}
+	OperationReadFRUDataReq = Operation{
+		Function: NetworkFunctionStorageReq,
+		Command:  0x11,
+	}
+	OperationReadFRUDataRsp = Operation{
+		Function: NetworkFunctionStorageRsp,
+		Command:  0x11,
+	}
+	OperationSetSensorHysteresisReq = Operation{
+		Function: NetworkFunctionSensorReq,
+		Command:  0x24,
+	}
+	OperationGetSensorHysteresisReq = Operation{
+		Function: NetworkFunctionSensorReq,
+		Command:  0x25,
+	}
+	OperationGetSensorHysteresisRsp = Operation{
+		Function: NetworkFunctionSensorRsp,
+		Command:  0x25,
+	}
+	OperationSetSensorThresholdsReq = Operation{
+		Function: NetworkFunctionSensorReq,
+		Command:  0x26,
+	}
+	OperationGetSensorThresholdsReq = Operation{
+		Function: NetworkFunctionSensorReq,
+		Command:  0x27,
+	}
+	OperationGetSensorThresholdsRsp = Operation{
+		Function: NetworkFunctionSensorRsp,
+		Command:  0x27,
+	}
+	OperationGetSensorReadingFactorsReq = Operation{
+		Function: NetworkFunctionSensorReq,
+		Command:  0x23,
+	}
+	OperationGetSensorReadingFactorsRsp = Operation{
+		Function: NetworkFunctionSensorRsp,
+		Command:  0x23,
+	}
+	OperationSetUserAccessReq = Operation{
+		Function: NetworkFunctionAppReq,
+		Command:  0x43,
+	}
+	OperationGetUserAccessReq = Operation{
+		Function: NetworkFunctionAppReq,
+		Command:  0x44,
+	}
+	OperationGetUserAccessRsp = Operation{
+		Function: NetworkFunctionAppRsp,
+		Command:  0x44,
+	}
+	OperationSetUserNameReq = Operation{
+		Function: NetworkFunctionAppReq,
+		Command:  0x45,
+	}
+	OperationGetUserNameReq = Operation{
+		Function: NetworkFunctionAppReq,
+		Command:  0x46,
+	}
+	OperationGetUserNameRsp = Operation{
+		Function: NetworkFunctionAppRsp,
+		Command:  0x46,
+	}
+	OperationSetUserPasswordReq = Operation{
+		Function: NetworkFunctionAppReq,
+		Command:  0x47,
+	}
+	OperationSetLANConfigurationParametersReq = Operation{
+		Function: NetworkFunctionTransportReq,
+		Command:  0x01,
+	}
+	OperationGetLANConfigurationParametersReq = Operation{
+		Function: NetworkFunctionTransportReq,
+		Command:  0x02,
+	}
+	OperationGetLANConfigurationParametersRsp = Operation{
+		Function: NetworkFunctionTransportRsp,
+		Command:  0x02,
+	}
+	OperationSetChannelAccessReq = Operation{
+		Function: NetworkFunctionAppReq,
+		Command:  0x40,
+	}
+	OperationGetChannelAccessReq = Operation{
+		Function: NetworkFunctionAppReq,
+		Command:  0x41,
+	}
+	OperationGetChannelAccessRsp = Operation{
+		Function: NetworkFunctionAppRsp,
+		Command:  0x41,
+	}
+	OperationGetChannelInfoReq = Operation{
+		Function: NetworkFunctionAppReq,
+		Command:  0x42,
+	}
+	OperationGetChannelInfoRsp = Operation{
+		Function: NetworkFunctionAppRsp,
+		Command:  0x42,
+	}
+	OperationResetWatchdogTimerReq = Operation{
+		Function: NetworkFunctionAppReq,
+		Command:  0x22,
+	}
+	OperationSetWatchdogTimerReq = Operation{
+		Function: NetworkFunctionAppReq,
+		Command:  0x24,
+	}
+	OperationGetWatchdogTimerReq = Operation{
+		Function: NetworkFunctionAppReq,
+		Command:  0x25,
+	}
+	OperationGetWatchdogTimerRsp = Operation{
+		Function: NetworkFunctionAppRsp,
+		Command:  0x25,
+	}
+	OperationGetSDRRepositoryTimeReq = Operation{
+		Function: NetworkFunctionStorageReq,
+		Command:  0x28,
+	}
+	OperationGetSDRRepositoryTimeRsp = Operation{
+		Function: NetworkFunctionStorageRsp,
+		Command:  0x28,
+	}
+	OperationGetSELTimeReq = Operation{
+		Function: NetworkFunctionStorageReq,
+		Command:  0x48,
+	}
+	OperationGetSELTimeRsp = Operation{
+		Function: NetworkFunctionStorageRsp,
+		Command:  0x48,
+	}
+	OperationSetSELTimeReq = Operation{
+		Function: NetworkFunctionStorageReq,
+		Command:  0x49,
+	}
+	OperationGetSELTimeUTCOffsetReq = Operation{
+		Function: NetworkFunctionStorageReq,
+		Command:  0x5c,
+	}
+	OperationGetSELTimeUTCOffsetRsp = Operation{
+		Function: NetworkFunctionStorageRsp,
+		Command:  0x5c,
+	}
+	OperationSetSELTimeUTCOffsetReq = Operation{
+		Function: NetworkFunctionStorageReq,
+		Command:  0x5d,
+	}
+	OperationGetPEFCapabilitiesReq = Operation{
+		Function: NetworkFunctionSensorReq,
+		Command:  0x10,
+	}
+	OperationGetPEFCapabilitiesRsp = Operation{
+		Function: NetworkFunctionSensorRsp,
+		Command:  0x10,
+	}
+	OperationArmPEFPostponeTimerReq = Operation{
+		Function: NetworkFunctionSensorReq,
+		Command:  0x11,
+	}
+	OperationArmPEFPostponeTimerRsp = Operation{
+		Function: NetworkFunctionSensorRsp,
+		Command:  0x11,
+	}
+	OperationSetPEFConfigurationParametersReq = Operation{
+		Function: NetworkFunctionSensorReq,
+		Command:  0x12,
+	}
+	OperationGetPEFConfigurationParametersReq = Operation{
+		Function: NetworkFunctionSensorReq,
+		Command:  0x13,
+	}
+	OperationGetPEFConfigurationParametersRsp = Operation{
+		Function: NetworkFunctionSensorRsp,
+		Command:  0x13,
+	}
+	OperationSetSystemInfoParametersReq = Operation{
+		Function: NetworkFunctionAppReq,
+		Command:  0x58,
+	}
+	OperationGetSystemInfoParametersReq = Operation{
+		Function: NetworkFunctionAppReq,
+		Command:  0x59,
+	}
+	OperationGetSystemInfoParametersRsp = Operation{
+		Function: NetworkFunctionAppRsp,
+		Command:  0x59,
+	}
 
 	// operationLayerTypes is how a Message finds out how to decode its
 	// payload. It tells us which layer comes next given a network function and
@@ -138,6 +466,8 @@ var (
 	// synchronisation.
 	operationLayerTypes = map[Operation]gopacket.LayerType{
 		OperationGetDeviceIDRsp:                          LayerTypeGetDeviceIDRsp,
+		OperationGetSelfTestResultsRsp:                   LayerTypeGetSelfTestResultsRsp,
+		OperationGetACPIPowerStateRsp:                    LayerTypeGetACPIPowerStateRsp,
 		OperationGetChassisStatusRsp:                     LayerTypeGetChassisStatusRsp,
 		OperationGetSystemGUIDRsp:                        LayerTypeGetSystemGUIDRsp,
 		OperationGetChannelAuthenticationCapabilitiesRsp: LayerTypeGetChannelAuthenticationCapabilitiesRsp,
@@ -148,6 +478,35 @@ var (
 		OperationGetSensorReadingRsp:                     LayerTypeGetSensorReadingRsp,
 		OperationGetSessionInfoRsp:                       LayerTypeGetSessionInfoRsp,
 		OperationGetChannelCipherSuitesRsp:               LayerTypeGetChannelCipherSuitesRsp,
+		OperationGetSessionChallengeRsp:                  LayerTypeGetSessionChallengeRsp,
+		OperationActivateSessionRsp:                      LayerTypeActivateSessionRsp,
+		OperationActivatePayloadRsp:                      LayerTypeActivatePayloadRsp,
+		OperationGetSOLConfigurationParametersRsp:        LayerTypeGetSOLConfigurationParametersRsp,
+		OperationGetSystemBootOptionsRsp:                 LayerTypeGetSystemBootOptionsRsp,
+		OperationGetSELInfoRsp:                           LayerTypeGetSELInfoRsp,
+		OperationGetSELAllocationInfoRsp:                 LayerTypeGetSELAllocationInfoRsp,
+		OperationReserveSELRsp:                           LayerTypeReserveSELRsp,
+		OperationGetSELEntryRsp:                          LayerTypeGetSELEntryRsp,
+		OperationDeleteSELEntryRsp:                       LayerTypeDeleteSELEntryRsp,
+		OperationClearSELRsp:                             LayerTypeClearSELRsp,
+		OperationGetFRUInventoryAreaInfoRsp:              LayerTypeGetFRUInventoryAreaInfoRsp,
+		OperationReadFRUDataRsp:                          LayerTypeReadFRUDataRsp,
+		OperationGetSensorHysteresisRsp:                  LayerTypeGetSensorHysteresisRsp,
+		OperationGetSensorThresholdsRsp:                  LayerTypeGetSensorThresholdsRsp,
+		OperationGetSensorReadingFactorsRsp:              LayerTypeGetSensorReadingFactorsRsp,
+		OperationGetUserAccessRsp:                        LayerTypeGetUserAccessRsp,
+		OperationGetUserNameRsp:                          LayerTypeGetUserNameRsp,
+		OperationGetLANConfigurationParametersRsp:        LayerTypeGetLANConfigurationParametersRsp,
+		OperationGetChannelAccessRsp:                     LayerTypeGetChannelAccessRsp,
+		OperationGetChannelInfoRsp:                       LayerTypeGetChannelInfoRsp,
+		OperationGetWatchdogTimerRsp:                     LayerTypeGetWatchdogTimerRsp,
+		OperationGetSDRRepositoryTimeRsp:                 LayerTypeGetSDRRepositoryTimeRsp,
+		OperationGetSELTimeRsp:                           LayerTypeGetSELTimeRsp,
+		OperationGetSELTimeUTCOffsetRsp:                  LayerTypeGetSELTimeUTCOffsetRsp,
+		OperationGetPEFCapabilitiesRsp:                   LayerTypeGetPEFCapabilitiesRsp,
+		OperationArmPEFPostponeTimerRsp:                  LayerTypeArmPEFPostponeTimerRsp,
+		OperationGetPEFConfigurationParametersRsp:        LayerTypeGetPEFConfigurationParametersRsp,
+		OperationGetSystemInfoParametersRsp:              LayerTypeGetSystemInfoParametersRsp,
 	}
 )
 
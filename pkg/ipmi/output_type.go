@@ -19,21 +19,107 @@ const (
 	// that are used in events it generates.
 	OutputTypeThreshold
 
-	// many not implemented; we'll save this complexity for when we actually
-	// need it. Sensor classes in particular are all special cases of each other
-	// and so are meaningless due to the overlap.
+	// 0x02 through 0x0c are generic discrete codes, whose state offsets are
+	// the same regardless of sensor type.
+
+	// OutputTypeUsageState indicates a DMI-based usage state: idle, active or
+	// busy.
+	OutputTypeUsageState
+
+	// OutputTypeState indicates a digital discrete sensor that is simply
+	// asserted or deasserted.
+	OutputTypeState
+
+	// OutputTypePredictiveFailure indicates a digital discrete sensor
+	// reporting whether a predictive failure is asserted.
+	OutputTypePredictiveFailure
+
+	// OutputTypeLimit indicates a digital discrete sensor reporting whether
+	// a limit has been exceeded.
+	OutputTypeLimit
+
+	// OutputTypePerformance indicates a digital discrete sensor reporting
+	// whether performance is lagging.
+	OutputTypePerformance
+
+	// OutputTypeSeverity indicates a sensor reporting transitions between
+	// OK, non-critical, critical and non-recoverable states.
+	OutputTypeSeverity
+
+	// OutputTypePresence indicates a digital discrete sensor reporting
+	// whether a device is present.
+	OutputTypePresence
+
+	// OutputTypeEnablement indicates a digital discrete sensor reporting
+	// whether a device is enabled.
+	OutputTypeEnablement
+
+	// OutputTypeAvailability indicates a sensor reporting the availability
+	// state of an entity, e.g. running, in test or off line.
+	OutputTypeAvailability
+
+	// OutputTypeRedundancy indicates a sensor reporting the redundancy state
+	// of an entity, e.g. a group of power supplies.
+	OutputTypeRedundancy
+
+	// OutputTypeACPIDevicePowerState indicates a sensor reporting the ACPI
+	// D-state of a device.
+	OutputTypeACPIDevicePowerState
+
+	// OutputTypeSensorSpecific indicates the meaning of state offsets depends
+	// on the sensor type, per Table 36-3 and 42-3 of v1.5 and v2.0
+	// respectively.
+	OutputTypeSensorSpecific OutputType = 0x6f
+
+	// 0x70-0x7f are OEM discrete codes
 )
 
 var (
 	outputTypeDescriptions = map[OutputType]string{
-		OutputTypeThreshold: "Threshold",
+		OutputTypeThreshold:            "Threshold",
+		OutputTypeUsageState:           "DMI-based Usage State",
+		OutputTypeState:                "State",
+		OutputTypePredictiveFailure:    "Predictive Failure",
+		OutputTypeLimit:                "Limit",
+		OutputTypePerformance:          "Performance",
+		OutputTypeSeverity:             "Severity",
+		OutputTypePresence:             "Presence",
+		OutputTypeEnablement:           "Enablement",
+		OutputTypeAvailability:         "Availability",
+		OutputTypeRedundancy:           "Redundancy",
+		OutputTypeACPIDevicePowerState: "ACPI Device Power State",
+		OutputTypeSensorSpecific:       "Sensor-specific",
 	}
 )
 
+// IsGeneric returns whether the Event/Reading Type Code is one of the generic
+// codes, whose state offsets are interpreted independently of the sensor type.
+// This includes the threshold code.
+func (o OutputType) IsGeneric() bool {
+	return OutputTypeThreshold <= o && o <= OutputTypeACPIDevicePowerState
+}
+
+// IsDiscrete returns whether the sensor reports a set of states rather than
+// an analogue value compared against thresholds. Readings of discrete sensors
+// are interpreted via the state bits of the Get Sensor Reading response.
+func (o OutputType) IsDiscrete() bool {
+	return o != OutputTypeThreshold && (o.IsGeneric() ||
+		o == OutputTypeSensorSpecific || o.IsOEM())
+}
+
+// IsOEM returns whether the Event/Reading Type Code is in the OEM range. The
+// meaning of states is not defined by the specification.
+func (o OutputType) IsOEM() bool {
+	return 0x70 <= o && o <= 0x7f
+}
+
 func (o OutputType) Description() string {
 	if desc, ok := outputTypeDescriptions[o]; ok {
 		return desc
 	}
+	if o.IsOEM() {
+		return "OEM"
+	}
 	return "Unknown"
 }
 
@@ -33,6 +33,9 @@ var (
 	PayloadDescriptorIPMI = PayloadDescriptor{
 		PayloadType: PayloadTypeIPMI,
 	}
+	PayloadDescriptorSOL = PayloadDescriptor{
+		PayloadType: PayloadTypeSOL,
+	}
 	PayloadDescriptorOpenSessionReq = PayloadDescriptor{
 		PayloadType: PayloadTypeOpenSessionReq,
 	}
@@ -54,6 +57,7 @@ var (
 
 	payloadLayerTypes = map[PayloadDescriptor]gopacket.LayerType{
 		PayloadDescriptorIPMI:           LayerTypeMessage,
+		PayloadDescriptorSOL:            LayerTypeSOL,
 		PayloadDescriptorOpenSessionReq: LayerTypeOpenSessionReq,
 		PayloadDescriptorOpenSessionRsp: LayerTypeOpenSessionRsp,
 		PayloadDescriptorRAKPMessage1:   LayerTypeRAKPMessage1,
@@ -14,6 +14,10 @@ const (
 
 	PayloadTypeIPMI PayloadType = 0x0
 
+	// PayloadTypeSOL is a Serial over LAN payload, which must be activated
+	// within a session using the Activate Payload command.
+	PayloadTypeSOL PayloadType = 0x1
+
 	// PayloadTypeOEM means "check the OEM IANA and OEM payload ID to find out
 	// what this actually is".
 	PayloadTypeOEM PayloadType = 0x2
@@ -31,6 +35,7 @@ const (
 var (
 	payloadTypeDescriptions = map[PayloadType]string{
 		PayloadTypeIPMI:           "IPMI",
+		PayloadTypeSOL:            "SOL",
 		PayloadTypeOEM:            "OEM Explicit",
 		PayloadTypeOpenSessionReq: "RMCP+ Open Session Request",
 		PayloadTypeOpenSessionRsp: "RMCP+ Open Session Response",
@@ -10,6 +10,7 @@ func TestPayloadTypeDescription(t *testing.T) {
 		want string
 	}{
 		{PayloadTypeIPMI, "IPMI"},
+		{PayloadTypeSOL, "SOL"},
 		{0x20, "OEM0"},
 		{0x27, "OEM7"},
 		{0x40, "Invalid"},
@@ -0,0 +1,67 @@
+package ipmi
+
+import (
+	"strings"
+)
+
+// PEFActions is a set of actions Platform Event Filtering can take when an
+// event matches a filter. The same bitfield is used to report the actions the
+// BMC supports in Get PEF Capabilities, to globally enable actions via the PEF
+// Action Global Control parameter, and to select actions in an event filter
+// table entry. Actions are specified in table 17-1 of IPMI v2.0.
+type PEFActions uint8
+
+const (
+	// PEFActionAlert sends an alert according to the filter's alert policy,
+	// e.g. a PET trap to a LAN destination.
+	PEFActionAlert PEFActions = 1 << iota
+
+	// PEFActionPowerOff powers down the system.
+	PEFActionPowerOff
+
+	// PEFActionReset hard resets the system.
+	PEFActionReset
+
+	// PEFActionPowerCycle powers the system down, then back up.
+	PEFActionPowerCycle
+
+	// PEFActionOEM performs an OEM-defined action.
+	PEFActionOEM
+
+	// PEFActionDiagnosticInterrupt pulses the diagnostic interrupt, typically
+	// an NMI.
+	PEFActionDiagnosticInterrupt
+
+	// PEFActionGroupControl performs a group control operation. This is only
+	// valid in event filters, and is rarely implemented.
+	PEFActionGroupControl
+)
+
+// pefActionNames are the names of each action, in bit order.
+var pefActionNames = []string{
+	"Alert",
+	"Power Off",
+	"Reset",
+	"Power Cycle",
+	"OEM",
+	"Diagnostic Interrupt",
+	"Group Control",
+}
+
+// Has returns whether all of the provided actions are in the set.
+func (a PEFActions) Has(actions PEFActions) bool {
+	return a&actions == actions
+}
+
+func (a PEFActions) String() string {
+	names := []string{}
+	for i, name := range pefActionNames {
+		if a&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "None"
+	}
+	return strings.Join(names, ", ")
+}
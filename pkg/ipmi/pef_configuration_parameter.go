@@ -0,0 +1,134 @@
+package ipmi
+
+import (
+	"fmt"
+)
+
+// PEFConfigurationParameter identifies a Platform Event Filtering
+// configuration parameter, used in the Get and Set PEF Configuration
+// Parameters commands. Parameters are specified in table 30-6 of IPMI v2.0.
+// The format of each parameter's data is specified alongside it.
+type PEFConfigurationParameter uint8
+
+const (
+	// PEFConfigurationParameterSetInProgress is used to indicate that
+	// parameters are being updated. Its data is 1 byte, the lower 2 bits of
+	// which are a SetInProgress value.
+	PEFConfigurationParameterSetInProgress PEFConfigurationParameter = iota
+
+	// PEFConfigurationParameterControl controls whether PEF is enabled, and
+	// whether startup delays apply. Its data is 1 byte; see PEFControl.
+	PEFConfigurationParameterControl
+
+	// PEFConfigurationParameterActionGlobalControl enables or disables each
+	// action regardless of the event filters. Its data is 1 byte; see
+	// PEFActions. Group control cannot be set here.
+	PEFConfigurationParameterActionGlobalControl
+
+	// PEFConfigurationParameterStartupDelay is how long PEF waits after a
+	// system power up or reset before taking actions. Its data is 1 byte, in
+	// seconds.
+	PEFConfigurationParameterStartupDelay
+
+	// PEFConfigurationParameterAlertStartupDelay is how long PEF waits after
+	// a system power up or reset before sending alerts. Its data is 1 byte,
+	// in seconds.
+	PEFConfigurationParameterAlertStartupDelay
+
+	// PEFConfigurationParameterEventFilterCount is the number of entries in
+	// the event filter table. It is read-only, and its data is 1 byte.
+	PEFConfigurationParameterEventFilterCount
+
+	// PEFConfigurationParameterEventFilterTable contains an event filter,
+	// selected by the set selector, which starts at 1. Its data is 21 bytes;
+	// see EventFilter.
+	PEFConfigurationParameterEventFilterTable
+
+	// PEFConfigurationParameterEventFilterTableData1 contains the first byte
+	// of an event filter, selected by the set selector. It can be used to
+	// enable or disable a filter without rewriting it. Its data is 2 bytes:
+	// the filter number and filter configuration.
+	PEFConfigurationParameterEventFilterTableData1
+
+	// PEFConfigurationParameterAlertPolicyCount is the number of entries in
+	// the alert policy table. It is read-only, and its data is 1 byte.
+	PEFConfigurationParameterAlertPolicyCount
+
+	// PEFConfigurationParameterAlertPolicyTable contains an alert policy
+	// entry, selected by the set selector, which starts at 1. Its data is 4
+	// bytes; see AlertPolicy.
+	PEFConfigurationParameterAlertPolicyTable
+
+	// PEFConfigurationParameterSystemGUID is the GUID sent in PET alerts. Its
+	// data is 17 bytes: bit 0 of the first byte indicates the following GUID
+	// is used rather than the one returned by Get System GUID.
+	PEFConfigurationParameterSystemGUID
+
+	// PEFConfigurationParameterAlertStringCount is the number of alert
+	// strings, excluding the volatile string 0. It is read-only, and its data
+	// is 1 byte.
+	PEFConfigurationParameterAlertStringCount
+
+	// PEFConfigurationParameterAlertStringKeys associates an alert string,
+	// selected by the set selector, with an event filter and alert string
+	// set. Its data is 3 bytes.
+	PEFConfigurationParameterAlertStringKeys
+
+	// PEFConfigurationParameterAlertStrings contains a block of an alert
+	// string, selected by the set and block selectors. Blocks are 16 bytes,
+	// and the string is null-terminated.
+	PEFConfigurationParameterAlertStrings
+
+	// PEFConfigurationParameterGroupControlCount is the number of entries in
+	// the group control table. It is read-only, and its data is 1 byte.
+	PEFConfigurationParameterGroupControlCount
+
+	// PEFConfigurationParameterGroupControlTable contains a group control
+	// entry, selected by the set selector. Its data is 11 bytes.
+	PEFConfigurationParameterGroupControlTable
+)
+
+func (p PEFConfigurationParameter) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(p), p.name())
+}
+
+func (p PEFConfigurationParameter) name() string {
+	switch p {
+	case PEFConfigurationParameterSetInProgress:
+		return "Set In Progress"
+	case PEFConfigurationParameterControl:
+		return "PEF Control"
+	case PEFConfigurationParameterActionGlobalControl:
+		return "PEF Action Global Control"
+	case PEFConfigurationParameterStartupDelay:
+		return "PEF Startup Delay"
+	case PEFConfigurationParameterAlertStartupDelay:
+		return "PEF Alert Startup Delay"
+	case PEFConfigurationParameterEventFilterCount:
+		return "Number of Event Filters"
+	case PEFConfigurationParameterEventFilterTable:
+		return "Event Filter Table"
+	case PEFConfigurationParameterEventFilterTableData1:
+		return "Event Filter Table Data 1"
+	case PEFConfigurationParameterAlertPolicyCount:
+		return "Number of Alert Policy Entries"
+	case PEFConfigurationParameterAlertPolicyTable:
+		return "Alert Policy Table"
+	case PEFConfigurationParameterSystemGUID:
+		return "System GUID"
+	case PEFConfigurationParameterAlertStringCount:
+		return "Number of Alert Strings"
+	case PEFConfigurationParameterAlertStringKeys:
+		return "Alert String Keys"
+	case PEFConfigurationParameterAlertStrings:
+		return "Alert Strings"
+	case PEFConfigurationParameterGroupControlCount:
+		return "Number of Group Control Table Entries"
+	case PEFConfigurationParameterGroupControlTable:
+		return "Group Control Table"
+	}
+	if p >= 0x60 && p <= 0x7f {
+		return "OEM"
+	}
+	return "Unknown"
+}
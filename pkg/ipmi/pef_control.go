@@ -0,0 +1,67 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+)
+
+// PEFControl is the data of the PEF Control PEF configuration parameter,
+// parameter 1 in table 30-6 of IPMI v2.0. It globally enables Platform Event
+// Filtering.
+type PEFControl struct {
+
+	// Enabled indicates PEF processes events. If false, no actions are taken,
+	// regardless of the event filters.
+	Enabled bool
+
+	// EventMessages indicates PEF logs an event message to the SEL when it
+	// takes an action.
+	EventMessages bool
+
+	// StartupDelay indicates actions are delayed after a system power up or
+	// reset by the PEF Startup Delay parameter.
+	StartupDelay bool
+
+	// AlertStartupDelay indicates alerts are delayed after a system power up
+	// or reset by the PEF Alert Startup Delay parameter.
+	AlertStartupDelay bool
+}
+
+// Serialise encodes the control onto the end of a buffer, returning an error
+// if one occurs.
+func (c *PEFControl) Serialise(b gopacket.SerializeBuffer) error {
+	d, err := b.AppendBytes(1)
+	if err != nil {
+		return err
+	}
+	d[0] = 0
+	if c.Enabled {
+		d[0] |= 1
+	}
+	if c.EventMessages {
+		d[0] |= 1 << 1
+	}
+	if c.StartupDelay {
+		d[0] |= 1 << 2
+	}
+	if c.AlertStartupDelay {
+		d[0] |= 1 << 3
+	}
+	return nil
+}
+
+// Deserialise reads a control from the supplied byte slice, returning
+// unconsumed remaining bytes.
+func (c *PEFControl) Deserialise(d []byte, df gopacket.DecodeFeedback) ([]byte, error) {
+	if len(d) < 1 {
+		df.SetTruncated()
+		return nil, fmt.Errorf("PEF control is 1 byte, only %v remaining",
+			len(d))
+	}
+	c.Enabled = d[0]&1 != 0
+	c.EventMessages = d[0]&(1<<1) != 0
+	c.StartupDelay = d[0]&(1<<2) != 0
+	c.AlertStartupDelay = d[0]&(1<<3) != 0
+	return d[1:], nil
+}
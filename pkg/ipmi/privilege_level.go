@@ -33,6 +33,11 @@ const (
 	PrivilegeLevelOperator
 	PrivilegeLevelAdministrator
 	PrivilegeLevelOEM
+
+	// PrivilegeLevelNoAccess is used in the Get and Set User Access commands
+	// to indicate a user cannot access a channel at all. It is not valid when
+	// establishing a session.
+	PrivilegeLevelNoAccess PrivilegeLevel = 0xf
 )
 
 func (p PrivilegeLevel) String() string {
@@ -49,6 +54,8 @@ func (p PrivilegeLevel) String() string {
 		return "Administrator"
 	case PrivilegeLevelOEM:
 		return "OEM"
+	case PrivilegeLevelNoAccess:
+		return "No Access"
 	default:
 		return "Unknown"
 	}
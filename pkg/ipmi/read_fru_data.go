@@ -0,0 +1,105 @@
+package ipmi
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// ReadFRUDataReq represents a request to read part of a FRU device's
+// inventory area. It is specified in 28.2 and 34.2 of IPMI v1.5 and v2.0
+// respectively. The maximum count is limited by the BMC's buffer size, so
+// reading an entire area takes several commands.
+type ReadFRUDataReq struct {
+	layers.BaseLayer
+
+	// DeviceID identifies the FRU device behind the BMC.
+	DeviceID uint8
+
+	// Offset is the position in the inventory area to start reading from.
+	// This is in words if the device is accessed by words.
+	Offset uint16
+
+	// Count is the number of bytes or words to read. If this is too large,
+	// the BMC will return CompletionCodeCannotReturnRequestedDataBytes or
+	// similar.
+	Count uint8
+}
+
+func (*ReadFRUDataReq) LayerType() gopacket.LayerType {
+	return LayerTypeReadFRUDataReq
+}
+
+func (r *ReadFRUDataReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(4)
+	if err != nil {
+		return err
+	}
+	bytes[0] = r.DeviceID
+	binary.LittleEndian.PutUint16(bytes[1:3], r.Offset)
+	bytes[3] = r.Count
+	return nil
+}
+
+// ReadFRUDataRsp contains data read from a FRU device's inventory area. The
+// data is contained in the layer payload.
+type ReadFRUDataRsp struct {
+	layers.BaseLayer
+
+	// Count is the number of bytes or words returned. This may be fewer than
+	// requested.
+	Count uint8
+}
+
+func (*ReadFRUDataRsp) LayerType() gopacket.LayerType {
+	return LayerTypeReadFRUDataRsp
+}
+
+func (r *ReadFRUDataRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*ReadFRUDataRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *ReadFRUDataRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 1 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be at least 1 byte, got %v", len(data))
+	}
+
+	r.Count = data[0]
+	r.BaseLayer.Contents = data[:1]
+	r.BaseLayer.Payload = data[1:]
+	return nil
+}
+
+type ReadFRUDataCmd struct {
+	Req ReadFRUDataReq
+	Rsp ReadFRUDataRsp
+}
+
+// Name returns "Read FRU Data".
+func (*ReadFRUDataCmd) Name() string {
+	return "Read FRU Data"
+}
+
+// Operation returns &OperationReadFRUDataReq.
+func (*ReadFRUDataCmd) Operation() *Operation {
+	return &OperationReadFRUDataReq
+}
+
+func (*ReadFRUDataCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (c *ReadFRUDataCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *ReadFRUDataCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
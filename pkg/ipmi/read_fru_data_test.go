@@ -0,0 +1,25 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/gopacket"
+)
+
+func TestReadFRUDataReqSerializeTo(t *testing.T) {
+	layer := &ReadFRUDataReq{
+		DeviceID: 3,
+		Offset:   0x1234,
+		Count:    32,
+	}
+	want := []byte{0x03, 0x34, 0x12, 0x20}
+
+	sb := gopacket.NewSerializeBuffer()
+	if err := layer.SerializeTo(sb, gopacket.SerializeOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := sb.Bytes(); !bytes.Equal(got, want) {
+		t.Errorf("serialize %v = %v, want %v", layer, got, want)
+	}
+}
@@ -28,7 +28,9 @@ const (
 
 var (
 	recordTypeLayerTypes = map[RecordType]gopacket.LayerType{
-		RecordTypeFullSensor: LayerTypeFullSensorRecord,
+		RecordTypeFullSensor:    LayerTypeFullSensorRecord,
+		RecordTypeCompactSensor: LayerTypeCompactSensorRecord,
+		RecordTypeEventOnly:     LayerTypeEventOnlyRecord,
 	}
 	recordTypeDescriptions = map[RecordType]string{
 		RecordTypeFullSensor:                        "Full Sensor Record",
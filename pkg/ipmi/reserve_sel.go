@@ -0,0 +1,68 @@
+package ipmi
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// ReserveSELRsp represents the response to a Reserve SEL command, specified in
+// 25.4 and 31.4 of IPMI v1.5 and v2.0 respectively. The reservation ID behaves
+// the same way as for the SDR Repository, and is required to delete an entry,
+// clear the SEL, or partially read an entry.
+type ReserveSELRsp struct {
+	layers.BaseLayer
+
+	ReservationID ReservationID
+}
+
+func (*ReserveSELRsp) LayerType() gopacket.LayerType {
+	return LayerTypeReserveSELRsp
+}
+
+func (r *ReserveSELRsp) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*ReserveSELRsp) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *ReserveSELRsp) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 2 {
+		df.SetTruncated()
+		return fmt.Errorf("response must be at least 2 bytes, got %v", len(data))
+	}
+
+	r.BaseLayer.Contents = data[:2]
+	r.ReservationID = ReservationID(binary.LittleEndian.Uint16(data[0:2]))
+	return nil
+}
+
+type ReserveSELCmd struct {
+	Rsp ReserveSELRsp
+}
+
+// Name returns "Reserve SEL".
+func (*ReserveSELCmd) Name() string {
+	return "Reserve SEL"
+}
+
+// Operation returns &OperationReserveSELReq.
+func (*ReserveSELCmd) Operation() *Operation {
+	return &OperationReserveSELReq
+}
+
+func (c *ReserveSELCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (c *ReserveSELCmd) Request() gopacket.SerializableLayer {
+	return nil
+}
+
+func (c *ReserveSELCmd) Response() gopacket.DecodingLayer {
+	return &c.Rsp
+}
@@ -0,0 +1,40 @@
+package ipmi
+
+import (
+	"github.com/google/gopacket"
+)
+
+const (
+	// CompletionCodeWatchdogUninitialised is returned in response to a Reset
+	// Watchdog Timer command if the timer has not been configured with Set
+	// Watchdog Timer since the BMC started.
+	CompletionCodeWatchdogUninitialised CompletionCode = 0x80
+)
+
+// ResetWatchdogTimerCmd implements the Reset Watchdog Timer command, specified
+// in 21.5 and 27.5 of IPMI v1.5 and v2.0 respectively. It starts the timer if
+// it is stopped, and restarts the countdown from the initial countdown value
+// if it is running. Neither the request nor response contain data.
+type ResetWatchdogTimerCmd struct{}
+
+// Name returns "Reset Watchdog Timer".
+func (*ResetWatchdogTimerCmd) Name() string {
+	return "Reset Watchdog Timer"
+}
+
+// Operation returns &OperationResetWatchdogTimerReq.
+func (*ResetWatchdogTimerCmd) Operation() *Operation {
+	return &OperationResetWatchdogTimerReq
+}
+
+func (*ResetWatchdogTimerCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (*ResetWatchdogTimerCmd) Request() gopacket.SerializableLayer {
+	return nil
+}
+
+func (*ResetWatchdogTimerCmd) Response() gopacket.DecodingLayer {
+	return nil
+}
@@ -0,0 +1,50 @@
+package ipmi
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// SELRecord represents the fields common to all System Event Log records,
+// specified in 26 and 32 of IPMI v1.5 and v2.0 respectively. Records are 16
+// bytes; the 13 bytes following this header are determined by the Type.
+type SELRecord struct {
+	layers.BaseLayer
+
+	// ID is the Record ID of the entry. Like SDR Record IDs, this may change
+	// if the SEL is modified.
+	ID RecordID
+
+	// Type indicates how the remainder of the record should be interpreted.
+	Type SELRecordType
+
+	// payload contains the record body
+}
+
+func (*SELRecord) LayerType() gopacket.LayerType {
+	return LayerTypeSELRecord
+}
+
+func (r *SELRecord) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (r *SELRecord) NextLayerType() gopacket.LayerType {
+	return r.Type.NextLayerType()
+}
+
+func (r *SELRecord) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 3 {
+		df.SetTruncated()
+		return fmt.Errorf("SEL record header is 3 bytes, got %v", len(data))
+	}
+	r.ID = RecordID(binary.LittleEndian.Uint16(data[0:2]))
+	r.Type = SELRecordType(data[2])
+
+	r.BaseLayer.Contents = data[:3]
+	r.BaseLayer.Payload = data[3:]
+	return nil
+}
@@ -0,0 +1,59 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+)
+
+// SELRecordType indicates the format of a System Event Log record. It is
+// specified in 26 and 32 of IPMI v1.5 and v2.0 respectively. Besides system
+// events, the only other standard formats are OEM records, which come in
+// timestamped (0xc0-0xdf) and non-timestamped (0xe0-0xff) varieties.
+type SELRecordType uint8
+
+const (
+	SELRecordTypeSystemEvent SELRecordType = 0x02
+)
+
+var (
+	selRecordTypeLayerTypes = map[SELRecordType]gopacket.LayerType{
+		SELRecordTypeSystemEvent: LayerTypeSystemEventRecord,
+	}
+)
+
+// IsTimestampedOEM returns whether the record is an OEM record that begins
+// with a timestamp and manufacturer ID.
+func (t SELRecordType) IsTimestampedOEM() bool {
+	return 0xc0 <= t && t <= 0xdf
+}
+
+// IsNonTimestampedOEM returns whether the record is an OEM record whose
+// contents are entirely OEM-defined.
+func (t SELRecordType) IsNonTimestampedOEM() bool {
+	return t >= 0xe0
+}
+
+func (t SELRecordType) NextLayerType() gopacket.LayerType {
+	if layer, ok := selRecordTypeLayerTypes[t]; ok {
+		return layer
+	}
+	return gopacket.LayerTypePayload
+}
+
+func (t SELRecordType) Description() string {
+	switch {
+	case t == SELRecordTypeSystemEvent:
+		return "System Event Record"
+	case t.IsTimestampedOEM():
+		return "OEM Timestamped Record"
+	case t.IsNonTimestampedOEM():
+		return "OEM Non-timestamped Record"
+	default:
+		return "Unknown"
+	}
+}
+
+func (t SELRecordType) String() string {
+	return fmt.Sprintf("%#x(%v)", uint8(t), t.Description())
+}
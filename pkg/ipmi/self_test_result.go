@@ -0,0 +1,129 @@
+package ipmi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SelfTestResult is the overall outcome of the BMC's self test, returned in
+// the first byte of the Get Self Test Results response. Values from 0x01 to
+// 0x54 and above 0x58 are device-specific.
+type SelfTestResult uint8
+
+const (
+	// SelfTestResultPassed means no errors were found.
+	SelfTestResultPassed SelfTestResult = 0x55
+
+	// SelfTestResultNotImplemented means the BMC does not run a self test.
+	SelfTestResultNotImplemented SelfTestResult = 0x56
+
+	// SelfTestResultCorrupted means data or a device is corrupted or
+	// inaccessible. The failures are detailed in the second byte of the
+	// response.
+	SelfTestResultCorrupted SelfTestResult = 0x57
+
+	// SelfTestResultFatal means the BMC has a fatal hardware error.
+	SelfTestResultFatal SelfTestResult = 0x58
+)
+
+func (r SelfTestResult) Description() string {
+	switch r {
+	case SelfTestResultPassed:
+		return "No error"
+	case SelfTestResultNotImplemented:
+		return "Self Test function not implemented"
+	case SelfTestResultCorrupted:
+		return "Corrupted or inaccessible data or devices"
+	case SelfTestResultFatal:
+		return "Fatal hardware error"
+	case 0xff:
+		return "Reserved"
+	default:
+		return "Device-specific"
+	}
+}
+
+func (r SelfTestResult) String() string {
+	return fmt.Sprintf("%#x(%v)", uint8(r), r.Description())
+}
+
+// SelfTestFailures is a bitfield of the failures found by the self test when
+// the result is SelfTestResultCorrupted.
+type SelfTestFailures uint8
+
+const (
+	// SelfTestFailureOperationalFirmware means the BMC's operational firmware
+	// is corrupted.
+	SelfTestFailureOperationalFirmware SelfTestFailures = 1 << iota
+
+	// SelfTestFailureBootBlockFirmware means the BMC's update "boot block"
+	// firmware is corrupted.
+	SelfTestFailureBootBlockFirmware
+
+	// SelfTestFailureFRUInternalUseArea means the internal use area of the
+	// BMC's FRU device is corrupted.
+	SelfTestFailureFRUInternalUseArea
+
+	// SelfTestFailureSDRRepositoryEmpty means the SDR repository contains no
+	// records.
+	SelfTestFailureSDRRepositoryEmpty
+
+	// SelfTestFailureIPMB means the IPMB signal lines do not respond.
+	SelfTestFailureIPMB
+
+	// SelfTestFailureFRUDevice means the BMC cannot access its FRU device.
+	SelfTestFailureFRUDevice
+
+	// SelfTestFailureSDRRepository means the BMC cannot access the SDR
+	// repository.
+	SelfTestFailureSDRRepository
+
+	// SelfTestFailureSEL means the BMC cannot access the SEL device.
+	SelfTestFailureSEL
+)
+
+func (f SelfTestFailures) description() string {
+	switch f {
+	case SelfTestFailureOperationalFirmware:
+		return "Controller operational firmware corrupted"
+	case SelfTestFailureBootBlockFirmware:
+		return "Controller update 'boot block' firmware corrupted"
+	case SelfTestFailureFRUInternalUseArea:
+		return "Internal Use Area of BMC FRU corrupted"
+	case SelfTestFailureSDRRepositoryEmpty:
+		return "SDR Repository empty"
+	case SelfTestFailureIPMB:
+		return "IPMB signal lines do not respond"
+	case SelfTestFailureFRUDevice:
+		return "Cannot access BMC FRU device"
+	case SelfTestFailureSDRRepository:
+		return "Cannot access SDR Repository"
+	case SelfTestFailureSEL:
+		return "Cannot access SEL device"
+	default:
+		return "Unknown"
+	}
+}
+
+// Failures returns the individual failures set, in ascending bit order.
+func (f SelfTestFailures) Failures() []SelfTestFailures {
+	failures := []SelfTestFailures{}
+	for bit := SelfTestFailureOperationalFirmware; bit != 0; bit <<= 1 {
+		if f&bit != 0 {
+			failures = append(failures, bit)
+		}
+	}
+	return failures
+}
+
+func (f SelfTestFailures) String() string {
+	failures := f.Failures()
+	if len(failures) == 0 {
+		return "None"
+	}
+	descriptions := make([]string, len(failures))
+	for i, failure := range failures {
+		descriptions[i] = failure.description()
+	}
+	return strings.Join(descriptions, ", ")
+}
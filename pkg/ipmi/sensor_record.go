@@ -0,0 +1,138 @@
+package ipmi
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/google/gopacket"
+)
+
+// SensorRecord is implemented by the SDR layers that describe a sensor: Full
+// Sensor Records, Compact Sensor Records and Event-Only Records. It exposes
+// the fields common to all three; use a type switch to access the rest.
+type SensorRecord interface {
+	gopacket.Layer
+
+	// Key returns the record key, which identifies the sensor on the BMC.
+	Key() SensorRecordKey
+
+	// Name returns the sensor's ID String.
+	Name() string
+
+	// Kind returns the sensor's type and Event/Reading Type Code, which
+	// together determine how its readings and events are interpreted.
+	Kind() (SensorType, OutputType)
+
+	// Monitored returns the entity the sensor monitors.
+	Monitored() (EntityID, EntityInstance)
+}
+
+// IDStringModifierType indicates how the ID String of each sensor sharing a
+// Compact Sensor Record or Event-Only Record is made unique.
+type IDStringModifierType uint8
+
+const (
+	// IDStringModifierTypeNumeric appends a decimal number, e.g. "Fan 0",
+	// "Fan 1".
+	IDStringModifierTypeNumeric IDStringModifierType = iota
+
+	// IDStringModifierTypeAlpha appends letters, e.g. "Fan A", "Fan B",
+	// continuing with "AA" after "Z".
+	IDStringModifierTypeAlpha
+)
+
+var (
+	idStringModifierTypeDescriptions = map[IDStringModifierType]string{
+		IDStringModifierTypeNumeric: "Numeric",
+		IDStringModifierTypeAlpha:   "Alpha",
+	}
+)
+
+func (t IDStringModifierType) Description() string {
+	if desc, ok := idStringModifierTypeDescriptions[t]; ok {
+		return desc
+	}
+	return "Unknown"
+}
+
+func (t IDStringModifierType) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(t), t.Description())
+}
+
+// modifier returns the suffix for a 0-based modifier value.
+func (t IDStringModifierType) modifier(n int) string {
+	if t == IDStringModifierTypeAlpha {
+		if n < 26 {
+			return string(rune('A' + n))
+		}
+		return string([]rune{rune('A' + n/26 - 1), rune('A' + n%26)})
+	}
+	return strconv.Itoa(n)
+}
+
+// SensorRecordSharing allows a single Compact Sensor Record or Event-Only
+// Record to describe several sensors with consecutive numbers, e.g. a bank of
+// identical fans. It is specified in bytes 24-25 of Table 43-2 in IPMI v2.0.
+type SensorRecordSharing struct {
+
+	// Count is the number of sensors sharing the record. 0 and 1 both mean the
+	// record describes a single sensor.
+	Count uint8
+
+	// ModifierType indicates how ID Strings are made unique.
+	ModifierType IDStringModifierType
+
+	// ModifierOffset is the modifier value of the first sensor, e.g. 1 to
+	// start numbering at 1 rather than 0.
+	ModifierOffset uint8
+
+	// InstanceIncrements indicates whether each sensor's entity instance is
+	// one greater than the previous. If false, all sensors pertain to the same
+	// instance.
+	InstanceIncrements bool
+}
+
+// Sensors returns the number of sensors described by the record.
+func (s *SensorRecordSharing) Sensors() int {
+	if s.Count == 0 {
+		return 1
+	}
+	return int(s.Count)
+}
+
+// share adjusts the fields of a copied record for the ith sensor sharing it.
+func (s *SensorRecordSharing) share(i int, number *uint8, instance *EntityInstance, identity *string) {
+	if s.Count <= 1 {
+		return
+	}
+	*number += uint8(i)
+	if s.InstanceIncrements {
+		*instance += EntityInstance(i)
+	}
+	*identity += s.ModifierType.modifier(int(s.ModifierOffset) + i)
+}
+
+// decode parses the two record sharing bytes.
+func (s *SensorRecordSharing) decode(b []byte) {
+	s.ModifierType = IDStringModifierType((b[0] >> 4) & 0x3)
+	s.Count = b[0] & 0xf
+	s.InstanceIncrements = b[1]&(1<<7) != 0
+	s.ModifierOffset = b[1] & 0x7f
+}
+
+// decodeIDString parses the ID String Type/Length byte at the start of b, and
+// the string following it. It returns the string and the number of bytes
+// consumed.
+func decodeIDString(b []byte) (string, int, error) {
+	encoding := StringEncoding(b[0] >> 6)
+	decoder, err := encoding.Decoder()
+	if err != nil {
+		return "", 0, err
+	}
+	characters := int(b[0] & 0x1f)
+	identity, consumed, err := decoder.Decode(b[1:], characters)
+	if err != nil {
+		return "", 0, err
+	}
+	return identity, 1 + consumed, nil
+}
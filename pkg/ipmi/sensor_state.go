@@ -0,0 +1,383 @@
+package ipmi
+
+// genericStateDescriptions contains the names of state offsets for the generic
+// Event/Reading Type Codes, specified in Table 36-2 and 42-2 of v1.5 and v2.0
+// respectively. The threshold offsets are event offsets; readings of threshold
+// sensors instead report comparison status.
+var genericStateDescriptions = map[OutputType][]string{
+	OutputTypeThreshold: {
+		"Lower Non-critical - going low",
+		"Lower Non-critical - going high",
+		"Lower Critical - going low",
+		"Lower Critical - going high",
+		"Lower Non-recoverable - going low",
+		"Lower Non-recoverable - going high",
+		"Upper Non-critical - going low",
+		"Upper Non-critical - going high",
+		"Upper Critical - going low",
+		"Upper Critical - going high",
+		"Upper Non-recoverable - going low",
+		"Upper Non-recoverable - going high",
+	},
+	OutputTypeUsageState: {
+		"Transition to Idle",
+		"Transition to Active",
+		"Transition to Busy",
+	},
+	OutputTypeState: {
+		"State Deasserted",
+		"State Asserted",
+	},
+	OutputTypePredictiveFailure: {
+		"Predictive Failure deasserted",
+		"Predictive Failure asserted",
+	},
+	OutputTypeLimit: {
+		"Limit Not Exceeded",
+		"Limit Exceeded",
+	},
+	OutputTypePerformance: {
+		"Performance Met",
+		"Performance Lags",
+	},
+	OutputTypeSeverity: {
+		"transition to OK",
+		"transition to Non-Critical from OK",
+		"transition to Critical from less severe",
+		"transition to Non-recoverable from less severe",
+		"transition to Non-Critical from more severe",
+		"transition to Critical from Non-recoverable",
+		"transition to Non-recoverable",
+		"Monitor",
+		"Informational",
+	},
+	OutputTypePresence: {
+		"Device Removed / Device Absent",
+		"Device Inserted / Device Present",
+	},
+	OutputTypeEnablement: {
+		"Device Disabled",
+		"Device Enabled",
+	},
+	OutputTypeAvailability: {
+		"transition to Running",
+		"transition to In Test",
+		"transition to Power Off",
+		"transition to On Line",
+		"transition to Off Line",
+		"transition to Off Duty",
+		"transition to Degraded",
+		"transition to Power Save",
+		"Install Error",
+	},
+	OutputTypeRedundancy: {
+		"Fully Redundant",
+		"Redundancy Lost",
+		"Redundancy Degraded",
+		"Non-redundant: Sufficient Resources from Redundant",
+		"Non-redundant: Sufficient Resources from Insufficient Resources",
+		"Non-redundant: Insufficient Resources",
+		"Redundancy Degraded from Fully Redundant",
+		"Redundancy Degraded from Non-redundant",
+	},
+	OutputTypeACPIDevicePowerState: {
+		"D0 Power State",
+		"D1 Power State",
+		"D2 Power State",
+		"D3 Power State",
+	},
+}
+
+// sensorSpecificStateDescriptions contains the names of sensor-specific state
+// offsets, used when a sensor's Event/Reading Type Code is
+// OutputTypeSensorSpecific. This is specified in Table 36-3 and 42-3 of v1.5
+// and v2.0 respectively. Reserved offsets are empty strings. Sensor types
+// without sensor-specific offsets are omitted.
+var sensorSpecificStateDescriptions = map[SensorType][]string{
+	SensorTypePhysicalSecurity: {
+		"General Chassis Intrusion",
+		"Drive Bay intrusion",
+		"I/O Card area intrusion",
+		"Processor area intrusion",
+		"LAN Leash Lost",
+		"Unauthorized dock/undock",
+		"FAN area intrusion",
+	},
+	SensorTypePlatformSecurity: {
+		"Secure Mode Violation attempt",
+		"Pre-boot Password Violation - user password",
+		"Pre-boot Password Violation attempt - setup password",
+		"Pre-boot Password Violation - network boot password",
+		"Other pre-boot Password Violation",
+		"Out-of-band Access Password Violation",
+	},
+	SensorTypeProcessor: {
+		"IERR",
+		"Thermal Trip",
+		"FRB1/BIST failure",
+		"FRB2/Hang in POST failure",
+		"FRB3/Processor Startup/Initialization failure",
+		"Configuration Error",
+		"SM BIOS Uncorrectable CPU-complex Error",
+		"Processor Presence detected",
+		"Processor disabled",
+		"Terminator Presence Detected",
+		"Processor Automatically Throttled",
+		"Machine Check Exception (Uncorrectable)",
+		"Correctable Machine Check Error",
+	},
+	SensorTypePowerSupply: {
+		"Presence detected",
+		"Power Supply Failure detected",
+		"Predictive Failure",
+		"Power Supply input lost (AC/DC)",
+		"Power Supply input lost or out-of-range",
+		"Power Supply input out-of-range, but present",
+		"Configuration error",
+		"Power Supply Inactive (in standby state)",
+	},
+	SensorTypePowerUnit: {
+		"Power Off / Power Down",
+		"Power Cycle",
+		"240VA Power Down",
+		"Interlock Power Down",
+		"AC lost / Power input lost",
+		"Soft Power Control Failure",
+		"Power Unit Failure detected",
+		"Predictive Failure",
+	},
+	SensorTypeMemory: {
+		"Correctable ECC / other correctable memory error",
+		"Uncorrectable ECC / other uncorrectable memory error",
+		"Parity",
+		"Memory Scrub Failed",
+		"Memory Device Disabled",
+		"Correctable ECC / other correctable memory error logging limit reached",
+		"Presence detected",
+		"Configuration error",
+		"Spare",
+		"Memory Automatically Throttled",
+		"Critical Overtemperature",
+	},
+	SensorTypeDriveBay: {
+		"Drive Presence",
+		"Drive Fault",
+		"Predictive Failure",
+		"Hot Spare",
+		"Consistency Check / Parity Check in progress",
+		"In Critical Array",
+		"In Failed Array",
+		"Rebuild/Remap in progress",
+		"Rebuild/Remap Aborted",
+	},
+	SensorTypeSystemFirmwareProgress: {
+		"System Firmware Error (POST Error)",
+		"System Firmware Hang",
+		"System Firmware Progress",
+	},
+	SensorTypeEventLoggingDisabled: {
+		"Correctable Memory Error Logging Disabled",
+		"Event 'Type' Logging Disabled",
+		"Log Area Reset/Cleared",
+		"All Event Logging Disabled",
+		"SEL Full",
+		"SEL Almost Full",
+		"Correctable Machine Check Error Logging Disabled",
+	},
+	SensorTypeWatchdog1: {
+		"BIOS Watchdog Reset",
+		"OS Watchdog Reset",
+		"OS Watchdog Shut Down",
+		"OS Watchdog Power Down",
+		"OS Watchdog Power Cycle",
+		"OS Watchdog NMI / Diagnostic Interrupt",
+		"OS Watchdog Expired, status only",
+		"OS Watchdog pre-timeout Interrupt, non-NMI",
+	},
+	SensorTypeSystemEvent: {
+		"System Reconfigured",
+		"OEM System Boot Event",
+		"Undetermined system hardware failure",
+		"Entry added to Auxiliary Log",
+		"PEF Action",
+		"Timestamp Clock Synch",
+	},
+	SensorTypeCriticalInterrupt: {
+		"Front Panel NMI / Diagnostic Interrupt",
+		"Bus Timeout",
+		"I/O channel check NMI",
+		"Software NMI",
+		"PCI PERR",
+		"PCI SERR",
+		"EISA Fail Safe Timeout",
+		"Bus Correctable Error",
+		"Bus Uncorrectable Error",
+		"Fatal NMI",
+		"Bus Fatal Error",
+		"Bus Degraded",
+	},
+	SensorTypeButtonSwitch: {
+		"Power Button pressed",
+		"Sleep Button pressed",
+		"Reset Button pressed",
+		"FRU latch open",
+		"FRU service request button",
+	},
+	SensorTypeChipSet: {
+		"Soft Power Control Failure",
+		"Thermal Trip",
+	},
+	SensorTypeCableInterconnect: {
+		"Cable/Interconnect is connected",
+		"Configuration Error - Incorrect cable connected / Incorrect interconnection",
+	},
+	SensorTypeSystemBootRestartInitiated: {
+		"Initiated by power up",
+		"Initiated by hard reset",
+		"Initiated by warm reset",
+		"User requested PXE boot",
+		"Automatic boot to diagnostic",
+		"OS / run-time software initiated hard reset",
+		"OS / run-time software initiated warm reset",
+		"System Restart",
+	},
+	SensorTypeBootError: {
+		"No bootable media",
+		"Non-bootable diskette left in drive",
+		"PXE Server not found",
+		"Invalid boot sector",
+		"Timeout waiting for user selection of boot source",
+	},
+	SensorTypeBaseOSBootInstallationStatus: {
+		"A: boot completed",
+		"C: boot completed",
+		"PXE boot completed",
+		"Diagnostic boot completed",
+		"CD-ROM boot completed",
+		"ROM boot completed",
+		"boot completed - boot device not specified",
+		"Base OS/Hypervisor Installation started",
+		"Base OS/Hypervisor Installation completed",
+		"Base OS/Hypervisor Installation aborted",
+		"Base OS/Hypervisor Installation failed",
+	},
+	SensorTypeOSStopShutdown: {
+		"Critical stop during OS load / initialization",
+		"Run-time Critical Stop",
+		"OS Graceful Stop",
+		"OS Graceful Shutdown",
+		"Soft Shutdown initiated by PEF",
+		"Agent Not Responding",
+	},
+	SensorTypeSlotConnector: {
+		"Fault Status asserted",
+		"Identify Status asserted",
+		"Slot / Connector Device installed/attached",
+		"Slot / Connector Ready for Device Installation",
+		"Slot / Connector Ready for Device Removal",
+		"Slot Power is Off",
+		"Slot / Connector Device Removal Request",
+		"Interlock asserted",
+		"Slot is Disabled",
+		"Slot holds spare device",
+	},
+	SensorTypeSystemACPIPowerState: {
+		"S0 / G0 working",
+		"S1 sleeping with system h/w & processor context maintained",
+		"S2 sleeping, processor context lost",
+		"S3 sleeping, processor & h/w context lost, memory retained",
+		"S4 non-volatile sleep / suspend-to-disk",
+		"S5 / G2 soft-off",
+		"S4 / S5 soft-off, particular S4 / S5 state cannot be determined",
+		"G3 / Mechanical Off",
+		"Sleeping in an S1, S2, or S3 states",
+		"G1 sleeping",
+		"S5 entered by override",
+		"Legacy ON state",
+		"Legacy OFF state",
+		"",
+		"Unknown",
+	},
+	SensorTypeWatchdog2: {
+		"Timer expired, status only",
+		"Hard Reset",
+		"Power Down",
+		"Power Cycle",
+		"",
+		"",
+		"",
+		"",
+		"Timer interrupt",
+	},
+	SensorTypePlatformAlert: {
+		"platform generated page",
+		"platform generated LAN alert",
+		"Platform Event Trap generated",
+		"platform generated SNMP trap, OEM format",
+	},
+	SensorTypeEntityPresence: {
+		"Entity Present",
+		"Entity Absent",
+		"Entity Disabled",
+	},
+	SensorTypeLAN: {
+		"LAN Heartbeat Lost",
+		"LAN Heartbeat",
+	},
+	SensorTypeManagementSubsystemHealth: {
+		"sensor access degraded or unavailable",
+		"controller access degraded or unavailable",
+		"management controller off-line",
+		"management controller unavailable",
+		"Sensor failure",
+		"FRU failure",
+	},
+	SensorTypeBattery: {
+		"battery low (predictive failure)",
+		"battery failed",
+		"battery presence detected",
+	},
+	SensorTypeSessionAudit: {
+		"Session Activated",
+		"Session Deactivated",
+		"Invalid Username or Password",
+		"Invalid password disable",
+	},
+	SensorTypeVersionChange: {
+		"Hardware change detected with associated Entity",
+		"Firmware or software change detected with associated Entity",
+		"Hardware incompatibility detected with associated Entity",
+		"Firmware or software incompatibility detected with associated Entity",
+		"Entity is of an invalid or unsupported hardware version",
+		"Entity contains an invalid or unsupported firmware or software version",
+		"Hardware Change detected with associated Entity was successful",
+		"Software or F/W Change detected with associated Entity was successful",
+	},
+	SensorTypeFRUState: {
+		"FRU Not Installed",
+		"FRU Inactive",
+		"FRU Activation Requested",
+		"FRU Activation In Progress",
+		"FRU Active",
+		"FRU Deactivation Requested",
+		"FRU Deactivation In Progress",
+		"FRU Communication Lost",
+	},
+}
+
+// StateDescription returns the name of a state offset for a sensor with this
+// Event/Reading Type Code and the provided sensor type, e.g. "Power Supply
+// Failure detected". The sensor type is only consulted for sensor-specific
+// codes. If the offset is undefined, reserved or OEM, "Unknown" is returned.
+func (o OutputType) StateDescription(t SensorType, offset uint8) string {
+	var descriptions []string
+	if o == OutputTypeSensorSpecific {
+		descriptions = sensorSpecificStateDescriptions[t]
+	} else {
+		descriptions = genericStateDescriptions[o]
+	}
+	if int(offset) < len(descriptions) && descriptions[offset] != "" {
+		return descriptions[offset]
+	}
+	return "Unknown"
+}
@@ -0,0 +1,30 @@
+package ipmi
+
+import (
+	"testing"
+)
+
+func TestOutputTypeStateDescription(t *testing.T) {
+	tests := []struct {
+		outputType OutputType
+		sensorType SensorType
+		offset     uint8
+		want       string
+	}{
+		{OutputTypePresence, SensorTypePowerSupply, 1, "Device Inserted / Device Present"},
+		{OutputTypeRedundancy, SensorTypePowerUnit, 1, "Redundancy Lost"},
+		{OutputTypeSensorSpecific, SensorTypePowerSupply, 1, "Power Supply Failure detected"},
+		{OutputTypeSensorSpecific, SensorTypeWatchdog2, 8, "Timer interrupt"},
+		{OutputTypeSensorSpecific, SensorTypeWatchdog2, 4, "Unknown"}, // reserved
+		{OutputTypeSensorSpecific, SensorTypeTemperature, 0, "Unknown"},
+		{OutputTypeState, SensorTypeTemperature, 2, "Unknown"},
+		{OutputType(0x70), SensorTypePowerSupply, 0, "Unknown"},
+	}
+	for _, test := range tests {
+		got := test.outputType.StateDescription(test.sensorType, test.offset)
+		if got != test.want {
+			t.Errorf("%v.StateDescription(%v, %v) = %q, want %q",
+				test.outputType, test.sensorType, test.offset, got, test.want)
+		}
+	}
+}
@@ -27,25 +27,87 @@ const (
 	SensorTypeOtherUnitsBasedSensor
 	SensorTypeMemory
 	SensorTypeDriveBay
+	SensorTypePOSTMemoryResize
+	SensorTypeSystemFirmwareProgress
+	SensorTypeEventLoggingDisabled
+	SensorTypeWatchdog1
+	SensorTypeSystemEvent
+	SensorTypeCriticalInterrupt
+	SensorTypeButtonSwitch
+	SensorTypeModuleBoard
+	SensorTypeMicrocontrollerCoprocessor
+	SensorTypeAddInCard
+	SensorTypeChassis
+	SensorTypeChipSet
+	SensorTypeOtherFRU
+	SensorTypeCableInterconnect
+	SensorTypeTerminator
+	SensorTypeSystemBootRestartInitiated
+	SensorTypeBootError
+	SensorTypeBaseOSBootInstallationStatus
+	SensorTypeOSStopShutdown
+	SensorTypeSlotConnector
+	SensorTypeSystemACPIPowerState
+	SensorTypeWatchdog2
+	SensorTypePlatformAlert
+	SensorTypeEntityPresence
+	SensorTypeMonitorASICIC
+	SensorTypeLAN
+	SensorTypeManagementSubsystemHealth
+	SensorTypeBattery
+	SensorTypeSessionAudit
+	SensorTypeVersionChange
+	SensorTypeFRUState
 
-	// non-exhaustive
+	// 0xc0-0xff are OEM reserved
 )
 
 var (
 	sensorTypeDescriptions = map[SensorType]string{
-		SensorTypeTemperature:           "Temperature",
-		SensorTypeVoltage:               "Voltage",
-		SensorTypeCurrent:               "Current",
-		SensorTypeFan:                   "Fan",
-		SensorTypePhysicalSecurity:      "Physical Security",
-		SensorTypePlatformSecurity:      "Platform Security",
-		SensorTypeProcessor:             "Processor",
-		SensorTypePowerSupply:           "Power Supply",
-		SensorTypePowerUnit:             "Power Unit",
-		SensorTypeCoolingDevice:         "Cooling Device",
-		SensorTypeOtherUnitsBasedSensor: "Other Units-based Sensor",
-		SensorTypeMemory:                "Memory",
-		SensorTypeDriveBay:              "Drive Bay",
+		SensorTypeTemperature:                  "Temperature",
+		SensorTypeVoltage:                      "Voltage",
+		SensorTypeCurrent:                      "Current",
+		SensorTypeFan:                          "Fan",
+		SensorTypePhysicalSecurity:             "Physical Security",
+		SensorTypePlatformSecurity:             "Platform Security",
+		SensorTypeProcessor:                    "Processor",
+		SensorTypePowerSupply:                  "Power Supply",
+		SensorTypePowerUnit:                    "Power Unit",
+		SensorTypeCoolingDevice:                "Cooling Device",
+		SensorTypeOtherUnitsBasedSensor:        "Other Units-based Sensor",
+		SensorTypeMemory:                       "Memory",
+		SensorTypeDriveBay:                     "Drive Bay",
+		SensorTypePOSTMemoryResize:             "POST Memory Resize",
+		SensorTypeSystemFirmwareProgress:       "System Firmware Progress",
+		SensorTypeEventLoggingDisabled:         "Event Logging Disabled",
+		SensorTypeWatchdog1:                    "Watchdog 1",
+		SensorTypeSystemEvent:                  "System Event",
+		SensorTypeCriticalInterrupt:            "Critical Interrupt",
+		SensorTypeButtonSwitch:                 "Button / Switch",
+		SensorTypeModuleBoard:                  "Module / Board",
+		SensorTypeMicrocontrollerCoprocessor:   "Microcontroller / Coprocessor",
+		SensorTypeAddInCard:                    "Add-in Card",
+		SensorTypeChassis:                      "Chassis",
+		SensorTypeChipSet:                      "Chip Set",
+		SensorTypeOtherFRU:                     "Other FRU",
+		SensorTypeCableInterconnect:            "Cable / Interconnect",
+		SensorTypeTerminator:                   "Terminator",
+		SensorTypeSystemBootRestartInitiated:   "System Boot / Restart Initiated",
+		SensorTypeBootError:                    "Boot Error",
+		SensorTypeBaseOSBootInstallationStatus: "Base OS Boot / Installation Status",
+		SensorTypeOSStopShutdown:               "OS Stop / Shutdown",
+		SensorTypeSlotConnector:                "Slot / Connector",
+		SensorTypeSystemACPIPowerState:         "System ACPI Power State",
+		SensorTypeWatchdog2:                    "Watchdog 2",
+		SensorTypePlatformAlert:                "Platform Alert",
+		SensorTypeEntityPresence:               "Entity Presence",
+		SensorTypeMonitorASICIC:                "Monitor ASIC / IC",
+		SensorTypeLAN:                          "LAN",
+		SensorTypeManagementSubsystemHealth:    "Management Subsystem Health",
+		SensorTypeBattery:                      "Battery",
+		SensorTypeSessionAudit:                 "Session Audit",
+		SensorTypeVersionChange:                "Version Change",
+		SensorTypeFRUState:                     "FRU State",
 	}
 )
 
@@ -53,6 +115,9 @@ func (t SensorType) Description() string {
 	if desc, ok := sensorTypeDescriptions[t]; ok {
 		return desc
 	}
+	if t >= 0xc0 {
+		return "OEM"
+	}
 	return "Unknown"
 }
 
@@ -0,0 +1,68 @@
+package ipmi
+
+import (
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// SetACPIPowerStateReq implements the Set ACPI Power State command, specified
+// in 17.6 and 20.6 of IPMI v1.5 and v2.0 respectively. It records the power
+// state of the system and device; it does not change it. It is typically
+// sent by system software on power state transitions.
+type SetACPIPowerStateReq struct {
+	layers.BaseLayer
+
+	// SystemState is the new system power state, or SystemPowerStateNoChange
+	// to leave it as-is.
+	SystemState SystemPowerState
+
+	// DeviceState is the new device power state, or DevicePowerStateNoChange
+	// to leave it as-is.
+	DeviceState DevicePowerState
+}
+
+func (*SetACPIPowerStateReq) LayerType() gopacket.LayerType {
+	return LayerTypeSetACPIPowerStateReq
+}
+
+func (r *SetACPIPowerStateReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(2)
+	if err != nil {
+		return err
+	}
+	bytes[0] = uint8(r.SystemState) & 0x7f
+	if r.SystemState != SystemPowerStateNoChange {
+		bytes[0] |= 1 << 7
+	}
+	bytes[1] = uint8(r.DeviceState) & 0x7f
+	if r.DeviceState != DevicePowerStateNoChange {
+		bytes[1] |= 1 << 7
+	}
+	return nil
+}
+
+type SetACPIPowerStateCmd struct {
+	Req SetACPIPowerStateReq
+}
+
+// Name returns "Set ACPI Power State".
+func (*SetACPIPowerStateCmd) Name() string {
+	return "Set ACPI Power State"
+}
+
+// Operation returns &OperationSetACPIPowerStateReq.
+func (*SetACPIPowerStateCmd) Operation() *Operation {
+	return &OperationSetACPIPowerStateReq
+}
+
+func (*SetACPIPowerStateCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (c *SetACPIPowerStateCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (*SetACPIPowerStateCmd) Response() gopacket.DecodingLayer {
+	return nil
+}
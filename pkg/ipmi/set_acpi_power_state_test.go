@@ -0,0 +1,47 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/gopacket"
+)
+
+func TestSetACPIPowerStateReqSerializeTo(t *testing.T) {
+	tests := []struct {
+		layer *SetACPIPowerStateReq
+		want  []byte
+	}{
+		{
+			&SetACPIPowerStateReq{
+				SystemState: SystemPowerStateNoChange,
+				DeviceState: DevicePowerStateNoChange,
+			},
+			[]byte{0x7f, 0x7f},
+		},
+		{
+			&SetACPIPowerStateReq{
+				SystemState: SystemPowerStateS0,
+				DeviceState: DevicePowerStateNoChange,
+			},
+			[]byte{0x80, 0x7f},
+		},
+		{
+			&SetACPIPowerStateReq{
+				SystemState: SystemPowerStateS5,
+				DeviceState: DevicePowerStateD3,
+			},
+			[]byte{0x85, 0x83},
+		},
+	}
+	for _, test := range tests {
+		sb := gopacket.NewSerializeBuffer()
+		if err := test.layer.SerializeTo(sb, gopacket.SerializeOptions{}); err != nil {
+			t.Errorf("serialize %+v failed with %v, wanted %v", test.layer, err, test.want)
+			continue
+		}
+		if got := sb.Bytes(); !bytes.Equal(got, test.want) {
+			t.Errorf("serialize %+v = %v, want %v", test.layer, got, test.want)
+		}
+	}
+}
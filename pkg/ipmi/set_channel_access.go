@@ -0,0 +1,82 @@
+package ipmi
+
+import (
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// SetChannelAccessReq represents a Set Channel Access command, specified in
+// 18.22 and 22.22 of IPMI v1.5 and v2.0 respectively. The BMC responds with
+// completion code 0x82 if the channel does not support access settings, and
+// 0x83 if it does not support the requested access mode.
+type SetChannelAccessReq struct {
+	layers.BaseLayer
+
+	// Channel is the channel whose access settings to modify.
+	// ChannelPresentInterface refers to the channel the request is sent over.
+	Channel Channel
+
+	// AccessType selects whether the alerting, authentication and mode
+	// settings are written to the non-volatile or volatile settings, or left
+	// unchanged.
+	AccessType ChannelAccessType
+
+	// PrivilegeLimitType selects whether the privilege limit is written to
+	// the non-volatile or volatile settings, or left unchanged.
+	PrivilegeLimitType ChannelAccessType
+
+	// ChannelAccess contains the new settings. Fields are ignored if their
+	// corresponding type is ChannelAccessTypeUnchanged.
+	ChannelAccess
+}
+
+func (*SetChannelAccessReq) LayerType() gopacket.LayerType {
+	return LayerTypeSetChannelAccessReq
+}
+
+func (r *SetChannelAccessReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(3)
+	if err != nil {
+		return err
+	}
+	bytes[0] = uint8(r.Channel) & 0xf
+	bytes[1] = (uint8(r.AccessType)&0x3)<<6 | uint8(r.Mode)&0x7
+	if r.PEFAlertingDisabled {
+		bytes[1] |= 1 << 5
+	}
+	if r.PerMessageAuthenticationDisabled {
+		bytes[1] |= 1 << 4
+	}
+	if r.UserLevelAuthenticationDisabled {
+		bytes[1] |= 1 << 3
+	}
+	bytes[2] = (uint8(r.PrivilegeLimitType)&0x3)<<6 |
+		uint8(r.PrivilegeLimit)&0xf
+	return nil
+}
+
+type SetChannelAccessCmd struct {
+	Req SetChannelAccessReq
+}
+
+// Name returns "Set Channel Access".
+func (*SetChannelAccessCmd) Name() string {
+	return "Set Channel Access"
+}
+
+// Operation returns &OperationSetChannelAccessReq.
+func (*SetChannelAccessCmd) Operation() *Operation {
+	return &OperationSetChannelAccessReq
+}
+
+func (*SetChannelAccessCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (c *SetChannelAccessCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (*SetChannelAccessCmd) Response() gopacket.DecodingLayer {
+	return nil
+}
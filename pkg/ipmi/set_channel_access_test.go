@@ -0,0 +1,46 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/gopacket"
+)
+
+func TestSetChannelAccessReqSerializeTo(t *testing.T) {
+	tests := []struct {
+		layer *SetChannelAccessReq
+		want  []byte
+	}{
+		{
+			&SetChannelAccessReq{
+				Channel: 1,
+			},
+			[]byte{0x01, 0x00, 0x00},
+		},
+		{
+			&SetChannelAccessReq{
+				Channel:            2,
+				AccessType:         ChannelAccessTypeNonVolatile,
+				PrivilegeLimitType: ChannelAccessTypeVolatile,
+				ChannelAccess: ChannelAccess{
+					PEFAlertingDisabled:              true,
+					PerMessageAuthenticationDisabled: true,
+					Mode:                             ChannelAccessModeAlwaysAvailable,
+					PrivilegeLimit:                   PrivilegeLevelAdministrator,
+				},
+			},
+			[]byte{0x02, 0x72, 0x84},
+		},
+	}
+	for _, test := range tests {
+		sb := gopacket.NewSerializeBuffer()
+		if err := test.layer.SerializeTo(sb, gopacket.SerializeOptions{}); err != nil {
+			t.Errorf("serialize %+v failed with %v, wanted %v", test.layer, err, test.want)
+			continue
+		}
+		if got := sb.Bytes(); !bytes.Equal(got, test.want) {
+			t.Errorf("serialize %+v = %v, want %v", test.layer, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package ipmi
+
+import (
+	"fmt"
+)
+
+// SetInProgress is the value of the Set In Progress parameter, which is
+// parameter 0 of several configuration parameter commands, including System
+// Boot Options and SOL Configuration Parameters. It allows a remote console to
+// indicate it is in the middle of updating several parameters, so other
+// parties do not act on a partial configuration. This is a 2-bit uint on the
+// wire.
+type SetInProgress uint8
+
+const (
+	// SetInProgressComplete indicates no update is in progress. Setting this
+	// value ends an update; if the BMC supports rollback, any changes not
+	// committed are discarded.
+	SetInProgressComplete SetInProgress = iota
+
+	// SetInProgressInProgress indicates a party is updating parameters. The
+	// BMC rejects this value if another update is already in progress.
+	SetInProgressInProgress
+
+	// SetInProgressCommitWrite asks the BMC to commit parameters written since
+	// the update began. This is optional.
+	SetInProgressCommitWrite
+)
+
+func (s SetInProgress) Description() string {
+	switch s {
+	case SetInProgressComplete:
+		return "Set complete"
+	case SetInProgressInProgress:
+		return "Set in progress"
+	case SetInProgressCommitWrite:
+		return "Commit write"
+	default:
+		return "Unknown"
+	}
+}
+
+func (s SetInProgress) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(s), s.Description())
+}
@@ -0,0 +1,67 @@
+package ipmi
+
+import (
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// SetLANConfigurationParametersReq implements the Set LAN Configuration
+// Parameters command, specified in section 23.1 of IPMI v2.0. The BMC
+// responds with completion code 0x80 if the parameter is not supported, 0x81
+// if another party is updating parameters, and 0x82 if the parameter is
+// read-only.
+type SetLANConfigurationParametersReq struct {
+	layers.BaseLayer
+
+	// Channel is the channel whose LAN configuration to modify.
+	// ChannelPresentInterface can be used to refer to the channel the request
+	// is sent over.
+	Channel Channel
+
+	// Parameter is the parameter to set.
+	Parameter LANConfigurationParameter
+
+	// Data is the new parameter data, whose format depends on the parameter.
+	Data []byte
+}
+
+func (*SetLANConfigurationParametersReq) LayerType() gopacket.LayerType {
+	return LayerTypeSetLANConfigurationParametersReq
+}
+
+func (s *SetLANConfigurationParametersReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(2 + len(s.Data))
+	if err != nil {
+		return err
+	}
+	bytes[0] = uint8(s.Channel) & 0xf
+	bytes[1] = uint8(s.Parameter)
+	copy(bytes[2:], s.Data)
+	return nil
+}
+
+type SetLANConfigurationParametersCmd struct {
+	Req SetLANConfigurationParametersReq
+}
+
+// Name returns "Set LAN Configuration Parameters".
+func (*SetLANConfigurationParametersCmd) Name() string {
+	return "Set LAN Configuration Parameters"
+}
+
+// Operation returns OperationSetLANConfigurationParametersReq.
+func (*SetLANConfigurationParametersCmd) Operation() *Operation {
+	return &OperationSetLANConfigurationParametersReq
+}
+
+func (c *SetLANConfigurationParametersCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (c *SetLANConfigurationParametersCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *SetLANConfigurationParametersCmd) Response() gopacket.DecodingLayer {
+	return nil
+}
@@ -0,0 +1,62 @@
+package ipmi
+
+import (
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// SetPEFConfigurationParametersReq implements the Set PEF Configuration
+// Parameters command, specified in section 30.3 of IPMI v2.0. The BMC
+// responds with completion code 0x80 if the parameter is not supported, 0x81
+// if another party is updating parameters, and 0x82 if the parameter is
+// read-only.
+type SetPEFConfigurationParametersReq struct {
+	layers.BaseLayer
+
+	// Parameter is the parameter to set. This is a 7-bit uint on the wire.
+	Parameter PEFConfigurationParameter
+
+	// Data is the new parameter data, whose format depends on the parameter.
+	// For tables, this begins with the set selector.
+	Data []byte
+}
+
+func (*SetPEFConfigurationParametersReq) LayerType() gopacket.LayerType {
+	return LayerTypeSetPEFConfigurationParametersReq
+}
+
+func (s *SetPEFConfigurationParametersReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(1 + len(s.Data))
+	if err != nil {
+		return err
+	}
+	bytes[0] = uint8(s.Parameter) & 0x7f
+	copy(bytes[1:], s.Data)
+	return nil
+}
+
+type SetPEFConfigurationParametersCmd struct {
+	Req SetPEFConfigurationParametersReq
+}
+
+// Name returns "Set PEF Configuration Parameters".
+func (*SetPEFConfigurationParametersCmd) Name() string {
+	return "Set PEF Configuration Parameters"
+}
+
+// Operation returns OperationSetPEFConfigurationParametersReq.
+func (*SetPEFConfigurationParametersCmd) Operation() *Operation {
+	return &OperationSetPEFConfigurationParametersReq
+}
+
+func (c *SetPEFConfigurationParametersCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (c *SetPEFConfigurationParametersCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *SetPEFConfigurationParametersCmd) Response() gopacket.DecodingLayer {
+	return nil
+}
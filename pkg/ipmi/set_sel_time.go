@@ -0,0 +1,62 @@
+package ipmi
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// SetSELTimeReq implements the Set SEL Time command, specified in 25.11 and
+// 31.11 of IPMI v1.5 and v2.0 respectively. It sets the SEL Device's clock,
+// which is used to timestamp subsequent SEL entries. Many BMCs synchronise
+// their clock from the host or NTP, so may overwrite the value. The response
+// has no data.
+type SetSELTimeReq struct {
+	layers.BaseLayer
+
+	// Time is the new value of the clock. This is truncated to the second,
+	// and must be between 2001 and 2106 to be representable as an absolute
+	// IPMI timestamp.
+	Time time.Time
+}
+
+func (*SetSELTimeReq) LayerType() gopacket.LayerType {
+	return LayerTypeSetSELTimeReq
+}
+
+func (r *SetSELTimeReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(4)
+	if err != nil {
+		return err
+	}
+	binary.LittleEndian.PutUint32(bytes, uint32(r.Time.Unix()))
+	return nil
+}
+
+type SetSELTimeCmd struct {
+	Req SetSELTimeReq
+}
+
+// Name returns "Set SEL Time".
+func (*SetSELTimeCmd) Name() string {
+	return "Set SEL Time"
+}
+
+// Operation returns &OperationSetSELTimeReq.
+func (*SetSELTimeCmd) Operation() *Operation {
+	return &OperationSetSELTimeReq
+}
+
+func (*SetSELTimeCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (c *SetSELTimeCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (*SetSELTimeCmd) Response() gopacket.DecodingLayer {
+	return nil
+}
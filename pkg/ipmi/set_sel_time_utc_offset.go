@@ -0,0 +1,58 @@
+package ipmi
+
+import (
+	"encoding/binary"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// SetSELTimeUTCOffsetReq implements the Set SEL Time UTC Offset command,
+// specified in 31.11b of IPMI v2.0. It does not change the SEL Device's
+// clock, which remains in UTC. The response has no data.
+type SetSELTimeUTCOffsetReq struct {
+	layers.BaseLayer
+
+	// Offset is the BMC's new local time offset from UTC, or
+	// UTCOffsetUnspecified to clear it.
+	Offset UTCOffset
+}
+
+func (*SetSELTimeUTCOffsetReq) LayerType() gopacket.LayerType {
+	return LayerTypeSetSELTimeUTCOffsetReq
+}
+
+func (r *SetSELTimeUTCOffsetReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(2)
+	if err != nil {
+		return err
+	}
+	binary.LittleEndian.PutUint16(bytes, uint16(r.Offset))
+	return nil
+}
+
+type SetSELTimeUTCOffsetCmd struct {
+	Req SetSELTimeUTCOffsetReq
+}
+
+// Name returns "Set SEL Time UTC Offset".
+func (*SetSELTimeUTCOffsetCmd) Name() string {
+	return "Set SEL Time UTC Offset"
+}
+
+// Operation returns &OperationSetSELTimeUTCOffsetReq.
+func (*SetSELTimeUTCOffsetCmd) Operation() *Operation {
+	return &OperationSetSELTimeUTCOffsetReq
+}
+
+func (*SetSELTimeUTCOffsetCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (c *SetSELTimeUTCOffsetCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (*SetSELTimeUTCOffsetCmd) Response() gopacket.DecodingLayer {
+	return nil
+}
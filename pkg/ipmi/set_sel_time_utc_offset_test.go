@@ -0,0 +1,45 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+)
+
+func TestSetSELTimeUTCOffsetReqSerializeTo(t *testing.T) {
+	tests := []struct {
+		layer *SetSELTimeUTCOffsetReq
+		want  []byte
+	}{
+		{
+			&SetSELTimeUTCOffsetReq{
+				Offset: UTCOffsetUnspecified,
+			},
+			[]byte{0xff, 0x07},
+		},
+		{
+			&SetSELTimeUTCOffsetReq{
+				Offset: NewUTCOffset(time.Hour),
+			},
+			[]byte{0x3c, 0x00},
+		},
+		{
+			&SetSELTimeUTCOffsetReq{
+				Offset: NewUTCOffset(-time.Hour*5 - time.Minute*30),
+			},
+			[]byte{0xb6, 0xfe},
+		},
+	}
+	for _, test := range tests {
+		sb := gopacket.NewSerializeBuffer()
+		if err := test.layer.SerializeTo(sb, gopacket.SerializeOptions{}); err != nil {
+			t.Errorf("serialize %+v failed with %v, wanted %v", test.layer, err, test.want)
+			continue
+		}
+		if got := sb.Bytes(); !bytes.Equal(got, test.want) {
+			t.Errorf("serialize %+v = %v, want %v", test.layer, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package ipmi
+
+import (
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// SetSensorHysteresisReq represents a Set Sensor Hysteresis command, specified
+// in 29.6 and 35.6 of v1.5 and v2.0 respectively.
+type SetSensorHysteresisReq struct {
+	layers.BaseLayer
+
+	// Number is the number of the sensor whose hysteresis to set.
+	Number uint8
+
+	// Positive is the raw positive-going threshold hysteresis.
+	Positive uint8
+
+	// Negative is the raw negative-going threshold hysteresis.
+	Negative uint8
+}
+
+func (*SetSensorHysteresisReq) LayerType() gopacket.LayerType {
+	return LayerTypeSetSensorHysteresisReq
+}
+
+func (r *SetSensorHysteresisReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(4)
+	if err != nil {
+		return err
+	}
+	bytes[0] = r.Number
+	bytes[1] = 0xff // reserved for future "hysteresis mask" definition
+	bytes[2] = r.Positive
+	bytes[3] = r.Negative
+	return nil
+}
+
+type SetSensorHysteresisCmd struct {
+	Req SetSensorHysteresisReq
+
+	// OwnerLUN is the remote LUN of the sensor, which we learn from the SDR.
+	OwnerLUN LUN
+}
+
+// Name returns "Set Sensor Hysteresis".
+func (*SetSensorHysteresisCmd) Name() string {
+	return "Set Sensor Hysteresis"
+}
+
+// Operation returns &OperationSetSensorHysteresisReq.
+func (*SetSensorHysteresisCmd) Operation() *Operation {
+	return &OperationSetSensorHysteresisReq
+}
+
+func (c *SetSensorHysteresisCmd) RemoteLUN() LUN {
+	return c.OwnerLUN
+}
+
+func (c *SetSensorHysteresisCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (*SetSensorHysteresisCmd) Response() gopacket.DecodingLayer {
+	return nil
+}
@@ -0,0 +1,73 @@
+package ipmi
+
+import (
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// SetSensorThresholdsReq represents a Set Sensor Thresholds command, specified
+// in 29.8 and 35.8 of v1.5 and v2.0 respectively. Only thresholds in the
+// sensor's SettableThresholds mask can be changed.
+type SetSensorThresholdsReq struct {
+	layers.BaseLayer
+
+	// Number is the number of the sensor whose thresholds to set.
+	Number uint8
+
+	// Set indicates which of the values in Thresholds to apply. Other values
+	// are ignored by the BMC.
+	Set ThresholdMask
+
+	// Thresholds contains the raw threshold values, in the sensor's analog
+	// data format.
+	Thresholds Thresholds
+}
+
+func (*SetSensorThresholdsReq) LayerType() gopacket.LayerType {
+	return LayerTypeSetSensorThresholdsReq
+}
+
+func (r *SetSensorThresholdsReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(8)
+	if err != nil {
+		return err
+	}
+	bytes[0] = r.Number
+	bytes[1] = uint8(r.Set & ThresholdMaskAll)
+	bytes[2] = r.Thresholds.LowerNonCritical
+	bytes[3] = r.Thresholds.LowerCritical
+	bytes[4] = r.Thresholds.LowerNonRecoverable
+	bytes[5] = r.Thresholds.UpperNonCritical
+	bytes[6] = r.Thresholds.UpperCritical
+	bytes[7] = r.Thresholds.UpperNonRecoverable
+	return nil
+}
+
+type SetSensorThresholdsCmd struct {
+	Req SetSensorThresholdsReq
+
+	// OwnerLUN is the remote LUN of the sensor, which we learn from the SDR.
+	OwnerLUN LUN
+}
+
+// Name returns "Set Sensor Thresholds".
+func (*SetSensorThresholdsCmd) Name() string {
+	return "Set Sensor Thresholds"
+}
+
+// Operation returns &OperationSetSensorThresholdsReq.
+func (*SetSensorThresholdsCmd) Operation() *Operation {
+	return &OperationSetSensorThresholdsReq
+}
+
+func (c *SetSensorThresholdsCmd) RemoteLUN() LUN {
+	return c.OwnerLUN
+}
+
+func (c *SetSensorThresholdsCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (*SetSensorThresholdsCmd) Response() gopacket.DecodingLayer {
+	return nil
+}
@@ -0,0 +1,27 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/gopacket"
+)
+
+func TestSetSensorThresholdsReqSerializeTo(t *testing.T) {
+	layer := &SetSensorThresholdsReq{
+		Number: 0x31,
+		Set:    ThresholdMaskUpperCritical | ThresholdMaskLowerCritical,
+		Thresholds: Thresholds{
+			LowerCritical: 0x05,
+			UpperCritical: 0x5a,
+		},
+	}
+	want := []byte{0x31, 0x12, 0x00, 0x05, 0x00, 0x00, 0x5a, 0x00}
+	sb := gopacket.NewSerializeBuffer()
+	if err := layer.SerializeTo(sb, gopacket.SerializeOptions{}); err != nil {
+		t.Fatalf("serialize %+v failed with %v", layer, err)
+	}
+	if got := sb.Bytes(); !bytes.Equal(got, want) {
+		t.Errorf("serialize %+v = %v, want %v", layer, got, want)
+	}
+}
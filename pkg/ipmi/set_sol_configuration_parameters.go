@@ -0,0 +1,67 @@
+package ipmi
+
+import (
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// SetSOLConfigurationParametersReq implements the Set SOL Configuration
+// Parameters command, specified in section 26.2 of IPMI v2.0. The BMC
+// responds with completion code 0x80 if the parameter is not supported, 0x81
+// if another party is updating parameters, and 0x82 if the parameter is
+// read-only.
+type SetSOLConfigurationParametersReq struct {
+	layers.BaseLayer
+
+	// Channel is the channel whose SOL configuration to modify.
+	// ChannelPresentInterface can be used to refer to the channel the request
+	// is sent over.
+	Channel Channel
+
+	// Parameter is the parameter to set.
+	Parameter SOLConfigurationParameter
+
+	// Data is the new parameter data, whose format depends on the parameter.
+	Data []byte
+}
+
+func (*SetSOLConfigurationParametersReq) LayerType() gopacket.LayerType {
+	return LayerTypeSetSOLConfigurationParametersReq
+}
+
+func (s *SetSOLConfigurationParametersReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(2 + len(s.Data))
+	if err != nil {
+		return err
+	}
+	bytes[0] = uint8(s.Channel) & 0xf
+	bytes[1] = uint8(s.Parameter)
+	copy(bytes[2:], s.Data)
+	return nil
+}
+
+type SetSOLConfigurationParametersCmd struct {
+	Req SetSOLConfigurationParametersReq
+}
+
+// Name returns "Set SOL Configuration Parameters".
+func (*SetSOLConfigurationParametersCmd) Name() string {
+	return "Set SOL Configuration Parameters"
+}
+
+// Operation returns OperationSetSOLConfigurationParametersReq.
+func (*SetSOLConfigurationParametersCmd) Operation() *Operation {
+	return &OperationSetSOLConfigurationParametersReq
+}
+
+func (c *SetSOLConfigurationParametersCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (c *SetSOLConfigurationParametersCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *SetSOLConfigurationParametersCmd) Response() gopacket.DecodingLayer {
+	return nil
+}
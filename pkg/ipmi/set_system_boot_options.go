@@ -0,0 +1,66 @@
+package ipmi
+
+import (
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// SetSystemBootOptionsReq implements the Set System Boot Options command,
+// specified in section 28.12 of IPMI v2.0. The BMC responds with completion
+// code 0x80 if the parameter is not supported, 0x81 if another party is
+// updating parameters, and 0x82 if the parameter is read-only.
+type SetSystemBootOptionsReq struct {
+	layers.BaseLayer
+
+	// Invalid marks the parameter invalid or locked, so it is not acted upon.
+	Invalid bool
+
+	// Parameter is the boot option to set.
+	Parameter BootOptionParameter
+
+	// Data is the new parameter data, whose format depends on the parameter.
+	Data []byte
+}
+
+func (*SetSystemBootOptionsReq) LayerType() gopacket.LayerType {
+	return LayerTypeSetSystemBootOptionsReq
+}
+
+func (s *SetSystemBootOptionsReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(1 + len(s.Data))
+	if err != nil {
+		return err
+	}
+	bytes[0] = uint8(s.Parameter) & 0x7f
+	if s.Invalid {
+		bytes[0] |= 1 << 7
+	}
+	copy(bytes[1:], s.Data)
+	return nil
+}
+
+type SetSystemBootOptionsCmd struct {
+	Req SetSystemBootOptionsReq
+}
+
+// Name returns "Set System Boot Options".
+func (*SetSystemBootOptionsCmd) Name() string {
+	return "Set System Boot Options"
+}
+
+// Operation returns OperationSetSystemBootOptionsReq.
+func (*SetSystemBootOptionsCmd) Operation() *Operation {
+	return &OperationSetSystemBootOptionsReq
+}
+
+func (c *SetSystemBootOptionsCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (c *SetSystemBootOptionsCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *SetSystemBootOptionsCmd) Response() gopacket.DecodingLayer {
+	return nil
+}
@@ -0,0 +1,63 @@
+package ipmi
+
+import (
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// SetSystemInfoParametersReq implements the Set System Info Parameters
+// command, specified in section 22.14a of IPMI v2.0. It is typically sent by
+// the host OS to publish its name and version to the BMC. The BMC responds
+// with completion code 0x80 if the parameter is not supported, 0x81 if
+// another party is updating parameters, and 0x82 if the parameter is
+// read-only.
+type SetSystemInfoParametersReq struct {
+	layers.BaseLayer
+
+	// Parameter is the parameter to set.
+	Parameter SystemInfoParameter
+
+	// Data is the new parameter data, whose format depends on the parameter.
+	// For strings, this begins with the set selector of the block.
+	Data []byte
+}
+
+func (*SetSystemInfoParametersReq) LayerType() gopacket.LayerType {
+	return LayerTypeSetSystemInfoParametersReq
+}
+
+func (s *SetSystemInfoParametersReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(1 + len(s.Data))
+	if err != nil {
+		return err
+	}
+	bytes[0] = uint8(s.Parameter)
+	copy(bytes[1:], s.Data)
+	return nil
+}
+
+type SetSystemInfoParametersCmd struct {
+	Req SetSystemInfoParametersReq
+}
+
+// Name returns "Set System Info Parameters".
+func (*SetSystemInfoParametersCmd) Name() string {
+	return "Set System Info Parameters"
+}
+
+// Operation returns OperationSetSystemInfoParametersReq.
+func (*SetSystemInfoParametersCmd) Operation() *Operation {
+	return &OperationSetSystemInfoParametersReq
+}
+
+func (c *SetSystemInfoParametersCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (c *SetSystemInfoParametersCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (c *SetSystemInfoParametersCmd) Response() gopacket.DecodingLayer {
+	return nil
+}
@@ -0,0 +1,90 @@
+package ipmi
+
+import (
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// SetUserAccessReq represents a Set User Access command, specified in 18.26
+// and 22.26 of IPMI v1.5 and v2.0 respectively. It sets a user's privilege
+// limit on a channel, and optionally the other access flags.
+type SetUserAccessReq struct {
+	layers.BaseLayer
+	UserAccess
+
+	// SetFlags indicates whether to change the CallbackOnly,
+	// LinkAuthentication and IPMIMessaging flags. If false, only the
+	// privilege limit and session limit are changed.
+	SetFlags bool
+
+	// Channel is the channel to set the user's access for.
+	Channel Channel
+
+	// UserID identifies the user. This is a 6-bit uint on the wire.
+	UserID uint8
+
+	// SessionLimit is the maximum number of simultaneous sessions the user
+	// can have on the channel. 0 means it is only limited by the channel, and
+	// this optional field is omitted. This is a 4-bit uint on the wire.
+	SessionLimit uint8
+}
+
+func (*SetUserAccessReq) LayerType() gopacket.LayerType {
+	return LayerTypeSetUserAccessReq
+}
+
+func (r *SetUserAccessReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	length := 3
+	if r.SessionLimit != 0 {
+		length++
+	}
+	bytes, err := b.PrependBytes(length)
+	if err != nil {
+		return err
+	}
+	bytes[0] = uint8(r.Channel) & 0xf
+	if r.SetFlags {
+		bytes[0] |= 1 << 7
+		if r.CallbackOnly {
+			bytes[0] |= 1 << 6
+		}
+		if r.LinkAuthentication {
+			bytes[0] |= 1 << 5
+		}
+		if r.IPMIMessaging {
+			bytes[0] |= 1 << 4
+		}
+	}
+	bytes[1] = r.UserID & 0x3f
+	bytes[2] = uint8(r.PrivilegeLimit) & 0xf
+	if r.SessionLimit != 0 {
+		bytes[3] = r.SessionLimit & 0xf
+	}
+	return nil
+}
+
+type SetUserAccessCmd struct {
+	Req SetUserAccessReq
+}
+
+// Name returns "Set User Access".
+func (*SetUserAccessCmd) Name() string {
+	return "Set User Access"
+}
+
+// Operation returns &OperationSetUserAccessReq.
+func (*SetUserAccessCmd) Operation() *Operation {
+	return &OperationSetUserAccessReq
+}
+
+func (*SetUserAccessCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (c *SetUserAccessCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (*SetUserAccessCmd) Response() gopacket.DecodingLayer {
+	return nil
+}
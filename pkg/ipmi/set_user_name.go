@@ -0,0 +1,69 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// SetUserNameReq represents a Set User Name command, specified in 18.28 and
+// 22.28 of IPMI v1.5 and v2.0 respectively. User ID 1 is typically the
+// anonymous user, whose name cannot be changed.
+type SetUserNameReq struct {
+	layers.BaseLayer
+
+	// UserID identifies the user. This is a 6-bit uint on the wire.
+	UserID uint8
+
+	// Name is the new name of the user. It must be at most 16 ASCII
+	// characters.
+	Name string
+}
+
+func (*SetUserNameReq) LayerType() gopacket.LayerType {
+	return LayerTypeSetUserNameReq
+}
+
+func (r *SetUserNameReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	if len(r.Name) > userNameLength {
+		return fmt.Errorf("user names can be at most %v bytes, got %v",
+			userNameLength, len(r.Name))
+	}
+	bytes, err := b.PrependBytes(1 + userNameLength)
+	if err != nil {
+		return err
+	}
+	bytes[0] = r.UserID & 0x3f
+	n := copy(bytes[1:], r.Name)
+	for i := 1 + n; i < len(bytes); i++ {
+		bytes[i] = 0
+	}
+	return nil
+}
+
+type SetUserNameCmd struct {
+	Req SetUserNameReq
+}
+
+// Name returns "Set User Name".
+func (*SetUserNameCmd) Name() string {
+	return "Set User Name"
+}
+
+// Operation returns &OperationSetUserNameReq.
+func (*SetUserNameCmd) Operation() *Operation {
+	return &OperationSetUserNameReq
+}
+
+func (*SetUserNameCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (c *SetUserNameCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (*SetUserNameCmd) Response() gopacket.DecodingLayer {
+	return nil
+}
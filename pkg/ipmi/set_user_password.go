@@ -0,0 +1,155 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+const (
+	// CompletionCodePasswordTestFailed is returned by Set User Password with
+	// PasswordOperationTest if the password does not match. This value is
+	// specific to the command.
+	CompletionCodePasswordTestFailed CompletionCode = 0x80
+
+	// CompletionCodePasswordTestFailedWrongSize is returned by Set User
+	// Password with PasswordOperationTest if the password matches but was
+	// stored with a different size. This value is specific to the command.
+	CompletionCodePasswordTestFailedWrongSize CompletionCode = 0x81
+)
+
+// PasswordOperation is the action taken by a Set User Password command. It is
+// a 2-bit uint on the wire.
+type PasswordOperation uint8
+
+const (
+	// PasswordOperationDisableUser disables the user ID.
+	PasswordOperationDisableUser PasswordOperation = iota
+
+	// PasswordOperationEnableUser enables the user ID.
+	PasswordOperationEnableUser
+
+	// PasswordOperationSet sets the user's password.
+	PasswordOperationSet
+
+	// PasswordOperationTest checks whether the password matches the stored
+	// password without changing it.
+	PasswordOperationTest
+)
+
+func (o PasswordOperation) Description() string {
+	switch o {
+	case PasswordOperationDisableUser:
+		return "Disable user"
+	case PasswordOperationEnableUser:
+		return "Enable user"
+	case PasswordOperationSet:
+		return "Set password"
+	case PasswordOperationTest:
+		return "Test password"
+	default:
+		return "Unknown"
+	}
+}
+
+func (o PasswordOperation) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(o), o.Description())
+}
+
+// PasswordSize is the length a password is stored as. Passwords are padded
+// with 0x00 to this length.
+type PasswordSize uint8
+
+const (
+	// PasswordSize16 stores the password as 16 bytes. This is the only size
+	// supported by IPMI v1.5, so must be used if the user needs to log in with
+	// a v1.5 session.
+	PasswordSize16 PasswordSize = 16
+
+	// PasswordSize20 stores the password as 20 bytes. It can only be used to
+	// log in over IPMI v2.0.
+	PasswordSize20 PasswordSize = 20
+)
+
+// SetUserPasswordReq represents a Set User Password command, specified in
+// 18.30 and 22.30 of IPMI v1.5 and v2.0 respectively. Despite the name, it is
+// also used to enable, disable and test users.
+type SetUserPasswordReq struct {
+	layers.BaseLayer
+
+	// UserID identifies the user. This is a 6-bit uint on the wire.
+	UserID uint8
+
+	// Operation is the action to take.
+	Operation PasswordOperation
+
+	// Size is the size of the password being set or tested. The zero value
+	// is treated as PasswordSize16.
+	Size PasswordSize
+
+	// Password is the password to set or test. It is ignored for other
+	// operations, and must not be longer than Size.
+	Password []byte
+}
+
+func (*SetUserPasswordReq) LayerType() gopacket.LayerType {
+	return LayerTypeSetUserPasswordReq
+}
+
+func (r *SetUserPasswordReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	size := PasswordSize16
+	if r.Size == PasswordSize20 {
+		size = PasswordSize20
+	}
+	length := 2
+	if r.Operation == PasswordOperationSet || r.Operation == PasswordOperationTest {
+		if len(r.Password) > int(size) {
+			return fmt.Errorf("password must be at most %v bytes, got %v",
+				size, len(r.Password))
+		}
+		length += int(size)
+	}
+	bytes, err := b.PrependBytes(length)
+	if err != nil {
+		return err
+	}
+	bytes[0] = r.UserID & 0x3f
+	if size == PasswordSize20 {
+		bytes[0] |= 1 << 7
+	}
+	bytes[1] = uint8(r.Operation) & 0x3
+	if length > 2 {
+		n := copy(bytes[2:], r.Password)
+		for i := 2 + n; i < len(bytes); i++ {
+			bytes[i] = 0
+		}
+	}
+	return nil
+}
+
+type SetUserPasswordCmd struct {
+	Req SetUserPasswordReq
+}
+
+// Name returns "Set User Password".
+func (*SetUserPasswordCmd) Name() string {
+	return "Set User Password"
+}
+
+// Operation returns &OperationSetUserPasswordReq.
+func (*SetUserPasswordCmd) Operation() *Operation {
+	return &OperationSetUserPasswordReq
+}
+
+func (*SetUserPasswordCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (c *SetUserPasswordCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (*SetUserPasswordCmd) Response() gopacket.DecodingLayer {
+	return nil
+}
@@ -0,0 +1,66 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/gopacket"
+)
+
+func TestSetUserPasswordReqSerializeTo(t *testing.T) {
+	tests := []struct {
+		layer *SetUserPasswordReq
+		want  []byte
+	}{
+		{
+			&SetUserPasswordReq{
+				UserID:    3,
+				Operation: PasswordOperationEnableUser,
+				Password:  []byte("ignored"),
+			},
+			[]byte{0x03, 0x01},
+		},
+		{
+			&SetUserPasswordReq{
+				UserID:    3,
+				Operation: PasswordOperationSet,
+				Password:  []byte("secret"),
+			},
+			[]byte{
+				0x03, 0x02,
+				's', 'e', 'c', 'r', 'e', 't', 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+			},
+		},
+		{
+			&SetUserPasswordReq{
+				UserID:    4,
+				Operation: PasswordOperationTest,
+				Size:      PasswordSize20,
+				Password:  []byte("0123456789abcdefghij"),
+			},
+			append([]byte{0x84, 0x03}, "0123456789abcdefghij"...),
+		},
+		{
+			&SetUserPasswordReq{
+				UserID:    4,
+				Operation: PasswordOperationSet,
+				Password:  []byte("0123456789abcdefg"),
+			},
+			nil,
+		},
+	}
+	for _, test := range tests {
+		sb := gopacket.NewSerializeBuffer()
+		err := test.layer.SerializeTo(sb, gopacket.SerializeOptions{})
+		got := sb.Bytes()
+
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("expected error serializing %+v, got none", test.layer)
+		case err != nil && test.want != nil:
+			t.Errorf("serialize %+v failed with %v, wanted %v", test.layer, err, test.want)
+		case err == nil && !bytes.Equal(got, test.want):
+			t.Errorf("serialize %+v = %v, want %v", test.layer, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package ipmi
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// SetWatchdogTimerReq implements the Set Watchdog Timer command, specified in
+// 21.6 and 27.6 of IPMI v1.5 and v2.0 respectively. It configures the timer,
+// which must then be started with Reset Watchdog Timer. Unless DontStop is
+// set, this stops the timer if it is running.
+type SetWatchdogTimerReq struct {
+	layers.BaseLayer
+	WatchdogTimer
+
+	// DontStop leaves the timer running if it is already running, loading the
+	// new initial countdown. It has no effect if the timer is stopped. This
+	// was introduced in IPMI v2.0.
+	DontStop bool
+
+	// ClearExpirationFlags contains the expiration flags to clear. Flags not
+	// included are left as-is.
+	ClearExpirationFlags WatchdogExpirationFlags
+}
+
+func (*SetWatchdogTimerReq) LayerType() gopacket.LayerType {
+	return LayerTypeSetWatchdogTimerReq
+}
+
+func (r *SetWatchdogTimerReq) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(6)
+	if err != nil {
+		return err
+	}
+	bytes[0] = uint8(r.Use) & 0x7
+	if r.DontLog {
+		bytes[0] |= 1 << 7
+	}
+	if r.DontStop {
+		bytes[0] |= 1 << 6
+	}
+	bytes[1] = (uint8(r.PreTimeoutInterrupt)&0x7)<<4 |
+		uint8(r.TimeoutAction)&0x7
+	bytes[2] = uint8(max(min(r.PreTimeoutInterval/time.Second, 0xff), 0))
+	bytes[3] = uint8(r.ClearExpirationFlags & WatchdogExpirationFlagsAll)
+	binary.LittleEndian.PutUint16(bytes[4:6], watchdogCountdown(r.InitialCountdown))
+	return nil
+}
+
+type SetWatchdogTimerCmd struct {
+	Req SetWatchdogTimerReq
+}
+
+// Name returns "Set Watchdog Timer".
+func (*SetWatchdogTimerCmd) Name() string {
+	return "Set Watchdog Timer"
+}
+
+// Operation returns &OperationSetWatchdogTimerReq.
+func (*SetWatchdogTimerCmd) Operation() *Operation {
+	return &OperationSetWatchdogTimerReq
+}
+
+func (*SetWatchdogTimerCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (c *SetWatchdogTimerCmd) Request() gopacket.SerializableLayer {
+	return &c.Req
+}
+
+func (*SetWatchdogTimerCmd) Response() gopacket.DecodingLayer {
+	return nil
+}
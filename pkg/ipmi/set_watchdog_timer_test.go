@@ -0,0 +1,64 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+)
+
+func TestSetWatchdogTimerReqSerializeTo(t *testing.T) {
+	tests := []struct {
+		layer *SetWatchdogTimerReq
+		want  []byte
+	}{
+		{
+			&SetWatchdogTimerReq{},
+			[]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			&SetWatchdogTimerReq{
+				WatchdogTimer: WatchdogTimer{
+					Use:                 WatchdogTimerUseSMSOS,
+					DontLog:             true,
+					TimeoutAction:       WatchdogTimeoutActionPowerCycle,
+					PreTimeoutInterrupt: WatchdogPreTimeoutInterruptNMI,
+					PreTimeoutInterval:  time.Second*10 + time.Millisecond*500,
+					InitialCountdown:    time.Minute + time.Millisecond*50,
+				},
+				DontStop:             true,
+				ClearExpirationFlags: WatchdogExpirationFlagsAll,
+			},
+			[]byte{0xc4, 0x23, 0x0a, 0x3e, 0x58, 0x02},
+		},
+		{
+			&SetWatchdogTimerReq{
+				WatchdogTimer: WatchdogTimer{
+					PreTimeoutInterval: time.Hour,
+					InitialCountdown:   time.Hour * 2,
+				},
+			},
+			[]byte{0x00, 0x00, 0xff, 0x00, 0xff, 0xff},
+		},
+		{
+			&SetWatchdogTimerReq{
+				WatchdogTimer: WatchdogTimer{
+					PreTimeoutInterval: -time.Second,
+					InitialCountdown:   -time.Second,
+				},
+			},
+			[]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		},
+	}
+	for _, test := range tests {
+		sb := gopacket.NewSerializeBuffer()
+		if err := test.layer.SerializeTo(sb, gopacket.SerializeOptions{}); err != nil {
+			t.Errorf("serialize %+v failed with %v, wanted %v", test.layer, err, test.want)
+			continue
+		}
+		if got := sb.Bytes(); !bytes.Equal(got, test.want) {
+			t.Errorf("serialize %+v = %v, want %v", test.layer, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// SOL represents a Serial over LAN payload, specified in section 15.9 of IPMI
+// v2.0. It carries serial character data in both directions, along with
+// acknowledgements of the other side's packets. The fourth byte of the header
+// has a different meaning depending on the direction: packets sent by the
+// remote console contain operations, while packets sent by the BMC contain
+// status. SerializeTo() writes the operation fields, and DecodeFromBytes()
+// reads the status fields, so this layer can be used as-is by a remote
+// console. The character data is the layer payload.
+type SOL struct {
+	layers.BaseLayer
+
+	// Sequence is the packet sequence number, from 1 through 15. A value of 0
+	// indicates the packet is an ACK/NACK only, so contains no character data.
+	// A retransmitted packet keeps its original sequence number. This is a
+	// 4-bit uint on the wire.
+	Sequence uint8
+
+	// AckSequence is the sequence number of the packet being ACKed or NACKed.
+	// A value of 0 indicates the packet is not an ACK/NACK. This is a 4-bit
+	// uint on the wire.
+	AckSequence uint8
+
+	// AcceptedCharacters is the number of characters accepted from the packet
+	// being ACKed. If this is less than the number of characters sent, the
+	// remainder must be resent in a new packet.
+	AcceptedCharacters uint8
+
+	// NACK indicates the packet identified by AckSequence was not accepted,
+	// because character transfer is unavailable. This is valid in both
+	// directions.
+	NACK bool
+
+	// RingWOR, when sent by the remote console, asks the BMC to assert the
+	// ring indicator or wake-on-ring for the baseboard serial controller.
+	RingWOR bool
+
+	// GenerateBreak, when sent by the remote console, asks the BMC to generate
+	// a break condition on the serial port. This is sent once.
+	GenerateBreak bool
+
+	// DeassertCTS, when sent by the remote console, asks the BMC to deassert
+	// CTS (clear to send) to the baseboard serial controller, pausing
+	// transmission. This state is sent in every packet.
+	DeassertCTS bool
+
+	// DeassertDCDDSR, when sent by the remote console, asks the BMC to
+	// deassert DCD (data carrier detect) and DSR (data set ready) to the
+	// baseboard serial controller. This state is sent in every packet.
+	DeassertDCDDSR bool
+
+	// FlushInbound, when sent by the remote console, asks the BMC to flush its
+	// buffer of data from the remote console to the serial controller.
+	FlushInbound bool
+
+	// FlushOutbound, when sent by the remote console, asks the BMC to flush
+	// its buffer of data from the serial controller to the remote console.
+	FlushOutbound bool
+
+	// CharacterTransferUnavailable, when sent by the BMC, indicates it cannot
+	// currently transfer characters to or from the serial controller.
+	CharacterTransferUnavailable bool
+
+	// Deactivating, when sent by the BMC, indicates it is deactivating SOL,
+	// e.g. because another session activated it or it was disabled. No
+	// further packets will be sent.
+	Deactivating bool
+
+	// TransmitOverrun, when sent by the BMC, indicates characters from the
+	// serial controller were dropped because its buffer was full.
+	TransmitOverrun bool
+
+	// BreakDetected, when sent by the BMC, indicates a break condition was
+	// detected on the serial port.
+	BreakDetected bool
+}
+
+func (*SOL) LayerType() gopacket.LayerType {
+	return LayerTypeSOL
+}
+
+func (s *SOL) CanDecode() gopacket.LayerClass {
+	return s.LayerType()
+}
+
+func (*SOL) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (s *SOL) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 4 {
+		df.SetTruncated()
+		return fmt.Errorf("SOL payloads must be at least 4 bytes, got %v",
+			len(data))
+	}
+
+	s.Sequence = data[0] & 0xf
+	s.AckSequence = data[1] & 0xf
+	s.AcceptedCharacters = data[2]
+	s.NACK = data[3]&(1<<6) != 0
+	s.CharacterTransferUnavailable = data[3]&(1<<5) != 0
+	s.Deactivating = data[3]&(1<<4) != 0
+	s.TransmitOverrun = data[3]&(1<<3) != 0
+	s.BreakDetected = data[3]&(1<<2) != 0
+
+	s.BaseLayer.Contents = data[:4]
+	s.BaseLayer.Payload = data[4:]
+	return nil
+}
+
+func (s *SOL) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	bytes, err := b.PrependBytes(4)
+	if err != nil {
+		return err
+	}
+	bytes[0] = s.Sequence & 0xf
+	bytes[1] = s.AckSequence & 0xf
+	bytes[2] = s.AcceptedCharacters
+	bytes[3] = 0
+	if s.NACK {
+		bytes[3] |= 1 << 6
+	}
+	if s.RingWOR {
+		bytes[3] |= 1 << 5
+	}
+	if s.GenerateBreak {
+		bytes[3] |= 1 << 4
+	}
+	if s.DeassertCTS {
+		bytes[3] |= 1 << 3
+	}
+	if s.DeassertDCDDSR {
+		bytes[3] |= 1 << 2
+	}
+	if s.FlushInbound {
+		bytes[3] |= 1 << 1
+	}
+	if s.FlushOutbound {
+		bytes[3] |= 1
+	}
+	return nil
+}
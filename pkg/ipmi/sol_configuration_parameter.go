@@ -0,0 +1,90 @@
+package ipmi
+
+import (
+	"fmt"
+)
+
+// SOLConfigurationParameter identifies a Serial over LAN configuration
+// parameter, used in the Get and Set SOL Configuration Parameters commands.
+// Parameters are specified in table 26-5 of IPMI v2.0. The format of each
+// parameter's data is specified alongside it.
+type SOLConfigurationParameter uint8
+
+const (
+	// SOLConfigurationParameterSetInProgress is used to indicate that
+	// parameters are being updated. Its data is 1 byte, the lower 2 bits of
+	// which are a SetInProgress value.
+	SOLConfigurationParameterSetInProgress SOLConfigurationParameter = iota
+
+	// SOLConfigurationParameterEnable controls whether SOL can be activated.
+	// Its data is 1 byte, the lowest bit of which is 1 if SOL is enabled.
+	SOLConfigurationParameterEnable
+
+	// SOLConfigurationParameterAuthentication controls the minimum privilege
+	// level required to activate SOL, and whether encryption and
+	// authentication are forced. Its data is 1 byte: bit 7 forces encryption,
+	// bit 6 forces authentication, and the lower 4 bits are the privilege
+	// level.
+	SOLConfigurationParameterAuthentication
+
+	// SOLConfigurationParameterCharacterAccumulation controls how long the
+	// BMC accumulates characters before sending them. Its data is 2 bytes:
+	// the interval in 5ms increments, and the number of characters that
+	// triggers an early send.
+	SOLConfigurationParameterCharacterAccumulation
+
+	// SOLConfigurationParameterRetry controls how the BMC retransmits packets.
+	// Its data is 2 bytes: the number of retries in the lower 3 bits, and the
+	// interval between them in 10ms increments.
+	SOLConfigurationParameterRetry
+
+	// SOLConfigurationParameterNonVolatileBitRate is the bit rate of the
+	// serial port that persists across resets. Its data is 1 byte, the lower
+	// 4 bits of which are: 6 for 9600 bps, 7 for 19.2 kbps, 8 for 38.4 kbps,
+	// 9 for 57.6 kbps and 10 for 115.2 kbps. 0 means use the setting of the
+	// IPMI serial channel.
+	SOLConfigurationParameterNonVolatileBitRate
+
+	// SOLConfigurationParameterVolatileBitRate is the current bit rate of the
+	// serial port. Its format is identical to the non-volatile bit rate.
+	SOLConfigurationParameterVolatileBitRate
+
+	// SOLConfigurationParameterPayloadChannel is the channel SOL is activated
+	// over. It is read-only, and its data is 1 byte.
+	SOLConfigurationParameterPayloadChannel
+
+	// SOLConfigurationParameterPayloadPort is the UDP port SOL packets are
+	// sent over. Its data is 2 bytes, least significant first.
+	SOLConfigurationParameterPayloadPort
+)
+
+func (p SOLConfigurationParameter) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(p), p.name())
+}
+
+func (p SOLConfigurationParameter) name() string {
+	switch p {
+	case SOLConfigurationParameterSetInProgress:
+		return "Set In Progress"
+	case SOLConfigurationParameterEnable:
+		return "SOL Enable"
+	case SOLConfigurationParameterAuthentication:
+		return "SOL Authentication"
+	case SOLConfigurationParameterCharacterAccumulation:
+		return "Character Accumulate Interval & Send Threshold"
+	case SOLConfigurationParameterRetry:
+		return "SOL Retry"
+	case SOLConfigurationParameterNonVolatileBitRate:
+		return "SOL non-volatile bit rate"
+	case SOLConfigurationParameterVolatileBitRate:
+		return "SOL volatile bit rate"
+	case SOLConfigurationParameterPayloadChannel:
+		return "SOL Payload Channel"
+	case SOLConfigurationParameterPayloadPort:
+		return "SOL Payload Port Number"
+	}
+	if p >= 0xc0 {
+		return "OEM"
+	}
+	return "Unknown"
+}
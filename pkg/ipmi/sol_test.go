@@ -0,0 +1,110 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestSOLDecodeFromBytes(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want *SOL
+	}{
+		{
+			// too short
+			[]byte{0x01, 0x00, 0x00},
+			nil,
+		},
+		{
+			// ACK only
+			[]byte{0x00, 0x03, 0x05, 0x00},
+			&SOL{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0x00, 0x03, 0x05, 0x00},
+					Payload:  []byte{},
+				},
+				AckSequence:        3,
+				AcceptedCharacters: 5,
+			},
+		},
+		{
+			// data with every status bit set; upper nibbles are reserved
+			[]byte{0xf2, 0xf0, 0x00, 0x7c, 'h', 'i'},
+			&SOL{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{0xf2, 0xf0, 0x00, 0x7c},
+					Payload:  []byte{'h', 'i'},
+				},
+				Sequence:                     2,
+				NACK:                         true,
+				CharacterTransferUnavailable: true,
+				Deactivating:                 true,
+				TransmitOverrun:              true,
+				BreakDetected:                true,
+			},
+		},
+	}
+	for _, test := range tests {
+		sol := &SOL{}
+		err := sol.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("expected error decoding %v, got none", test.in)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, sol); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, sol, test.want, diff)
+			}
+		case err != nil && test.want != nil:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestSOLSerializeTo(t *testing.T) {
+	tests := []struct {
+		layer *SOL
+		want  []byte
+	}{
+		{
+			&SOL{
+				Sequence: 15,
+			},
+			[]byte{0x0f, 0x00, 0x00, 0x00},
+		},
+		{
+			&SOL{
+				AckSequence:        1,
+				AcceptedCharacters: 0xff,
+				NACK:               true,
+				GenerateBreak:      true,
+				DeassertDCDDSR:     true,
+			},
+			[]byte{0x00, 0x01, 0xff, 0x54},
+		},
+		{
+			&SOL{
+				RingWOR:       true,
+				DeassertCTS:   true,
+				FlushInbound:  true,
+				FlushOutbound: true,
+			},
+			[]byte{0x00, 0x00, 0x00, 0x2b},
+		},
+	}
+	opts := gopacket.SerializeOptions{}
+	for _, test := range tests {
+		sb := gopacket.NewSerializeBuffer()
+		if err := test.layer.SerializeTo(sb, opts); err != nil {
+			t.Errorf("serialize %v = error %v, want %v", test.layer, err,
+				test.want)
+			continue
+		}
+		if got := sb.Bytes(); !bytes.Equal(got, test.want) {
+			t.Errorf("serialize %v = %v, want %v", test.layer, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package ipmi
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+const (
+	// timestampPreInitMax is the largest timestamp relative to the
+	// initialisation of the BMC rather than the Unix epoch, as the SEL
+	// Device's clock had not been set when it was recorded. See 37 of IPMI
+	// v2.0.
+	timestampPreInitMax = 0x20000000
+)
+
+// SystemEventRecord represents a SEL record of type SELRecordTypeSystemEvent,
+// specified in 26.1 and 32.1 of IPMI v1.5 and v2.0 respectively. It is an
+// event message, as generated by a sensor, stamped with the time it was
+// logged. This layer represents the 13 bytes after the SEL record header.
+type SystemEventRecord struct {
+	layers.BaseLayer
+
+	// SensorRecordKey identifies the sensor that generated the event. The
+	// owner fields contain the Generator ID, which can also be a system
+	// software ID. Together with the sensor number, this is the key of the
+	// sensor's SDR, which describes the entity the event pertains to.
+	SensorRecordKey
+
+	// Timestamp is when the event was logged. If the BMC's clock had not been
+	// set at this point, this is relative to its initialisation; see
+	// IsPreInit(). It is the zero value if unspecified.
+	Timestamp time.Time
+
+	// EvMRev is the event message format revision. This is 0x04 for IPMI v2.0
+	// and v1.5 events, and 0x03 for IPMI v1.0.
+	EvMRev uint8
+
+	// SensorType is the type of sensor that generated the event. This is used
+	// to interpret sensor-specific event offsets.
+	SensorType SensorType
+
+	// Deassertion indicates whether the event is the result of a state
+	// becoming deasserted, as opposed to asserted.
+	Deassertion bool
+
+	// OutputType is the Event/Reading Type Code of the sensor, which indicates
+	// how to interpret EventData.
+	OutputType OutputType
+
+	// EventData contains the 3 event data bytes. The lower 4 bits of the
+	// first byte are the offset of the event state within the OutputType or
+	// SensorType's states. The rest depends on the event.
+	EventData [3]uint8
+}
+
+func (*SystemEventRecord) LayerType() gopacket.LayerType {
+	return LayerTypeSystemEventRecord
+}
+
+func (r *SystemEventRecord) CanDecode() gopacket.LayerClass {
+	return r.LayerType()
+}
+
+func (*SystemEventRecord) NextLayerType() gopacket.LayerType {
+	return gopacket.LayerTypePayload
+}
+
+func (r *SystemEventRecord) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 13 {
+		df.SetTruncated()
+		return fmt.Errorf("System Event Records must be at least 13 bytes "+
+			"excluding the header, got %v", len(data))
+	}
+
+	r.Timestamp = decodeTimestamp(data[0:4])
+	r.OwnerAddress = Address(data[4])
+	r.Channel = Channel(data[5] >> 4)
+	r.OwnerLUN = LUN(data[5] & 0x3)
+	r.EvMRev = data[6]
+	r.SensorType = SensorType(data[7])
+	r.Number = data[8]
+	r.Deassertion = data[9]&(1<<7) != 0
+	r.OutputType = OutputType(data[9] & 0x7f)
+	copy(r.EventData[:], data[10:13])
+
+	r.BaseLayer.Contents = data[:13]
+	r.BaseLayer.Payload = data[13:]
+	return nil
+}
+
+// IsPreInit returns whether the timestamp is relative to the BMC's
+// initialisation rather than the Unix epoch. The timestamp should be
+// considered as an offset in this case.
+func (r *SystemEventRecord) IsPreInit() bool {
+	return !r.Timestamp.IsZero() && r.Timestamp.Unix() <= timestampPreInitMax
+}
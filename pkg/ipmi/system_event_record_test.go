@@ -0,0 +1,167 @@
+package ipmi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestSystemEventRecordDecodeFromBytes(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want *SystemEventRecord
+	}{
+		// too short
+		{
+			make([]byte, 12),
+			nil,
+		},
+		{
+			[]byte{
+				0x00, 0x10, 0x5e, 0x5f,
+				0x20, 0x00,
+				0x04,
+				0x0c,
+				0x53,
+				0x6f,
+				0xa1, 0xff, 0x02,
+			},
+			&SystemEventRecord{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{
+						0x00, 0x10, 0x5e, 0x5f,
+						0x20, 0x00,
+						0x04,
+						0x0c,
+						0x53,
+						0x6f,
+						0xa1, 0xff, 0x02,
+					},
+					Payload: []byte{},
+				},
+				SensorRecordKey: SensorRecordKey{
+					OwnerAddress: 0x20,
+					Number:       0x53,
+				},
+				Timestamp:  time.Unix(1600000000, 0),
+				EvMRev:     4,
+				SensorType: SensorTypeMemory,
+				OutputType: 0x6f,
+				EventData:  [3]uint8{0xa1, 0xff, 0x02},
+			},
+		},
+		{
+			[]byte{
+				0x2c, 0x01, 0x00, 0x00,
+				0x41, 0x23,
+				0x04,
+				0x01,
+				0x30,
+				0x81,
+				0x59, 0x50, 0x4b,
+			},
+			&SystemEventRecord{
+				BaseLayer: layers.BaseLayer{
+					Contents: []byte{
+						0x2c, 0x01, 0x00, 0x00,
+						0x41, 0x23,
+						0x04,
+						0x01,
+						0x30,
+						0x81,
+						0x59, 0x50, 0x4b,
+					},
+					Payload: []byte{},
+				},
+				SensorRecordKey: SensorRecordKey{
+					OwnerAddress: 0x41,
+					Channel:      2,
+					OwnerLUN:     3,
+					Number:       0x30,
+				},
+				Timestamp:   time.Unix(300, 0),
+				EvMRev:      4,
+				SensorType:  SensorTypeTemperature,
+				Deassertion: true,
+				OutputType:  OutputTypeThreshold,
+				EventData:   [3]uint8{0x59, 0x50, 0x4b},
+			},
+		},
+	}
+	for _, test := range tests {
+		rsp := &SystemEventRecord{}
+		err := rsp.DecodeFromBytes(test.in, gopacket.NilDecodeFeedback)
+		switch {
+		case err == nil && test.want == nil:
+			t.Errorf("expected error decoding %v, got none", test.in)
+		case err == nil && test.want != nil:
+			if diff := cmp.Diff(test.want, rsp); diff != "" {
+				t.Errorf("decode %v = %v, want %v: %v", test.in, rsp, test.want, diff)
+			}
+		case err != nil && test.want != nil:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestSystemEventRecordIsPreInit(t *testing.T) {
+	tests := []struct {
+		timestamp time.Time
+		want      bool
+	}{
+		{time.Time{}, false},
+		{time.Unix(300, 0), true},
+		{time.Unix(0x20000000, 0), true},
+		{time.Unix(1600000000, 0), false},
+	}
+	for _, test := range tests {
+		r := &SystemEventRecord{
+			Timestamp: test.timestamp,
+		}
+		if got := r.IsPreInit(); got != test.want {
+			t.Errorf("IsPreInit() for %v = %v, want %v", test.timestamp, got,
+				test.want)
+		}
+	}
+}
+
+func TestGetSELEntryRspDecodesSystemEventRecord(t *testing.T) {
+	packet := gopacket.NewPacket([]byte{
+		0xff, 0xff, // next
+		0x34, 0x12, 0x02, // header
+		0x00, 0x10, 0x5e, 0x5f,
+		0x20, 0x00,
+		0x04,
+		0x08,
+		0x62,
+		0x6f,
+		0x01, 0xff, 0xff,
+	}, LayerTypeGetSELEntryRsp, gopacket.Default)
+	if err := packet.ErrorLayer(); err != nil {
+		t.Fatal(err.Error())
+	}
+	got := []gopacket.LayerType{}
+	for _, layer := range packet.Layers() {
+		got = append(got, layer.LayerType())
+	}
+	want := []gopacket.LayerType{
+		LayerTypeGetSELEntryRsp,
+		LayerTypeSELRecord,
+		LayerTypeSystemEventRecord,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("layers = %v, want %v", got, want)
+	}
+	header := packet.Layer(LayerTypeSELRecord).(*SELRecord)
+	if header.ID != 0x1234 {
+		t.Errorf("record ID = %v, want %v", header.ID, 0x1234)
+	}
+	record := packet.Layer(LayerTypeSystemEventRecord).(*SystemEventRecord)
+	if record.SensorType != SensorTypePowerSupply {
+		t.Errorf("sensor type = %v, want %v", record.SensorType,
+			SensorTypePowerSupply)
+	}
+}
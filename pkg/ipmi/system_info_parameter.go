@@ -0,0 +1,76 @@
+package ipmi
+
+import (
+	"fmt"
+)
+
+// SystemInfoParameter identifies a system info parameter, used in the Get and
+// Set System Info Parameters commands. Parameters are specified in table
+// 22-16a of IPMI v2.0. Other than Set In Progress, each parameter is a string
+// stored in 16-byte blocks, selected by the set selector; see
+// SystemInfoString.
+type SystemInfoParameter uint8
+
+const (
+	// SystemInfoParameterSetInProgress is used to indicate that parameters
+	// are being updated. Its data is 1 byte, the lower 2 bits of which are a
+	// SetInProgress value.
+	SystemInfoParameterSetInProgress SystemInfoParameter = iota
+
+	// SystemInfoParameterFirmwareVersion is the version of the system
+	// firmware, e.g. BIOS or UEFI, typically set by the firmware itself.
+	SystemInfoParameterFirmwareVersion
+
+	// SystemInfoParameterSystemName is the name of the system, typically its
+	// hostname.
+	SystemInfoParameterSystemName
+
+	// SystemInfoParameterPrimaryOSName is the name of the primary operating
+	// system. It is non-volatile.
+	SystemInfoParameterPrimaryOSName
+
+	// SystemInfoParameterOSName is the name of the operating system currently
+	// running. It is cleared when the system resets or powers down.
+	SystemInfoParameterOSName
+
+	// SystemInfoParameterOSVersion is the version of the operating system
+	// currently running, e.g. its kernel release. It is cleared when the
+	// system resets or powers down.
+	SystemInfoParameterOSVersion
+
+	// SystemInfoParameterBMCURL is the URL of the BMC's web interface.
+	SystemInfoParameterBMCURL
+
+	// SystemInfoParameterHypervisorURL is the URL of the base OS or
+	// hypervisor's management interface.
+	SystemInfoParameterHypervisorURL
+)
+
+func (p SystemInfoParameter) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(p), p.name())
+}
+
+func (p SystemInfoParameter) name() string {
+	switch p {
+	case SystemInfoParameterSetInProgress:
+		return "Set In Progress"
+	case SystemInfoParameterFirmwareVersion:
+		return "System Firmware Version"
+	case SystemInfoParameterSystemName:
+		return "System Name"
+	case SystemInfoParameterPrimaryOSName:
+		return "Primary Operating System Name"
+	case SystemInfoParameterOSName:
+		return "Operating System Name"
+	case SystemInfoParameterOSVersion:
+		return "Present OS Version Number"
+	case SystemInfoParameterBMCURL:
+		return "BMC URL"
+	case SystemInfoParameterHypervisorURL:
+		return "Base OS/Hypervisor URL"
+	}
+	if p >= 0xc0 {
+		return "OEM"
+	}
+	return "Unknown"
+}
@@ -0,0 +1,201 @@
+package ipmi
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/gopacket"
+)
+
+const (
+	// SystemInfoStringBlockLength is the length of each block of a system
+	// info string on the wire: its set selector, followed by
+	// systemInfoBlockSize bytes of the string. It is the length of the data
+	// of each Get or Set System Info Parameters command for the string.
+	SystemInfoStringBlockLength = 1 + systemInfoBlockSize
+
+	// systemInfoBlockSize is the number of string bytes in each block of a
+	// system info parameter, excluding the set selector.
+	systemInfoBlockSize = 16
+
+	// systemInfoHeaderSize is the number of bytes at the beginning of the
+	// first block occupied by the encoding and length.
+	systemInfoHeaderSize = 2
+)
+
+// SystemInfoStringEncoding is the character encoding of a system info string,
+// specified alongside the System Firmware Version parameter in table 22-16a
+// of IPMI v2.0. Note the values differ from StringEncoding, which is used in
+// SDRs and FRU data. This is a 4-bit uint on the wire.
+type SystemInfoStringEncoding uint8
+
+const (
+	SystemInfoStringEncodingASCIILatin1 SystemInfoStringEncoding = iota
+	SystemInfoStringEncodingUTF8
+
+	// SystemInfoStringEncodingUnicode has the same ambiguity as
+	// StringEncodingUnicode, and is decoded in the same way.
+	SystemInfoStringEncodingUnicode
+)
+
+var (
+	// systemInfoStringEncodingDecoders maps encodings to decoders. Where an
+	// equivalent StringEncoding exists, its decoder is reused.
+	systemInfoStringEncodingDecoders = map[SystemInfoStringEncoding]StringDecoder{
+		SystemInfoStringEncodingASCIILatin1: stringEncodingDecoders[StringEncoding8BitAsciiLatin1],
+		SystemInfoStringEncodingUTF8:        StringDecoderFunc(decodeUTF8),
+		SystemInfoStringEncodingUnicode:     stringEncodingDecoders[StringEncodingUnicode],
+	}
+)
+
+// Decoder returns a decoder for the encoding, whose character count is the
+// length of the string in bytes.
+func (e SystemInfoStringEncoding) Decoder() (StringDecoder, error) {
+	if decoder, ok := systemInfoStringEncodingDecoders[e]; ok {
+		return decoder, nil
+	}
+	return nil, fmt.Errorf("no decoder found for encoding %v", e)
+}
+
+func (e SystemInfoStringEncoding) Description() string {
+	switch e {
+	case SystemInfoStringEncodingASCIILatin1:
+		return "ASCII + Latin 1"
+	case SystemInfoStringEncodingUTF8:
+		return "UTF-8"
+	case SystemInfoStringEncodingUnicode:
+		return "Unicode"
+	default:
+		return "Unknown"
+	}
+}
+
+func (e SystemInfoStringEncoding) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(e), e.Description())
+}
+
+// decodeUTF8 interprets the first c bytes of b as a UTF-8 string.
+func decodeUTF8(b []byte, c int) (string, int, error) {
+	if len(b) < c {
+		return "", 0, fmt.Errorf("expected %v bytes, got %v", c, len(b))
+	}
+	if !utf8.Valid(b[:c]) {
+		return "", 0, fmt.Errorf("invalid UTF-8: %v", b[:c])
+	}
+	return string(b[:c]), c, nil
+}
+
+// SystemInfoStringBlocks returns the number of blocks required to store a
+// system info string of a given length in bytes. The first block also
+// contains the encoding and length, so holds 2 fewer bytes of the string.
+func SystemInfoStringBlocks(length int) int {
+	return (systemInfoHeaderSize + length + systemInfoBlockSize - 1) /
+		systemInfoBlockSize
+}
+
+// SystemInfoString is the value of a string system info parameter, e.g.
+// System Name. On the wire, it is split into 16-byte blocks, each of which is
+// retrieved or set by its set selector, starting at 0. The first block begins
+// with the encoding and length of the string in bytes, which has a maximum of
+// 255.
+type SystemInfoString struct {
+
+	// Encoding is the character encoding of the string on the wire.
+	Encoding SystemInfoStringEncoding
+
+	// Value is the string. Any trailing null bytes are removed when
+	// decoding.
+	Value string
+}
+
+func (s *SystemInfoString) String() string {
+	return s.Value
+}
+
+// Serialise encodes the string onto the end of a buffer as a sequence of
+// 17-byte blocks, each beginning with its set selector, returning an error if
+// one occurs. Each block is the data of one Set System Info Parameters
+// command. Only ASCII + Latin 1 and UTF-8 can be encoded.
+func (s *SystemInfoString) Serialise(b gopacket.SerializeBuffer) error {
+	var encoded []byte
+	switch s.Encoding {
+	case SystemInfoStringEncodingASCIILatin1:
+		encoded = make([]byte, 0, len(s.Value))
+		for _, r := range s.Value {
+			if r > 0xff {
+				return fmt.Errorf("%q cannot be represented in %v", r,
+					s.Encoding)
+			}
+			encoded = append(encoded, uint8(r))
+		}
+	case SystemInfoStringEncodingUTF8:
+		encoded = []byte(s.Value)
+	default:
+		return fmt.Errorf("cannot encode system info strings as %v",
+			s.Encoding)
+	}
+	if len(encoded) > 0xff {
+		return fmt.Errorf("system info strings can be at most 255 bytes, "+
+			"got %v", len(encoded))
+	}
+
+	blocks := SystemInfoStringBlocks(len(encoded))
+	payload := make([]byte, blocks*systemInfoBlockSize)
+	payload[0] = uint8(s.Encoding) & 0xf
+	payload[1] = uint8(len(encoded))
+	copy(payload[systemInfoHeaderSize:], encoded)
+
+	d, err := b.AppendBytes(blocks * SystemInfoStringBlockLength)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < blocks; i++ {
+		block := d[i*SystemInfoStringBlockLength:]
+		block[0] = uint8(i)
+		copy(block[1:1+systemInfoBlockSize],
+			payload[i*systemInfoBlockSize:])
+	}
+	return nil
+}
+
+// Deserialise reads a string from the supplied byte slice, which must contain
+// its blocks in order, each beginning with its set selector. A final block
+// shorter than 17 bytes is accepted provided it contains the end of the
+// string. Unconsumed remaining bytes are returned.
+func (s *SystemInfoString) Deserialise(d []byte, df gopacket.DecodeFeedback) ([]byte, error) {
+	if len(d) < 1+systemInfoHeaderSize {
+		df.SetTruncated()
+		return nil, fmt.Errorf("system info strings are at least %v bytes, "+
+			"only %v remaining", 1+systemInfoHeaderSize, len(d))
+	}
+	encoding := SystemInfoStringEncoding(d[1] & 0xf)
+	length := int(d[2])
+	consumed := min(len(d), SystemInfoStringBlocks(length)*SystemInfoStringBlockLength)
+
+	payload := make([]byte, 0, consumed)
+	for offset := 0; offset < consumed; offset += SystemInfoStringBlockLength {
+		payload = append(payload,
+			d[offset+1:min(offset+SystemInfoStringBlockLength, consumed)]...)
+	}
+	if len(payload) < systemInfoHeaderSize+length {
+		df.SetTruncated()
+		return nil, fmt.Errorf("system info string is %v bytes, only %v "+
+			"remaining", length, len(payload)-systemInfoHeaderSize)
+	}
+
+	decoder, err := encoding.Decoder()
+	if err != nil {
+		return nil, err
+	}
+	// pass the padding too, as the ASCII + Latin 1 decoder requires at least
+	// 2 bytes
+	value, _, err := decoder.Decode(payload[systemInfoHeaderSize:], length)
+	if err != nil {
+		return nil, err
+	}
+	s.Encoding = encoding
+	// some BMCs include a null terminator in the length
+	s.Value = strings.TrimRight(value, "\x00")
+	return d[consumed:], nil
+}
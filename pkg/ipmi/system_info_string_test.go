@@ -0,0 +1,107 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+)
+
+func TestSystemInfoStringBlocks(t *testing.T) {
+	tests := []struct {
+		length int
+		want   int
+	}{
+		{0, 1},
+		{14, 1},
+		{15, 2},
+		{30, 2},
+		{31, 3},
+		{255, 17},
+	}
+	for _, test := range tests {
+		if got := SystemInfoStringBlocks(test.length); got != test.want {
+			t.Errorf("SystemInfoStringBlocks(%v) = %v, want %v", test.length,
+				got, test.want)
+		}
+	}
+}
+
+func TestSystemInfoString(t *testing.T) {
+	tests := []struct {
+		name string
+		str  *SystemInfoString
+		wire []byte
+	}{
+		{
+			"single block",
+			&SystemInfoString{
+				Encoding: SystemInfoStringEncodingASCIILatin1,
+				Value:    "host1",
+			},
+			[]byte{
+				0x00, 0x00, 0x05, 'h', 'o', 's', 't', '1', 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			"several blocks",
+			&SystemInfoString{
+				Encoding: SystemInfoStringEncodingUTF8,
+				Value:    "db-01.rack-ä.example.com",
+			},
+			[]byte{
+				0x00, 0x01, 0x19, 'd', 'b', '-', '0', '1', '.', 'r', 'a',
+				'c', 'k', '-', 0xc3, 0xa4, '.',
+				0x01, 'e', 'x', 'a', 'm', 'p', 'l', 'e', '.', 'c', 'o', 'm',
+				0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := gopacket.NewSerializeBuffer()
+			if err := test.str.Serialise(b); err != nil {
+				t.Fatalf("serialise %v = error %v", test.str, err)
+			}
+			if got := b.Bytes(); !bytes.Equal(got, test.wire) {
+				t.Errorf("serialise %v = %v, want %v", test.str, got,
+					test.wire)
+			}
+
+			str := &SystemInfoString{}
+			remaining, err := str.Deserialise(test.wire, gopacket.NilDecodeFeedback)
+			if err != nil {
+				t.Fatalf("deserialise %v = error %v", test.wire, err)
+			}
+			if len(remaining) != 0 {
+				t.Errorf("deserialise %v left %v bytes", test.wire, len(remaining))
+			}
+			if diff := cmp.Diff(test.str, str); diff != "" {
+				t.Errorf("deserialise %v = %v, want %v: %v", test.wire, str,
+					test.str, diff)
+			}
+		})
+	}
+}
+
+func TestSystemInfoStringDeserialiseLenient(t *testing.T) {
+	// null terminator included in the length, and a short final block
+	wire := []byte{
+		0x00, 0x00, 0x11, 'U', 'b', 'u', 'n', 't', 'u', ' ', '2', '4',
+		'.', '0', '4', ' ', 'L',
+		0x01, 'T', 'S', 0x00,
+	}
+	want := &SystemInfoString{
+		Encoding: SystemInfoStringEncodingASCIILatin1,
+		Value:    "Ubuntu 24.04 LTS",
+	}
+	got := &SystemInfoString{}
+	if _, err := got.Deserialise(wire, gopacket.NilDecodeFeedback); err != nil {
+		t.Fatalf("deserialise %v = error %v", wire, err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("deserialise %v = %v, want %v: %v", wire, got, want, diff)
+	}
+}
@@ -0,0 +1,205 @@
+package ipmi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ThresholdMask is a bitfield identifying zero or more of the thresholds of a
+// threshold-based sensor. The same bit layout is used by the readable and
+// settable threshold masks of the Full Sensor Record, Get/Set Sensor
+// Thresholds, and the threshold comparison status of Get Sensor Reading. Each
+// constant identifies a single threshold. This is a 6-bit field on the wire.
+type ThresholdMask uint8
+
+const (
+	ThresholdMaskLowerNonCritical ThresholdMask = 1 << iota
+	ThresholdMaskLowerCritical
+	ThresholdMaskLowerNonRecoverable
+	ThresholdMaskUpperNonCritical
+	ThresholdMaskUpperCritical
+	ThresholdMaskUpperNonRecoverable
+
+	// ThresholdMaskAll identifies every threshold.
+	ThresholdMaskAll ThresholdMask = 0x3f
+)
+
+var (
+	// thresholdMasks contains the individual thresholds in bit order.
+	thresholdMasks = [...]ThresholdMask{
+		ThresholdMaskLowerNonCritical,
+		ThresholdMaskLowerCritical,
+		ThresholdMaskLowerNonRecoverable,
+		ThresholdMaskUpperNonCritical,
+		ThresholdMaskUpperCritical,
+		ThresholdMaskUpperNonRecoverable,
+	}
+	thresholdMaskDescriptions = map[ThresholdMask]string{
+		ThresholdMaskLowerNonCritical:    "Lower Non-critical",
+		ThresholdMaskLowerCritical:       "Lower Critical",
+		ThresholdMaskLowerNonRecoverable: "Lower Non-recoverable",
+		ThresholdMaskUpperNonCritical:    "Upper Non-critical",
+		ThresholdMaskUpperCritical:       "Upper Critical",
+		ThresholdMaskUpperNonRecoverable: "Upper Non-recoverable",
+	}
+)
+
+// Thresholds returns the individual thresholds set in the mask, from Lower
+// Non-critical to Upper Non-recoverable.
+func (m ThresholdMask) Thresholds() []ThresholdMask {
+	var thresholds []ThresholdMask
+	for _, threshold := range thresholdMasks {
+		if m&threshold != 0 {
+			thresholds = append(thresholds, threshold)
+		}
+	}
+	return thresholds
+}
+
+// IsLower returns whether the mask contains any lower thresholds.
+func (m ThresholdMask) IsLower() bool {
+	return m&(ThresholdMaskLowerNonCritical|ThresholdMaskLowerCritical|
+		ThresholdMaskLowerNonRecoverable) != 0
+}
+
+// Status returns the most severe status implied by the mask, interpreted as
+// the thresholds a reading is at or beyond. This is how the comparison status
+// of Get Sensor Reading is turned into ok/warning/critical.
+func (m ThresholdMask) Status() ThresholdStatus {
+	switch {
+	case m&(ThresholdMaskLowerNonRecoverable|ThresholdMaskUpperNonRecoverable) != 0:
+		return ThresholdStatusNonRecoverable
+	case m&(ThresholdMaskLowerCritical|ThresholdMaskUpperCritical) != 0:
+		return ThresholdStatusCritical
+	case m&(ThresholdMaskLowerNonCritical|ThresholdMaskUpperNonCritical) != 0:
+		return ThresholdStatusNonCritical
+	default:
+		return ThresholdStatusOK
+	}
+}
+
+func (m ThresholdMask) String() string {
+	thresholds := m.Thresholds()
+	if len(thresholds) == 0 {
+		return "None"
+	}
+	descriptions := make([]string, 0, len(thresholds))
+	for _, threshold := range thresholds {
+		descriptions = append(descriptions, thresholdMaskDescriptions[threshold])
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// ThresholdStatus summarises the thresholds a sensor reading has crossed into
+// a single severity.
+type ThresholdStatus uint8
+
+const (
+	// ThresholdStatusOK indicates the reading has not crossed any thresholds.
+	ThresholdStatusOK ThresholdStatus = iota
+
+	// ThresholdStatusNonCritical indicates the reading is at or beyond a
+	// non-critical threshold. This is a warning.
+	ThresholdStatusNonCritical
+
+	// ThresholdStatusCritical indicates the reading is at or beyond a critical
+	// threshold.
+	ThresholdStatusCritical
+
+	// ThresholdStatusNonRecoverable indicates the reading is at or beyond a
+	// non-recoverable threshold, so damage may have occurred.
+	ThresholdStatusNonRecoverable
+)
+
+func (s ThresholdStatus) Description() string {
+	switch s {
+	case ThresholdStatusOK:
+		return "OK"
+	case ThresholdStatusNonCritical:
+		return "Non-critical"
+	case ThresholdStatusCritical:
+		return "Critical"
+	case ThresholdStatusNonRecoverable:
+		return "Non-recoverable"
+	default:
+		return "Unknown"
+	}
+}
+
+func (s ThresholdStatus) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(s), s.Description())
+}
+
+// Thresholds contains the raw values of a sensor's thresholds. These are in
+// the format indicated by the SDR's AnalogDataFormat, and must be converted in
+// the same way as a reading. Which values are meaningful is indicated by an
+// accompanying ThresholdMask.
+type Thresholds struct {
+	LowerNonCritical    uint8
+	LowerCritical       uint8
+	LowerNonRecoverable uint8
+	UpperNonCritical    uint8
+	UpperCritical       uint8
+	UpperNonRecoverable uint8
+}
+
+// Value returns the raw value of a single threshold. It returns 0 if the mask
+// does not identify exactly one threshold.
+func (t *Thresholds) Value(threshold ThresholdMask) uint8 {
+	switch threshold {
+	case ThresholdMaskLowerNonCritical:
+		return t.LowerNonCritical
+	case ThresholdMaskLowerCritical:
+		return t.LowerCritical
+	case ThresholdMaskLowerNonRecoverable:
+		return t.LowerNonRecoverable
+	case ThresholdMaskUpperNonCritical:
+		return t.UpperNonCritical
+	case ThresholdMaskUpperCritical:
+		return t.UpperCritical
+	case ThresholdMaskUpperNonRecoverable:
+		return t.UpperNonRecoverable
+	default:
+		return 0
+	}
+}
+
+// SensorAccess indicates whether a sensor's thresholds or hysteresis values
+// can be read and written. It is specified in the Sensor Capabilities byte of
+// the Full and Compact Sensor Records. This is a 2-bit uint on the wire.
+type SensorAccess uint8
+
+const (
+	// SensorAccessNone indicates the sensor has no such values, or they are
+	// not accessible.
+	SensorAccessNone SensorAccess = iota
+
+	// SensorAccessReadable indicates the values can be read but not set.
+	SensorAccessReadable
+
+	// SensorAccessSettable indicates the values can be read and set.
+	SensorAccessSettable
+
+	// SensorAccessFixed indicates the values are fixed and cannot be read.
+	// For thresholds, this means the values in the SDR should be used.
+	SensorAccessFixed
+)
+
+func (a SensorAccess) Description() string {
+	switch a {
+	case SensorAccessNone:
+		return "None"
+	case SensorAccessReadable:
+		return "Readable"
+	case SensorAccessSettable:
+		return "Readable and settable"
+	case SensorAccessFixed:
+		return "Fixed, unreadable"
+	default:
+		return "Unknown"
+	}
+}
+
+func (a SensorAccess) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(a), a.Description())
+}
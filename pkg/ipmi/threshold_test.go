@@ -0,0 +1,39 @@
+package ipmi
+
+import (
+	"testing"
+)
+
+func TestThresholdMaskStatus(t *testing.T) {
+	tests := []struct {
+		in   ThresholdMask
+		want ThresholdStatus
+	}{
+		{0, ThresholdStatusOK},
+		{ThresholdMaskUpperNonCritical, ThresholdStatusNonCritical},
+		{ThresholdMaskLowerNonCritical | ThresholdMaskLowerCritical, ThresholdStatusCritical},
+		{ThresholdMaskUpperNonCritical | ThresholdMaskUpperCritical |
+			ThresholdMaskUpperNonRecoverable, ThresholdStatusNonRecoverable},
+	}
+	for _, test := range tests {
+		if got := test.in.Status(); got != test.want {
+			t.Errorf("%v.Status() = %v, want %v", test.in, got, test.want)
+		}
+	}
+}
+
+func TestThresholdMaskString(t *testing.T) {
+	tests := []struct {
+		in   ThresholdMask
+		want string
+	}{
+		{0, "None"},
+		{ThresholdMaskLowerCritical | ThresholdMaskUpperNonCritical,
+			"Lower Critical, Upper Non-critical"},
+	}
+	for _, test := range tests {
+		if got := test.in.String(); got != test.want {
+			t.Errorf("String() = %q, want %q", got, test.want)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package ipmi
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+const (
+	// timestampUnspecified is the wire value of an invalid or unspecified
+	// timestamp.
+	timestampUnspecified = 0xffffffff
+)
+
+// decodeTimestamp interprets 4 bytes as a little-endian IPMI timestamp,
+// specified in 37 of IPMI v2.0. This is the number of seconds since the Unix
+// epoch, so it will overflow in 2106. The unspecified timestamp is returned as
+// the zero value.
+func decodeTimestamp(b []byte) time.Time {
+	seconds := binary.LittleEndian.Uint32(b)
+	if seconds == timestampUnspecified {
+		return time.Time{}
+	}
+	return time.Unix(int64(seconds), 0)
+}
+
+// ClockReading is the value of a device's clock, e.g. the SEL Device or SDR
+// Repository Device. Until the clock is set, typically by system software or
+// the BMC itself once it has synchronised, it counts seconds since the
+// device's initialisation rather than the Unix epoch. Such readings are
+// exposed as an offset in SinceInit rather than an implausible 1970s time.
+type ClockReading struct {
+
+	// Time is the current time according to the clock. This is the zero
+	// value if the clock has not been set, in which case SinceInit is
+	// populated instead.
+	Time time.Time
+
+	// SinceInit is the time elapsed since the device was initialised. It is
+	// only populated if the clock has not been set.
+	SinceInit time.Duration
+}
+
+// IsPreInit returns whether the clock has not been set, so the reading is
+// relative to the device's initialisation. See SinceInit.
+func (r ClockReading) IsPreInit() bool {
+	return r.Time.IsZero()
+}
+
+// String returns the reading as a time, or an offset from initialisation if
+// the clock has not been set, e.g. "init+1h2m3s".
+func (r ClockReading) String() string {
+	if r.IsPreInit() {
+		return "init+" + r.SinceInit.String()
+	}
+	return r.Time.String()
+}
+
+// decodeClockReading interprets 4 bytes as a little-endian IPMI timestamp
+// read from a device clock, separating out times relative to the device's
+// initialisation.
+func decodeClockReading(b []byte) ClockReading {
+	seconds := binary.LittleEndian.Uint32(b)
+	if seconds <= timestampPreInitMax {
+		return ClockReading{
+			SinceInit: time.Duration(seconds) * time.Second,
+		}
+	}
+	return ClockReading{
+		Time: decodeTimestamp(b),
+	}
+}
@@ -0,0 +1,61 @@
+package ipmi
+
+import (
+	"fmt"
+)
+
+// UserAccess contains a user's access rights for a single channel. It is used
+// by the Get and Set User Access commands.
+type UserAccess struct {
+
+	// CallbackOnly restricts the user to callback connections on the channel,
+	// regardless of PrivilegeLimit.
+	CallbackOnly bool
+
+	// LinkAuthentication indicates whether the user is enabled for link
+	// authentication, e.g. PPP, on the channel.
+	LinkAuthentication bool
+
+	// IPMIMessaging indicates whether the user can establish sessions and
+	// send IPMI messages over the channel. It must be true for a user to log
+	// in over LAN.
+	IPMIMessaging bool
+
+	// PrivilegeLimit is the maximum privilege level the user can have on the
+	// channel. PrivilegeLevelNoAccess prevents the user using the channel.
+	PrivilegeLimit PrivilegeLevel
+}
+
+// UserStatus indicates whether a user ID is enabled. It is returned by the Get
+// User Access command in IPMI v2.0, and is a 2-bit uint on the wire.
+type UserStatus uint8
+
+const (
+	// UserStatusUnspecified is returned by BMCs that do not report whether a
+	// user is enabled, including all IPMI v1.5 implementations.
+	UserStatusUnspecified UserStatus = iota
+
+	// UserStatusEnabled indicates the user was enabled via Set User Password.
+	UserStatusEnabled
+
+	// UserStatusDisabled indicates the user was disabled via Set User
+	// Password.
+	UserStatusDisabled
+)
+
+func (s UserStatus) Description() string {
+	switch s {
+	case UserStatusUnspecified:
+		return "Unspecified"
+	case UserStatusEnabled:
+		return "Enabled"
+	case UserStatusDisabled:
+		return "Disabled"
+	default:
+		return "Unknown"
+	}
+}
+
+func (s UserStatus) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(s), s.Description())
+}
@@ -0,0 +1,51 @@
+package ipmi
+
+import (
+	"fmt"
+	"time"
+)
+
+// UTCOffset is the offset of the SEL Device's local time from UTC in minutes,
+// used by Get and Set SEL Time UTC Offset. The spec defines a range of -1440
+// to 1440, i.e. ±24 hours. SEL timestamps are always in UTC; this value
+// exists so software can convert them to the BMC's local time.
+type UTCOffset int16
+
+const (
+	// UTCOffsetUnspecified indicates the BMC has no UTC offset configured.
+	UTCOffsetUnspecified UTCOffset = 0x07ff
+)
+
+// NewUTCOffset returns the UTCOffset corresponding to a duration, truncated
+// to the minute.
+func NewUTCOffset(d time.Duration) UTCOffset {
+	return UTCOffset(d / time.Minute)
+}
+
+// IsSpecified returns whether the offset has been configured.
+func (o UTCOffset) IsSpecified() bool {
+	return o != UTCOffsetUnspecified
+}
+
+// Duration returns the offset as a duration. This is meaningless if the
+// offset is unspecified.
+func (o UTCOffset) Duration() time.Duration {
+	return time.Duration(o) * time.Minute
+}
+
+// Location returns a fixed time zone with the offset, suitable for
+// presenting SEL timestamps in the BMC's local time. UTC is returned if the
+// offset is unspecified.
+func (o UTCOffset) Location() *time.Location {
+	if !o.IsSpecified() {
+		return time.UTC
+	}
+	return time.FixedZone("", int(o.Duration()/time.Second))
+}
+
+func (o UTCOffset) String() string {
+	if !o.IsSpecified() {
+		return "Unspecified"
+	}
+	return fmt.Sprintf("%+dm", int16(o))
+}
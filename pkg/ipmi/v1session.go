@@ -1,14 +1,28 @@
 package ipmi
 
 import (
+	"crypto/subtle"
 	"encoding/binary"
 	"fmt"
-	"hash"
 
 	"github.com/google/gopacket"
 	"github.com/google/gopacket/layers"
 )
 
+// V1AuthenticationAlgorithm is implemented by IPMI v1.5 authentication types
+// that produce an AuthCode for the session header, i.e. MD2, MD5 and straight
+// password/key. The algorithms are specified in section 6.12.11 and 22.17.1 of
+// IPMI v1.5 and v2.0 respectively. Implementations are expected to be loaded
+// with the user's password.
+type V1AuthenticationAlgorithm interface {
+
+	// AuthCode calculates the 16-byte AuthCode for a packet with the provided
+	// session ID and sequence number, wrapping the provided IPMI message. The
+	// message is everything after the session header, i.e. from the
+	// responder address through to the final checksum inclusive.
+	AuthCode(id, sequence uint32, message []byte) [16]byte
+}
+
 // V1Session represents the IPMI v1.5 session header. It wraps all IPMI commands.
 // The zero value is suitable for commands sent "outside" of a session, e.g. Get
 // Channel Authentication Capabilities, and Get Device GUID. See 6.11.7 for more
@@ -47,8 +61,12 @@ type V1Session struct {
 	// Length is the length of the contained IPMI message.
 	Length uint8
 
-	// AuthenticationAlgorithm is called to generate a checksum of the packet.
-	AuthenticationAlgorithm hash.Hash
+	// AuthenticationAlgorithm is called to generate the AuthCode of packets
+	// when serialising with ComputeChecksums set, and to validate the AuthCode
+	// of received packets. If this is nil, the AuthCode field is written
+	// verbatim and not validated. It is only used if AuthType is not
+	// AuthenticationTypeNone.
+	AuthenticationAlgorithm V1AuthenticationAlgorithm
 }
 
 func (*V1Session) LayerType() gopacket.LayerType {
@@ -86,8 +104,22 @@ func (s *V1Session) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) err
 
 		s.BaseLayer.Contents = data[:26]
 		s.BaseLayer.Payload = data[26:]
-		copy(s.AuthCode[:], data[9:25]) // TODO work out byte order - probably need to reverse
+		// the AuthCode is the raw output of the hash, so is not reversed
+		copy(s.AuthCode[:], data[9:25])
 		s.Length = uint8(data[25])
+
+		if s.AuthenticationAlgorithm != nil {
+			if len(s.BaseLayer.Payload) < int(s.Length) {
+				df.SetTruncated()
+				return fmt.Errorf("v1.5 session payload shorter than length field suggests: want %v bytes, got %v", s.Length, len(s.BaseLayer.Payload))
+			}
+			want := s.AuthenticationAlgorithm.AuthCode(s.ID, s.Sequence,
+				s.BaseLayer.Payload[:s.Length])
+			if subtle.ConstantTimeCompare(s.AuthCode[:], want[:]) != 1 {
+				return fmt.Errorf("invalid AuthCode: want %v, got %v", want,
+					s.AuthCode)
+			}
+		}
 	}
 	return nil
 }
@@ -114,7 +146,12 @@ func (s *V1Session) SerializeTo(b gopacket.SerializeBuffer, opts gopacket.Serial
 	if s.AuthType == AuthenticationTypeNone {
 		bytes[9] = s.Length
 	} else {
-		copy(bytes[9:25], s.AuthCode[:]) // TODO work out byte order - probably need to reverse
+		if opts.ComputeChecksums && s.AuthenticationAlgorithm != nil {
+			// the message is everything after the header we just prepended
+			s.AuthCode = s.AuthenticationAlgorithm.AuthCode(s.ID, s.Sequence,
+				b.Bytes()[size:])
+		}
+		copy(bytes[9:25], s.AuthCode[:])
 		bytes[25] = s.Length
 	}
 	return nil
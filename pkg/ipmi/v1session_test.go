@@ -74,3 +74,53 @@ func TestV1Session(t *testing.T) {
 		}
 	}
 }
+
+// xorAuthCode is a trivial V1AuthenticationAlgorithm, sufficient to check the
+// session layer signs and validates the correct bytes.
+type xorAuthCode struct{}
+
+func (xorAuthCode) AuthCode(id, sequence uint32, message []byte) [16]byte {
+	code := [16]byte{byte(id), byte(sequence)}
+	for i, b := range message {
+		code[2+i%14] ^= b
+	}
+	return code
+}
+
+func TestV1SessionAuthCode(t *testing.T) {
+	layer := &V1Session{
+		AuthType:                AuthenticationTypeMD5,
+		Sequence:                5,
+		ID:                      0x12,
+		AuthenticationAlgorithm: xorAuthCode{},
+	}
+	sb := gopacket.NewSerializeBuffer()
+	if err := gopacket.Payload([]byte{0x1, 0x2, 0x3}).SerializeTo(sb,
+		gopacket.SerializeOptions{}); err != nil {
+		t.Fatalf("serialize payload failed: %v", err)
+	}
+	if err := layer.SerializeTo(sb, gopacket.SerializeOptions{
+		FixLengths:       true,
+		ComputeChecksums: true,
+	}); err != nil {
+		t.Fatalf("serialize failed: %v", err)
+	}
+	wire := sb.Bytes()
+	wantAuthCode := []byte{0x12, 0x5, 0x1, 0x2, 0x3, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0}
+	if !bytes.Equal(wire[9:25], wantAuthCode) {
+		t.Errorf("AuthCode = %v, want %v", wire[9:25], wantAuthCode)
+	}
+
+	decoded := &V1Session{
+		AuthenticationAlgorithm: xorAuthCode{},
+	}
+	if err := decoded.DecodeFromBytes(wire, gopacket.NilDecodeFeedback); err != nil {
+		t.Errorf("decode %v failed with %v", wire, err)
+	}
+
+	wire[len(wire)-1] ^= 0xff
+	if err := decoded.DecodeFromBytes(wire, gopacket.NilDecodeFeedback); err == nil {
+		t.Errorf("decode %v with tampered message succeeded, want error", wire)
+	}
+}
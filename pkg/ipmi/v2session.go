@@ -86,7 +86,7 @@ func (s *V2Session) CanDecode() gopacket.LayerClass {
 
 func (s *V2Session) NextLayerType() gopacket.LayerType {
 	layerType := s.PayloadDescriptor.NextLayerType()
-	if layerType == LayerTypeMessage && s.Encrypted {
+	if (layerType == LayerTypeMessage || layerType == LayerTypeSOL) && s.Encrypted {
 		// special case - this must be handled here, because lower layers don't
 		// know whether it's encrypted. I imagine the spec authors left the
 		// encrypted bit in the session layer, so it could apply to OEM payloads,
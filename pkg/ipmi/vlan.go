@@ -0,0 +1,55 @@
+package ipmi
+
+import (
+	"fmt"
+
+	"github.com/google/gopacket"
+)
+
+// VLAN is the data of the 802.1q VLAN ID LAN configuration parameter,
+// parameter 20 in table 23-4 of IPMI v2.0. It controls whether the BMC tags
+// its traffic, and with which VLAN.
+type VLAN struct {
+
+	// Enabled indicates the BMC tags its traffic with ID. If false, traffic
+	// is untagged, and ID should be ignored.
+	Enabled bool
+
+	// ID is the VLAN ID, from 1 to 4094. This is a 12-bit uint on the wire.
+	ID uint16
+}
+
+func (v VLAN) String() string {
+	if !v.Enabled {
+		return "Disabled"
+	}
+	return fmt.Sprintf("%v", v.ID)
+}
+
+// Serialise encodes the VLAN onto the end of a buffer, returning an error if
+// one occurs.
+func (v *VLAN) Serialise(b gopacket.SerializeBuffer) error {
+	d, err := b.AppendBytes(2)
+	if err != nil {
+		return err
+	}
+	d[0] = uint8(v.ID)
+	d[1] = uint8(v.ID>>8) & 0xf
+	if v.Enabled {
+		d[1] |= 1 << 7
+	}
+	return nil
+}
+
+// Deserialise reads a VLAN from the supplied byte slice, returning unconsumed
+// remaining bytes.
+func (v *VLAN) Deserialise(d []byte, df gopacket.DecodeFeedback) ([]byte, error) {
+	if len(d) < 2 {
+		df.SetTruncated()
+		return nil, fmt.Errorf("VLAN ID is 2 bytes, only %v remaining",
+			len(d))
+	}
+	v.Enabled = d[1]&(1<<7) != 0
+	v.ID = uint16(d[1]&0xf)<<8 | uint16(d[0])
+	return d[2:], nil
+}
@@ -0,0 +1,62 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+)
+
+func TestVLAN(t *testing.T) {
+	tests := []struct {
+		name string
+		vlan *VLAN
+		wire []byte
+	}{
+		{
+			"disabled",
+			&VLAN{},
+			[]byte{0x00, 0x00},
+		},
+		{
+			"enabled",
+			&VLAN{
+				Enabled: true,
+				ID:      0xabc,
+			},
+			[]byte{0xbc, 0x8a},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := gopacket.NewSerializeBuffer()
+			if err := test.vlan.Serialise(b); err != nil {
+				t.Fatalf("serialise %v = error %v", test.vlan, err)
+			}
+			if got := b.Bytes(); !bytes.Equal(got, test.wire) {
+				t.Errorf("serialise %v = %v, want %v", test.vlan, got, test.wire)
+			}
+
+			vlan := &VLAN{}
+			remaining, err := vlan.Deserialise(test.wire, gopacket.NilDecodeFeedback)
+			if err != nil {
+				t.Fatalf("deserialise %v = error %v", test.wire, err)
+			}
+			if len(remaining) != 0 {
+				t.Errorf("deserialise %v left %v bytes", test.wire, len(remaining))
+			}
+			if diff := cmp.Diff(test.vlan, vlan); diff != "" {
+				t.Errorf("deserialise %v = %v, want %v: %v", test.wire, vlan,
+					test.vlan, diff)
+			}
+		})
+	}
+}
+
+func TestVLANDeserialiseTruncated(t *testing.T) {
+	vlan := &VLAN{}
+	if _, err := vlan.Deserialise([]byte{0x01}, gopacket.NilDecodeFeedback); err == nil {
+		t.Error("expected error deserialising 1 byte, got none")
+	}
+}
@@ -0,0 +1,34 @@
+package ipmi
+
+import (
+	"github.com/google/gopacket"
+)
+
+// WarmResetCmd implements the Warm Reset command, specified in 17.3 and 20.3
+// of IPMI v1.5 and v2.0 respectively. It resets the BMC's firmware without
+// re-initialising its hardware or sensor state, ending all sessions. The BMC
+// may reset before responding. Not all BMCs implement it; Cold Reset is more
+// widely supported. Neither the request nor response contain data.
+type WarmResetCmd struct{}
+
+// Name returns "Warm Reset".
+func (*WarmResetCmd) Name() string {
+	return "Warm Reset"
+}
+
+// Operation returns &OperationWarmResetReq.
+func (*WarmResetCmd) Operation() *Operation {
+	return &OperationWarmResetReq
+}
+
+func (*WarmResetCmd) RemoteLUN() LUN {
+	return LUNBMC
+}
+
+func (*WarmResetCmd) Request() gopacket.SerializableLayer {
+	return nil
+}
+
+func (*WarmResetCmd) Response() gopacket.DecodingLayer {
+	return nil
+}
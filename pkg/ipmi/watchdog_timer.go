@@ -0,0 +1,200 @@
+package ipmi
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WatchdogTimer contains the configuration of the BMC's watchdog timer, which
+// takes an action, e.g. resetting the system, if software fails to reset it
+// before its countdown reaches 0. It is used by the Get and Set Watchdog Timer
+// commands, specified in sections 21 and 27 of IPMI v1.5 and v2.0
+// respectively.
+type WatchdogTimer struct {
+
+	// Use indicates what the timer is being used to monitor. When the timer
+	// expires, the corresponding expiration flag is set, so the cause of a
+	// reset can be determined.
+	Use WatchdogTimerUse
+
+	// DontLog stops the BMC adding a SEL entry when the timer expires.
+	DontLog bool
+
+	// TimeoutAction is the action taken when the countdown reaches 0.
+	TimeoutAction WatchdogTimeoutAction
+
+	// PreTimeoutInterrupt is the interrupt raised PreTimeoutInterval before
+	// the countdown reaches 0, giving software a chance to log diagnostics.
+	PreTimeoutInterrupt WatchdogPreTimeoutInterrupt
+
+	// PreTimeoutInterval is how long before timeout the pre-timeout interrupt
+	// is raised. It is sent as a whole number of seconds, so is truncated, and
+	// clamped to 0-255 seconds.
+	PreTimeoutInterval time.Duration
+
+	// InitialCountdown is the value the countdown is set to when the timer is
+	// reset. It is sent in 100ms increments, so is truncated, and clamped to
+	// 0-6553.5 seconds.
+	InitialCountdown time.Duration
+}
+
+// watchdogCountdown encodes a countdown in 100ms increments.
+func watchdogCountdown(d time.Duration) uint16 {
+	return uint16(max(min(d/(time.Millisecond*100), 0xffff), 0))
+}
+
+// WatchdogTimerUse indicates what the watchdog timer is being used to monitor.
+// It is specified alongside the Set Watchdog Timer command, and is a 3-bit
+// uint on the wire.
+type WatchdogTimerUse uint8
+
+const (
+	// WatchdogTimerUseBIOSFRB2 monitors the BIOS fault-resilient booting
+	// level 2 process.
+	WatchdogTimerUseBIOSFRB2 WatchdogTimerUse = iota + 1
+
+	// WatchdogTimerUseBIOSPOST monitors the BIOS power-on self test.
+	WatchdogTimerUseBIOSPOST
+
+	// WatchdogTimerUseOSLoad monitors the OS loader.
+	WatchdogTimerUseOSLoad
+
+	// WatchdogTimerUseSMSOS monitors the running OS, typically via a
+	// watchdog daemon.
+	WatchdogTimerUseSMSOS
+
+	WatchdogTimerUseOEM
+)
+
+func (u WatchdogTimerUse) Description() string {
+	switch u {
+	case WatchdogTimerUseBIOSFRB2:
+		return "BIOS FRB2"
+	case WatchdogTimerUseBIOSPOST:
+		return "BIOS/POST"
+	case WatchdogTimerUseOSLoad:
+		return "OS Load"
+	case WatchdogTimerUseSMSOS:
+		return "SMS/OS"
+	case WatchdogTimerUseOEM:
+		return "OEM"
+	default:
+		return "Unknown"
+	}
+}
+
+func (u WatchdogTimerUse) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(u), u.Description())
+}
+
+// WatchdogTimeoutAction is the action the BMC takes when the watchdog timer
+// expires. This is a 3-bit uint on the wire.
+type WatchdogTimeoutAction uint8
+
+const (
+	// WatchdogTimeoutActionNone only sets the expiration flag and logs the
+	// expiry.
+	WatchdogTimeoutActionNone WatchdogTimeoutAction = iota
+
+	WatchdogTimeoutActionHardReset
+	WatchdogTimeoutActionPowerDown
+	WatchdogTimeoutActionPowerCycle
+)
+
+func (a WatchdogTimeoutAction) Description() string {
+	switch a {
+	case WatchdogTimeoutActionNone:
+		return "No action"
+	case WatchdogTimeoutActionHardReset:
+		return "Hard Reset"
+	case WatchdogTimeoutActionPowerDown:
+		return "Power Down"
+	case WatchdogTimeoutActionPowerCycle:
+		return "Power Cycle"
+	default:
+		return "Unknown"
+	}
+}
+
+func (a WatchdogTimeoutAction) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(a), a.Description())
+}
+
+// WatchdogPreTimeoutInterrupt is the interrupt the BMC raises shortly before
+// the watchdog timer expires. This is a 3-bit uint on the wire.
+type WatchdogPreTimeoutInterrupt uint8
+
+const (
+	WatchdogPreTimeoutInterruptNone WatchdogPreTimeoutInterrupt = iota
+	WatchdogPreTimeoutInterruptSMI
+
+	// WatchdogPreTimeoutInterruptNMI raises an NMI or diagnostic interrupt,
+	// which typically causes the OS to panic and write a crash dump.
+	WatchdogPreTimeoutInterruptNMI
+
+	// WatchdogPreTimeoutInterruptMessaging raises the messaging interrupt of
+	// the system interface.
+	WatchdogPreTimeoutInterruptMessaging
+)
+
+func (i WatchdogPreTimeoutInterrupt) Description() string {
+	switch i {
+	case WatchdogPreTimeoutInterruptNone:
+		return "None"
+	case WatchdogPreTimeoutInterruptSMI:
+		return "SMI"
+	case WatchdogPreTimeoutInterruptNMI:
+		return "NMI / Diagnostic Interrupt"
+	case WatchdogPreTimeoutInterruptMessaging:
+		return "Messaging Interrupt"
+	default:
+		return "Unknown"
+	}
+}
+
+func (i WatchdogPreTimeoutInterrupt) String() string {
+	return fmt.Sprintf("%v(%v)", uint8(i), i.Description())
+}
+
+// WatchdogExpirationFlags records which timer uses have expired since the
+// flags were last cleared, e.g. to find out whether a system was reset by the
+// watchdog. The flags are preserved across system resets and power cycles,
+// but cleared when the BMC loses power. Bit n corresponds to timer use n.
+type WatchdogExpirationFlags uint8
+
+// WatchdogExpirationFlagsAll selects every timer use, e.g. to clear all flags.
+const WatchdogExpirationFlagsAll WatchdogExpirationFlags = 0x3e
+
+// Has returns whether the flag for a given timer use is set.
+func (f WatchdogExpirationFlags) Has(u WatchdogTimerUse) bool {
+	return f&WatchdogExpirationFlag(u) != 0
+}
+
+// Uses returns the timer uses whose flags are set, in ascending order.
+func (f WatchdogExpirationFlags) Uses() []WatchdogTimerUse {
+	uses := []WatchdogTimerUse{}
+	for u := WatchdogTimerUseBIOSFRB2; u <= WatchdogTimerUseOEM; u++ {
+		if f.Has(u) {
+			uses = append(uses, u)
+		}
+	}
+	return uses
+}
+
+func (f WatchdogExpirationFlags) String() string {
+	uses := f.Uses()
+	if len(uses) == 0 {
+		return "None"
+	}
+	descriptions := make([]string, len(uses))
+	for i, u := range uses {
+		descriptions[i] = u.Description()
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// WatchdogExpirationFlag returns the expiration flag of a timer use.
+func WatchdogExpirationFlag(u WatchdogTimerUse) WatchdogExpirationFlags {
+	return WatchdogExpirationFlags(1<<u) & WatchdogExpirationFlagsAll
+}
@@ -0,0 +1,236 @@
+package ipmi
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/rc4"
+	"crypto/subtle"
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+const (
+	// xRC4IVLength is the length of the initialisation vector in the
+	// confidentiality header of packets that (re-)initialise the keystream.
+	xRC4IVLength = 16
+
+	xRC4128KeyLength = 16
+	xRC440KeyLength  = 5
+)
+
+// XRC4 implements the xRC4-128 and xRC4-40 confidentiality algorithms
+// specified in section 13.29.2 of IPMI v2.0. It is a payload layer type, used
+// to encrypt and decrypt IPMI messages. Note the default instance is not
+// usable: use NewXRC4128() or NewXRC440() to create one.
+//
+// Unlike AES-CBC-128, xRC4 is a stream cipher, and each side of the session
+// uses a single keystream for all packets it sends. The confidentiality
+// header contains the offset into the keystream of the first encrypted byte.
+// A zero offset is followed by a 16-byte initialisation vector, from which the
+// keystream is derived: its key is the MD5 of the first 128 bits of K2
+// followed by the IV, truncated to 40 bits for xRC4-40. There is no
+// confidentiality trailer.
+//
+// This implementation re-initialises the keystream for every packet it
+// serialises, so requests can be sent concurrently and retried without the
+// two sides' view of the keystream diverging. The managed system's keystream
+// is shared between the layer and any created by calling Fork() on it.
+type XRC4 struct {
+	layers.BaseLayer
+
+	// DataOffset is the offset into the sender's keystream of the first byte
+	// of the payload. This is set when decoding, and is always 0 when
+	// serialising.
+	DataOffset uint32
+
+	// k2 is the first 128 bits of the session's K2, from which keystream keys
+	// are derived. This field is of course not included in the packet data.
+	k2 [16]byte
+
+	// keyLength is the number of bytes of keystream keys used: 16 for
+	// xRC4-128, or 5 for xRC4-40.
+	keyLength int
+
+	// inbound is the keystream the managed system is using to encrypt the
+	// packets it sends.
+	inbound *xRC4Keystream
+}
+
+// NewXRC4128 creates an xRC4-128 layer loaded with the first 128 bits of K2.
+func NewXRC4128(k2 [16]byte) *XRC4 {
+	return newXRC4(k2, xRC4128KeyLength)
+}
+
+// NewXRC440 creates an xRC4-40 layer loaded with the first 128 bits of K2.
+func NewXRC440(k2 [16]byte) *XRC4 {
+	return newXRC4(k2, xRC440KeyLength)
+}
+
+func newXRC4(k2 [16]byte, keyLength int) *XRC4 {
+	return &XRC4{
+		k2:        k2,
+		keyLength: keyLength,
+		inbound:   &xRC4Keystream{},
+	}
+}
+
+// Fork returns a new layer with the same key, sharing the managed system's
+// keystream with the original. A given layer cannot be used concurrently, so
+// this is used to create a layer for each request in flight within a session.
+func (x *XRC4) Fork() *XRC4 {
+	return &XRC4{
+		k2:        x.k2,
+		keyLength: x.keyLength,
+		inbound:   x.inbound,
+	}
+}
+
+func (*XRC4) LayerType() gopacket.LayerType {
+	return layerTypeXRC4
+}
+
+func (x *XRC4) CanDecode() gopacket.LayerClass {
+	return x.LayerType()
+}
+
+func (x *XRC4) NextLayerType() gopacket.LayerType {
+	return LayerTypeMessage
+}
+
+// key derives the keystream key for a given initialisation vector.
+func (x *XRC4) key(iv []byte) []byte {
+	h := md5.New()
+	h.Write(x.k2[:])
+	h.Write(iv)
+	return h.Sum(nil)[:x.keyLength]
+}
+
+func (x *XRC4) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 4 {
+		df.SetTruncated()
+		return fmt.Errorf("xRC4 payload must be at least 4 bytes for the data "+
+			"offset, got %v", len(data))
+	}
+	x.DataOffset = binary.LittleEndian.Uint32(data[0:4])
+	headerLength := 4
+	if x.DataOffset == 0 {
+		headerLength += xRC4IVLength
+		if len(data) < headerLength {
+			df.SetTruncated()
+			return fmt.Errorf("xRC4 payload initialising keystream must be at "+
+				"least %v bytes, got %v", headerLength, len(data))
+		}
+		x.inbound.initialise(x.key(data[4:headerLength]))
+	}
+	x.BaseLayer.Contents = data[:headerLength]
+	x.BaseLayer.Payload = data[headerLength:]
+	// as with AES-CBC-128, we decrypt in place
+	return x.inbound.xor(x.DataOffset, x.BaseLayer.Payload)
+}
+
+func (x *XRC4) SerializeTo(b gopacket.SerializeBuffer, _ gopacket.SerializeOptions) error {
+	// secure random IV for confidentiality header, re-initialising the
+	// keystream
+	iv := [xRC4IVLength]byte{}
+	if _, err := rand.Read(iv[:]); err != nil {
+		return err
+	}
+	c, err := rc4.NewCipher(x.key(iv[:]))
+	if err != nil {
+		return err
+	}
+	// encrypt before prepending, which may move the payload
+	toEncrypt := b.Bytes()
+	c.XORKeyStream(toEncrypt, toEncrypt)
+
+	header, err := b.PrependBytes(4 + xRC4IVLength)
+	if err != nil {
+		return err
+	}
+	x.DataOffset = 0
+	binary.LittleEndian.PutUint32(header[0:4], x.DataOffset)
+	copy(header[4:], iv[:])
+	return nil
+}
+
+// xRC4Keystream tracks the managed system's position in its keystream. Each
+// packet the BMC sends may be offered to several layers, e.g. if it is a late
+// response to a request that timed out, and packets may arrive out of order,
+// so this keeps the keystream bytes of the last payload decrypted, and
+// regenerates the keystream from the start if we are asked to go backwards.
+// It is safe for concurrent use.
+type xRC4Keystream struct {
+	mu sync.Mutex
+
+	// key is the keystream key derived from the last initialisation vector
+	// received, or nil if we have yet to receive one.
+	key []byte
+
+	// cipher generates the keystream from position onwards.
+	cipher   *rc4.Cipher
+	position uint32
+
+	// window contains the keystream bytes from windowStart to position.
+	window      []byte
+	windowStart uint32
+}
+
+// initialise prepares the keystream for a given key, unless it is already
+// using it.
+func (k *xRC4Keystream) initialise(key []byte) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if subtle.ConstantTimeCompare(k.key, key) == 1 {
+		return
+	}
+	k.key = key
+	k.restart()
+}
+
+// restart begins generating the keystream from the start. The lock must be
+// held.
+func (k *xRC4Keystream) restart() {
+	// cannot fail, as the key length is always between 1 and 256 bytes
+	k.cipher, _ = rc4.NewCipher(k.key)
+	k.position = 0
+	k.window = k.window[:0]
+	k.windowStart = 0
+}
+
+// xor combines data with the keystream starting at offset.
+func (k *xRC4Keystream) xor(offset uint32, data []byte) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.key == nil {
+		return fmt.Errorf("cannot decrypt data at offset %v of the xRC4 "+
+			"keystream without having received an initialisation vector",
+			offset)
+	}
+	end := offset + uint32(len(data))
+	if offset < k.windowStart || end > k.position {
+		if offset < k.position {
+			k.restart()
+		}
+		discard := [256]byte{}
+		for k.position < offset {
+			n := min(offset-k.position, uint32(len(discard)))
+			k.cipher.XORKeyStream(discard[:n], discard[:n])
+			k.position += n
+		}
+		if cap(k.window) < len(data) {
+			k.window = make([]byte, len(data))
+		} else {
+			k.window = k.window[:len(data)]
+			clear(k.window)
+		}
+		k.cipher.XORKeyStream(k.window, k.window)
+		k.windowStart = offset
+		k.position = end
+	}
+	subtle.XORBytes(data, data, k.window[offset-k.windowStart:])
+	return nil
+}
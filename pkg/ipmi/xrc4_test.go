@@ -0,0 +1,107 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/gopacket"
+)
+
+var (
+	xRC4TestK2 = [16]byte{
+		0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09,
+		0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
+	}
+	xRC4TestIV = []byte{
+		0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19,
+		0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f,
+	}
+)
+
+func TestXRC4DecodeFromBytes(t *testing.T) {
+	first := []byte{0x81, 0x1c, 0x63, 0x20, 0x04, 0x37, 0x85}
+	second := []byte{0x01, 0x02, 0x03, 0x04, 0x05}
+	table := []struct {
+		name string
+		new  func([16]byte) *XRC4
+
+		// encrypted first and second messages, which are consecutive in the
+		// keystream. These were generated with OpenSSL's RC4 implementation,
+		// e.g. openssl enc -rc4-40 -K b4ffcb2373 for xRC4-40, where the key is
+		// the MD5 of K2 followed by the IV, truncated to the key length.
+		first, second []byte
+	}{
+		{
+			name:   "xRC4-128",
+			new:    NewXRC4128,
+			first:  []byte{0x47, 0x8a, 0x21, 0xf9, 0x18, 0x7d, 0x84},
+			second: []byte{0xd4, 0x18, 0x0b, 0x03, 0x99},
+		},
+		{
+			name:   "xRC4-40",
+			new:    NewXRC440,
+			first:  []byte{0x96, 0x0f, 0xa2, 0xb2, 0x51, 0xf5, 0xa2},
+			second: []byte{0x0d, 0xd4, 0x81, 0x26, 0x45},
+		},
+	}
+	for _, test := range table {
+		layer := test.new(xRC4TestK2)
+		fork := layer.Fork()
+
+		// first message initialises the keystream
+		firstData := append([]byte{0x00, 0x00, 0x00, 0x00}, xRC4TestIV...)
+		firstData = append(firstData, test.first...)
+		// second message continues at offset 7, with no IV
+		secondData := append([]byte{0x07, 0x00, 0x00, 0x00}, test.second...)
+
+		// decode on different layers to check the keystream is shared, and
+		// decode the first message again, as a late response would be
+		for _, step := range []struct {
+			layer *XRC4
+			data  []byte
+			want  []byte
+		}{
+			{layer, firstData, first},
+			{fork, secondData, second},
+			{layer, firstData, first},
+		} {
+			data := append([]byte(nil), step.data...)
+			if err := step.layer.DecodeFromBytes(data,
+				gopacket.NilDecodeFeedback); err != nil {
+				t.Errorf("%v: unexpected error: %v", test.name, err)
+				continue
+			}
+			if !bytes.Equal(step.layer.Payload, step.want) {
+				t.Errorf("%v: decode %v = %v, want %v", test.name, step.data,
+					step.layer.Payload, step.want)
+			}
+		}
+	}
+}
+
+func TestXRC4DecodeFromBytesNoIV(t *testing.T) {
+	layer := NewXRC4128(xRC4TestK2)
+	if err := layer.DecodeFromBytes([]byte{0x07, 0x00, 0x00, 0x00, 0x01},
+		gopacket.NilDecodeFeedback); err == nil {
+		t.Errorf("expected error decoding without an IV, got none")
+	}
+}
+
+func TestXRC4SerializeTo(t *testing.T) {
+	message := []byte{0x81, 0x1c, 0x63, 0x20, 0x04, 0x37, 0x85}
+	sender := NewXRC4128(xRC4TestK2)
+	buf := gopacket.NewSerializeBuffer()
+	if err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{},
+		sender, gopacket.Payload(message)); err != nil {
+		t.Fatal(err)
+	}
+
+	receiver := NewXRC4128(xRC4TestK2)
+	if err := receiver.DecodeFromBytes(buf.Bytes(),
+		gopacket.NilDecodeFeedback); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(receiver.Payload, message) {
+		t.Errorf("round trip of %v produced %v", message, receiver.Payload)
+	}
+}
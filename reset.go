@@ -0,0 +1,78 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gebn/bmc/pkg/ipmi"
+)
+
+// resetPollInterval is how long ResetBMC waits after issuing the reset before
+// first trying to reconnect, and between subsequent attempts. BMCs typically
+// take between 30 seconds and a few minutes to return.
+const resetPollInterval = time.Second * 5
+
+// ResetBMC cold resets the BMC over s, then waits until it answers Get Device
+// ID within a new session, established over a fresh DialV2 connection to addr
+// with the provided options. This is useful to recover a wedged BMC. The
+// reset ends all sessions, so s and its transport cannot be used afterwards,
+// and should be closed. The BMC may reset before responding, so a reset
+// command that times out is assumed to have succeeded; only a non-normal
+// completion code is returned as an error. The BMC may also keep answering
+// for a short time before it resets, so it must fail to answer at least once
+// before it is considered to have returned. Reconnection is attempted until
+// the context is cancelled, whose error is returned along with the last
+// failure.
+func ResetBMC(ctx context.Context, s Session, addr string, opts *V2SessionOpts, dialOpts ...DialConfigOption) error {
+	return resetBMC(ctx, s, resetPollInterval, func(ctx context.Context) error {
+		return probeBMC(ctx, addr, opts, dialOpts)
+	})
+}
+
+// resetBMC implements ResetBMC, calling probe until it fails, showing the BMC
+// has gone down, then until it succeeds, showing the BMC has returned.
+func resetBMC(ctx context.Context, s Session, interval time.Duration, probe func(context.Context) error) error {
+	// a timeout or other transport error likely means the BMC reset before
+	// it could respond
+	if code, err := s.SendCommand(ctx, &ipmi.ColdResetCmd{}); err == nil {
+		if err := ValidateResponse(code, nil); err != nil {
+			return err
+		}
+	}
+	down := false
+	var err error
+	for {
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				return fmt.Errorf("%v; last attempt failed: %v", ctx.Err(), err)
+			}
+			return fmt.Errorf("%v; BMC did not stop answering after the "+
+				"reset", ctx.Err())
+		case <-time.After(interval):
+		}
+		if err = probe(ctx); err != nil {
+			down = true
+		} else if down {
+			return nil
+		}
+	}
+}
+
+// probeBMC establishes a new connection and session with the BMC, sends Get
+// Device ID, and tears them down.
+func probeBMC(ctx context.Context, addr string, opts *V2SessionOpts, dialOpts []DialConfigOption) error {
+	machine, err := DialV2(addr, dialOpts...)
+	if err != nil {
+		return err
+	}
+	defer machine.Close()
+	sess, err := machine.NewV2Session(ctx, opts)
+	if err != nil {
+		return err
+	}
+	defer sess.Close(ctx)
+	_, err = sess.GetDeviceID(ctx)
+	return err
+}
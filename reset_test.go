@@ -0,0 +1,112 @@
+package bmc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gebn/bmc/pkg/ipmi"
+)
+
+// newResetSession returns a session responding to Cold Reset with a fixed
+// completion code and error.
+func newResetSession(code ipmi.CompletionCode, err error) *fakeSession {
+	s := &fakeSession{}
+	handle(s, func(*ipmi.ColdResetCmd) (ipmi.CompletionCode, error) {
+		return code, err
+	})
+	return s
+}
+
+func TestResetBMC(t *testing.T) {
+	errUnreachable := errors.New("unreachable")
+	tests := []struct {
+		name    string
+		session *fakeSession
+
+		// probes are the results of successive probes; any further probes
+		// succeed
+		probes     []error
+		wantProbes int
+		wantErr    bool
+	}{
+		{
+			name:       "responds before reset",
+			session:    newResetSession(ipmi.CompletionCodeNormal, nil),
+			probes:     []error{errUnreachable, errUnreachable},
+			wantProbes: 3,
+		},
+		{
+			name:       "resets before responding",
+			session:    newResetSession(0, context.DeadlineExceeded),
+			probes:     []error{errUnreachable},
+			wantProbes: 2,
+		},
+		{
+			name:       "answers before going down",
+			session:    newResetSession(ipmi.CompletionCodeNormal, nil),
+			probes:     []error{nil, nil, errUnreachable},
+			wantProbes: 4,
+		},
+		{
+			name:    "reset rejected",
+			session: newResetSession(ipmi.CompletionCodeInsufficientPrivileges, nil),
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			probes := 0
+			err := resetBMC(context.Background(), test.session, time.Millisecond,
+				func(context.Context) error {
+					probes++
+					if probes <= len(test.probes) {
+						return test.probes[probes-1]
+					}
+					return nil
+				})
+			switch {
+			case err != nil && !test.wantErr:
+				t.Fatalf("unexpected error: %v", err)
+			case err == nil && test.wantErr:
+				t.Fatal("expected error, got none")
+			}
+			if probes != test.wantProbes {
+				t.Errorf("probed %v times, want %v", probes, test.wantProbes)
+			}
+		})
+	}
+}
+
+func TestResetBMCCancelled(t *testing.T) {
+	tests := []struct {
+		name  string
+		probe func(context.Context) error
+	}{
+		{
+			name: "never returns",
+			probe: func(context.Context) error {
+				return errors.New("unreachable")
+			},
+		},
+		{
+			name: "never goes down",
+			probe: func(context.Context) error {
+				return nil
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(),
+				time.Millisecond*20)
+			defer cancel()
+			session := newResetSession(ipmi.CompletionCodeNormal, nil)
+			if err := resetBMC(ctx, session, time.Millisecond,
+				test.probe); err == nil {
+				t.Error("expected error once context expired, got none")
+			}
+		})
+	}
+}
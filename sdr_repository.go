@@ -21,13 +21,62 @@ var (
 		"the SDR Repository was modified during enumeration")
 )
 
-// SDRRepository is a retrieved SDR Repository. For the time being, this is a
-// collection of Full Sensor Records, indexed by record ID. Note that because
-// this is a map, iteration order is randomised and almost definitely not the
-// same as retrieval order, which has no guarantees anyway.
-type SDRRepository map[ipmi.RecordID]*ipmi.FullSensorRecord
+// SDRRepository is a retrieved SDR Repository. It contains the sensor
+// records, i.e. Full Sensor Records, Compact Sensor Records and Event-Only
+// Records, indexed by record ID. Note that because this is a map, iteration
+// order is randomised and almost definitely not the same as retrieval order,
+// which has no guarantees anyway.
+type SDRRepository map[ipmi.RecordID]ipmi.SensorRecord
 
-// RetrieveSDRRepository enumerates all Full Sensor Records in the BMC's SDR
+// FullSensorRecords returns the subset of the repository that are Full Sensor
+// Records, indexed by record ID. These are the only records that can describe
+// sensors with analogue readings.
+func (r SDRRepository) FullSensorRecords() map[ipmi.RecordID]*ipmi.FullSensorRecord {
+	records := map[ipmi.RecordID]*ipmi.FullSensorRecord{}
+	for id, record := range r {
+		if fsr, ok := record.(*ipmi.FullSensorRecord); ok {
+			records[id] = fsr
+		}
+	}
+	return records
+}
+
+// SensorRecord returns the record describing the sensor identified by a
+// record key, or nil if the repository does not contain it. Records shared by
+// several sensors are expanded, so the returned record's number, instance and
+// name are those of the sensor. This can be used to find the entity of the
+// sensor that generated a system event.
+func (r SDRRepository) SensorRecord(key ipmi.SensorRecordKey) ipmi.SensorRecord {
+	for _, record := range r.expand() {
+		if record.Key() == key {
+			return record
+		}
+	}
+	return nil
+}
+
+// expand returns the records in the repository, with records shared by
+// several sensors expanded into one per sensor.
+func (r SDRRepository) expand() []ipmi.SensorRecord {
+	records := []ipmi.SensorRecord{}
+	for _, record := range r {
+		switch record := record.(type) {
+		case *ipmi.CompactSensorRecord:
+			for _, shared := range record.Expand() {
+				records = append(records, shared)
+			}
+		case *ipmi.EventOnlyRecord:
+			for _, shared := range record.Expand() {
+				records = append(records, shared)
+			}
+		default:
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// RetrieveSDRRepository enumerates all sensor records in the BMC's SDR
 // Repository. This method will back-off if an error occurs, or it detects a
 // change mid-way through iteration, which would invalidate records retrieved so
 // far. The session-configured timeout is used for individual commands.
@@ -66,7 +115,7 @@ func RetrieveSDRRepository(ctx context.Context, s Session) (SDRRepository, error
 // changing behind its back.
 //
 // For each SDR, it starts by requesting the header and inspecting the type. If
-// it's a FullSensorRecord, it then requests the key fields and body. Otherwise,
+// it's a sensor record, it then requests the key fields and body. Otherwise,
 // it skips to the next SDR.
 // This is more expensive than reading the entire SDR at once, but it's
 // resilient to BMCs that return a malformed packet when the request's Length is
@@ -107,7 +156,7 @@ func walkSDRs(ctx context.Context, s Session) (SDRRepository, error) {
 		}
 		header := headerLayer.(*ipmi.SDR)
 
-		if header.Type == ipmi.RecordTypeFullSensor {
+		if layerType := header.Type.NextLayerType(); layerType != gopacket.LayerTypePayload {
 			if header.Length > sdrMaxLength {
 				// SDR exceeds the specified max length, which means we need to implement
 				// partial reading. Hopefully we'll be alright - yet to see a SDR >70 bytes
@@ -121,17 +170,18 @@ func walkSDRs(ctx context.Context, s Session) (SDRRepository, error) {
 			if err := ValidateResponse(s.SendCommand(ctx, getSDRCmd)); err != nil {
 				return nil, err
 			}
-			fsrPacket := gopacket.NewPacket(getSDRCmd.Rsp.Payload, ipmi.LayerTypeFullSensorRecord,
+			recordPacket := gopacket.NewPacket(getSDRCmd.Rsp.Payload, layerType,
 				gopacket.DecodeOptions{Lazy: true})
-			if fsrPacket == nil {
-				return nil, fmt.Errorf("invalid Full Sensor Record: %v", getSDRCmd)
-			}
-			fsrLayer := fsrPacket.Layer(ipmi.LayerTypeFullSensorRecord)
-			if fsrLayer == nil {
-				return nil, fmt.Errorf("packet is missing Full Sensor Record layer: %v",
+			if recordPacket == nil {
+				return nil, fmt.Errorf("invalid %v: %v", header.Type.Description(),
 					getSDRCmd)
 			}
-			repo[getSDRCmd.Req.RecordID] = fsrLayer.(*ipmi.FullSensorRecord)
+			recordLayer := recordPacket.Layer(layerType)
+			if recordLayer == nil {
+				return nil, fmt.Errorf("packet is missing %v layer: %v",
+					header.Type.Description(), getSDRCmd)
+			}
+			repo[getSDRCmd.Req.RecordID] = recordLayer.(ipmi.SensorRecord)
 		}
 
 		getSDRCmd.Req.RecordID = getSDRCmd.Rsp.Next
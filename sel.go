@@ -0,0 +1,147 @@
+package bmc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gebn/bmc/pkg/ipmi"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/google/gopacket"
+)
+
+const (
+	// selEntireRecord is the Get SEL Entry length to retrieve the whole
+	// record. SEL records are 16 bytes, so unlike SDRs, there is no need to
+	// read them in parts.
+	selEntireRecord = 0xff
+
+	// selClearPollInterval is how often to check whether the BMC has finished
+	// erasing the SEL.
+	selClearPollInterval = time.Millisecond * 250
+)
+
+var (
+	errSELModified = errors.New(
+		"the SEL was modified during enumeration")
+)
+
+// SELEntry is a single record in the System Event Log.
+type SELEntry struct {
+
+	// ID is the Record ID of the entry at the time of retrieval.
+	ID ipmi.RecordID
+
+	// Type is the record type, which indicates whether SystemEvent is set.
+	Type ipmi.SELRecordType
+
+	// SystemEvent contains the decoded record if Type is
+	// ipmi.SELRecordTypeSystemEvent, otherwise it is nil.
+	SystemEvent *ipmi.SystemEventRecord
+
+	// Data contains the 13 bytes following the record header. This is
+	// mainly useful for OEM records, which cannot be decoded in a standard
+	// way.
+	Data []byte
+}
+
+// SEL is a retrieved System Event Log, in the order returned by the BMC. This
+// is usually oldest first, but the specification makes no guarantee.
+type SEL []*SELEntry
+
+// RetrieveSEL enumerates all records in the BMC's System Event Log. Like
+// RetrieveSDRRepository(), this method will back-off if an error occurs, or it
+// detects a change mid-way through iteration. Note that events logged during
+// retrieval count as a change. The session-configured timeout is used for
+// individual commands.
+func RetrieveSEL(ctx context.Context, s Session) (SEL, error) {
+	var sel SEL
+	err := backoff.Retry(func() error {
+		initialInfo, err := s.GetSELInfo(ctx)
+		if err != nil {
+			return err
+		}
+		candidateSEL, err := walkSEL(ctx, s, initialInfo.Entries)
+		if err != nil {
+			return err
+		}
+		finalInfo, err := s.GetSELInfo(ctx)
+		if err != nil {
+			return err
+		}
+		if initialInfo.LastAddition.Before(finalInfo.LastAddition) ||
+			initialInfo.LastErase.Before(finalInfo.LastErase) {
+			// tough luck, start again
+			return errSELModified
+		}
+		sel = candidateSEL
+		return nil
+	}, backoff.WithContext(backoff.NewExponentialBackOff(), ctx))
+	if err != nil {
+		return nil, err
+	}
+	return sel, nil
+}
+
+// walkSEL iterates over the SEL, reading each record in its entirety. It is
+// not concerned with the SEL changing behind its back. The number of entries
+// is used as a size hint only.
+func walkSEL(ctx context.Context, s Session, entries uint16) (SEL, error) {
+	sel := make(SEL, 0, entries)
+	if entries == 0 {
+		// an empty SEL will fail with 0xcb on the first Get SEL Entry
+		return sel, nil
+	}
+	reserveSELCmdResp, err := s.ReserveSEL(ctx)
+	if err != nil {
+		return nil, err
+	}
+	getSELEntryCmd := &ipmi.GetSELEntryCmd{
+		Req: ipmi.GetSELEntryReq{
+			ReservationID: reserveSELCmdResp.ReservationID,
+			RecordID:      ipmi.RecordIDFirst,
+			Length:        selEntireRecord,
+		},
+	}
+
+	// unlike the SDR Repository, the final record has only one Record ID,
+	// with Next set to ipmi.RecordIDLast
+	for getSELEntryCmd.Req.RecordID != ipmi.RecordIDLast {
+		if err := ValidateResponse(s.SendCommand(ctx, getSELEntryCmd)); err != nil {
+			return nil, err
+		}
+		packet := gopacket.NewPacket(getSELEntryCmd.Rsp.Payload, ipmi.LayerTypeSELRecord,
+			gopacket.DecodeOptions{
+				Lazy: true,
+				// we can't set NoCopy because we reuse getSELEntryCmd.Rsp
+			})
+		if packet == nil {
+			return nil, fmt.Errorf("invalid SEL record: %v", getSELEntryCmd)
+		}
+		headerLayer := packet.Layer(ipmi.LayerTypeSELRecord)
+		if headerLayer == nil {
+			return nil, fmt.Errorf("packet is missing SEL record layer: %v",
+				getSELEntryCmd)
+		}
+		header := headerLayer.(*ipmi.SELRecord)
+		entry := &SELEntry{
+			ID:   header.ID,
+			Type: header.Type,
+			Data: header.Payload,
+		}
+		if header.Type == ipmi.SELRecordTypeSystemEvent {
+			eventLayer := packet.Layer(ipmi.LayerTypeSystemEventRecord)
+			if eventLayer == nil {
+				return nil, fmt.Errorf("packet is missing System Event Record layer: %v",
+					getSELEntryCmd)
+			}
+			entry.SystemEvent = eventLayer.(*ipmi.SystemEventRecord)
+		}
+		sel = append(sel, entry)
+
+		getSELEntryCmd.Req.RecordID = getSELEntryCmd.Rsp.Next
+	}
+	return sel, nil
+}
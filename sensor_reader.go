@@ -38,12 +38,13 @@ type SensorReader interface {
 // NewSensorReader returns an appropriate SensorReader implementation for a
 // given SDR.
 func NewSensorReader(r *ipmi.FullSensorRecord) (SensorReader, error) {
-	// TODO non-linear
 	switch {
 	case r.Linearisation.IsLinear():
 		return newLinearSensorReader(r)
 	case r.Linearisation.IsLinearised():
 		return newLinearisedSensorReader(r)
+	case r.Linearisation.IsNonLinear():
+		return newNonLinearSensorReader(r)
 	default:
 		return nil, fmt.Errorf("unsupported sensor linearisation: %v",
 			r.Linearisation)
@@ -88,8 +89,18 @@ func (r *linearSensorReader) Read(ctx context.Context, s Session) (float64, erro
 	if !r.readingCmd.Rsp.ScanningEnabled {
 		return 0, ErrSensorScanningDisabled
 	}
-	parsed := r.parser.Parse(r.readingCmd.Rsp.Reading)
-	return r.factors.ConvertReading(parsed), nil
+	return r.factors.ConvertReading(r.parser.Parse(r.readingCmd.Rsp.Reading)), nil
+}
+
+// convert applies analog data format parsing and conversion factors to a raw
+// value, which can be a reading or a threshold.
+func (r *linearSensorReader) convert(_ context.Context, _ Session, raw byte) (float64, error) {
+	return r.factors.ConvertReading(r.parser.Parse(raw)), nil
+}
+
+// response returns the most recent Get Sensor Reading response.
+func (r *linearSensorReader) response() *ipmi.GetSensorReadingRsp {
+	return &r.readingCmd.Rsp
 }
 
 // linearisedSensorReader implements a reader for linearised sensors. These are
@@ -122,3 +133,86 @@ func (r *linearisedSensorReader) Read(ctx context.Context, s Session) (float64,
 	}
 	return r.lineariser.Linearise(reading), nil
 }
+
+func (r *linearisedSensorReader) convert(ctx context.Context, s Session, raw byte) (float64, error) {
+	converted, err := r.linearReader.convert(ctx, s, raw)
+	if err != nil {
+		return 0, err
+	}
+	return r.lineariser.Linearise(converted), nil
+}
+
+func (r *linearisedSensorReader) response() *ipmi.GetSensorReadingRsp {
+	return r.linearReader.response()
+}
+
+// nonLinearSensorReader implements a reader for non-linear sensors, including
+// OEM non-linear sensors. These have conversion factors that vary by raw
+// reading, so must be obtained from the BMC via Get Sensor Reading Factors.
+// The factors for a given raw value never change, so are cached to avoid a
+// second round trip for steady readings.
+type nonLinearSensorReader struct {
+	readingCmd ipmi.GetSensorReadingCmd
+	factorsCmd ipmi.GetSensorReadingFactorsCmd
+	parser     ipmi.AnalogDataFormatParser
+
+	// factors contains conversion factors retrieved so far, keyed by raw
+	// value.
+	factors map[uint8]ipmi.ConversionFactors
+}
+
+func newNonLinearSensorReader(r *ipmi.FullSensorRecord) (*nonLinearSensorReader, error) {
+	parser, err := r.AnalogDataFormat.Parser()
+	if err != nil {
+		// sensor does not provide analog readings
+		return nil, err
+	}
+	return &nonLinearSensorReader{
+		readingCmd: ipmi.GetSensorReadingCmd{
+			Req: ipmi.GetSensorReadingReq{
+				Number: r.Number,
+			},
+			OwnerLUN: r.OwnerLUN,
+		},
+		factorsCmd: ipmi.GetSensorReadingFactorsCmd{
+			Req: ipmi.GetSensorReadingFactorsReq{
+				Number: r.Number,
+			},
+			OwnerLUN: r.OwnerLUN,
+		},
+		parser:  parser,
+		factors: map[uint8]ipmi.ConversionFactors{},
+	}, nil
+}
+
+func (r *nonLinearSensorReader) Read(ctx context.Context, s Session) (float64, error) {
+	if err := ValidateResponse(s.SendCommand(ctx, &r.readingCmd)); err != nil {
+		return 0, err
+	}
+	if r.readingCmd.Rsp.ReadingUnavailable {
+		return 0, ErrSensorReadingUnavailable
+	}
+	if !r.readingCmd.Rsp.ScanningEnabled {
+		return 0, ErrSensorScanningDisabled
+	}
+	return r.convert(ctx, s, r.readingCmd.Rsp.Reading)
+}
+
+// convert applies the conversion factors for a raw value, retrieving them
+// from the BMC if they are not already cached.
+func (r *nonLinearSensorReader) convert(ctx context.Context, s Session, raw byte) (float64, error) {
+	factors, ok := r.factors[raw]
+	if !ok {
+		r.factorsCmd.Req.Reading = raw
+		if err := ValidateResponse(s.SendCommand(ctx, &r.factorsCmd)); err != nil {
+			return 0, err
+		}
+		factors = r.factorsCmd.Rsp.ConversionFactors
+		r.factors[raw] = factors
+	}
+	return factors.ConvertReading(r.parser.Parse(raw)), nil
+}
+
+func (r *nonLinearSensorReader) response() *ipmi.GetSensorReadingRsp {
+	return &r.readingCmd.Rsp
+}
@@ -0,0 +1,44 @@
+package bmc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gebn/bmc/pkg/ipmi"
+)
+
+func TestNonLinearSensorReaderCachesFactors(t *testing.T) {
+	reader, err := NewSensorReader(&ipmi.FullSensorRecord{
+		SensorType:       ipmi.SensorTypeCurrent,
+		OutputType:       ipmi.OutputTypeThreshold,
+		AnalogDataFormat: ipmi.AnalogDataFormatUnsigned,
+		Linearisation:    ipmi.LinearisationNonLinear,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeSession{}
+	handle(s, func(c *ipmi.GetSensorReadingCmd) (ipmi.CompletionCode, error) {
+		// raw 20, scanning enabled
+		return respond(c, ipmi.CompletionCodeNormal, []byte{0x14, 0x40, 0x00})
+	})
+	factors := 0
+	handle(s, func(c *ipmi.GetSensorReadingFactorsCmd) (ipmi.CompletionCode, error) {
+		factors++
+		// M = 3, B = 5, R exp = -1
+		return respond(c, ipmi.CompletionCodeNormal,
+			[]byte{0x15, 0x03, 0x00, 0x05, 0x00, 0x00, 0xf0})
+	})
+	for i := 0; i < 2; i++ {
+		got, err := reader.Read(context.Background(), s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := 6.5; got != want {
+			t.Errorf("Read() = %v, want %v", got, want)
+		}
+	}
+	if factors != 1 {
+		t.Errorf("sent Get Sensor Reading Factors %v times, want 1", factors)
+	}
+}
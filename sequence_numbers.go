@@ -1,5 +1,20 @@
 package bmc
 
+import (
+	"sync"
+
+	"github.com/gebn/bmc/pkg/ipmi"
+)
+
+const (
+	// sequenceWindowBehind and sequenceWindowAhead define the sliding window
+	// of outbound sequence numbers accepted in an IPMI v2.0 session, relative
+	// to the highest received so far. Section 6.12.13 of IPMI v2.0 specifies a
+	// 32 packet window: the 16 preceding, and 15 following.
+	sequenceWindowBehind = 16
+	sequenceWindowAhead  = 15
+)
+
 // sequenceNumbers maintains a pair of sequence numbers for a session. In IPMI
 // v1.5, there is one set for all packets. In IPMI v2.0, there is one set for
 // authenticated packets, and another for unauthenticated packets. The first
@@ -14,6 +29,66 @@ type sequenceNumbers struct {
 	Inbound uint32
 
 	// Outbound is the sequence number of the last packet the managed system
-	// sent to the remote console.
+	// sent to the remote console. In IPMI v2.0, this is the highest sequence
+	// number accepted, and is protected by mu.
 	Outbound uint32
+
+	// mu protects Outbound and received in IPMI v2.0 sessions. IPMI v1.5
+	// sessions access Outbound atomically.
+	mu sync.Mutex
+
+	// received records which of the sequenceWindowBehind sequence numbers
+	// preceding Outbound have been accepted: bit i is set if Outbound - (i +
+	// 1) has been seen.
+	received uint16
+}
+
+// acceptOutbound returns whether a sequence number received from the managed
+// system falls within the window and has not been seen before, recording it if
+// so. It is only used by IPMI v2.0 sessions.
+func (n *sequenceNumbers) acceptOutbound(sequence uint32) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	// signed, so the window works across wraparound
+	delta := int32(sequence - n.Outbound)
+	switch {
+	case delta > sequenceWindowAhead:
+		return false
+	case delta > 0:
+		received := uint32(n.received) << delta
+		if n.Outbound != 0 {
+			// the previous highest is now behind the new one; 0 is never
+			// received, as sequence numbers start at 1
+			received |= 1 << (delta - 1)
+		}
+		n.received = uint16(received)
+		n.Outbound = sequence
+		return true
+	case delta == 0, delta < -sequenceWindowBehind:
+		return false
+	default:
+		bit := uint16(1) << (-delta - 1)
+		if n.received&bit != 0 {
+			return false
+		}
+		n.received |= bit
+		return true
+	}
+}
+
+// acceptV2Sequence checks the sequence number of a packet received within an
+// IPMI v2.0 session against the window of the relevant pair of sequence
+// numbers, counting rejections. Authenticated and unauthenticated packets are
+// numbered independently.
+func acceptV2Sequence(authenticated, unauthenticated *sequenceNumbers, layer *ipmi.V2Session) bool {
+	numbers := authenticated
+	if !layer.Authenticated {
+		numbers = unauthenticated
+	}
+	if !numbers.acceptOutbound(layer.Sequence) {
+		sessionRejectedSequenceNumbers.Inc()
+		return false
+	}
+	return true
 }
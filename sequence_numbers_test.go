@@ -0,0 +1,65 @@
+package bmc
+
+import (
+	"testing"
+)
+
+func TestSequenceNumbersAcceptOutbound(t *testing.T) {
+	tests := []struct {
+		name      string
+		sequences []uint32
+		want      []bool
+	}{
+		{
+			"in order",
+			[]uint32{1, 2, 3},
+			[]bool{true, true, true},
+		},
+		{
+			"zero is never valid",
+			[]uint32{0, 1},
+			[]bool{false, true},
+		},
+		{
+			"duplicate of highest",
+			[]uint32{1, 1},
+			[]bool{true, false},
+		},
+		{
+			"out of order",
+			[]uint32{1, 3, 2, 2, 4},
+			[]bool{true, true, true, false, true},
+		},
+		{
+			"duplicate of previous highest",
+			[]uint32{5, 6, 5},
+			[]bool{true, true, false},
+		},
+		{
+			"too far ahead",
+			[]uint32{1, 17, 16},
+			[]bool{true, false, true},
+		},
+		{
+			"too far behind",
+			[]uint32{1, 16, 31, 15, 14},
+			[]bool{true, true, true, true, false},
+		},
+		{
+			"wraparound",
+			[]uint32{0xfffffff0, 0xffffffff, 2, 0xfffffffe, 0xffffffff},
+			[]bool{true, true, true, true, false},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			n := &sequenceNumbers{}
+			for i, sequence := range test.sequences {
+				if got := n.acceptOutbound(sequence); got != test.want[i] {
+					t.Errorf("acceptOutbound(%v) = %v, want %v", sequence,
+						got, test.want[i])
+				}
+			}
+		})
+	}
+}
@@ -22,9 +22,6 @@ var (
 	// we could time session establishment, however do we really care, provided
 	// it succeeds? would also be a very sparse histogram
 
-	// session re-opens must be tracked by the user of the library; we don't
-	// have any visibility here (at least not currently)
-
 	sessionOpenAttempts = promauto.NewCounter(prometheus.CounterOpts{
 		Namespace: namespace,
 		Subsystem: "session",
@@ -45,6 +42,33 @@ var (
 		Help: "The number of sessions currently established. We regard " +
 			"sessions that failed to close cleanly as closed.",
 	})
+
+	// re-opens are only visible to us for managed sessions; users managing
+	// their own sessions must track these themselves
+	sessionReopenAttempts = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "session",
+		Name:      "reopen_attempts_total",
+		Help: "The number of times a managed session has begun " +
+			"re-establishing its session, having found it expired.",
+	})
+	sessionReopenFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "session",
+		Name:      "reopen_failures_total",
+		Help: "The number of times a managed session failed to re-establish " +
+			"its session.",
+	})
+
+	sessionRejectedSequenceNumbers = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "session",
+		Name:      "rejected_sequence_numbers_total",
+		Help: "The number of packets received within an RMCP+ session that " +
+			"were discarded because their sequence number was outside the " +
+			"window or had already been seen, e.g. because they were " +
+			"replayed.",
+	})
 )
 
 // Session is an established session-based IPMI v1.5 or 2.0 connection. More
@@ -89,16 +113,16 @@ type SessionOpts struct {
 	Password []byte
 
 	// MaxPrivilegeLevel is the upper privilege limit for the session. It
-	// defaults to ipmi.PrivilegeLevelHighest for IPMI v2.0, and
-	// ipmi.PrivilegeLevelUser for IPMI v1.5, which does not have that value,
-	// however the channel or user privilege level limit may further constrain
-	// allowed commands for either version.
+	// defaults to ipmi.PrivilegeLevelHighest, however the channel or user
+	// privilege level limit may further constrain allowed commands. IPMI v1.5
+	// does not have that value, so for v1.5 sessions, it is emulated by
+	// requesting Administrator, then Operator, then User, using the first the
+	// BMC accepts. The session is raised to the level obtained.
 	//
-	// Due to the deliberate inconsistency between IPMI versions, it is always
-	// recommended to explicitly set this. Unfortunately it was not possible to
-	// default to User for v2.0 as, because Highest has value 0, we cannot
-	// distinguish between it being implicitly and explicitly set, so we don't
-	// know when to intervene.
+	// As Highest may grant more privileges than needed, and costs extra
+	// round-trips in v1.5, it is always recommended to explicitly set this.
+	// Because Highest has value 0, we cannot distinguish between it being
+	// implicitly and explicitly set.
 	MaxPrivilegeLevel ipmi.PrivilegeLevel
 
 	// timeout is inherited from the session-less connection used to create the
@@ -2,8 +2,11 @@ package bmc
 
 import (
 	"context"
+	"time"
 
 	"github.com/gebn/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket"
 )
 
 // SessionCommands contains high-level wrappers for sending commands within an
@@ -23,6 +26,59 @@ type SessionCommands interface {
 	// in 17.1 and 20.1 of IPMI v1.5 and 2.0 respectively.
 	GetDeviceID(context.Context) (*ipmi.GetDeviceIDRsp, error)
 
+	// ColdReset resets the BMC as if it had been power cycled. It is
+	// specified in 17.2 and 20.2 of IPMI v1.5 and 2.0 respectively. The
+	// session and its transport cannot be used afterwards. The BMC may reset
+	// before responding, so a timeout does not mean the reset failed. Use
+	// ResetBMC() to also wait for the BMC to return.
+	ColdReset(context.Context) error
+
+	// WarmReset resets the BMC's firmware without re-initialising its
+	// hardware. It is specified in 17.3 and 20.3 of IPMI v1.5 and 2.0
+	// respectively. Like ColdReset, the session cannot be used afterwards.
+	WarmReset(context.Context) error
+
+	// GetSelfTestResults retrieves the outcome of the BMC's power-on self
+	// test. It is specified in 17.4 and 20.4 of IPMI v1.5 and 2.0
+	// respectively.
+	GetSelfTestResults(context.Context) (*ipmi.GetSelfTestResultsRsp, error)
+
+	// SetACPIPowerState records the ACPI power state of the system and
+	// device. It is specified in 17.6 and 20.6 of IPMI v1.5 and 2.0
+	// respectively.
+	SetACPIPowerState(context.Context, *ipmi.SetACPIPowerStateReq) error
+
+	// GetACPIPowerState retrieves the last recorded ACPI power state of the
+	// system and device. It is specified in 17.7 and 20.7 of IPMI v1.5 and
+	// 2.0 respectively.
+	GetACPIPowerState(context.Context) (*ipmi.GetACPIPowerStateRsp, error)
+
+	// GetSystemInfoParameters retrieves a system info parameter, or a block
+	// of one. It is specified in 22.14b of IPMI v2.0. Use GetSystemInfo() to
+	// retrieve the strings in reassembled form.
+	GetSystemInfoParameters(context.Context, *ipmi.GetSystemInfoParametersReq) (*ipmi.GetSystemInfoParametersRsp, error)
+
+	// SetSystemInfoParameters sets a system info parameter, or a block of
+	// one. It is specified in 22.14a of IPMI v2.0. Use SetSystemInfoString()
+	// to set an entire string.
+	SetSystemInfoParameters(context.Context, *ipmi.SetSystemInfoParametersReq) error
+
+	// GetWatchdogTimer retrieves the configuration and state of the BMC's
+	// watchdog timer. It is specified in 21.7 and 27.7 of IPMI v1.5 and 2.0
+	// respectively.
+	GetWatchdogTimer(context.Context) (*ipmi.GetWatchdogTimerRsp, error)
+
+	// SetWatchdogTimer configures the BMC's watchdog timer, stopping it unless
+	// the request says otherwise. It is specified in 21.6 and 27.6 of IPMI
+	// v1.5 and 2.0 respectively. Use ArmWatchdog() to configure and start the
+	// timer.
+	SetWatchdogTimer(context.Context, *ipmi.SetWatchdogTimerReq) error
+
+	// ResetWatchdogTimer starts the BMC's watchdog timer, or restarts its
+	// countdown if it is running. It is specified in 21.5 and 27.5 of IPMI
+	// v1.5 and 2.0 respectively.
+	ResetWatchdogTimer(context.Context) error
+
 	// GetChassisStatus sends a Get Chassis Status command to the BMC. This is
 	// specified in 22.2 and 28.2 of IPMI v1.5 and 2.0 respectively.
 	GetChassisStatus(context.Context) (*ipmi.GetChassisStatusRsp, error)
@@ -31,6 +87,54 @@ type SessionCommands interface {
 	// specified in 22.3 and 28.3 of IPMI v1.5 and 2.0 respectively.
 	ChassisControl(context.Context, ipmi.ChassisControl) error
 
+	// ChassisIdentify activates the chassis identification mechanism, usually
+	// a flashing light, for the provided interval, or indefinitely if forced
+	// on. An interval of 0 turns it off. It is specified in 22.5 and 28.5 of
+	// IPMI v1.5 and 2.0 respectively; forcing it on requires IPMI v2.0.
+	ChassisIdentify(ctx context.Context, interval time.Duration, forceOn bool) error
+
+	// GetSystemBootOptions retrieves a boot option parameter. It is specified
+	// in 28.13 of IPMI v2.0.
+	GetSystemBootOptions(context.Context, *ipmi.GetSystemBootOptionsReq) (*ipmi.GetSystemBootOptionsRsp, error)
+
+	// SetSystemBootOptions sets a boot option parameter. It is specified in
+	// 28.12 of IPMI v2.0.
+	SetSystemBootOptions(context.Context, *ipmi.SetSystemBootOptionsReq) error
+
+	// SetBootDevice overrides the device the system boots from next time, or
+	// every time if the flags are persistent, marking the flags valid. The
+	// BMC clears non-persistent flags if the system does not restart within
+	// 60 seconds, so this should be followed by a power cycle or reset. This
+	// is a convenience wrapper around several Set System Boot Options
+	// commands, mirroring ipmitool's chassis bootdev.
+	SetBootDevice(context.Context, *ipmi.BootFlags) error
+
+	// GetLANConfigurationParameters retrieves a LAN configuration parameter.
+	// It is specified in 19.2 and 23.2 of IPMI v1.5 and 2.0 respectively. Use
+	// GetLANConfig() to retrieve the common parameters in decoded form.
+	GetLANConfigurationParameters(context.Context, *ipmi.GetLANConfigurationParametersReq) (*ipmi.GetLANConfigurationParametersRsp, error)
+
+	// SetLANConfigurationParameters sets a LAN configuration parameter. It is
+	// specified in 19.1 and 23.1 of IPMI v1.5 and 2.0 respectively. Use
+	// SetLANConfig() to set several parameters within the set in progress
+	// lock.
+	SetLANConfigurationParameters(context.Context, *ipmi.SetLANConfigurationParametersReq) error
+
+	// GetChannelInfo retrieves the medium, protocol and session support of a
+	// channel. It is specified in 18.24 and 22.24 of IPMI v1.5 and 2.0
+	// respectively. Use RetrieveChannels() to discover all channels.
+	GetChannelInfo(context.Context, ipmi.Channel) (*ipmi.GetChannelInfoRsp, error)
+
+	// GetChannelAccess retrieves the access mode and privilege limit of a
+	// channel. It is specified in 18.23 and 22.23 of IPMI v1.5 and 2.0
+	// respectively.
+	GetChannelAccess(context.Context, *ipmi.GetChannelAccessReq) (*ipmi.GetChannelAccessRsp, error)
+
+	// SetChannelAccess modifies the access mode and privilege limit of a
+	// channel. It is specified in 18.22 and 22.22 of IPMI v1.5 and 2.0
+	// respectively.
+	SetChannelAccess(context.Context, *ipmi.SetChannelAccessReq) error
+
 	// GetSDRRepositoryInfo obtains information about the BMC's Sensor Data
 	// Record Repository. It is specified in 27.9 and 33.9 of IPMI v1.5 and 2.0
 	// respectively.
@@ -42,6 +146,67 @@ type SessionCommands interface {
 	// This is specified in 33.11 of IPMI v2.0.
 	ReserveSDRRepository(context.Context) (*ipmi.ReserveSDRRepositoryRsp, error)
 
+	// GetSDRRepositoryTime retrieves the SDR Repository Device's clock. It is
+	// specified in 27.17 and 33.17 of IPMI v1.5 and 2.0 respectively.
+	GetSDRRepositoryTime(context.Context) (*ipmi.GetSDRRepositoryTimeRsp, error)
+
+	// GetSELInfo obtains information about the BMC's System Event Log. It is
+	// specified in 25.2 and 31.2 of IPMI v1.5 and 2.0 respectively.
+	GetSELInfo(context.Context) (*ipmi.GetSELInfoRsp, error)
+
+	// GetSELAllocationInfo retrieves how the SEL's storage is allocated. It is
+	// specified in 25.3 and 31.3 of IPMI v1.5 and 2.0 respectively.
+	GetSELAllocationInfo(context.Context) (*ipmi.GetSELAllocationInfoRsp, error)
+
+	// ReserveSEL sets the requester as the present "owner" of the SEL. The
+	// returned reservation ID must be included in requests that delete or
+	// partially read an entry, or clear the SEL. This is specified in 25.4 and
+	// 31.4 of IPMI v1.5 and 2.0 respectively.
+	ReserveSEL(context.Context) (*ipmi.ReserveSELRsp, error)
+
+	// GetSELEntry retrieves a single SEL record. It is specified in 25.5 and
+	// 31.5 of IPMI v1.5 and 2.0 respectively. Use RetrieveSEL() to obtain the
+	// entire SEL.
+	GetSELEntry(context.Context, *ipmi.GetSELEntryReq) (*ipmi.GetSELEntryRsp, error)
+
+	// DeleteSELEntry removes a single SEL record. It is specified in 25.8 and
+	// 31.8 of IPMI v1.5 and 2.0 respectively.
+	DeleteSELEntry(context.Context, *ipmi.DeleteSELEntryReq) (*ipmi.DeleteSELEntryRsp, error)
+
+	// ClearSEL erases all records in the SEL, blocking until the BMC reports
+	// erasure is complete. This is a convenience wrapper around Reserve SEL
+	// and several Clear SEL commands, the latter of which is specified in
+	// 25.9 and 31.9 of IPMI v1.5 and 2.0 respectively.
+	ClearSEL(context.Context) error
+
+	// GetSELTime retrieves the SEL Device's clock, which is used to timestamp
+	// SEL entries. It is specified in 25.10 and 31.10 of IPMI v1.5 and 2.0
+	// respectively. Use ClockSkew() to compare it with the local clock.
+	GetSELTime(context.Context) (*ipmi.GetSELTimeRsp, error)
+
+	// SetSELTime sets the SEL Device's clock. It is specified in 25.11 and
+	// 31.11 of IPMI v1.5 and 2.0 respectively. Use SyncClock() to set it to
+	// the local time.
+	SetSELTime(context.Context, time.Time) error
+
+	// GetSELTimeUTCOffset retrieves the offset of the BMC's local time from
+	// UTC. It is specified in 31.11a of IPMI v2.0.
+	GetSELTimeUTCOffset(context.Context) (*ipmi.GetSELTimeUTCOffsetRsp, error)
+
+	// SetSELTimeUTCOffset sets the offset of the BMC's local time from UTC.
+	// It is specified in 31.11b of IPMI v2.0.
+	SetSELTimeUTCOffset(context.Context, ipmi.UTCOffset) error
+
+	// GetFRUInventoryAreaInfo retrieves the size of a FRU device's inventory
+	// area, and whether it is accessed in bytes or words. It is specified in
+	// 28.1 and 34.1 of IPMI v1.5 and 2.0 respectively.
+	GetFRUInventoryAreaInfo(ctx context.Context, deviceID uint8) (*ipmi.GetFRUInventoryAreaInfoRsp, error)
+
+	// ReadFRUData reads part of a FRU device's inventory area. It is
+	// specified in 28.2 and 34.2 of IPMI v1.5 and 2.0 respectively. Use
+	// ReadFRUInventory() to read the entire area.
+	ReadFRUData(context.Context, *ipmi.ReadFRUDataReq) (*ipmi.ReadFRUDataRsp, error)
+
 	// GetSensorReading retrieves the current value of a sensor, identified by
 	// its number. It is specified in 29.14 and 35.14 of IPMI v1.5 and 2.0
 	// respectively. Note, the raw value is in one of three formats, and is
@@ -49,6 +214,71 @@ type SessionCommands interface {
 	// it requires the SDR.
 	GetSensorReading(context.Context, uint8) (*ipmi.GetSensorReadingRsp, error)
 
+	// GetSensorReadingFactors retrieves the conversion factors for a raw
+	// reading of a non-linear sensor. It is specified in 29.5 and 35.5 of IPMI
+	// v1.5 and 2.0 respectively. NewSensorReader() handles this automatically.
+	GetSensorReadingFactors(context.Context, *ipmi.GetSensorReadingFactorsReq) (*ipmi.GetSensorReadingFactorsRsp, error)
+
+	// GetSensorThresholds retrieves the current thresholds of a sensor,
+	// identified by its number. It is specified in 29.9 and 35.9 of IPMI v1.5
+	// and 2.0 respectively. Like readings, the values are raw.
+	GetSensorThresholds(context.Context, uint8) (*ipmi.GetSensorThresholdsRsp, error)
+
+	// SetSensorThresholds changes one or more thresholds of a sensor. It is
+	// specified in 29.8 and 35.8 of IPMI v1.5 and 2.0 respectively.
+	SetSensorThresholds(context.Context, *ipmi.SetSensorThresholdsReq) error
+
+	// GetSensorHysteresis retrieves the current hysteresis values of a
+	// sensor, identified by its number. It is specified in 29.7 and 35.7 of
+	// IPMI v1.5 and 2.0 respectively.
+	GetSensorHysteresis(context.Context, uint8) (*ipmi.GetSensorHysteresisRsp, error)
+
+	// SetSensorHysteresis changes the hysteresis values of a sensor. It is
+	// specified in 29.6 and 35.6 of IPMI v1.5 and 2.0 respectively.
+	SetSensorHysteresis(context.Context, *ipmi.SetSensorHysteresisReq) error
+
+	// GetPEFCapabilities retrieves the Platform Event Filtering actions the
+	// BMC supports, and the size of its event filter table. It is specified
+	// in 30.1 of IPMI v2.0.
+	GetPEFCapabilities(context.Context) (*ipmi.GetPEFCapabilitiesRsp, error)
+
+	// ArmPEFPostponeTimer sets the PEF postpone timer, returning its present
+	// value. It is specified in 30.2 of IPMI v2.0.
+	ArmPEFPostponeTimer(context.Context, ipmi.PEFPostponeTimer) (*ipmi.ArmPEFPostponeTimerRsp, error)
+
+	// GetPEFConfigurationParameters retrieves a PEF configuration parameter.
+	// It is specified in 30.4 of IPMI v2.0. Use GetPEFConfig() to retrieve
+	// the event filters and alert policies in decoded form.
+	GetPEFConfigurationParameters(context.Context, *ipmi.GetPEFConfigurationParametersReq) (*ipmi.GetPEFConfigurationParametersRsp, error)
+
+	// SetPEFConfigurationParameters sets a PEF configuration parameter. It is
+	// specified in 30.3 of IPMI v2.0. Use SetPEFConfig() to set several
+	// parameters within the set in progress lock.
+	SetPEFConfigurationParameters(context.Context, *ipmi.SetPEFConfigurationParametersReq) error
+
+	// GetUserAccess retrieves a user's access rights on a channel, and the
+	// number of users the BMC supports. It is specified in 18.27 and 22.27 of
+	// IPMI v1.5 and 2.0 respectively.
+	GetUserAccess(context.Context, *ipmi.GetUserAccessReq) (*ipmi.GetUserAccessRsp, error)
+
+	// SetUserAccess changes a user's access rights on a channel. It is
+	// specified in 18.26 and 22.26 of IPMI v1.5 and 2.0 respectively.
+	SetUserAccess(context.Context, *ipmi.SetUserAccessReq) error
+
+	// GetUserName retrieves the name of a user ID. It is specified in 18.29
+	// and 22.29 of IPMI v1.5 and 2.0 respectively.
+	GetUserName(ctx context.Context, userID uint8) (string, error)
+
+	// SetUserName changes the name of a user ID. It is specified in 18.28 and
+	// 22.28 of IPMI v1.5 and 2.0 respectively.
+	SetUserName(ctx context.Context, userID uint8, name string) error
+
+	// SetUserPassword sets or tests a user's password, or enables or disables
+	// the user. It is specified in 18.30 and 22.30 of IPMI v1.5 and 2.0
+	// respectively. Use TestUserPassword() to test a password without
+	// treating a mismatch as an error.
+	SetUserPassword(context.Context, *ipmi.SetUserPasswordReq) error
+
 	// GetSessionPrivilegeLevel retrieves the current session privilege level. This is
 	// specified in 18.16 and 22.18 of IPMI v1.5 and 2.0 respectively.
 	GetSessionPrivilegeLevel(context.Context) (ipmi.PrivilegeLevel, error)
@@ -63,3 +293,552 @@ type SessionCommands interface {
 	// Close() on the session itself to invoke this.
 	closeSession(context.Context) error
 }
+
+func getSessionInfo(ctx context.Context, c Connection, r *ipmi.GetSessionInfoReq) (*ipmi.GetSessionInfoRsp, error) {
+	cmd := &ipmi.GetSessionInfoCmd{
+		Req: *r,
+	}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func getDeviceID(ctx context.Context, c Connection) (*ipmi.GetDeviceIDRsp, error) {
+	cmd := &ipmi.GetDeviceIDCmd{}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func coldReset(ctx context.Context, c Connection) error {
+	return ValidateResponse(c.SendCommand(ctx, &ipmi.ColdResetCmd{}))
+}
+
+func warmReset(ctx context.Context, c Connection) error {
+	return ValidateResponse(c.SendCommand(ctx, &ipmi.WarmResetCmd{}))
+}
+
+func getSelfTestResults(ctx context.Context, c Connection) (*ipmi.GetSelfTestResultsRsp, error) {
+	cmd := &ipmi.GetSelfTestResultsCmd{}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func setACPIPowerState(ctx context.Context, c Connection, r *ipmi.SetACPIPowerStateReq) error {
+	cmd := &ipmi.SetACPIPowerStateCmd{
+		Req: *r,
+	}
+	return ValidateResponse(c.SendCommand(ctx, cmd))
+}
+
+func getACPIPowerState(ctx context.Context, c Connection) (*ipmi.GetACPIPowerStateRsp, error) {
+	cmd := &ipmi.GetACPIPowerStateCmd{}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func getSystemInfoParameters(ctx context.Context, c Connection, r *ipmi.GetSystemInfoParametersReq) (*ipmi.GetSystemInfoParametersRsp, error) {
+	cmd := &ipmi.GetSystemInfoParametersCmd{
+		Req: *r,
+	}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func setSystemInfoParameters(ctx context.Context, c Connection, r *ipmi.SetSystemInfoParametersReq) error {
+	cmd := &ipmi.SetSystemInfoParametersCmd{
+		Req: *r,
+	}
+	return ValidateResponse(c.SendCommand(ctx, cmd))
+}
+
+func getWatchdogTimer(ctx context.Context, c Connection) (*ipmi.GetWatchdogTimerRsp, error) {
+	cmd := &ipmi.GetWatchdogTimerCmd{}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func setWatchdogTimer(ctx context.Context, c Connection, r *ipmi.SetWatchdogTimerReq) error {
+	cmd := &ipmi.SetWatchdogTimerCmd{
+		Req: *r,
+	}
+	return ValidateResponse(c.SendCommand(ctx, cmd))
+}
+
+func resetWatchdogTimer(ctx context.Context, c Connection) error {
+	return ValidateResponse(c.SendCommand(ctx, &ipmi.ResetWatchdogTimerCmd{}))
+}
+
+func getChassisStatus(ctx context.Context, c Connection) (*ipmi.GetChassisStatusRsp, error) {
+	cmd := &ipmi.GetChassisStatusCmd{}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func chassisControl(ctx context.Context, c Connection, control ipmi.ChassisControl) error {
+	cmd := &ipmi.ChassisControlCmd{
+		Req: ipmi.ChassisControlReq{
+			ChassisControl: control,
+		},
+	}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func chassisIdentify(ctx context.Context, c Connection, interval time.Duration, forceOn bool) error {
+	cmd := &ipmi.ChassisIdentifyCmd{
+		Req: ipmi.ChassisIdentifyReq{
+			Interval: interval,
+			ForceOn:  forceOn,
+		},
+	}
+	return ValidateResponse(c.SendCommand(ctx, cmd))
+}
+
+func getSystemBootOptions(ctx context.Context, c Connection, r *ipmi.GetSystemBootOptionsReq) (*ipmi.GetSystemBootOptionsRsp, error) {
+	cmd := &ipmi.GetSystemBootOptionsCmd{
+		Req: *r,
+	}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func setSystemBootOptions(ctx context.Context, c Connection, r *ipmi.SetSystemBootOptionsReq) error {
+	cmd := &ipmi.SetSystemBootOptionsCmd{
+		Req: *r,
+	}
+	return ValidateResponse(c.SendCommand(ctx, cmd))
+}
+
+func setBootDevice(ctx context.Context, c Connection, f *ipmi.BootFlags) error {
+	return writeInProgress(func(state ipmi.SetInProgress) (ipmi.CompletionCode, error) {
+		return c.SendCommand(ctx, &ipmi.SetSystemBootOptionsCmd{
+			Req: ipmi.SetSystemBootOptionsReq{
+				Parameter: ipmi.BootOptionParameterSetInProgress,
+				Data:      []byte{uint8(state)},
+			},
+		})
+	}, func() error {
+		flags := *f
+		flags.Valid = true
+		return setBootFlags(ctx, c, &flags)
+	})
+}
+
+// setBootFlags writes boot flags, first indicating that the BIOS has not yet
+// handled them, so it acts on them next boot.
+func setBootFlags(ctx context.Context, c Connection, f *ipmi.BootFlags) error {
+	if err := setSystemBootOptions(ctx, c, &ipmi.SetSystemBootOptionsReq{
+		Parameter: ipmi.BootOptionParameterBootInfoAcknowledge,
+		Data: []byte{
+			uint8(ipmi.BootInitiatorBIOS), // write mask
+			uint8(ipmi.BootInitiatorBIOS),
+		},
+	}); err != nil {
+		return err
+	}
+	b := gopacket.NewSerializeBuffer()
+	if err := f.Serialise(b); err != nil {
+		return err
+	}
+	return setSystemBootOptions(ctx, c, &ipmi.SetSystemBootOptionsReq{
+		Parameter: ipmi.BootOptionParameterBootFlags,
+		Data:      b.Bytes(),
+	})
+}
+
+func getLANConfigurationParameters(ctx context.Context, c Connection, r *ipmi.GetLANConfigurationParametersReq) (*ipmi.GetLANConfigurationParametersRsp, error) {
+	cmd := &ipmi.GetLANConfigurationParametersCmd{
+		Req: *r,
+	}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func setLANConfigurationParameters(ctx context.Context, c Connection, r *ipmi.SetLANConfigurationParametersReq) error {
+	cmd := &ipmi.SetLANConfigurationParametersCmd{
+		Req: *r,
+	}
+	return ValidateResponse(c.SendCommand(ctx, cmd))
+}
+
+func getChannelInfo(ctx context.Context, c Connection, channel ipmi.Channel) (*ipmi.GetChannelInfoRsp, error) {
+	cmd := &ipmi.GetChannelInfoCmd{
+		Req: ipmi.GetChannelInfoReq{
+			Channel: channel,
+		},
+	}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func getChannelAccess(ctx context.Context, c Connection, r *ipmi.GetChannelAccessReq) (*ipmi.GetChannelAccessRsp, error) {
+	cmd := &ipmi.GetChannelAccessCmd{
+		Req: *r,
+	}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func setChannelAccess(ctx context.Context, c Connection, r *ipmi.SetChannelAccessReq) error {
+	cmd := &ipmi.SetChannelAccessCmd{
+		Req: *r,
+	}
+	return ValidateResponse(c.SendCommand(ctx, cmd))
+}
+
+func getSDRRepositoryInfo(ctx context.Context, c Connection) (*ipmi.GetSDRRepositoryInfoRsp, error) {
+	cmd := &ipmi.GetSDRRepositoryInfoCmd{}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func reserveSDRRepository(ctx context.Context, c Connection) (*ipmi.ReserveSDRRepositoryRsp, error) {
+	cmd := &ipmi.ReserveSDRRepositoryCmd{}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func getSDRRepositoryTime(ctx context.Context, c Connection) (*ipmi.GetSDRRepositoryTimeRsp, error) {
+	cmd := &ipmi.GetSDRRepositoryTimeCmd{}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func getSELInfo(ctx context.Context, c Connection) (*ipmi.GetSELInfoRsp, error) {
+	cmd := &ipmi.GetSELInfoCmd{}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func getSELAllocationInfo(ctx context.Context, c Connection) (*ipmi.GetSELAllocationInfoRsp, error) {
+	cmd := &ipmi.GetSELAllocationInfoCmd{}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func reserveSEL(ctx context.Context, c Connection) (*ipmi.ReserveSELRsp, error) {
+	cmd := &ipmi.ReserveSELCmd{}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func getSELEntry(ctx context.Context, c Connection, r *ipmi.GetSELEntryReq) (*ipmi.GetSELEntryRsp, error) {
+	cmd := &ipmi.GetSELEntryCmd{
+		Req: *r,
+	}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func deleteSELEntry(ctx context.Context, c Connection, r *ipmi.DeleteSELEntryReq) (*ipmi.DeleteSELEntryRsp, error) {
+	cmd := &ipmi.DeleteSELEntryCmd{
+		Req: *r,
+	}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func clearSEL(ctx context.Context, c Connection) error {
+	reservation, err := reserveSEL(ctx, c)
+	if err != nil {
+		return err
+	}
+	cmd := &ipmi.ClearSELCmd{
+		Req: ipmi.ClearSELReq{
+			ReservationID: reservation.ReservationID,
+			Initiate:      true,
+		},
+	}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return err
+	}
+	cmd.Req.Initiate = false
+	for !cmd.Rsp.Complete {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(selClearPollInterval):
+		}
+		if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getSELTime(ctx context.Context, c Connection) (*ipmi.GetSELTimeRsp, error) {
+	cmd := &ipmi.GetSELTimeCmd{}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func setSELTime(ctx context.Context, c Connection, t time.Time) error {
+	cmd := &ipmi.SetSELTimeCmd{
+		Req: ipmi.SetSELTimeReq{
+			Time: t,
+		},
+	}
+	return ValidateResponse(c.SendCommand(ctx, cmd))
+}
+
+func getSELTimeUTCOffset(ctx context.Context, c Connection) (*ipmi.GetSELTimeUTCOffsetRsp, error) {
+	cmd := &ipmi.GetSELTimeUTCOffsetCmd{}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func setSELTimeUTCOffset(ctx context.Context, c Connection, o ipmi.UTCOffset) error {
+	cmd := &ipmi.SetSELTimeUTCOffsetCmd{
+		Req: ipmi.SetSELTimeUTCOffsetReq{
+			Offset: o,
+		},
+	}
+	return ValidateResponse(c.SendCommand(ctx, cmd))
+}
+
+func getFRUInventoryAreaInfo(ctx context.Context, c Connection, deviceID uint8) (*ipmi.GetFRUInventoryAreaInfoRsp, error) {
+	cmd := &ipmi.GetFRUInventoryAreaInfoCmd{
+		Req: ipmi.GetFRUInventoryAreaInfoReq{
+			DeviceID: deviceID,
+		},
+	}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func readFRUData(ctx context.Context, c Connection, r *ipmi.ReadFRUDataReq) (*ipmi.ReadFRUDataRsp, error) {
+	cmd := &ipmi.ReadFRUDataCmd{
+		Req: *r,
+	}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func getSensorReading(ctx context.Context, c Connection, sensor uint8) (*ipmi.GetSensorReadingRsp, error) {
+	cmd := &ipmi.GetSensorReadingCmd{
+		Req: ipmi.GetSensorReadingReq{
+			Number: sensor,
+		},
+	}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func getSensorReadingFactors(ctx context.Context, c Connection, r *ipmi.GetSensorReadingFactorsReq) (*ipmi.GetSensorReadingFactorsRsp, error) {
+	cmd := &ipmi.GetSensorReadingFactorsCmd{
+		Req: *r,
+	}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func getSensorThresholds(ctx context.Context, c Connection, sensor uint8) (*ipmi.GetSensorThresholdsRsp, error) {
+	cmd := &ipmi.GetSensorThresholdsCmd{
+		Req: ipmi.GetSensorThresholdsReq{
+			Number: sensor,
+		},
+	}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func setSensorThresholds(ctx context.Context, c Connection, r *ipmi.SetSensorThresholdsReq) error {
+	cmd := &ipmi.SetSensorThresholdsCmd{
+		Req: *r,
+	}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func getSensorHysteresis(ctx context.Context, c Connection, sensor uint8) (*ipmi.GetSensorHysteresisRsp, error) {
+	cmd := &ipmi.GetSensorHysteresisCmd{
+		Req: ipmi.GetSensorHysteresisReq{
+			Number: sensor,
+		},
+	}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func setSensorHysteresis(ctx context.Context, c Connection, r *ipmi.SetSensorHysteresisReq) error {
+	cmd := &ipmi.SetSensorHysteresisCmd{
+		Req: *r,
+	}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func getPEFCapabilities(ctx context.Context, c Connection) (*ipmi.GetPEFCapabilitiesRsp, error) {
+	cmd := &ipmi.GetPEFCapabilitiesCmd{}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func armPEFPostponeTimer(ctx context.Context, c Connection, t ipmi.PEFPostponeTimer) (*ipmi.ArmPEFPostponeTimerRsp, error) {
+	cmd := &ipmi.ArmPEFPostponeTimerCmd{
+		Req: ipmi.ArmPEFPostponeTimerReq{
+			Timer: t,
+		},
+	}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func getPEFConfigurationParameters(ctx context.Context, c Connection, r *ipmi.GetPEFConfigurationParametersReq) (*ipmi.GetPEFConfigurationParametersRsp, error) {
+	cmd := &ipmi.GetPEFConfigurationParametersCmd{
+		Req: *r,
+	}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func setPEFConfigurationParameters(ctx context.Context, c Connection, r *ipmi.SetPEFConfigurationParametersReq) error {
+	cmd := &ipmi.SetPEFConfigurationParametersCmd{
+		Req: *r,
+	}
+	return ValidateResponse(c.SendCommand(ctx, cmd))
+}
+
+func getUserAccess(ctx context.Context, c Connection, r *ipmi.GetUserAccessReq) (*ipmi.GetUserAccessRsp, error) {
+	cmd := &ipmi.GetUserAccessCmd{
+		Req: *r,
+	}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+func setUserAccess(ctx context.Context, c Connection, r *ipmi.SetUserAccessReq) error {
+	cmd := &ipmi.SetUserAccessCmd{
+		Req: *r,
+	}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func getUserName(ctx context.Context, c Connection, userID uint8) (string, error) {
+	cmd := &ipmi.GetUserNameCmd{
+		Req: ipmi.GetUserNameReq{
+			UserID: userID,
+		},
+	}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return "", err
+	}
+	return cmd.Rsp.Name, nil
+}
+
+func setUserName(ctx context.Context, c Connection, userID uint8, name string) error {
+	cmd := &ipmi.SetUserNameCmd{
+		Req: ipmi.SetUserNameReq{
+			UserID: userID,
+			Name:   name,
+		},
+	}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func setUserPassword(ctx context.Context, c Connection, r *ipmi.SetUserPasswordReq) error {
+	cmd := &ipmi.SetUserPasswordCmd{
+		Req: *r,
+	}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func getSessionPrivilegeLevel(ctx context.Context, c Connection) (ipmi.PrivilegeLevel, error) {
+	cmd := &ipmi.SetSessionPrivilegeLevelCmd{
+		Req: ipmi.SetSessionPrivilegeLevelReq{
+			// PrivilegeLevel omitted to retrieve current level
+		},
+	}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return 0, err
+	}
+	return cmd.Rsp.PrivilegeLevel, nil
+}
+
+func setSessionPrivilegeLevel(ctx context.Context, c Connection, level ipmi.PrivilegeLevel) (ipmi.PrivilegeLevel, error) {
+	cmd := &ipmi.SetSessionPrivilegeLevelCmd{
+		Req: ipmi.SetSessionPrivilegeLevelReq{
+			PrivilegeLevel: level,
+		},
+	}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return 0, err
+	}
+	return cmd.Rsp.PrivilegeLevel, nil
+}
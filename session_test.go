@@ -0,0 +1,92 @@
+package bmc
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/gebn/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket"
+)
+
+// fakeSession is a Session whose commands are answered by handlers registered
+// with handle(), keyed by command type. Typed methods used by the code under
+// test are implemented in terms of SendCommand, as they are by real sessions,
+// so tests only need to handle the commands they expect. Calling any other
+// command will panic.
+type fakeSession struct {
+	Session
+	handlers map[reflect.Type]func(ipmi.Command) (ipmi.CompletionCode, error)
+}
+
+// handle registers h to answer commands of type C sent to s, replacing any
+// existing handler. Handlers have the same contract as SendCommand.
+func handle[C ipmi.Command](s *fakeSession, h func(C) (ipmi.CompletionCode, error)) {
+	if s.handlers == nil {
+		s.handlers = map[reflect.Type]func(ipmi.Command) (ipmi.CompletionCode, error){}
+	}
+	s.handlers[reflect.TypeFor[C]()] = func(c ipmi.Command) (ipmi.CompletionCode, error) {
+		return h(c.(C))
+	}
+}
+
+// respond decodes data into c's response layer, returning code along with any
+// decode error. As with a real session, the response is decoded regardless of
+// the completion code, so nil data results in the error a truncated response
+// would cause.
+func respond(c ipmi.Command, code ipmi.CompletionCode, data []byte) (ipmi.CompletionCode, error) {
+	return code, c.Response().DecodeFromBytes(data, gopacket.NilDecodeFeedback)
+}
+
+func (s *fakeSession) SendCommand(_ context.Context, c ipmi.Command) (ipmi.CompletionCode, error) {
+	h, ok := s.handlers[reflect.TypeOf(c)]
+	if !ok {
+		panic("unexpected command: " + c.Name())
+	}
+	return h(c)
+}
+
+func (s *fakeSession) GetFRUInventoryAreaInfo(ctx context.Context, deviceID uint8) (*ipmi.GetFRUInventoryAreaInfoRsp, error) {
+	return getFRUInventoryAreaInfo(ctx, s, deviceID)
+}
+
+func (s *fakeSession) GetUserAccess(ctx context.Context, r *ipmi.GetUserAccessReq) (*ipmi.GetUserAccessRsp, error) {
+	return getUserAccess(ctx, s, r)
+}
+
+func (s *fakeSession) GetUserName(ctx context.Context, userID uint8) (string, error) {
+	return getUserName(ctx, s, userID)
+}
+
+func (s *fakeSession) GetWatchdogTimer(ctx context.Context) (*ipmi.GetWatchdogTimerRsp, error) {
+	return getWatchdogTimer(ctx, s)
+}
+
+func (s *fakeSession) SetWatchdogTimer(ctx context.Context, r *ipmi.SetWatchdogTimerReq) error {
+	return setWatchdogTimer(ctx, s, r)
+}
+
+func (s *fakeSession) ResetWatchdogTimer(ctx context.Context) error {
+	return resetWatchdogTimer(ctx, s)
+}
+
+func (s *fakeSession) GetSELTime(ctx context.Context) (*ipmi.GetSELTimeRsp, error) {
+	return getSELTime(ctx, s)
+}
+
+func (s *fakeSession) SetSELTime(ctx context.Context, t time.Time) error {
+	return setSELTime(ctx, s, t)
+}
+
+func (s *fakeSession) GetPEFCapabilities(ctx context.Context) (*ipmi.GetPEFCapabilitiesRsp, error) {
+	return getPEFCapabilities(ctx, s)
+}
+
+func (s *fakeSession) GetPEFConfigurationParameters(ctx context.Context, r *ipmi.GetPEFConfigurationParametersReq) (*ipmi.GetPEFConfigurationParametersRsp, error) {
+	return getPEFConfigurationParameters(ctx, s, r)
+}
+
+func (s *fakeSession) SetSystemInfoParameters(ctx context.Context, r *ipmi.SetSystemInfoParametersReq) error {
+	return setSystemInfoParameters(ctx, s, r)
+}
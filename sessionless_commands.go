@@ -25,3 +25,32 @@ type SessionlessCommands interface {
 	// compatibility.
 	GetChannelAuthenticationCapabilities(context.Context, *ipmi.GetChannelAuthenticationCapabilitiesReq) (*ipmi.GetChannelAuthenticationCapabilitiesRsp, error)
 }
+
+func getSystemGUID(ctx context.Context, c Connection) ([16]byte, error) {
+	cmd := &ipmi.GetSystemGUIDCmd{}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return [16]byte{}, err
+	}
+
+	// we could return a google/uuid type, however that requires the BMC return
+	// a valid GUID in network byte order, and the spec says it should be
+	// treated as an opaque value. The user can interpret these bytes how they
+	// wish.
+	return cmd.Rsp.GUID, nil
+}
+
+func getChannelAuthenticationCapabilities(
+	ctx context.Context,
+	c Connection,
+	req *ipmi.GetChannelAuthenticationCapabilitiesReq,
+) (*ipmi.GetChannelAuthenticationCapabilitiesRsp, error) {
+	// we could set req.ExtendedData here if we're using IPMI v2.0, however let
+	// the user decide
+	cmd := &ipmi.GetChannelAuthenticationCapabilitiesCmd{
+		Req: *req,
+	}
+	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
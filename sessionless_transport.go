@@ -29,6 +29,19 @@ type SessionlessTransport interface {
 	NewSession(ctx context.Context, opts *SessionOpts) (Session, error)
 }
 
+// V1SessionlessTransport is a session-less connection to a BMC using an IPMI
+// v1.5 session wrapper, along with its underlying transport. A pointer to this
+// type is returned by DialV1().
+type V1SessionlessTransport struct {
+	transport.Transport
+	*V1Sessionless
+}
+
+func (s *V1SessionlessTransport) Close() error {
+	defer v1ConnectionsOpen.Dec()
+	return s.Transport.Close()
+}
+
 // V2SessionlessTransport is a session-less connection to a BMC using an IPMI
 // v2.0/RMCP+ session wrapper, along with its underlying transport. A pointer to
 // this type is returned by DialV2().
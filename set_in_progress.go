@@ -0,0 +1,39 @@
+package bmc
+
+import (
+	"github.com/gebn/bmc/pkg/ipmi"
+)
+
+// writeInProgress calls write within the set in progress lock of a group of
+// configuration parameters, e.g. boot options, which is set via setInProgress.
+// Once write succeeds, the BMC is asked to commit the parameters, then the
+// lock is released. If write fails, the lock is released without committing,
+// so BMCs that support rollback discard the earlier writes. The lock and
+// commit are optional; if the BMC does not support them, responding with
+// ipmi.CompletionCodeParameterNotSupported, write is called without them.
+func writeInProgress(setInProgress func(ipmi.SetInProgress) (ipmi.CompletionCode, error), write func() error) error {
+	code, err := setInProgress(ipmi.SetInProgressInProgress)
+	if err != nil {
+		return err
+	}
+	inProgress := code == ipmi.CompletionCodeNormal
+	if !inProgress && code != ipmi.CompletionCodeParameterNotSupported {
+		return ValidateResponse(code, nil)
+	}
+
+	err = write()
+	if !inProgress {
+		return err
+	}
+	if err == nil {
+		code, err = setInProgress(ipmi.SetInProgressCommitWrite)
+		if err == nil && code != ipmi.CompletionCodeParameterNotSupported {
+			err = ValidateResponse(code, nil)
+		}
+	}
+	complete := ValidateResponse(setInProgress(ipmi.SetInProgressComplete))
+	if err == nil {
+		err = complete
+	}
+	return err
+}
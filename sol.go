@@ -0,0 +1,535 @@
+package bmc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gebn/bmc/pkg/ipmi"
+	"github.com/gebn/bmc/pkg/layerexts"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+const (
+	// solRetryInterval is how long we wait for the BMC to acknowledge a SOL
+	// packet before retransmitting it, and how long we back off for when the
+	// BMC cannot currently accept characters.
+	solRetryInterval = 500 * time.Millisecond
+
+	// solRetries is the number of times an unacknowledged packet is
+	// retransmitted before we give up on the console.
+	solRetries = 7
+
+	// solMaxCharacters is the largest number of characters we send in a
+	// single packet, as the BMC acknowledges them with a 1 byte count.
+	solMaxCharacters = 255
+
+	// solDeactivateTimeout bounds the Deactivate Payload command sent when a
+	// console is closed, as io.Closer does not allow for a context.
+	solDeactivateTimeout = 5 * time.Second
+
+	// solInboundDepth is the number of packets from the BMC that can be
+	// queued before further ones are dropped, to be retransmitted by the BMC.
+	solInboundDepth = 16
+)
+
+var (
+	// ErrSOLActive is returned when attempting to activate a SOL console on a
+	// session that already has one.
+	ErrSOLActive = errors.New("a SOL console is already active on this " +
+		"session")
+
+	// ErrSOLDeactivated is returned when writing to a console that has been
+	// closed, or that the BMC has deactivated.
+	ErrSOLDeactivated = errors.New("SOL console deactivated")
+)
+
+// sessionAlgorithms contains an instance of each of a session's integrity and
+// confidentiality algorithms, loaded with its keys. Either is nil if None was
+// negotiated.
+type sessionAlgorithms struct {
+	integrity       hash.Hash
+	confidentiality layerexts.SerializableDecodingLayer
+}
+
+// solPacket is the part of a SOL packet from the BMC that we act on. Data is
+// a copy, as the transport reuses its buffer.
+type solPacket struct {
+	sequence     uint8
+	ackSequence  uint8
+	accepted     uint8
+	nack         bool
+	deactivating bool
+	data         []byte
+}
+
+// SOLConsole is an active Serial over LAN payload, which relays characters to
+// and from a managed system's serial port. It is obtained from
+// V2Session.ActivateSOL(), and must be closed once finished with. Reads and
+// writes can happen concurrently with each other; concurrent writes are
+// serialised.
+type SOLConsole struct {
+	session  *V2Session
+	instance uint8
+
+	// maxCharacters is the largest number of characters we send in one
+	// packet, limited by the BMC's inbound payload size.
+	maxCharacters int
+
+	// stopListening deregisters the console's transport listener.
+	stopListening func()
+
+	// receive contains layers for decoding packets from the BMC. It is only
+	// accessed by the transport's receive goroutine, via listen().
+	receive struct {
+		layers               []gopacket.LayerType
+		decode               gopacket.DecodingLayerFunc
+		rmcpLayer            layers.RMCP
+		sessionSelectorLayer ipmi.SessionSelector
+		v2SessionLayer       ipmi.V2Session
+		solLayer             ipmi.SOL
+		sessionAlgorithms
+	}
+
+	// sendMu protects send, which is used by both writers and the goroutine
+	// acknowledging the BMC's packets.
+	sendMu sync.Mutex
+	send   struct {
+		buffer         gopacket.SerializeBuffer
+		rmcpLayer      layers.RMCP
+		v2SessionLayer ipmi.V2Session
+		sessionAlgorithms
+	}
+
+	// inbound carries packets from the listener to run().
+	inbound chan solPacket
+
+	// acks carries acknowledgements of our packets from run() to the writer
+	// awaiting them. It has capacity 1, and only the latest is kept.
+	acks chan solPacket
+
+	// writeMu ensures only one of our packets is outstanding at a time, and
+	// protects sequence.
+	writeMu  sync.Mutex
+	sequence uint8
+
+	// deassertCTS and deassertDCDDSR are the handshake states included in
+	// every packet we send.
+	deassertCTS    atomic.Bool
+	deassertDCDDSR atomic.Bool
+
+	// readMu protects readBuf and readErr, and readCond is signalled when
+	// either changes.
+	readMu   sync.Mutex
+	readCond *sync.Cond
+	readBuf  []byte
+	readErr  error
+
+	// ctx is cancelled when the console is closed or the BMC deactivates it,
+	// stopping run() and any writes.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// done is closed once run() has returned.
+	done chan struct{}
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// ActivateSOL activates the Serial over LAN payload instance, returning a
+// console connected to the managed system's serial port. Packets are
+// authenticated and encrypted if the session's algorithms allow. Only one
+// console can be active per session; ErrSOLActive is returned if one already
+// is.
+func (s *V2Session) ActivateSOL(ctx context.Context, instance uint8) (*SOLConsole, error) {
+	if !s.consoleActive.CompareAndSwap(false, true) {
+		return nil, ErrSOLActive
+	}
+	console, err := s.activateSOL(ctx, instance)
+	if err != nil {
+		s.consoleActive.Store(false)
+		return nil, err
+	}
+	return console, nil
+}
+
+func (s *V2Session) activateSOL(ctx context.Context, instance uint8) (*SOLConsole, error) {
+	cmd := &ipmi.ActivatePayloadCmd{
+		Req: ipmi.ActivatePayloadReq{
+			PayloadType:   ipmi.PayloadTypeSOL,
+			Instance:      instance,
+			Encrypted:     s.consoleSend.confidentiality != nil,
+			Authenticated: s.consoleSend.integrity != nil,
+		},
+	}
+	if err := ValidateResponse(s.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+
+	// we only have one socket, so cannot follow the BMC to another port
+	if addr, ok := s.transport.Address().(*net.UDPAddr); ok &&
+		int(cmd.Rsp.Port) != addr.Port {
+		s.deactivateSOL(ctx, instance)
+		return nil, fmt.Errorf("BMC wants SOL on port %v, but we are "+
+			"connected to %v", cmd.Rsp.Port, addr.Port)
+	}
+	// the inbound payload size includes the 4 byte SOL header
+	if cmd.Rsp.InboundPayloadSize <= 4 {
+		s.deactivateSOL(ctx, instance)
+		return nil, fmt.Errorf("BMC inbound SOL payload size too small: %v",
+			cmd.Rsp.InboundPayloadSize)
+	}
+
+	c := newSOLConsole(s, instance,
+		min(int(cmd.Rsp.InboundPayloadSize)-4, solMaxCharacters))
+	c.stopListening = s.transport.Listen(c.listen)
+	go c.run()
+	return c, nil
+}
+
+// deactivateSOL sends a Deactivate Payload command for a SOL instance. The BMC
+// having already deactivated it is not considered an error.
+func (s *V2Session) deactivateSOL(ctx context.Context, instance uint8) error {
+	cmd := &ipmi.DeactivatePayloadCmd{
+		Req: ipmi.DeactivatePayloadReq{
+			PayloadType: ipmi.PayloadTypeSOL,
+			Instance:    instance,
+		},
+	}
+	code, err := s.SendCommand(ctx, cmd)
+	if err == nil && code == ipmi.CompletionCodePayloadAlreadyDeactivated {
+		return nil
+	}
+	return ValidateResponse(code, err)
+}
+
+func newSOLConsole(s *V2Session, instance uint8, maxCharacters int) *SOLConsole {
+	c := &SOLConsole{
+		session:       s,
+		instance:      instance,
+		maxCharacters: maxCharacters,
+		inbound:       make(chan solPacket, solInboundDepth),
+		acks:          make(chan solPacket, 1),
+		done:          make(chan struct{}),
+	}
+	c.readCond = sync.NewCond(&c.readMu)
+	c.ctx, c.cancel = context.WithCancel(context.Background())
+
+	c.send.buffer = gopacket.NewSerializeBuffer()
+	c.send.sessionAlgorithms = s.consoleSend
+	c.send.rmcpLayer = layers.RMCP{
+		Version:  layers.RMCPVersion1,
+		Sequence: 0xFF, // do not send us an ACK
+		Class:    layers.RMCPClassIPMI,
+	}
+
+	c.receive.sessionAlgorithms = s.consoleReceive
+	c.receive.v2SessionLayer.IntegrityAlgorithm = s.consoleReceive.integrity
+	// the SOL layer is decoded manually, as the confidentiality layers assume
+	// they contain an IPMI message
+	dlc := gopacket.DecodingLayerContainer(gopacket.DecodingLayerArray(nil))
+	dlc = dlc.Put(&c.receive.rmcpLayer)
+	dlc = dlc.Put(&c.receive.sessionSelectorLayer)
+	dlc = dlc.Put(&c.receive.v2SessionLayer)
+	if c.receive.confidentiality != nil {
+		c.receive.v2SessionLayer.ConfidentialityLayerType =
+			c.receive.confidentiality.LayerType()
+		dlc = dlc.Put(c.receive.confidentiality)
+	}
+	c.receive.decode = dlc.LayersDecoder(c.receive.rmcpLayer.LayerType(),
+		gopacket.NilDecodeFeedback)
+	return c
+}
+
+// listen is the console's transport listener. It accepts SOL packets for our
+// session, queueing them for run().
+func (c *SOLConsole) listen(b []byte) bool {
+	r := &c.receive
+	_, err := r.decode(b, &r.layers)
+	var unsupported gopacket.UnsupportedLayerType
+	if err != nil && !errors.As(err, &unsupported) {
+		return false
+	}
+	if r.v2SessionLayer.ID != c.session.LocalID ||
+		r.v2SessionLayer.PayloadType != ipmi.PayloadTypeSOL {
+		return false
+	}
+	if r.integrity != nil && !r.v2SessionLayer.Authenticated {
+		return false
+	}
+	payload := r.v2SessionLayer.LayerPayload()
+	if r.v2SessionLayer.Encrypted {
+		if r.confidentiality == nil ||
+			layerexts.DecodedTypes(r.layers).InnermostEquals(
+				r.confidentiality.LayerType()) != nil {
+			return false
+		}
+		payload = r.confidentiality.LayerPayload()
+	}
+	if err := r.solLayer.DecodeFromBytes(payload,
+		gopacket.NilDecodeFeedback); err != nil {
+		return false
+	}
+	if !acceptV2Sequence(&c.session.AuthenticatedSequenceNumbers,
+		&c.session.UnauthenticatedSequenceNumbers, &r.v2SessionLayer) {
+		// reject so the packet is counted as discarded
+		return false
+	}
+	packet := solPacket{
+		sequence:     r.solLayer.Sequence,
+		ackSequence:  r.solLayer.AckSequence,
+		accepted:     r.solLayer.AcceptedCharacters,
+		nack:         r.solLayer.NACK,
+		deactivating: r.solLayer.Deactivating,
+		data:         append([]byte(nil), r.solLayer.LayerPayload()...),
+	}
+	select {
+	case c.inbound <- packet:
+	default:
+		// the BMC will retransmit it
+	}
+	return true
+}
+
+// run processes packets from the BMC until the console is closed or
+// deactivated, acknowledging character data and passing on acknowledgements
+// of our own packets.
+func (c *SOLConsole) run() {
+	defer close(c.done)
+	lastSequence := uint8(0)
+	for {
+		select {
+		case <-c.ctx.Done():
+			c.setReadErr(io.EOF)
+			return
+		case packet := <-c.inbound:
+			if packet.ackSequence != 0 {
+				// replace any acknowledgement the writer has not picked up
+				select {
+				case <-c.acks:
+				default:
+				}
+				c.acks <- packet
+			}
+			if packet.sequence != 0 {
+				// a retransmission means our ACK was lost, so we re-send it,
+				// but must not deliver the data twice
+				if packet.sequence != lastSequence {
+					lastSequence = packet.sequence
+					c.readMu.Lock()
+					c.readBuf = append(c.readBuf, packet.data...)
+					c.readCond.Broadcast()
+					c.readMu.Unlock()
+				}
+				// errors are inconsequential; the BMC will retransmit
+				_ = c.transmit(&ipmi.SOL{
+					AckSequence:        packet.sequence,
+					AcceptedCharacters: uint8(len(packet.data)),
+				}, nil)
+			}
+			if packet.deactivating {
+				c.cancel()
+			}
+		}
+	}
+}
+
+func (c *SOLConsole) setReadErr(err error) {
+	c.readMu.Lock()
+	defer c.readMu.Unlock()
+	if c.readErr == nil {
+		c.readErr = err
+	}
+	c.readCond.Broadcast()
+}
+
+// transmit serialises and sends a single SOL packet to the BMC, filling in the
+// handshake state. It does not wait for an acknowledgement.
+func (c *SOLConsole) transmit(sol *ipmi.SOL, data []byte) error {
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+
+	sol.DeassertCTS = c.deassertCTS.Load()
+	sol.DeassertDCDDSR = c.deassertDCDDSR.Load()
+	s := &c.send
+	s.v2SessionLayer = ipmi.V2Session{
+		Encrypted:          s.confidentiality != nil,
+		Authenticated:      s.integrity != nil,
+		ID:                 c.session.RemoteID,
+		PayloadDescriptor:  ipmi.PayloadDescriptorSOL,
+		IntegrityAlgorithm: s.integrity,
+	}
+	sequenceNumbers := &c.session.AuthenticatedSequenceNumbers
+	if !s.v2SessionLayer.Authenticated {
+		sequenceNumbers = &c.session.UnauthenticatedSequenceNumbers
+	}
+	s.v2SessionLayer.Sequence = atomic.AddUint32(&sequenceNumbers.Inbound, 1)
+
+	var err error
+	if s.confidentiality == nil {
+		err = gopacket.SerializeLayers(s.buffer, serializeOptions,
+			&s.rmcpLayer,
+			&s.v2SessionLayer,
+			sol,
+			gopacket.Payload(data))
+	} else {
+		err = gopacket.SerializeLayers(s.buffer, serializeOptions,
+			&s.rmcpLayer,
+			&s.v2SessionLayer,
+			s.confidentiality,
+			sol,
+			gopacket.Payload(data))
+	}
+	if err != nil {
+		return err
+	}
+	return c.session.transport.Write(s.buffer.Bytes())
+}
+
+// exchange sends a packet with a new sequence number, retransmitting it until
+// the BMC acknowledges it, and returns the number of characters accepted. A
+// NACK is returned as 0 characters accepted. The caller must hold writeMu.
+func (c *SOLConsole) exchange(sol *ipmi.SOL, data []byte) (int, error) {
+	// sequence numbers cycle through 1-15; 0 is reserved for ACK-only packets
+	c.sequence = c.sequence%15 + 1
+	sol.Sequence = c.sequence
+
+	timer := time.NewTimer(solRetryInterval)
+	defer timer.Stop()
+	for attempt := 0; attempt <= solRetries; attempt++ {
+		if err := c.transmit(sol, data); err != nil {
+			return 0, err
+		}
+		timer.Reset(solRetryInterval)
+	wait:
+		for {
+			select {
+			case <-c.ctx.Done():
+				return 0, ErrSOLDeactivated
+			case ack := <-c.acks:
+				if ack.ackSequence != sol.Sequence {
+					continue
+				}
+				if ack.nack {
+					return 0, nil
+				}
+				return int(ack.accepted), nil
+			case <-timer.C:
+				break wait
+			}
+		}
+	}
+	return 0, fmt.Errorf("SOL packet %v not acknowledged after %v attempts",
+		sol.Sequence, solRetries+1)
+}
+
+// pause waits for the retry interval, returning early with ErrSOLDeactivated
+// if the console stops.
+func (c *SOLConsole) pause() error {
+	timer := time.NewTimer(solRetryInterval)
+	defer timer.Stop()
+	select {
+	case <-c.ctx.Done():
+		return ErrSOLDeactivated
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Read reads characters sent from the managed system's serial port, blocking
+// until some are available. It returns io.EOF once the console is closed or
+// deactivated by the BMC, and all buffered characters have been read.
+func (c *SOLConsole) Read(p []byte) (int, error) {
+	c.readMu.Lock()
+	defer c.readMu.Unlock()
+	for len(c.readBuf) == 0 && c.readErr == nil {
+		c.readCond.Wait()
+	}
+	if len(c.readBuf) == 0 {
+		return 0, c.readErr
+	}
+	n := copy(p, c.readBuf)
+	c.readBuf = c.readBuf[n:]
+	return n, nil
+}
+
+// Write sends characters to the managed system's serial port, blocking until
+// the BMC has accepted all of them. If the BMC only accepts some characters in
+// a packet, or cannot currently accept any, the remainder is resent.
+func (c *SOLConsole) Write(p []byte) (int, error) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	written := 0
+	for written < len(p) {
+		chunk := p[written:min(len(p), written+c.maxCharacters)]
+		accepted, err := c.exchange(&ipmi.SOL{}, chunk)
+		written += min(accepted, len(chunk))
+		if err != nil {
+			return written, err
+		}
+		if accepted == 0 {
+			if err := c.pause(); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+// Break asks the BMC to generate a break condition on the serial port.
+func (c *SOLConsole) Break() error {
+	return c.control(&ipmi.SOL{
+		GenerateBreak: true,
+	})
+}
+
+// SetCTS asks the BMC to assert or deassert CTS (clear to send) to the
+// managed system's serial controller. Deasserting it pauses transmission of
+// characters to us. CTS is asserted on activation.
+func (c *SOLConsole) SetCTS(asserted bool) error {
+	c.deassertCTS.Store(!asserted)
+	return c.control(&ipmi.SOL{})
+}
+
+// SetDCDDSR asks the BMC to assert or deassert DCD (data carrier detect) and
+// DSR (data set ready) to the managed system's serial controller. They are
+// asserted on activation.
+func (c *SOLConsole) SetDCDDSR(asserted bool) error {
+	c.deassertDCDDSR.Store(!asserted)
+	return c.control(&ipmi.SOL{})
+}
+
+// control sends a packet without character data, waiting for the BMC to
+// acknowledge it.
+func (c *SOLConsole) control(sol *ipmi.SOL) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	_, err := c.exchange(sol, nil)
+	return err
+}
+
+// Close deactivates the console, after which reads return io.EOF and writes
+// fail. The session remains open, and a new console can be activated on it.
+func (c *SOLConsole) Close() error {
+	c.closeOnce.Do(func() {
+		c.cancel()
+		c.stopListening()
+		<-c.done
+		ctx, cancel := context.WithTimeout(context.Background(),
+			solDeactivateTimeout)
+		defer cancel()
+		c.closeErr = c.session.deactivateSOL(ctx, c.instance)
+		c.session.consoleActive.Store(false)
+	})
+	return c.closeErr
+}
@@ -0,0 +1,183 @@
+package bmc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/gebn/bmc/internal/pkg/transport"
+	"github.com/gebn/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// solTransport plays the part of the BMC in SOL console tests. Packets
+// written by the console are decoded and made available on written.
+type solTransport struct {
+	mu       sync.Mutex
+	listener transport.Listener
+	sequence uint32
+	written  chan *ipmi.SOL
+}
+
+func (*solTransport) Address() net.Addr {
+	return &net.UDPAddr{Port: 623}
+}
+
+func (*solTransport) Send(context.Context, []byte, []byte, transport.Matcher) ([]byte, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (t *solTransport) Write(b []byte) error {
+	session := &ipmi.V2Session{}
+	if err := session.DecodeFromBytes(b[4:], gopacket.NilDecodeFeedback); err != nil {
+		return err
+	}
+	sol := &ipmi.SOL{}
+	// copy, as the console reuses its buffer
+	payload := append([]byte(nil), session.LayerPayload()...)
+	if err := sol.DecodeFromBytes(payload, gopacket.NilDecodeFeedback); err != nil {
+		return err
+	}
+	t.written <- sol
+	return nil
+}
+
+func (t *solTransport) Listen(l transport.Listener) func() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.listener = l
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		t.listener = nil
+	}
+}
+
+func (*solTransport) Close() error {
+	return nil
+}
+
+// deliver sends the console a SOL packet from the BMC. As SOL.SerializeTo()
+// writes operation rather than status bits, GenerateBreak is received as
+// Deactivating.
+func (t *solTransport) deliver(tb *testing.T, sol *ipmi.SOL, data string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.sequence++
+	buf := gopacket.NewSerializeBuffer()
+	if err := gopacket.SerializeLayers(buf, serializeOptions,
+		&layers.RMCP{
+			Version:  layers.RMCPVersion1,
+			Sequence: 0xFF,
+			Class:    layers.RMCPClassIPMI,
+		},
+		&ipmi.V2Session{
+			ID:                1,
+			Sequence:          t.sequence,
+			PayloadDescriptor: ipmi.PayloadDescriptorSOL,
+		},
+		sol,
+		gopacket.Payload(data)); err != nil {
+		tb.Fatal(err)
+	}
+	if !t.listener(buf.Bytes()) {
+		tb.Fatalf("console rejected %v", sol)
+	}
+}
+
+func newTestSOLConsole() (*SOLConsole, *solTransport) {
+	t := &solTransport{
+		written: make(chan *ipmi.SOL, 8),
+	}
+	s := &V2Session{
+		v2ConnectionShared: &v2ConnectionShared{
+			transport: t,
+		},
+		LocalID:  1,
+		RemoteID: 2,
+	}
+	c := newSOLConsole(s, 1, 4)
+	c.stopListening = t.Listen(c.listen)
+	go c.run()
+	return c, t
+}
+
+func TestSOLConsoleWrite(t *testing.T) {
+	c, bmc := newTestSOLConsole()
+	defer c.cancel()
+
+	result := make(chan error, 1)
+	go func() {
+		n, err := c.Write([]byte("hello"))
+		if err == nil && n != 5 {
+			err = errors.New("short write")
+		}
+		result <- err
+	}()
+
+	// the first packet is limited to the BMC's inbound payload size
+	sol := <-bmc.written
+	if sol.Sequence != 1 || string(sol.LayerPayload()) != "hell" {
+		t.Fatalf("first packet = %v %q, want sequence 1 \"hell\"",
+			sol.Sequence, sol.LayerPayload())
+	}
+	bmc.deliver(t, &ipmi.SOL{
+		AckSequence:        1,
+		AcceptedCharacters: 2,
+	}, "")
+
+	// the remainder is sent in a new packet
+	sol = <-bmc.written
+	if sol.Sequence != 2 || string(sol.LayerPayload()) != "llo" {
+		t.Fatalf("second packet = %v %q, want sequence 2 \"llo\"",
+			sol.Sequence, sol.LayerPayload())
+	}
+	bmc.deliver(t, &ipmi.SOL{
+		AckSequence:        2,
+		AcceptedCharacters: 3,
+	}, "")
+
+	if err := <-result; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSOLConsoleRead(t *testing.T) {
+	c, bmc := newTestSOLConsole()
+	defer c.cancel()
+
+	// the second packet is a retransmission, e.g. because our ACK was lost
+	for _, data := range []string{"abc", "abc"} {
+		bmc.deliver(t, &ipmi.SOL{
+			Sequence: 1,
+		}, data)
+		ack := <-bmc.written
+		if ack.Sequence != 0 || ack.AckSequence != 1 || ack.AcceptedCharacters != 3 {
+			t.Fatalf("ACK = %v/%v/%v, want 0/1/3", ack.Sequence,
+				ack.AckSequence, ack.AcceptedCharacters)
+		}
+	}
+	bmc.deliver(t, &ipmi.SOL{
+		Sequence:      2,
+		GenerateBreak: true, // deactivating
+	}, "d")
+	<-bmc.written
+
+	got, err := io.ReadAll(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, []byte("abcd")) {
+		t.Errorf("read %q, want \"abcd\"", got)
+	}
+	if _, err := c.Write([]byte("x")); !errors.Is(err, ErrSOLDeactivated) {
+		t.Errorf("write after deactivation = %v, want %v", err,
+			ErrSOLDeactivated)
+	}
+}
@@ -0,0 +1,153 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gebn/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket"
+)
+
+// SystemInfo contains the string system info parameters of a BMC, typically
+// pushed by the host OS and system firmware. Parameters the BMC does not
+// support, or that have not been set, are empty.
+type SystemInfo struct {
+
+	// FirmwareVersion is the version of the system firmware, e.g. BIOS.
+	FirmwareVersion string
+
+	// SystemName is the name of the system, typically its hostname. This can
+	// be used to map BMC addresses to hosts.
+	SystemName string
+
+	// PrimaryOSName is the name of the primary operating system.
+	PrimaryOSName string
+
+	// OSName is the name of the operating system currently running.
+	OSName string
+
+	// OSVersion is the version of the operating system currently running.
+	OSVersion string
+
+	// BMCURL is the URL of the BMC's web interface.
+	BMCURL string
+
+	// HypervisorURL is the URL of the base OS or hypervisor's management
+	// interface.
+	HypervisorURL string
+}
+
+// GetSystemInfo retrieves the string system info parameters of the BMC,
+// reassembling strings that span several blocks.
+func GetSystemInfo(ctx context.Context, s Session) (*SystemInfo, error) {
+	info := &SystemInfo{}
+	for _, field := range []struct {
+		parameter ipmi.SystemInfoParameter
+		value     *string
+	}{
+		{ipmi.SystemInfoParameterFirmwareVersion, &info.FirmwareVersion},
+		{ipmi.SystemInfoParameterSystemName, &info.SystemName},
+		{ipmi.SystemInfoParameterPrimaryOSName, &info.PrimaryOSName},
+		{ipmi.SystemInfoParameterOSName, &info.OSName},
+		{ipmi.SystemInfoParameterOSVersion, &info.OSVersion},
+		{ipmi.SystemInfoParameterBMCURL, &info.BMCURL},
+		{ipmi.SystemInfoParameterHypervisorURL, &info.HypervisorURL},
+	} {
+		str, err := getOptionalSystemInfoString(ctx, s, field.parameter)
+		if err != nil {
+			return nil, err
+		}
+		if str != nil {
+			*field.value = str.Value
+		}
+	}
+	return info, nil
+}
+
+// GetSystemInfoString retrieves a string system info parameter, reassembling
+// it if it spans several blocks.
+func GetSystemInfoString(ctx context.Context, s Session, p ipmi.SystemInfoParameter) (*ipmi.SystemInfoString, error) {
+	str, err := getOptionalSystemInfoString(ctx, s, p)
+	if err != nil {
+		return nil, err
+	}
+	if str == nil {
+		return nil, fmt.Errorf("system info parameter %v is not supported", p)
+	}
+	return str, nil
+}
+
+// getOptionalSystemInfoString is like GetSystemInfoString, but returns nil
+// rather than an error if the BMC does not support the parameter.
+func getOptionalSystemInfoString(ctx context.Context, s Session, p ipmi.SystemInfoParameter) (*ipmi.SystemInfoString, error) {
+	d := []byte{}
+	blocks := 1
+	for i := 0; i < blocks; i++ {
+		cmd := &ipmi.GetSystemInfoParametersCmd{
+			Req: ipmi.GetSystemInfoParametersReq{
+				Parameter:   p,
+				SetSelector: uint8(i),
+			},
+		}
+		// BMCs typically truncate the response after a non-normal code, so
+		// this is checked regardless of any decode error
+		code, err := s.SendCommand(ctx, cmd)
+		if i == 0 && code == ipmi.CompletionCodeParameterNotSupported {
+			return nil, nil
+		}
+		if err := ValidateResponse(code, err); err != nil {
+			return nil, fmt.Errorf("failed to get block %v of %v: %v", i, p,
+				err)
+		}
+		if i == 0 {
+			if len(cmd.Rsp.Data) < 3 {
+				return nil, fmt.Errorf("system info parameter %v must be at "+
+					"least 3 bytes, got %v", p, len(cmd.Rsp.Data))
+			}
+			blocks = ipmi.SystemInfoStringBlocks(int(cmd.Rsp.Data[2]))
+		}
+		// the data refers to the packet, so must be copied before the next
+		// command is sent; short blocks are padded so subsequent blocks
+		// remain aligned
+		block := make([]byte, ipmi.SystemInfoStringBlockLength)
+		copy(block, cmd.Rsp.Data)
+		d = append(d, block...)
+	}
+	str := &ipmi.SystemInfoString{}
+	if _, err := str.Deserialise(d, gopacket.NilDecodeFeedback); err != nil {
+		return nil, err
+	}
+	return str, nil
+}
+
+// SetSystemInfoString sets a string system info parameter, splitting it into
+// blocks. The blocks are written within the set in progress lock, so a BMC
+// supporting it will not expose a partially written string. This can be used
+// to publish the host's name to the BMC, as the OS would.
+func SetSystemInfoString(ctx context.Context, s Session, p ipmi.SystemInfoParameter, str *ipmi.SystemInfoString) error {
+	b := gopacket.NewSerializeBuffer()
+	if err := str.Serialise(b); err != nil {
+		return err
+	}
+	return writeInProgress(func(state ipmi.SetInProgress) (ipmi.CompletionCode, error) {
+		return s.SendCommand(ctx, &ipmi.SetSystemInfoParametersCmd{
+			Req: ipmi.SetSystemInfoParametersReq{
+				Parameter: ipmi.SystemInfoParameterSetInProgress,
+				Data:      []byte{uint8(state)},
+			},
+		})
+	}, func() error {
+		d := b.Bytes()
+		for i := 0; len(d) > 0; i++ {
+			if err := s.SetSystemInfoParameters(ctx, &ipmi.SetSystemInfoParametersReq{
+				Parameter: p,
+				Data:      d[:ipmi.SystemInfoStringBlockLength],
+			}); err != nil {
+				return fmt.Errorf("failed to set block %v of %v: %v", i, p, err)
+			}
+			d = d[ipmi.SystemInfoStringBlockLength:]
+		}
+		return nil
+	})
+}
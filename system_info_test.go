@@ -0,0 +1,103 @@
+package bmc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gebn/bmc/pkg/ipmi"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// systemInfoBMC stores system info parameter blocks, keyed by parameter and
+// set selector, responding with a truncated
+// ipmi.CompletionCodeParameterNotSupported for parameters without any blocks,
+// as a session would. Set In Progress is not supported.
+type systemInfoBMC struct {
+	blocks map[ipmi.SystemInfoParameter]map[uint8][]byte
+}
+
+// session returns a session handling Get and Set System Info Parameters
+// against the BMC.
+func (b *systemInfoBMC) session() *fakeSession {
+	s := &fakeSession{}
+	handle(s, func(c *ipmi.GetSystemInfoParametersCmd) (ipmi.CompletionCode, error) {
+		blocks, ok := b.blocks[c.Req.Parameter]
+		if !ok {
+			return respond(c, ipmi.CompletionCodeParameterNotSupported, nil)
+		}
+		return respond(c, ipmi.CompletionCodeNormal,
+			append([]byte{0x11}, blocks[c.Req.SetSelector]...))
+	})
+	handle(s, func(c *ipmi.SetSystemInfoParametersCmd) (ipmi.CompletionCode, error) {
+		if c.Req.Parameter == ipmi.SystemInfoParameterSetInProgress {
+			return ipmi.CompletionCodeParameterNotSupported, nil
+		}
+		if b.blocks[c.Req.Parameter] == nil {
+			b.blocks[c.Req.Parameter] = map[uint8][]byte{}
+		}
+		b.blocks[c.Req.Parameter][c.Req.Data[0]] = append([]byte{}, c.Req.Data...)
+		return ipmi.CompletionCodeNormal, nil
+	})
+	return s
+}
+
+func TestGetSystemInfo(t *testing.T) {
+	b := &systemInfoBMC{
+		blocks: map[ipmi.SystemInfoParameter]map[uint8][]byte{
+			ipmi.SystemInfoParameterSystemName: {
+				0: {
+					0x00, 0x00, 0x13, 'w', 'e', 'b', '-', '0', '1', '.', 'e',
+					'x', 'a', 'm', 'p', 'l', 'e',
+				},
+				// some BMCs truncate the final block
+				1: {0x01, '.', 'c', 'o', 'm', 0x00},
+			},
+			ipmi.SystemInfoParameterOSName: {
+				0: {
+					0x00, 0x01, 0x05, 'L', 'i', 'n', 'u', 'x', 0x00, 0x00,
+					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				},
+			},
+		},
+	}
+	want := &SystemInfo{
+		SystemName: "web-01.example.com",
+		OSName:     "Linux",
+	}
+
+	info, err := GetSystemInfo(context.Background(), b.session())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(want, info); diff != "" {
+		t.Errorf("GetSystemInfo() = %v, want %v: %v", info, want, diff)
+	}
+}
+
+func TestSetSystemInfoString(t *testing.T) {
+	b := &systemInfoBMC{
+		blocks: map[ipmi.SystemInfoParameter]map[uint8][]byte{},
+	}
+	want := &ipmi.SystemInfoString{
+		Encoding: ipmi.SystemInfoStringEncodingUTF8,
+		Value:    "an-unusually-long-hostname.datacentre.example.com",
+	}
+
+	s := b.session()
+	if err := SetSystemInfoString(context.Background(), s,
+		ipmi.SystemInfoParameterSystemName, want); err != nil {
+		t.Fatalf("unexpected error setting string: %v", err)
+	}
+	if blocks := len(b.blocks[ipmi.SystemInfoParameterSystemName]); blocks != 4 {
+		t.Errorf("wrote %v blocks, want 4", blocks)
+	}
+	got, err := GetSystemInfoString(context.Background(), s,
+		ipmi.SystemInfoParameterSystemName)
+	if err != nil {
+		t.Fatalf("unexpected error getting string: %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GetSystemInfoString() = %v, want %v: %v", got, want, diff)
+	}
+}
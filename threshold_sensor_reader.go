@@ -0,0 +1,123 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gebn/bmc/pkg/ipmi"
+)
+
+// SensorThresholds contains the converted values of a sensor's thresholds,
+// keyed by individual threshold, e.g. ipmi.ThresholdMaskUpperCritical. Only
+// readable thresholds are present.
+type SensorThresholds map[ipmi.ThresholdMask]float64
+
+// Evaluate returns the thresholds a converted reading is at or beyond. This
+// allows computing a status locally for BMCs that do not return threshold
+// comparison status, or to apply thresholds other than the BMC's.
+func (t SensorThresholds) Evaluate(value float64) ipmi.ThresholdMask {
+	crossed := ipmi.ThresholdMask(0)
+	for threshold, limit := range t {
+		if threshold.IsLower() && value <= limit ||
+			!threshold.IsLower() && value >= limit {
+			crossed |= threshold
+		}
+	}
+	return crossed
+}
+
+// ThresholdReading is a converted sensor reading along with its position
+// relative to the sensor's thresholds.
+type ThresholdReading struct {
+
+	// Value is the reading, with conversion factors and linearisation
+	// applied.
+	Value float64
+
+	// Crossed contains the thresholds the reading is at or beyond, as
+	// reported by the BMC. Only thresholds the SDR indicates are compared are
+	// included.
+	Crossed ipmi.ThresholdMask
+
+	// Status is the most severe status implied by Crossed.
+	Status ipmi.ThresholdStatus
+}
+
+// thresholdSensorReader is implemented by the linear, linearised and
+// non-linear readers, exposing what is needed to interpret thresholds.
+type thresholdSensorReader interface {
+	SensorReader
+
+	// convert turns a raw value into a reading in the same way as Read().
+	convert(context.Context, Session, byte) (float64, error)
+
+	// response returns the Get Sensor Reading response from the last Read().
+	response() *ipmi.GetSensorReadingRsp
+}
+
+// ThresholdSensorReader reads the value of a threshold-based sensor, along
+// with the thresholds it has crossed. It is a variant of SensorReader for
+// callers that want an ok/warning/critical status next to the value.
+type ThresholdSensorReader struct {
+	reader        thresholdSensorReader
+	compared      ipmi.ThresholdMask
+	thresholdsCmd ipmi.GetSensorThresholdsCmd
+}
+
+// NewThresholdSensorReader returns a ThresholdSensorReader for a given SDR.
+// It returns an error if the sensor is not threshold-based, or its
+// linearisation is not supported.
+func NewThresholdSensorReader(r *ipmi.FullSensorRecord) (*ThresholdSensorReader, error) {
+	if r.OutputType != ipmi.OutputTypeThreshold {
+		return nil, fmt.Errorf("sensor %v is not threshold-based: %v",
+			r.Identity, r.OutputType)
+	}
+	reader, err := NewSensorReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return &ThresholdSensorReader{
+		// all SensorReader implementations implement thresholdSensorReader
+		reader:   reader.(thresholdSensorReader),
+		compared: r.ComparedThresholds,
+		thresholdsCmd: ipmi.GetSensorThresholdsCmd{
+			Req: ipmi.GetSensorThresholdsReq{
+				Number: r.Number,
+			},
+			OwnerLUN: r.OwnerLUN,
+		},
+	}, nil
+}
+
+// Read returns the current value of the sensor and the thresholds it has
+// crossed. Errors are as for SensorReader.
+func (r *ThresholdSensorReader) Read(ctx context.Context, s Session) (*ThresholdReading, error) {
+	value, err := r.reader.Read(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	crossed := r.reader.response().ThresholdComparison() & r.compared
+	return &ThresholdReading{
+		Value:   value,
+		Crossed: crossed,
+		Status:  crossed.Status(),
+	}, nil
+}
+
+// Thresholds retrieves the sensor's current readable thresholds from the BMC
+// and converts them in the same way as readings.
+func (r *ThresholdSensorReader) Thresholds(ctx context.Context, s Session) (SensorThresholds, error) {
+	if err := ValidateResponse(s.SendCommand(ctx, &r.thresholdsCmd)); err != nil {
+		return nil, err
+	}
+	rsp := &r.thresholdsCmd.Rsp
+	thresholds := SensorThresholds{}
+	for _, threshold := range rsp.Readable.Thresholds() {
+		value, err := r.reader.convert(ctx, s, rsp.Thresholds.Value(threshold))
+		if err != nil {
+			return nil, err
+		}
+		thresholds[threshold] = value
+	}
+	return thresholds, nil
+}
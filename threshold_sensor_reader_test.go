@@ -0,0 +1,81 @@
+package bmc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gebn/bmc/pkg/ipmi"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var (
+	// testThresholdSensorRecord is a linear temperature sensor whose raw
+	// values are degrees Celsius multiplied by 2.
+	testThresholdSensorRecord = &ipmi.FullSensorRecord{
+		ConversionFactors: ipmi.ConversionFactors{
+			M:    5,
+			RExp: -1,
+		},
+		SensorType:         ipmi.SensorTypeTemperature,
+		OutputType:         ipmi.OutputTypeThreshold,
+		AnalogDataFormat:   ipmi.AnalogDataFormatUnsigned,
+		Linearisation:      ipmi.LinearisationLinear,
+		ComparedThresholds: ipmi.ThresholdMaskUpperNonCritical | ipmi.ThresholdMaskUpperCritical,
+		Identity:           "Inlet Temp",
+	}
+)
+
+func TestThresholdSensorReaderRead(t *testing.T) {
+	reader, err := NewThresholdSensorReader(testThresholdSensorRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeSession{}
+	handle(s, func(c *ipmi.GetSensorReadingCmd) (ipmi.CompletionCode, error) {
+		// 42C, scanning enabled, at or above upper non-critical; the upper
+		// non-recoverable bit is not compared so must be ignored
+		return respond(c, ipmi.CompletionCodeNormal,
+			[]byte{0x54, 0x40, 0x28, 0x80})
+	})
+	got, err := reader.Read(context.Background(), s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &ThresholdReading{
+		Value:   42,
+		Crossed: ipmi.ThresholdMaskUpperNonCritical,
+		Status:  ipmi.ThresholdStatusNonCritical,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Read() = %v, want %v: %v", got, want, diff)
+	}
+}
+
+func TestThresholdSensorReaderThresholds(t *testing.T) {
+	reader, err := NewThresholdSensorReader(testThresholdSensorRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeSession{}
+	handle(s, func(c *ipmi.GetSensorThresholdsCmd) (ipmi.CompletionCode, error) {
+		// upper non-critical and critical readable
+		return respond(c, ipmi.CompletionCodeNormal,
+			[]byte{0x18, 0x00, 0x00, 0x00, 0x5a, 0x64, 0xff})
+	})
+	got, err := reader.Thresholds(context.Background(), s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := SensorThresholds{
+		ipmi.ThresholdMaskUpperNonCritical: 45,
+		ipmi.ThresholdMaskUpperCritical:    50,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Thresholds() = %v, want %v: %v", got, want, diff)
+	}
+	if crossed := got.Evaluate(47); crossed != ipmi.ThresholdMaskUpperNonCritical {
+		t.Errorf("Evaluate(47) = %v, want %v", crossed,
+			ipmi.ThresholdMaskUpperNonCritical)
+	}
+}
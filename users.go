@@ -0,0 +1,148 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gebn/bmc/pkg/ipmi"
+)
+
+// User is an entry in the BMC's user table.
+type User struct {
+
+	// ID is the user's ID, which is used to identify it in commands. User ID
+	// 1 is typically the anonymous user.
+	ID uint8
+
+	// Name is the user's name, used to log in. This is empty for the
+	// anonymous user and unused IDs.
+	Name string
+
+	// Status indicates whether the user is enabled. This is
+	// ipmi.UserStatusUnspecified on BMCs that do not report it.
+	Status ipmi.UserStatus
+
+	// Access contains the user's access rights on the channel the session
+	// was established over.
+	Access ipmi.UserAccess
+}
+
+// ListUsers retrieves every user ID the BMC supports, including unused ones,
+// which have an empty name and no access. Access rights are retrieved for
+// the channel the session is established over.
+func ListUsers(ctx context.Context, s Session) ([]*User, error) {
+	// user 1 always exists, so use it to find out how many there are
+	first, err := s.GetUserAccess(ctx, &ipmi.GetUserAccessReq{
+		Channel: ipmi.ChannelPresentInterface,
+		UserID:  1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	users := make([]*User, 0, first.MaxUsers)
+	for id := uint8(1); id <= first.MaxUsers; id++ {
+		user, err := getUser(ctx, s, id)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+// getUser retrieves the name and access rights of a single user ID.
+func getUser(ctx context.Context, s Session, id uint8) (*User, error) {
+	access, err := s.GetUserAccess(ctx, &ipmi.GetUserAccessReq{
+		Channel: ipmi.ChannelPresentInterface,
+		UserID:  id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	name, err := s.GetUserName(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &User{
+		ID:     id,
+		Name:   name,
+		Status: access.Status,
+		Access: access.UserAccess,
+	}, nil
+}
+
+// TestUserPassword returns whether a password matches the one stored for a
+// user, without changing it. Passwords longer than 16 bytes are tested as 20
+// byte passwords. A mismatch, including a password stored with a different
+// size, is not an error.
+func TestUserPassword(ctx context.Context, s Session, id uint8, password []byte) (bool, error) {
+	cmd := &ipmi.SetUserPasswordCmd{
+		Req: ipmi.SetUserPasswordReq{
+			UserID:    id,
+			Operation: ipmi.PasswordOperationTest,
+			Size:      passwordSize(password),
+			Password:  password,
+		},
+	}
+	code, err := s.SendCommand(ctx, cmd)
+	if err != nil {
+		return false, err
+	}
+	switch code {
+	case ipmi.CompletionCodeNormal:
+		return true, nil
+	case ipmi.CompletionCodePasswordTestFailed,
+		ipmi.CompletionCodePasswordTestFailedWrongSize:
+		return false, nil
+	default:
+		return false, ValidateResponse(code, nil)
+	}
+}
+
+// RotatePassword sets a new password for a user, then verifies it by
+// establishing a new RMCP+ session as that user via t, which must be
+// connected to the same BMC as s. Passwords of up to 16 bytes are stored as 16
+// bytes so the user can still log in with IPMI v1.5; longer passwords are
+// stored as 20 bytes. The user must be able to log in over the channel s is
+// established over, otherwise the password is left unchanged and an error is
+// returned. If verification fails, the new password has been set, and the
+// returned error says so.
+func RotatePassword(ctx context.Context, s Session, t *V2SessionlessTransport, id uint8, password []byte) error {
+	user, err := getUser(ctx, s, id)
+	if err != nil {
+		return err
+	}
+	if !user.Access.IPMIMessaging ||
+		user.Access.PrivilegeLimit == ipmi.PrivilegeLevelNoAccess {
+		return fmt.Errorf("user %v cannot log in over this channel, so "+
+			"the new password could not be verified", id)
+	}
+	if err := s.SetUserPassword(ctx, &ipmi.SetUserPasswordReq{
+		UserID:    id,
+		Operation: ipmi.PasswordOperationSet,
+		Size:      passwordSize(password),
+		Password:  password,
+	}); err != nil {
+		return err
+	}
+	verification, err := t.NewV2Session(ctx, &V2SessionOpts{
+		SessionOpts: SessionOpts{
+			Username:          user.Name,
+			Password:          password,
+			MaxPrivilegeLevel: user.Access.PrivilegeLimit,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("password for user %v was set, but logging in "+
+			"with it failed: %v", id, err)
+	}
+	return verification.Close(ctx)
+}
+
+// passwordSize returns the smallest size a password can be stored as.
+func passwordSize(password []byte) ipmi.PasswordSize {
+	if len(password) > int(ipmi.PasswordSize16) {
+		return ipmi.PasswordSize20
+	}
+	return ipmi.PasswordSize16
+}
@@ -0,0 +1,97 @@
+package bmc
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/gebn/bmc/pkg/ipmi"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestListUsers(t *testing.T) {
+	names := []string{"", "ADMIN", ""}
+	access := []ipmi.UserAccess{
+		{PrivilegeLimit: ipmi.PrivilegeLevelNoAccess},
+		{IPMIMessaging: true, PrivilegeLimit: ipmi.PrivilegeLevelAdministrator},
+		{PrivilegeLimit: ipmi.PrivilegeLevelNoAccess},
+	}
+	s := &fakeSession{}
+	handle(s, func(c *ipmi.GetUserAccessCmd) (ipmi.CompletionCode, error) {
+		c.Rsp = ipmi.GetUserAccessRsp{
+			UserAccess: access[c.Req.UserID-1],
+			MaxUsers:   uint8(len(names)),
+			Status:     ipmi.UserStatusEnabled,
+		}
+		return ipmi.CompletionCodeNormal, nil
+	})
+	handle(s, func(c *ipmi.GetUserNameCmd) (ipmi.CompletionCode, error) {
+		c.Rsp.Name = names[c.Req.UserID-1]
+		return ipmi.CompletionCodeNormal, nil
+	})
+	users, err := ListUsers(context.Background(), s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*User{
+		{
+			ID:     1,
+			Status: ipmi.UserStatusEnabled,
+			Access: ipmi.UserAccess{PrivilegeLimit: ipmi.PrivilegeLevelNoAccess},
+		},
+		{
+			ID:     2,
+			Name:   "ADMIN",
+			Status: ipmi.UserStatusEnabled,
+			Access: ipmi.UserAccess{
+				IPMIMessaging:  true,
+				PrivilegeLimit: ipmi.PrivilegeLevelAdministrator,
+			},
+		},
+		{
+			ID:     3,
+			Status: ipmi.UserStatusEnabled,
+			Access: ipmi.UserAccess{PrivilegeLimit: ipmi.PrivilegeLevelNoAccess},
+		},
+	}
+	if diff := cmp.Diff(want, users); diff != "" {
+		t.Errorf("ListUsers() = %v, want %v: %v", users, want, diff)
+	}
+}
+
+func TestTestUserPassword(t *testing.T) {
+	passwords := map[uint8][]byte{
+		2: []byte("hunter2"),
+	}
+	s := &fakeSession{}
+	handle(s, func(c *ipmi.SetUserPasswordCmd) (ipmi.CompletionCode, error) {
+		stored := passwords[c.Req.UserID]
+		switch {
+		case !bytes.Equal(stored, c.Req.Password):
+			return ipmi.CompletionCodePasswordTestFailed, nil
+		case passwordSize(stored) != c.Req.Size:
+			return ipmi.CompletionCodePasswordTestFailedWrongSize, nil
+		default:
+			return ipmi.CompletionCodeNormal, nil
+		}
+	})
+	tests := []struct {
+		password string
+		want     bool
+	}{
+		{"hunter2", true},
+		{"hunter3", false},
+	}
+	for _, test := range tests {
+		got, err := TestUserPassword(context.Background(), s, 2,
+			[]byte(test.password))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != test.want {
+			t.Errorf("TestUserPassword(%q) = %v, want %v", test.password, got,
+				test.want)
+		}
+	}
+}
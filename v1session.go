@@ -0,0 +1,403 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/gebn/bmc/pkg/ipmi"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// V1Session represents an established IPMI v1.5 session with a BMC. It is safe
+// for concurrent use.
+type V1Session struct {
+	*v1ConnectionShared
+
+	// slots each have their own instance of the authentication algorithm,
+	// loaded with the user's password.
+	slots [packetBufferDepth]v1Slot
+
+	// id is the session ID, chosen by the BMC and returned in the Activate
+	// Session response. Unlike v2.0, both sides use the same ID.
+	id uint32
+
+	// SequenceNumbers is the pair of sequence numbers for the session. Unlike
+	// v2.0, IPMI v1.5 has a single pair, used for all packets.
+	SequenceNumbers sequenceNumbers
+
+	// AuthenticationType is the algorithm used to sign packets sent by the
+	// remote console and managed system, as returned in the Activate Session
+	// response. This library authenticates all packets it sends inside a
+	// session, regardless of whether per-message authentication is enabled on
+	// the BMC.
+	AuthenticationType ipmi.AuthenticationType
+
+	// timeout is the time allowed per attempt of a command. The context passed
+	// in by the user controls end-to-end.
+	timeout time.Duration
+}
+
+// String returns a summary of the session's attributes on one line.
+func (s *V1Session) String() string {
+	return fmt.Sprintf("V1Session(Authentication: %v, ID: %v)",
+		s.AuthenticationType, s.id)
+}
+
+func (s *V1Session) Version() string {
+	return "1.5"
+}
+
+func (s *V1Session) ID() uint32 {
+	return s.id
+}
+
+func (s *V1Session) SendCommand(ctx context.Context, c ipmi.Command) (ipmi.CompletionCode, error) {
+	timer := prometheus.NewTimer(commandDuration)
+	defer timer.ObserveDuration()
+	commandAttempts.WithLabelValues(c.Name()).Inc()
+
+	token, err := s.tokens.acquire(ctx)
+	if err != nil {
+		commandFailures.WithLabelValues(c.Name()).Inc()
+		return 0, err
+	}
+	defer s.tokens.release(token)
+	slot := &s.slots[token]
+
+	if err := s.buildAndSend(ctx, slot, c); err != nil {
+		commandFailures.WithLabelValues(c.Name()).Inc()
+		return 0, err
+	}
+
+	code := slot.messageLayer.CompletionCode
+
+	if c.Response() != nil {
+		if err := decodeResponse(c.Response(),
+			slot.messageLayer.LayerPayload()); err != nil {
+			commandFailures.WithLabelValues(c.Name()).Inc()
+			return code, err
+		}
+	}
+
+	return code, nil
+}
+
+func (s *V1Session) buildAndSend(ctx context.Context, slot *v1Slot, c ipmi.Command) error {
+	slot.rmcpLayer = layers.RMCP{
+		Version:  layers.RMCPVersion1,
+		Sequence: 0xFF, // do not send us an ACK
+		Class:    layers.RMCPClassIPMI,
+	}
+	slot.sequence = s.sequence.next()
+	slot.operation = *c.Operation()
+	slot.messageLayer = ipmi.Message{
+		Operation:     slot.operation,
+		RemoteAddress: ipmi.SlaveAddressBMC.Address(),
+		RemoteLUN:     c.RemoteLUN(),
+		LocalAddress:  ipmi.SoftwareIDRemoteConsole1.Address(),
+		Sequence:      slot.sequence,
+	}
+
+	firstAttempt := true
+	terminalErr := error(nil)
+	retryable := func() error {
+		if firstAttempt {
+			firstAttempt = false
+		} else {
+			commandRetries.Inc()
+		}
+
+		// the session layer is overwritten by decoding, so must be reset each
+		// attempt; the sequence number changes anyway
+		slot.v1SessionLayer = ipmi.V1Session{
+			AuthType:                s.AuthenticationType,
+			Sequence:                atomic.AddUint32(&s.SequenceNumbers.Inbound, 1),
+			ID:                      s.id,
+			AuthenticationAlgorithm: slot.authenticationAlgorithm,
+		}
+		if err := gopacket.SerializeLayers(slot.buffer, serializeOptions,
+			&slot.rmcpLayer,
+			// session selector only used when decoding
+			&slot.v1SessionLayer,
+			&slot.messageLayer,
+			serializableLayerOrEmpty(c.Request())); err != nil {
+			// this is not a retryable error
+			terminalErr = err
+			return nil
+		}
+		requestCtx, cancel := context.WithTimeout(ctx, s.timeout)
+		_, err := s.transport.Send(requestCtx, slot.buffer.Bytes(),
+			slot.response[:], slot.matchMessage)
+		cancel()
+		if err != nil {
+			// see V2Session for why this is not retried
+			terminalErr = err
+			return nil
+		}
+		atomic.StoreUint32(&s.SequenceNumbers.Outbound,
+			slot.v1SessionLayer.Sequence)
+		code := slot.messageLayer.CompletionCode
+		commandResponses.WithLabelValues(code.String()).Inc()
+		if code.IsTemporary() {
+			return errRetryableCode
+		}
+		return nil
+	}
+	slot.backoff.Reset()
+	if err := backoff.Retry(retryable, backoff.WithContext(slot.backoff, ctx)); err != nil {
+		return err
+	}
+	return terminalErr
+}
+
+func (s *V1Session) GetSystemGUID(ctx context.Context) ([16]byte, error) {
+	return getSystemGUID(ctx, s)
+}
+
+func (s *V1Session) GetChannelAuthenticationCapabilities(
+	ctx context.Context,
+	r *ipmi.GetChannelAuthenticationCapabilitiesReq,
+) (*ipmi.GetChannelAuthenticationCapabilitiesRsp, error) {
+	return getChannelAuthenticationCapabilities(ctx, s, r)
+}
+
+func (s *V1Session) GetSessionInfo(ctx context.Context, r *ipmi.GetSessionInfoReq) (*ipmi.GetSessionInfoRsp, error) {
+	return getSessionInfo(ctx, s, r)
+}
+
+func (s *V1Session) GetDeviceID(ctx context.Context) (*ipmi.GetDeviceIDRsp, error) {
+	return getDeviceID(ctx, s)
+}
+
+func (s *V1Session) ColdReset(ctx context.Context) error {
+	return coldReset(ctx, s)
+}
+
+func (s *V1Session) WarmReset(ctx context.Context) error {
+	return warmReset(ctx, s)
+}
+
+func (s *V1Session) GetSelfTestResults(ctx context.Context) (*ipmi.GetSelfTestResultsRsp, error) {
+	return getSelfTestResults(ctx, s)
+}
+
+func (s *V1Session) SetACPIPowerState(ctx context.Context, r *ipmi.SetACPIPowerStateReq) error {
+	return setACPIPowerState(ctx, s, r)
+}
+
+func (s *V1Session) GetACPIPowerState(ctx context.Context) (*ipmi.GetACPIPowerStateRsp, error) {
+	return getACPIPowerState(ctx, s)
+}
+
+func (s *V1Session) GetSystemInfoParameters(ctx context.Context, r *ipmi.GetSystemInfoParametersReq) (*ipmi.GetSystemInfoParametersRsp, error) {
+	return getSystemInfoParameters(ctx, s, r)
+}
+
+func (s *V1Session) SetSystemInfoParameters(ctx context.Context, r *ipmi.SetSystemInfoParametersReq) error {
+	return setSystemInfoParameters(ctx, s, r)
+}
+
+func (s *V1Session) GetWatchdogTimer(ctx context.Context) (*ipmi.GetWatchdogTimerRsp, error) {
+	return getWatchdogTimer(ctx, s)
+}
+
+func (s *V1Session) SetWatchdogTimer(ctx context.Context, r *ipmi.SetWatchdogTimerReq) error {
+	return setWatchdogTimer(ctx, s, r)
+}
+
+func (s *V1Session) ResetWatchdogTimer(ctx context.Context) error {
+	return resetWatchdogTimer(ctx, s)
+}
+
+func (s *V1Session) GetChassisStatus(ctx context.Context) (*ipmi.GetChassisStatusRsp, error) {
+	return getChassisStatus(ctx, s)
+}
+
+func (s *V1Session) ChassisControl(ctx context.Context, c ipmi.ChassisControl) error {
+	return chassisControl(ctx, s, c)
+}
+
+func (s *V1Session) ChassisIdentify(ctx context.Context, interval time.Duration, forceOn bool) error {
+	return chassisIdentify(ctx, s, interval, forceOn)
+}
+
+func (s *V1Session) GetSystemBootOptions(ctx context.Context, r *ipmi.GetSystemBootOptionsReq) (*ipmi.GetSystemBootOptionsRsp, error) {
+	return getSystemBootOptions(ctx, s, r)
+}
+
+func (s *V1Session) SetSystemBootOptions(ctx context.Context, r *ipmi.SetSystemBootOptionsReq) error {
+	return setSystemBootOptions(ctx, s, r)
+}
+
+func (s *V1Session) SetBootDevice(ctx context.Context, f *ipmi.BootFlags) error {
+	return setBootDevice(ctx, s, f)
+}
+
+func (s *V1Session) GetLANConfigurationParameters(ctx context.Context, r *ipmi.GetLANConfigurationParametersReq) (*ipmi.GetLANConfigurationParametersRsp, error) {
+	return getLANConfigurationParameters(ctx, s, r)
+}
+
+func (s *V1Session) SetLANConfigurationParameters(ctx context.Context, r *ipmi.SetLANConfigurationParametersReq) error {
+	return setLANConfigurationParameters(ctx, s, r)
+}
+
+func (s *V1Session) GetChannelInfo(ctx context.Context, channel ipmi.Channel) (*ipmi.GetChannelInfoRsp, error) {
+	return getChannelInfo(ctx, s, channel)
+}
+
+func (s *V1Session) GetChannelAccess(ctx context.Context, r *ipmi.GetChannelAccessReq) (*ipmi.GetChannelAccessRsp, error) {
+	return getChannelAccess(ctx, s, r)
+}
+
+func (s *V1Session) SetChannelAccess(ctx context.Context, r *ipmi.SetChannelAccessReq) error {
+	return setChannelAccess(ctx, s, r)
+}
+
+func (s *V1Session) GetSDRRepositoryInfo(ctx context.Context) (*ipmi.GetSDRRepositoryInfoRsp, error) {
+	return getSDRRepositoryInfo(ctx, s)
+}
+
+func (s *V1Session) ReserveSDRRepository(ctx context.Context) (*ipmi.ReserveSDRRepositoryRsp, error) {
+	return reserveSDRRepository(ctx, s)
+}
+
+func (s *V1Session) GetSDRRepositoryTime(ctx context.Context) (*ipmi.GetSDRRepositoryTimeRsp, error) {
+	return getSDRRepositoryTime(ctx, s)
+}
+
+func (s *V1Session) GetSELInfo(ctx context.Context) (*ipmi.GetSELInfoRsp, error) {
+	return getSELInfo(ctx, s)
+}
+
+func (s *V1Session) GetSELAllocationInfo(ctx context.Context) (*ipmi.GetSELAllocationInfoRsp, error) {
+	return getSELAllocationInfo(ctx, s)
+}
+
+func (s *V1Session) ReserveSEL(ctx context.Context) (*ipmi.ReserveSELRsp, error) {
+	return reserveSEL(ctx, s)
+}
+
+func (s *V1Session) GetSELEntry(ctx context.Context, r *ipmi.GetSELEntryReq) (*ipmi.GetSELEntryRsp, error) {
+	return getSELEntry(ctx, s, r)
+}
+
+func (s *V1Session) DeleteSELEntry(ctx context.Context, r *ipmi.DeleteSELEntryReq) (*ipmi.DeleteSELEntryRsp, error) {
+	return deleteSELEntry(ctx, s, r)
+}
+
+func (s *V1Session) ClearSEL(ctx context.Context) error {
+	return clearSEL(ctx, s)
+}
+
+func (s *V1Session) GetSELTime(ctx context.Context) (*ipmi.GetSELTimeRsp, error) {
+	return getSELTime(ctx, s)
+}
+
+func (s *V1Session) SetSELTime(ctx context.Context, t time.Time) error {
+	return setSELTime(ctx, s, t)
+}
+
+func (s *V1Session) GetSELTimeUTCOffset(ctx context.Context) (*ipmi.GetSELTimeUTCOffsetRsp, error) {
+	return getSELTimeUTCOffset(ctx, s)
+}
+
+func (s *V1Session) SetSELTimeUTCOffset(ctx context.Context, o ipmi.UTCOffset) error {
+	return setSELTimeUTCOffset(ctx, s, o)
+}
+
+func (s *V1Session) GetFRUInventoryAreaInfo(ctx context.Context, deviceID uint8) (*ipmi.GetFRUInventoryAreaInfoRsp, error) {
+	return getFRUInventoryAreaInfo(ctx, s, deviceID)
+}
+
+func (s *V1Session) ReadFRUData(ctx context.Context, r *ipmi.ReadFRUDataReq) (*ipmi.ReadFRUDataRsp, error) {
+	return readFRUData(ctx, s, r)
+}
+
+func (s *V1Session) GetUserAccess(ctx context.Context, r *ipmi.GetUserAccessReq) (*ipmi.GetUserAccessRsp, error) {
+	return getUserAccess(ctx, s, r)
+}
+
+func (s *V1Session) SetUserAccess(ctx context.Context, r *ipmi.SetUserAccessReq) error {
+	return setUserAccess(ctx, s, r)
+}
+
+func (s *V1Session) GetUserName(ctx context.Context, userID uint8) (string, error) {
+	return getUserName(ctx, s, userID)
+}
+
+func (s *V1Session) SetUserName(ctx context.Context, userID uint8, name string) error {
+	return setUserName(ctx, s, userID, name)
+}
+
+func (s *V1Session) SetUserPassword(ctx context.Context, r *ipmi.SetUserPasswordReq) error {
+	return setUserPassword(ctx, s, r)
+}
+
+func (s *V1Session) GetSensorReading(ctx context.Context, sensor uint8) (*ipmi.GetSensorReadingRsp, error) {
+	return getSensorReading(ctx, s, sensor)
+}
+
+func (s *V1Session) GetSensorReadingFactors(ctx context.Context, r *ipmi.GetSensorReadingFactorsReq) (*ipmi.GetSensorReadingFactorsRsp, error) {
+	return getSensorReadingFactors(ctx, s, r)
+}
+
+func (s *V1Session) GetSensorThresholds(ctx context.Context, sensor uint8) (*ipmi.GetSensorThresholdsRsp, error) {
+	return getSensorThresholds(ctx, s, sensor)
+}
+
+func (s *V1Session) SetSensorThresholds(ctx context.Context, r *ipmi.SetSensorThresholdsReq) error {
+	return setSensorThresholds(ctx, s, r)
+}
+
+func (s *V1Session) GetSensorHysteresis(ctx context.Context, sensor uint8) (*ipmi.GetSensorHysteresisRsp, error) {
+	return getSensorHysteresis(ctx, s, sensor)
+}
+
+func (s *V1Session) SetSensorHysteresis(ctx context.Context, r *ipmi.SetSensorHysteresisReq) error {
+	return setSensorHysteresis(ctx, s, r)
+}
+
+func (s *V1Session) GetPEFCapabilities(ctx context.Context) (*ipmi.GetPEFCapabilitiesRsp, error) {
+	return getPEFCapabilities(ctx, s)
+}
+
+func (s *V1Session) ArmPEFPostponeTimer(ctx context.Context, t ipmi.PEFPostponeTimer) (*ipmi.ArmPEFPostponeTimerRsp, error) {
+	return armPEFPostponeTimer(ctx, s, t)
+}
+
+func (s *V1Session) GetPEFConfigurationParameters(ctx context.Context, r *ipmi.GetPEFConfigurationParametersReq) (*ipmi.GetPEFConfigurationParametersRsp, error) {
+	return getPEFConfigurationParameters(ctx, s, r)
+}
+
+func (s *V1Session) SetPEFConfigurationParameters(ctx context.Context, r *ipmi.SetPEFConfigurationParametersReq) error {
+	return setPEFConfigurationParameters(ctx, s, r)
+}
+
+func (s *V1Session) GetSessionPrivilegeLevel(ctx context.Context) (ipmi.PrivilegeLevel, error) {
+	return getSessionPrivilegeLevel(ctx, s)
+}
+
+func (s *V1Session) SetSessionPrivilegeLevel(ctx context.Context, level ipmi.PrivilegeLevel) (ipmi.PrivilegeLevel, error) {
+	return setSessionPrivilegeLevel(ctx, s, level)
+}
+
+func (s *V1Session) closeSession(ctx context.Context) error {
+	// see V2Session for why we decrement regardless of the result
+	defer sessionsOpen.Dec()
+	cmd := &ipmi.CloseSessionCmd{
+		Req: ipmi.CloseSessionReq{
+			ID: s.id,
+		},
+	}
+	return ValidateResponse(s.SendCommand(ctx, cmd))
+}
+
+func (s *V1Session) Close(ctx context.Context) error {
+	return s.closeSession(ctx)
+}
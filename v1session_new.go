@@ -0,0 +1,212 @@
+package bmc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+
+	"github.com/gebn/bmc/pkg/ipmi"
+)
+
+var (
+	ErrNoSupportedAuthenticationType = errors.New("none of the provided " +
+		"authentication type options were supported by the BMC")
+
+	// errPrivilegeLevelExceedsLimit is returned by newV1SessionAtLevel if the
+	// BMC rejects the requested maximum privilege level.
+	errPrivilegeLevelExceedsLimit = errors.New("requested maximum privilege " +
+		"level exceeds the channel or user privilege limit")
+
+	defaultAuthenticationTypes = []ipmi.AuthenticationType{
+		ipmi.AuthenticationTypeMD5,
+		ipmi.AuthenticationTypeMD2,
+		ipmi.AuthenticationTypePassword,
+	}
+)
+
+// V1SessionOpts contains configurable parameters for IPMI v1.5 session
+// establishment. The default value is used when creating a version-agnostic
+// Session instance.
+type V1SessionOpts struct {
+	SessionOpts
+
+	// AuthenticationTypes is the list of algorithms to sign packets with, in
+	// descending order of preference. The first one the BMC supports at the
+	// requested maximum privilege level will be used. If omitted, the library
+	// will use MD5 if possible, falling back on MD2, then straight password.
+	// AuthenticationTypeNone must be explicitly provided to be used, and
+	// AuthenticationTypeOEM is not supported.
+	AuthenticationTypes []ipmi.AuthenticationType
+}
+
+// NewSession establishes a new IPMI v1.5 session, offering all authentication
+// types supported by the library other than none.
+func (s *V1SessionlessTransport) NewSession(
+	ctx context.Context,
+	opts *SessionOpts,
+) (Session, error) {
+	return s.NewV1Session(ctx, &V1SessionOpts{
+		SessionOpts: *opts,
+	})
+}
+
+// NewV1Session establishes a new IPMI v1.5 session with fine-grained
+// parameters. This function does not modify the input options.
+func (s *V1SessionlessTransport) NewV1Session(ctx context.Context, opts *V1SessionOpts) (*V1Session, error) {
+	sessionOpenAttempts.Inc()
+	sess, err := s.newV1Session(ctx, opts)
+	if err != nil {
+		sessionOpenFailures.Inc()
+		return nil, err
+	}
+	sessionsOpen.Inc()
+	return sess, nil
+}
+
+// newV1Session negotiates a new session via Get Session Challenge and Activate
+// Session, returning it on success. IPMI v1.5 has no equivalent of
+// PrivilegeLevelHighest, so if it is requested, Administrator, Operator and
+// User are tried in turn until the BMC accepts one.
+func (s *V1SessionlessTransport) newV1Session(ctx context.Context, opts *V1SessionOpts) (*V1Session, error) {
+	if opts.MaxPrivilegeLevel != ipmi.PrivilegeLevelHighest {
+		return s.newV1SessionAtLevel(ctx, opts, opts.MaxPrivilegeLevel)
+	}
+	for _, level := range []ipmi.PrivilegeLevel{
+		ipmi.PrivilegeLevelAdministrator,
+		ipmi.PrivilegeLevelOperator,
+	} {
+		sess, err := s.newV1SessionAtLevel(ctx, opts, level)
+		if err != errPrivilegeLevelExceedsLimit {
+			return sess, err
+		}
+	}
+	return s.newV1SessionAtLevel(ctx, opts, ipmi.PrivilegeLevelUser)
+}
+
+// newV1SessionAtLevel establishes a new session with a given maximum
+// privilege level, then raises the session to that level. If the level
+// exceeds the channel or user privilege limit, errPrivilegeLevelExceedsLimit
+// is returned.
+func (s *V1SessionlessTransport) newV1SessionAtLevel(ctx context.Context, opts *V1SessionOpts, maxPrivilegeLevel ipmi.PrivilegeLevel) (*V1Session, error) {
+	authenticationType, err := s.determineAuthenticationType(ctx,
+		maxPrivilegeLevel, opts.AuthenticationTypes)
+	if err != nil {
+		return nil, err
+	}
+	algorithm, err := algorithmAuthCode(authenticationType, opts.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	challengeRsp, err := s.getSessionChallenge(ctx, &ipmi.GetSessionChallengeReq{
+		AuthenticationType: authenticationType,
+		Username:           opts.Username,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the BMC uses this as the sequence number of the first packet it sends us;
+	// 0 is reserved for session-less packets
+	initialOutboundSequence := uint32(0)
+	for initialOutboundSequence == 0 {
+		if err := binary.Read(rand.Reader, binary.LittleEndian,
+			&initialOutboundSequence); err != nil {
+			return nil, err
+		}
+	}
+	activateSessionRsp, err := s.activateSession(ctx, &ipmi.V1Session{
+		AuthType:                authenticationType,
+		ID:                      challengeRsp.TemporarySessionID,
+		AuthenticationAlgorithm: algorithm,
+	}, &ipmi.ActivateSessionReq{
+		AuthenticationType:      authenticationType,
+		MaxPrivilegeLevel:       maxPrivilegeLevel,
+		Challenge:               challengeRsp.Challenge,
+		InitialOutboundSequence: initialOutboundSequence,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sess := &V1Session{
+		v1ConnectionShared: &s.v1ConnectionShared,
+		id:                 activateSessionRsp.SessionID,
+		SequenceNumbers: sequenceNumbers{
+			// pre-increment
+			Inbound:  activateSessionRsp.InitialInboundSequence - 1,
+			Outbound: initialOutboundSequence - 1,
+		},
+		AuthenticationType: activateSessionRsp.AuthenticationType,
+		timeout:            s.timeout,
+	}
+	for i := range sess.slots {
+		// the BMC is allowed to pick a different algorithm for the session
+		// itself, and digests are stateful, so each slot needs its own
+		algorithm, err := algorithmAuthCode(sess.AuthenticationType, opts.Password)
+		if err != nil {
+			return nil, err
+		}
+		sess.slots[i].init(sess.id, algorithm)
+	}
+
+	// v1.5 sessions start at the User privilege level regardless of the
+	// maximum, so we must raise it ourselves
+	if maxPrivilegeLevel > ipmi.PrivilegeLevelUser {
+		if _, err := sess.SetSessionPrivilegeLevel(ctx, maxPrivilegeLevel); err != nil {
+			// the session was activated, so attempt to clean it up; we don't
+			// use closeSession() as the session was never counted as open
+			sess.SendCommand(ctx, &ipmi.CloseSessionCmd{
+				Req: ipmi.CloseSessionReq{
+					ID: sess.id,
+				},
+			})
+			return nil, err
+		}
+	}
+	return sess, nil
+}
+
+// determineAuthenticationType picks the algorithm that will be used to sign
+// packets, based on what the BMC supports at the requested privilege level.
+func (s *V1SessionlessTransport) determineAuthenticationType(
+	ctx context.Context,
+	level ipmi.PrivilegeLevel,
+	desiredTypes []ipmi.AuthenticationType,
+) (ipmi.AuthenticationType, error) {
+	if len(desiredTypes) == 0 {
+		desiredTypes = defaultAuthenticationTypes
+	}
+
+	caps, err := s.GetChannelAuthenticationCapabilities(ctx,
+		&ipmi.GetChannelAuthenticationCapabilitiesReq{
+			Channel:           ipmi.ChannelPresentInterface,
+			MaxPrivilegeLevel: level,
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, desiredType := range desiredTypes {
+		switch desiredType {
+		case ipmi.AuthenticationTypeNone:
+			if caps.AuthenticationTypeNone {
+				return desiredType, nil
+			}
+		case ipmi.AuthenticationTypeMD2:
+			if caps.AuthenticationTypeMD2 {
+				return desiredType, nil
+			}
+		case ipmi.AuthenticationTypeMD5:
+			if caps.AuthenticationTypeMD5 {
+				return desiredType, nil
+			}
+		case ipmi.AuthenticationTypePassword:
+			if caps.AuthenticationTypePassword {
+				return desiredType, nil
+			}
+		}
+	}
+	return 0, ErrNoSupportedAuthenticationType
+}
@@ -0,0 +1,165 @@
+package bmc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gebn/bmc/internal/pkg/transport"
+	"github.com/gebn/bmc/pkg/ipmi"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// v1BMC plays the part of a BMC in IPMI v1.5 tests, using authentication type
+// none. respond is called with each request message; it returns the
+// completion code and response data, or false to drop the request, in which
+// case the request times out.
+type v1BMC struct {
+	respond func(m *ipmi.Message) (ipmi.CompletionCode, []byte, bool)
+}
+
+func (*v1BMC) Address() net.Addr {
+	return &net.UDPAddr{Port: 623}
+}
+
+func (b *v1BMC) Send(ctx context.Context, request, response []byte, match transport.Matcher) ([]byte, error) {
+	// skip the RMCP header
+	session := &ipmi.V1Session{}
+	if err := session.DecodeFromBytes(request[4:], gopacket.NilDecodeFeedback); err != nil {
+		return nil, err
+	}
+	message := &ipmi.Message{}
+	if err := message.DecodeFromBytes(session.LayerPayload(), gopacket.NilDecodeFeedback); err != nil {
+		return nil, err
+	}
+	code, data, ok := b.respond(message)
+	if !ok {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	buf := gopacket.NewSerializeBuffer()
+	if err := gopacket.SerializeLayers(buf, serializeOptions,
+		&layers.RMCP{
+			Version:  layers.RMCPVersion1,
+			Sequence: 0xFF,
+			Class:    layers.RMCPClassIPMI,
+		},
+		&ipmi.V1Session{
+			ID: session.ID,
+		},
+		&ipmi.Message{
+			Operation: ipmi.Operation{
+				Function: message.Function + 1,
+				Command:  message.Command,
+			},
+			RemoteAddress:  message.LocalAddress,
+			LocalAddress:   message.RemoteAddress,
+			Sequence:       message.Sequence,
+			CompletionCode: code,
+		},
+		gopacket.Payload(data)); err != nil {
+		return nil, err
+	}
+	n := copy(response, buf.Bytes())
+	if !match(response[:n]) {
+		return nil, errors.New("response rejected")
+	}
+	return response[:n], nil
+}
+
+func (*v1BMC) Write([]byte) error {
+	return errors.New("not implemented")
+}
+
+func (*v1BMC) Listen(transport.Listener) func() {
+	return func() {}
+}
+
+func (*v1BMC) Close() error {
+	return nil
+}
+
+// privilegeLimitBMC returns a v1BMC that establishes sessions using
+// authentication type none, rejecting maximum privilege levels above limit.
+// The level requested in Activate Session and the level the session was
+// raised to are stored in activated and raised.
+func privilegeLimitBMC(limit ipmi.PrivilegeLevel, activated, raised *ipmi.PrivilegeLevel) *v1BMC {
+	return &v1BMC{
+		respond: func(m *ipmi.Message) (ipmi.CompletionCode, []byte, bool) {
+			data := m.LayerPayload()
+			switch m.Operation {
+			case ipmi.OperationGetChannelAuthenticationCapabilitiesReq:
+				return ipmi.CompletionCodeNormal, []byte{0x01, 0x01, 0x04, 0, 0, 0, 0, 0}, true
+			case ipmi.OperationGetSessionChallengeReq:
+				return ipmi.CompletionCodeNormal, make([]byte, 20), true
+			case ipmi.OperationActivateSessionReq:
+				level := ipmi.PrivilegeLevel(data[1] & 0xf)
+				if level > limit {
+					// requested maximum privilege level exceeds user and/or
+					// channel privilege limit, per 22.17 of IPMI v2.0
+					return 0x86, nil, true
+				}
+				*activated = level
+				return ipmi.CompletionCodeNormal, []byte{0, 1, 0, 0, 0, 1, 0, 0, 0, uint8(level)}, true
+			case ipmi.OperationSetSessionPrivilegeLevelReq:
+				*raised = ipmi.PrivilegeLevel(data[0] & 0xf)
+				return ipmi.CompletionCodeNormal, []byte{uint8(*raised)}, true
+			default:
+				return ipmi.CompletionCodeUnrecognisedCommand, nil, true
+			}
+		},
+	}
+}
+
+func TestNewV1SessionPrivilegeLevel(t *testing.T) {
+	tests := []struct {
+		name      string
+		requested ipmi.PrivilegeLevel
+		limit     ipmi.PrivilegeLevel
+		want      ipmi.PrivilegeLevel // Highest if error expected
+	}{
+		{"highest, administrator limit", ipmi.PrivilegeLevelHighest, ipmi.PrivilegeLevelAdministrator, ipmi.PrivilegeLevelAdministrator},
+		{"highest, operator limit", ipmi.PrivilegeLevelHighest, ipmi.PrivilegeLevelOperator, ipmi.PrivilegeLevelOperator},
+		{"highest, user limit", ipmi.PrivilegeLevelHighest, ipmi.PrivilegeLevelUser, ipmi.PrivilegeLevelUser},
+		{"highest, callback limit", ipmi.PrivilegeLevelHighest, ipmi.PrivilegeLevelCallback, ipmi.PrivilegeLevelHighest},
+		{"operator, administrator limit", ipmi.PrivilegeLevelOperator, ipmi.PrivilegeLevelAdministrator, ipmi.PrivilegeLevelOperator},
+		{"operator, user limit", ipmi.PrivilegeLevelOperator, ipmi.PrivilegeLevelUser, ipmi.PrivilegeLevelHighest},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			activated, raised := ipmi.PrivilegeLevelHighest, ipmi.PrivilegeLevelUser
+			b := privilegeLimitBMC(test.limit, &activated, &raised)
+			s := &V1SessionlessTransport{
+				Transport:     b,
+				V1Sessionless: newV1Sessionless(b, time.Second),
+			}
+			_, err := s.newV1Session(context.Background(), &V1SessionOpts{
+				SessionOpts: SessionOpts{
+					MaxPrivilegeLevel: test.requested,
+				},
+				AuthenticationTypes: []ipmi.AuthenticationType{
+					ipmi.AuthenticationTypeNone,
+				},
+			})
+			if test.want == ipmi.PrivilegeLevelHighest {
+				if err == nil {
+					t.Errorf("expected error, activated at %v", activated)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if activated != test.want {
+				t.Errorf("activated at %v, want %v", activated, test.want)
+			}
+			if raised != test.want {
+				t.Errorf("raised to %v, want %v", raised, test.want)
+			}
+		})
+	}
+}
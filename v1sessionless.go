@@ -0,0 +1,305 @@
+package bmc
+
+import (
+	"context"
+	"time"
+
+	"github.com/gebn/bmc/internal/pkg/transport"
+	"github.com/gebn/bmc/pkg/ipmi"
+	"github.com/gebn/bmc/pkg/layerexts"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	v1ConnectionOpenAttempts = connectionOpenAttempts.WithLabelValues("1.5")
+	v1ConnectionOpenFailures = connectionOpenFailures.WithLabelValues("1.5")
+	v1ConnectionsOpen        = connectionsOpen.WithLabelValues("1.5")
+)
+
+// v1ConnectionLayers contains layers common to all v1.5 connections. As with
+// v2ConnectionLayers, each slot of each connection has its own set.
+type v1ConnectionLayers struct {
+	rmcpLayer            layers.RMCP
+	sessionSelectorLayer ipmi.SessionSelector
+	v1SessionLayer       ipmi.V1Session
+	messageLayer         ipmi.Message
+}
+
+// v1Slot contains the state required to send a single request and receive its
+// response over a v1.5 connection. It is the v1.5 equivalent of v2Slot.
+type v1Slot struct {
+	v1ConnectionLayers
+
+	// sequence and operation identify the IPMI message currently being sent
+	// using this slot, and are used to match up its response.
+	sequence  uint8
+	operation ipmi.Operation
+
+	// sessionID is the session ID the BMC sends responses to us with. Unlike
+	// v2.0, this varies between requests of a session-less connection, as
+	// Activate Session is sent with a temporary session ID.
+	sessionID uint32
+
+	// anySessionID disables checking of the session ID of responses. This is
+	// only set for Activate Session, as the spec does not make clear whether
+	// the BMC answers using the temporary or the new session ID.
+	anySessionID bool
+
+	// buffer is used to build all packets to send using this slot.
+	buffer gopacket.SerializeBuffer
+
+	// response is the buffer the transport copies received packets into. The
+	// layers decoded from a matched response refer to it.
+	response [transport.MaxPacketSize]byte
+
+	// layers contains layer types decoded by decode.
+	layers []gopacket.LayerType
+
+	// decode parses the layers in v1ConnectionLayers.
+	decode gopacket.DecodingLayerFunc
+
+	// matchMessage is created once to avoid allocating a closure each
+	// request.
+	matchMessage transport.Matcher
+
+	// backoff saves allocating a backoff each request. We must call .Reset()
+	// to reset this between requests.
+	backoff backoff.BackOff
+
+	// authenticationAlgorithm is only set for slots belonging to sessions. As
+	// it cannot be used by multiple requests at once, each slot has its own
+	// instance.
+	authenticationAlgorithm ipmi.V1AuthenticationAlgorithm
+}
+
+// init prepares a slot for use. The authentication algorithm is nil for
+// session-less connections.
+func (s *v1Slot) init(sessionID uint32, authenticationAlgorithm ipmi.V1AuthenticationAlgorithm) {
+	s.sessionID = sessionID
+	s.buffer = gopacket.NewSerializeBuffer()
+	s.backoff = backoff.NewExponentialBackOff()
+	s.authenticationAlgorithm = authenticationAlgorithm
+	s.matchMessage = s.isMessageResponse
+
+	dlc := gopacket.DecodingLayerContainer(gopacket.DecodingLayerArray(nil))
+	dlc = dlc.Put(&s.rmcpLayer)
+	dlc = dlc.Put(&s.sessionSelectorLayer)
+	dlc = dlc.Put(&s.v1SessionLayer)
+	dlc = dlc.Put(&s.messageLayer)
+	s.decode = dlc.LayersDecoder(s.rmcpLayer.LayerType(), gopacket.NilDecodeFeedback)
+}
+
+// isMessageResponse returns whether a packet is the response to the IPMI
+// message sent using this slot. It is called by the transport, and decodes the
+// packet into the slot's layers. Anything else, e.g. a late response to a
+// request that timed out, is rejected, leaving the transport to keep waiting.
+// The session layer's authentication algorithm survives decoding, so the auth
+// code of responses to authenticated requests is validated.
+func (s *v1Slot) isMessageResponse(b []byte) bool {
+	if _, err := s.decode(b, &s.layers); err != nil {
+		return false
+	}
+	types := layerexts.DecodedTypes(s.layers)
+	if err := types.InnermostEquals(ipmi.LayerTypeMessage); err != nil {
+		return false
+	}
+	return (s.anySessionID || s.v1SessionLayer.ID == s.sessionID) &&
+		isResponseTo(&s.messageLayer, s.sequence, &s.operation)
+}
+
+// v1ConnectionShared contains fields that a session-less connection passes to
+// sessions created from it. It is the v1.5 equivalent of v2ConnectionShared.
+type v1ConnectionShared struct {
+
+	// transport is the underlying UDP socket for the connection.
+	transport transport.Transport
+
+	// tokens limits the number of requests in flight to the BMC, and allocates
+	// slots to them.
+	tokens slotTokens
+
+	// sequence allocates IPMI message sequence numbers to requests.
+	sequence messageSequence
+}
+
+// V1Sessionless represents a session-less connection to a BMC using a "null"
+// IPMI v1.5 session wrapper. It is safe for concurrent use.
+type V1Sessionless struct {
+	v1ConnectionShared
+
+	slots [packetBufferDepth]v1Slot
+
+	// timeout is the time we allow the BMC to respond to each UDP request. This
+	// contrasts with the context, which includes retries.
+	timeout time.Duration
+}
+
+func newV1Sessionless(t transport.Transport, timeout time.Duration) *V1Sessionless {
+	s := &V1Sessionless{
+		v1ConnectionShared: v1ConnectionShared{
+			transport: t,
+			tokens:    newSlotTokens(),
+		},
+		timeout: timeout,
+	}
+	for i := range s.slots {
+		s.slots[i].init(0, nil)
+	}
+	return s
+}
+
+func (s *V1Sessionless) Version() string {
+	return "1.5"
+}
+
+// SetTimeout configures the per-request timeout for a given IPMI command.
+// Methods will retry temporary errors until the context expires; this
+// configures how long we will wait for a response. This must not be called
+// concurrently with sending commands.
+func (s *V1Sessionless) SetTimeout(t time.Duration) {
+	s.timeout = t
+}
+
+func (s *V1Sessionless) SendCommand(ctx context.Context, c ipmi.Command) (ipmi.CompletionCode, error) {
+	// a null session header: AuthType none, sequence number and session ID 0
+	return s.sendCommand(ctx, c, &ipmi.V1Session{})
+}
+
+// sendCommand sends a command wrapped in the provided session header. This is
+// a null header for all commands except Activate Session, which is sent with
+// the temporary session ID and an auth code.
+func (s *V1Sessionless) sendCommand(ctx context.Context, c ipmi.Command, session *ipmi.V1Session) (ipmi.CompletionCode, error) {
+	timer := prometheus.NewTimer(commandDuration)
+	defer timer.ObserveDuration()
+	commandAttempts.WithLabelValues(c.Name()).Inc()
+
+	token, err := s.tokens.acquire(ctx)
+	if err != nil {
+		commandFailures.WithLabelValues(c.Name()).Inc()
+		return 0, err
+	}
+	defer s.tokens.release(token)
+	slot := &s.slots[token]
+
+	if err := s.buildAndSendCommand(ctx, slot, c, session); err != nil {
+		commandFailures.WithLabelValues(c.Name()).Inc()
+		return 0, err
+	}
+
+	code := slot.messageLayer.CompletionCode
+
+	if c.Response() != nil {
+		if err := decodeResponse(c.Response(),
+			slot.messageLayer.LayerPayload()); err != nil {
+			commandFailures.WithLabelValues(c.Name()).Inc()
+			return code, err
+		}
+	}
+
+	return code, nil
+}
+
+func (s *V1Sessionless) buildAndSendCommand(ctx context.Context, slot *v1Slot, c ipmi.Command, session *ipmi.V1Session) error {
+	slot.rmcpLayer = layers.RMCP{
+		Version:  layers.RMCPVersion1,
+		Sequence: 0xFF, // do not send us an ACK
+		Class:    layers.RMCPClassIPMI,
+	}
+	// the session layer's AuthenticationAlgorithm survives decoding, so a
+	// response to an authenticated request will have its auth code validated
+	slot.v1SessionLayer = *session
+	slot.sessionID = session.ID
+	// only Activate Session is sent with a non-null session ID
+	slot.anySessionID = session.ID != 0
+	slot.sequence = s.sequence.next()
+	slot.operation = *c.Operation()
+	slot.messageLayer = ipmi.Message{
+		Operation:     slot.operation,
+		RemoteAddress: ipmi.SlaveAddressBMC.Address(),
+		RemoteLUN:     c.RemoteLUN(),
+		LocalAddress:  ipmi.SoftwareIDRemoteConsole1.Address(),
+		Sequence:      slot.sequence,
+	}
+
+	// we don't need to increment a sequence number between retries, so can
+	// serialise this just once
+	if err := gopacket.SerializeLayers(slot.buffer, serializeOptions,
+		&slot.rmcpLayer,
+		// session selector only used when decoding
+		&slot.v1SessionLayer,
+		&slot.messageLayer,
+		serializableLayerOrEmpty(c.Request())); err != nil {
+		return err
+	}
+
+	slot.backoff.Reset()
+	firstAttempt := true
+	return backoff.Retry(func() error {
+		if firstAttempt {
+			firstAttempt = false
+		} else {
+			commandRetries.Inc()
+		}
+
+		requestCtx, cancel := context.WithTimeout(ctx, s.timeout)
+		_, err := s.transport.Send(requestCtx, slot.buffer.Bytes(),
+			slot.response[:], slot.matchMessage)
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		code := slot.messageLayer.CompletionCode
+		commandResponses.WithLabelValues(code.String()).Inc()
+		if code.IsTemporary() {
+			return errRetryableCode
+		}
+		return nil
+	}, backoff.WithContext(slot.backoff, ctx))
+}
+
+func (s *V1Sessionless) GetSystemGUID(ctx context.Context) ([16]byte, error) {
+	return getSystemGUID(ctx, s)
+}
+
+func (s *V1Sessionless) GetChannelAuthenticationCapabilities(
+	ctx context.Context,
+	r *ipmi.GetChannelAuthenticationCapabilitiesReq,
+) (*ipmi.GetChannelAuthenticationCapabilitiesRsp, error) {
+	return getChannelAuthenticationCapabilities(ctx, s, r)
+}
+
+func (s *V1Sessionless) getSessionChallenge(ctx context.Context, r *ipmi.GetSessionChallengeReq) (*ipmi.GetSessionChallengeRsp, error) {
+	cmd := &ipmi.GetSessionChallengeCmd{
+		Req: *r,
+	}
+	if err := ValidateResponse(s.SendCommand(ctx, cmd)); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
+
+// activateSession sends an Activate Session command inside the provided
+// session header, which must contain the temporary session ID returned by Get
+// Session Challenge, and the algorithm to sign the request with. If the
+// requested maximum privilege level is too high, errPrivilegeLevelExceedsLimit
+// is returned.
+func (s *V1Sessionless) activateSession(ctx context.Context, session *ipmi.V1Session, r *ipmi.ActivateSessionReq) (*ipmi.ActivateSessionRsp, error) {
+	cmd := &ipmi.ActivateSessionCmd{
+		Req: *r,
+	}
+	// BMCs typically truncate the response after a non-normal code, so this
+	// is checked regardless of any decode error
+	code, err := s.sendCommand(ctx, cmd, session)
+	if code == ipmi.CompletionCodeRequestedPrivilegeLevelExceedsLimit {
+		return nil, errPrivilegeLevelExceedsLimit
+	}
+	if err := ValidateResponse(code, err); err != nil {
+		return nil, err
+	}
+	return &cmd.Rsp, nil
+}
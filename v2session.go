@@ -4,26 +4,24 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
-	"hash"
+	"sync/atomic"
 	"time"
 
 	"github.com/gebn/bmc/pkg/ipmi"
-	"github.com/gebn/bmc/pkg/layerexts"
 
 	"github.com/cenkalti/backoff/v4"
-	"github.com/google/gopacket"
 	"github.com/google/gopacket/layers"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-// V2Session represents an established IPMI v2.0/RMCP+ session with a BMC.
+// V2Session represents an established IPMI v2.0/RMCP+ session with a BMC. It
+// is safe for concurrent use.
 type V2Session struct {
-	v2ConnectionLayers
 	*v2ConnectionShared
 
-	// decode parses the layers in v2ConnectionShared, plus a confidentiality
-	// layer.
-	decode gopacket.DecodingLayerFunc
+	// slots each have their own instances of the integrity and
+	// confidentiality algorithms, loaded with the session's keys.
+	slots [packetBufferDepth]v2Slot
 
 	// LocalID is the remote console's session ID, used by the BMC to send us
 	// packets.
@@ -76,20 +74,16 @@ type V2Session struct {
 	// providing K_n for information purposes.
 	AdditionalKeyMaterialGenerator
 
-	integrityAlgorithm hash.Hash
-
-	// confidentialityLayer is used to send packets (we encrypt all outgoing
-	// packets), and to decode incoming packets. It is created during session
-	// establishment, and loaded with the right key material. The session
-	// layer's ConfidentialityLayerType field is set to this layer's type, so it
-	// returns this as the NextLayerType() of encrypted packets. When sending a
-	// message, this layer's SerializeTo is called before adding the session
-	// wrapper.
-	confidentialityLayer layerexts.SerializableDecodingLayer
-
 	// timeout is the time allowed per attempt of a command. The context passed
 	// in by the user controls end-to-end.
 	timeout time.Duration
+
+	// consoleSend and consoleReceive are instances of the integrity and
+	// confidentiality algorithms reserved for Serial over LAN, which sends and
+	// receives packets independently of the slots. As only one console may use
+	// them at a time, consoleActive is set while one is.
+	consoleSend, consoleReceive sessionAlgorithms
+	consoleActive               atomic.Bool
 }
 
 // String returns a summary of the session's attributes on one line.
@@ -117,16 +111,24 @@ func (s *V2Session) SendCommand(ctx context.Context, c ipmi.Command) (ipmi.Compl
 	defer timer.ObserveDuration()
 	commandAttempts.WithLabelValues(c.Name()).Inc()
 
-	if err := s.buildAndSend(ctx, c); err != nil {
+	token, err := s.tokens.acquire(ctx)
+	if err != nil {
+		commandFailures.WithLabelValues(c.Name()).Inc()
+		return 0, err
+	}
+	defer s.tokens.release(token)
+	slot := &s.slots[token]
+
+	if err := s.buildAndSend(ctx, slot, c); err != nil {
 		commandFailures.WithLabelValues(c.Name()).Inc()
 		return 0, err
 	}
 
-	code := s.messageLayer.CompletionCode
+	code := slot.messageLayer.CompletionCode
 
 	if c.Response() != nil {
-		if err := c.Response().DecodeFromBytes(s.messageLayer.LayerPayload(),
-			gopacket.NilDecodeFeedback); err != nil {
+		if err := decodeResponse(c.Response(),
+			slot.messageLayer.LayerPayload()); err != nil {
 			commandFailures.WithLabelValues(c.Name()).Inc()
 			return code, err
 		}
@@ -135,26 +137,38 @@ func (s *V2Session) SendCommand(ctx context.Context, c ipmi.Command) (ipmi.Compl
 	return code, nil
 }
 
-func (s *V2Session) buildAndSend(ctx context.Context, c ipmi.Command) error {
-	s.rmcpLayer = layers.RMCP{
+func (s *V2Session) buildAndSend(ctx context.Context, slot *v2Slot, c ipmi.Command) error {
+	slot.rmcpLayer = layers.RMCP{
 		Version:  layers.RMCPVersion1,
 		Sequence: 0xFF, // do not send us an ACK
 		Class:    layers.RMCPClassIPMI,
 	}
-	s.v2SessionLayer = ipmi.V2Session{
-		Encrypted:                true,
-		Authenticated:            true,
-		ID:                       s.RemoteID,
-		PayloadDescriptor:        ipmi.PayloadDescriptorIPMI,
-		IntegrityAlgorithm:       s.integrityAlgorithm,
-		ConfidentialityLayerType: s.confidentialityLayer.LayerType(),
+	// integrity and confidentiality algorithms of None have nil
+	// implementations, in which case we send unauthenticated and/or
+	// unencrypted packets
+	slot.v2SessionLayer = ipmi.V2Session{
+		Encrypted:          slot.confidentialityLayer != nil,
+		Authenticated:      slot.integrityAlgorithm != nil,
+		ID:                 s.RemoteID,
+		PayloadDescriptor:  ipmi.PayloadDescriptorIPMI,
+		IntegrityAlgorithm: slot.integrityAlgorithm,
+	}
+	if slot.confidentialityLayer != nil {
+		slot.v2SessionLayer.ConfidentialityLayerType =
+			slot.confidentialityLayer.LayerType()
 	}
-	s.messageLayer = ipmi.Message{
-		Operation:     *c.Operation(),
+	sequenceNumbers := &s.AuthenticatedSequenceNumbers
+	if !slot.v2SessionLayer.Authenticated {
+		sequenceNumbers = &s.UnauthenticatedSequenceNumbers
+	}
+	slot.sequence = s.sequence.next()
+	slot.operation = *c.Operation()
+	slot.messageLayer = ipmi.Message{
+		Operation:     slot.operation,
 		RemoteAddress: ipmi.SlaveAddressBMC.Address(),
 		RemoteLUN:     c.RemoteLUN(),
 		LocalAddress:  ipmi.SoftwareIDRemoteConsole1.Address(),
-		Sequence:      1, // used at the session level
+		Sequence:      slot.sequence,
 	}
 
 	firstAttempt := true
@@ -166,23 +180,18 @@ func (s *V2Session) buildAndSend(ctx context.Context, c ipmi.Command) error {
 			commandRetries.Inc()
 		}
 
-		// TODO handle AuthenticationAlgorithmNone properly
-		// TODO handle ConfidentialityAlgorithmNone properly
-		s.AuthenticatedSequenceNumbers.Inbound++
-		s.v2SessionLayer.Sequence = s.AuthenticatedSequenceNumbers.Inbound
-		if err := gopacket.SerializeLayers(s.buffer, serializeOptions,
-			&s.rmcpLayer,
-			// session selector only used when decoding
-			&s.v2SessionLayer,
-			s.confidentialityLayer,
-			&s.messageLayer,
-			serializableLayerOrEmpty(c.Request())); err != nil {
+		// the BMC tolerates sequence numbers arriving slightly out of order, so
+		// concurrent requests are fine
+		slot.v2SessionLayer.Sequence = atomic.AddUint32(
+			&sequenceNumbers.Inbound, 1)
+		if err := slot.serialize(c.Request()); err != nil {
 			// this is not a retryable error
 			terminalErr = err
 			return nil
 		}
 		requestCtx, cancel := context.WithTimeout(ctx, s.timeout)
-		response, err := s.transport.Send(requestCtx, s.buffer.Bytes())
+		_, err := s.transport.Send(requestCtx, slot.buffer.Bytes(),
+			slot.response[:], slot.matchMessage)
 		cancel()
 		if err != nil {
 			// session is now in an unknown state - if we send another command,
@@ -191,14 +200,7 @@ func (s *V2Session) buildAndSend(ctx context.Context, c ipmi.Command) error {
 			terminalErr = err
 			return nil
 		}
-		if _, err := s.decode(response, &s.layers); err != nil {
-			return err
-		}
-		types := layerexts.DecodedTypes(s.layers)
-		if err := types.InnermostEquals(ipmi.LayerTypeMessage); err != nil {
-			return err
-		}
-		code := s.messageLayer.CompletionCode
+		code := slot.messageLayer.CompletionCode
 		// must increment here, otherwise we'll miss temporary codes at the
 		// higher levels
 		commandResponses.WithLabelValues(code.String()).Inc()
@@ -207,8 +209,8 @@ func (s *V2Session) buildAndSend(ctx context.Context, c ipmi.Command) error {
 		}
 		return nil
 	}
-	s.backoff.Reset()
-	if err := backoff.Retry(retryable, backoff.WithContext(s.backoff, ctx)); err != nil {
+	slot.backoff.Reset()
+	if err := backoff.Retry(retryable, backoff.WithContext(slot.backoff, ctx)); err != nil {
 		return err
 	}
 	return terminalErr
@@ -226,93 +228,223 @@ func (s *V2Session) GetChannelAuthenticationCapabilities(
 }
 
 func (s *V2Session) GetSessionInfo(ctx context.Context, r *ipmi.GetSessionInfoReq) (*ipmi.GetSessionInfoRsp, error) {
-	cmd := &ipmi.GetSessionInfoCmd{
-		Req: *r,
-	}
-	if err := ValidateResponse(s.SendCommand(ctx, cmd)); err != nil {
-		return nil, err
-	}
-	return &cmd.Rsp, nil
+	return getSessionInfo(ctx, s, r)
 }
 
 func (s *V2Session) GetDeviceID(ctx context.Context) (*ipmi.GetDeviceIDRsp, error) {
-	cmd := &ipmi.GetDeviceIDCmd{}
-	if err := ValidateResponse(s.SendCommand(ctx, cmd)); err != nil {
-		return nil, err
-	}
-	return &cmd.Rsp, nil
+	return getDeviceID(ctx, s)
+}
+
+func (s *V2Session) ColdReset(ctx context.Context) error {
+	return coldReset(ctx, s)
+}
+
+func (s *V2Session) WarmReset(ctx context.Context) error {
+	return warmReset(ctx, s)
+}
+
+func (s *V2Session) GetSelfTestResults(ctx context.Context) (*ipmi.GetSelfTestResultsRsp, error) {
+	return getSelfTestResults(ctx, s)
+}
+
+func (s *V2Session) SetACPIPowerState(ctx context.Context, r *ipmi.SetACPIPowerStateReq) error {
+	return setACPIPowerState(ctx, s, r)
+}
+
+func (s *V2Session) GetACPIPowerState(ctx context.Context) (*ipmi.GetACPIPowerStateRsp, error) {
+	return getACPIPowerState(ctx, s)
+}
+
+func (s *V2Session) GetSystemInfoParameters(ctx context.Context, r *ipmi.GetSystemInfoParametersReq) (*ipmi.GetSystemInfoParametersRsp, error) {
+	return getSystemInfoParameters(ctx, s, r)
+}
+
+func (s *V2Session) SetSystemInfoParameters(ctx context.Context, r *ipmi.SetSystemInfoParametersReq) error {
+	return setSystemInfoParameters(ctx, s, r)
+}
+
+func (s *V2Session) GetWatchdogTimer(ctx context.Context) (*ipmi.GetWatchdogTimerRsp, error) {
+	return getWatchdogTimer(ctx, s)
+}
+
+func (s *V2Session) SetWatchdogTimer(ctx context.Context, r *ipmi.SetWatchdogTimerReq) error {
+	return setWatchdogTimer(ctx, s, r)
+}
+
+func (s *V2Session) ResetWatchdogTimer(ctx context.Context) error {
+	return resetWatchdogTimer(ctx, s)
 }
 
 func (s *V2Session) GetChassisStatus(ctx context.Context) (*ipmi.GetChassisStatusRsp, error) {
-	cmd := &ipmi.GetChassisStatusCmd{}
-	if err := ValidateResponse(s.SendCommand(ctx, cmd)); err != nil {
-		return nil, err
-	}
-	return &cmd.Rsp, nil
+	return getChassisStatus(ctx, s)
 }
 
 func (s *V2Session) ChassisControl(ctx context.Context, c ipmi.ChassisControl) error {
-	cmd := &ipmi.ChassisControlCmd{
-		Req: ipmi.ChassisControlReq{
-			ChassisControl: c,
-		},
-	}
-	if err := ValidateResponse(s.SendCommand(ctx, cmd)); err != nil {
-		return err
-	}
-	return nil
+	return chassisControl(ctx, s, c)
+}
+
+func (s *V2Session) ChassisIdentify(ctx context.Context, interval time.Duration, forceOn bool) error {
+	return chassisIdentify(ctx, s, interval, forceOn)
+}
+
+func (s *V2Session) GetSystemBootOptions(ctx context.Context, r *ipmi.GetSystemBootOptionsReq) (*ipmi.GetSystemBootOptionsRsp, error) {
+	return getSystemBootOptions(ctx, s, r)
+}
+
+func (s *V2Session) SetSystemBootOptions(ctx context.Context, r *ipmi.SetSystemBootOptionsReq) error {
+	return setSystemBootOptions(ctx, s, r)
+}
+
+func (s *V2Session) SetBootDevice(ctx context.Context, f *ipmi.BootFlags) error {
+	return setBootDevice(ctx, s, f)
+}
+
+func (s *V2Session) GetLANConfigurationParameters(ctx context.Context, r *ipmi.GetLANConfigurationParametersReq) (*ipmi.GetLANConfigurationParametersRsp, error) {
+	return getLANConfigurationParameters(ctx, s, r)
+}
+
+func (s *V2Session) SetLANConfigurationParameters(ctx context.Context, r *ipmi.SetLANConfigurationParametersReq) error {
+	return setLANConfigurationParameters(ctx, s, r)
+}
+
+func (s *V2Session) GetChannelInfo(ctx context.Context, channel ipmi.Channel) (*ipmi.GetChannelInfoRsp, error) {
+	return getChannelInfo(ctx, s, channel)
+}
+
+func (s *V2Session) GetChannelAccess(ctx context.Context, r *ipmi.GetChannelAccessReq) (*ipmi.GetChannelAccessRsp, error) {
+	return getChannelAccess(ctx, s, r)
+}
+
+func (s *V2Session) SetChannelAccess(ctx context.Context, r *ipmi.SetChannelAccessReq) error {
+	return setChannelAccess(ctx, s, r)
 }
 
 func (s *V2Session) GetSDRRepositoryInfo(ctx context.Context) (*ipmi.GetSDRRepositoryInfoRsp, error) {
-	cmd := &ipmi.GetSDRRepositoryInfoCmd{}
-	if err := ValidateResponse(s.SendCommand(ctx, cmd)); err != nil {
-		return nil, err
-	}
-	return &cmd.Rsp, nil
+	return getSDRRepositoryInfo(ctx, s)
 }
 
 func (s *V2Session) ReserveSDRRepository(ctx context.Context) (*ipmi.ReserveSDRRepositoryRsp, error) {
-	cmd := &ipmi.ReserveSDRRepositoryCmd{}
-	if err := ValidateResponse(s.SendCommand(ctx, cmd)); err != nil {
-		return nil, err
-	}
-	return &cmd.Rsp, nil
+	return reserveSDRRepository(ctx, s)
+}
+
+func (s *V2Session) GetSDRRepositoryTime(ctx context.Context) (*ipmi.GetSDRRepositoryTimeRsp, error) {
+	return getSDRRepositoryTime(ctx, s)
+}
+
+func (s *V2Session) GetSELInfo(ctx context.Context) (*ipmi.GetSELInfoRsp, error) {
+	return getSELInfo(ctx, s)
+}
+
+func (s *V2Session) GetSELAllocationInfo(ctx context.Context) (*ipmi.GetSELAllocationInfoRsp, error) {
+	return getSELAllocationInfo(ctx, s)
+}
+
+func (s *V2Session) ReserveSEL(ctx context.Context) (*ipmi.ReserveSELRsp, error) {
+	return reserveSEL(ctx, s)
+}
+
+func (s *V2Session) GetSELEntry(ctx context.Context, r *ipmi.GetSELEntryReq) (*ipmi.GetSELEntryRsp, error) {
+	return getSELEntry(ctx, s, r)
+}
+
+func (s *V2Session) DeleteSELEntry(ctx context.Context, r *ipmi.DeleteSELEntryReq) (*ipmi.DeleteSELEntryRsp, error) {
+	return deleteSELEntry(ctx, s, r)
+}
+
+func (s *V2Session) ClearSEL(ctx context.Context) error {
+	return clearSEL(ctx, s)
+}
+
+func (s *V2Session) GetSELTime(ctx context.Context) (*ipmi.GetSELTimeRsp, error) {
+	return getSELTime(ctx, s)
+}
+
+func (s *V2Session) SetSELTime(ctx context.Context, t time.Time) error {
+	return setSELTime(ctx, s, t)
+}
+
+func (s *V2Session) GetSELTimeUTCOffset(ctx context.Context) (*ipmi.GetSELTimeUTCOffsetRsp, error) {
+	return getSELTimeUTCOffset(ctx, s)
+}
+
+func (s *V2Session) SetSELTimeUTCOffset(ctx context.Context, o ipmi.UTCOffset) error {
+	return setSELTimeUTCOffset(ctx, s, o)
+}
+
+func (s *V2Session) GetFRUInventoryAreaInfo(ctx context.Context, deviceID uint8) (*ipmi.GetFRUInventoryAreaInfoRsp, error) {
+	return getFRUInventoryAreaInfo(ctx, s, deviceID)
+}
+
+func (s *V2Session) ReadFRUData(ctx context.Context, r *ipmi.ReadFRUDataReq) (*ipmi.ReadFRUDataRsp, error) {
+	return readFRUData(ctx, s, r)
+}
+
+func (s *V2Session) GetUserAccess(ctx context.Context, r *ipmi.GetUserAccessReq) (*ipmi.GetUserAccessRsp, error) {
+	return getUserAccess(ctx, s, r)
+}
+
+func (s *V2Session) SetUserAccess(ctx context.Context, r *ipmi.SetUserAccessReq) error {
+	return setUserAccess(ctx, s, r)
+}
+
+func (s *V2Session) GetUserName(ctx context.Context, userID uint8) (string, error) {
+	return getUserName(ctx, s, userID)
+}
+
+func (s *V2Session) SetUserName(ctx context.Context, userID uint8, name string) error {
+	return setUserName(ctx, s, userID, name)
+}
+
+func (s *V2Session) SetUserPassword(ctx context.Context, r *ipmi.SetUserPasswordReq) error {
+	return setUserPassword(ctx, s, r)
 }
 
 func (s *V2Session) GetSensorReading(ctx context.Context, sensor uint8) (*ipmi.GetSensorReadingRsp, error) {
-	cmd := &ipmi.GetSensorReadingCmd{
-		Req: ipmi.GetSensorReadingReq{
-			Number: sensor,
-		},
-	}
-	if err := ValidateResponse(s.SendCommand(ctx, cmd)); err != nil {
-		return nil, err
-	}
-	return &cmd.Rsp, nil
+	return getSensorReading(ctx, s, sensor)
+}
+
+func (s *V2Session) GetSensorReadingFactors(ctx context.Context, r *ipmi.GetSensorReadingFactorsReq) (*ipmi.GetSensorReadingFactorsRsp, error) {
+	return getSensorReadingFactors(ctx, s, r)
+}
+
+func (s *V2Session) GetSensorThresholds(ctx context.Context, sensor uint8) (*ipmi.GetSensorThresholdsRsp, error) {
+	return getSensorThresholds(ctx, s, sensor)
+}
+
+func (s *V2Session) SetSensorThresholds(ctx context.Context, r *ipmi.SetSensorThresholdsReq) error {
+	return setSensorThresholds(ctx, s, r)
+}
+
+func (s *V2Session) GetSensorHysteresis(ctx context.Context, sensor uint8) (*ipmi.GetSensorHysteresisRsp, error) {
+	return getSensorHysteresis(ctx, s, sensor)
+}
+
+func (s *V2Session) SetSensorHysteresis(ctx context.Context, r *ipmi.SetSensorHysteresisReq) error {
+	return setSensorHysteresis(ctx, s, r)
+}
+
+func (s *V2Session) GetPEFCapabilities(ctx context.Context) (*ipmi.GetPEFCapabilitiesRsp, error) {
+	return getPEFCapabilities(ctx, s)
+}
+
+func (s *V2Session) ArmPEFPostponeTimer(ctx context.Context, t ipmi.PEFPostponeTimer) (*ipmi.ArmPEFPostponeTimerRsp, error) {
+	return armPEFPostponeTimer(ctx, s, t)
+}
+
+func (s *V2Session) GetPEFConfigurationParameters(ctx context.Context, r *ipmi.GetPEFConfigurationParametersReq) (*ipmi.GetPEFConfigurationParametersRsp, error) {
+	return getPEFConfigurationParameters(ctx, s, r)
+}
+
+func (s *V2Session) SetPEFConfigurationParameters(ctx context.Context, r *ipmi.SetPEFConfigurationParametersReq) error {
+	return setPEFConfigurationParameters(ctx, s, r)
 }
 
 func (s *V2Session) GetSessionPrivilegeLevel(ctx context.Context) (ipmi.PrivilegeLevel, error) {
-	cmd := &ipmi.SetSessionPrivilegeLevelCmd{
-		Req: ipmi.SetSessionPrivilegeLevelReq{
-			// PrivilegeLevel omitted to retrieve current level
-		},
-	}
-	if err := ValidateResponse(s.SendCommand(ctx, cmd)); err != nil {
-		return 0, err
-	}
-	return cmd.Rsp.PrivilegeLevel, nil
+	return getSessionPrivilegeLevel(ctx, s)
 }
 
 func (s *V2Session) SetSessionPrivilegeLevel(ctx context.Context, level ipmi.PrivilegeLevel) (ipmi.PrivilegeLevel, error) {
-	cmd := &ipmi.SetSessionPrivilegeLevelCmd{
-		Req: ipmi.SetSessionPrivilegeLevelReq{
-			PrivilegeLevel: level,
-		},
-	}
-	if err := ValidateResponse(s.SendCommand(ctx, cmd)); err != nil {
-		return 0, err
-	}
-	return cmd.Rsp.PrivilegeLevel, nil
+	return setSessionPrivilegeLevel(ctx, s, level)
 }
 
 func (s *V2Session) closeSession(ctx context.Context) error {
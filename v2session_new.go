@@ -4,13 +4,12 @@ import (
 	"context"
 	"crypto/hmac"
 	"crypto/rand"
+	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"fmt"
 
 	"github.com/gebn/bmc/pkg/ipmi"
-
-	"github.com/google/gopacket"
 )
 
 var (
@@ -22,6 +21,11 @@ var (
 	defaultCipherSuites = []ipmi.CipherSuite{
 		ipmi.CipherSuite17,
 		ipmi.CipherSuite3,
+		// older firmware may only offer xRC4 on some channels. The 40-bit
+		// suites 5 and 10 are excluded, as Get Channel Cipher Suites is
+		// unauthenticated, so an on-path attacker could otherwise force a
+		// session whose key can be brute-forced.
+		ipmi.CipherSuite9,
 	}
 )
 
@@ -49,8 +53,12 @@ type V2SessionOpts struct {
 	// CipherSuites is the list of authentication, integrity and confidentiality
 	// algorithms to use in descending order of preference. If omitted, the
 	// library will use Cipher Suite 17 if possible, falling back on Cipher
-	// Suite 3, for which support is mandatory. To avoid performing
-	// discovery, provide a single cipher suite.
+	// Suite 3, for which support is mandatory, then the xRC4-128 Cipher Suite
+	// 9 for BMCs that do not offer it on the channel. The suites a BMC
+	// supports are discovered via an unauthenticated command, so an on-path
+	// attacker can cause the least preferred suite in the list to be used. The
+	// 40-bit xRC4 Cipher Suites 5 and 10 are therefore only used if included
+	// here. To avoid performing discovery, provide a single cipher suite.
 	CipherSuites []ipmi.CipherSuite
 }
 
@@ -92,9 +100,22 @@ func (s *V2SessionlessTransport) newV2Session(ctx context.Context, opts *V2Sessi
 		return nil, err
 	}
 
+	// the session ID and tag allow responses to be matched up with the right
+	// session if several are being established or used concurrently, so
+	// should be unique
+	remoteConsoleSessionID, err := randomSessionID()
+	if err != nil {
+		return nil, err
+	}
+	tag := [1]byte{}
+	if _, err := rand.Read(tag[:]); err != nil {
+		return nil, err
+	}
+
 	openSessionRsp, err := s.openSession(ctx, &ipmi.OpenSessionReq{
+		Tag:               tag[0],
 		MaxPrivilegeLevel: opts.MaxPrivilegeLevel,
-		SessionID:         1,
+		SessionID:         remoteConsoleSessionID,
 		AuthenticationPayload: ipmi.AuthenticationPayload{
 			Algorithm: cipherSuite.AuthenticationAlgorithm,
 		},
@@ -115,6 +136,7 @@ func (s *V2SessionlessTransport) newV2Session(ctx context.Context, opts *V2Sessi
 		return nil, err
 	}
 	rakpMessage1 := &ipmi.RAKPMessage1{
+		Tag:                    tag[0],
 		ManagedSystemSessionID: openSessionRsp.ManagedSystemSessionID,
 		RemoteConsoleRandom:    remoteConsoleRandom,
 		PrivilegeLevelLookup:   opts.PrivilegeLevelLookup,
@@ -140,6 +162,7 @@ func (s *V2SessionlessTransport) newV2Session(ctx context.Context, opts *V2Sessi
 	}
 
 	rakpMessage4, err := s.rakpMessage3(ctx, &ipmi.RAKPMessage3{
+		Tag:                    tag[0],
 		Status:                 ipmi.StatusCodeOK,
 		ManagedSystemSessionID: openSessionRsp.ManagedSystemSessionID,
 		AuthCode: calculateRAKPMessage3AuthCode(
@@ -167,17 +190,6 @@ func (s *V2SessionlessTransport) newV2Session(ctx context.Context, opts *V2Sessi
 	keyMaterialGen := additionalKeyMaterialGenerator{
 		hash: hashGenerator.K(sik),
 	}
-	hasher, err := algorithmHasher(openSessionRsp.IntegrityPayload.Algorithm,
-		keyMaterialGen)
-	if err != nil {
-		return nil, err
-	}
-	cipherLayer, err := algorithmCipher(
-		openSessionRsp.ConfidentialityPayload.Algorithm, keyMaterialGen)
-	if err != nil {
-		return nil, err
-	}
-
 	sess := &V2Session{
 		v2ConnectionShared:             &s.v2ConnectionShared,
 		LocalID:                        openSessionRsp.RemoteConsoleSessionID,
@@ -187,22 +199,52 @@ func (s *V2SessionlessTransport) newV2Session(ctx context.Context, opts *V2Sessi
 		IntegrityAlgorithm:             openSessionRsp.IntegrityPayload.Algorithm,
 		ConfidentialityAlgorithm:       openSessionRsp.ConfidentialityPayload.Algorithm,
 		AdditionalKeyMaterialGenerator: keyMaterialGen,
-		integrityAlgorithm:             hasher,
-		confidentialityLayer:           cipherLayer,
 		timeout:                        s.timeout,
 	}
-	// do not set properties of the session layer here, as it is overwritten
-	// each send
-	dlc := gopacket.DecodingLayerContainer(gopacket.DecodingLayerArray(nil))
-	dlc = dlc.Put(&sess.rmcpLayer)
-	dlc = dlc.Put(&sess.sessionSelectorLayer)
-	dlc = dlc.Put(&sess.v2SessionLayer)
-	dlc = dlc.Put(cipherLayer)
-	dlc = dlc.Put(&sess.messageLayer)
-	sess.decode = dlc.LayersDecoder(sess.rmcpLayer.LayerType(), gopacket.NilDecodeFeedback)
+	// hashes and ciphers are stateful, so each slot needs its own, as do the
+	// send and receive sides of a SOL console
+	algorithms := make([]sessionAlgorithms, len(sess.slots)+2)
+	cipherLayers, err := algorithmCipher(sess.ConfidentialityAlgorithm,
+		keyMaterialGen, len(algorithms))
+	if err != nil {
+		return nil, err
+	}
+	for i := range algorithms {
+		hasher, err := algorithmHasher(sess.IntegrityAlgorithm, keyMaterialGen,
+			opts.Password)
+		if err != nil {
+			return nil, err
+		}
+		algorithms[i] = sessionAlgorithms{
+			integrity:       hasher,
+			confidentiality: cipherLayers[i],
+		}
+	}
+	for i := range sess.slots {
+		sess.slots[i].init(sess.LocalID, algorithms[i].integrity,
+			algorithms[i].confidentiality)
+		sess.slots[i].authenticatedSequenceNumbers =
+			&sess.AuthenticatedSequenceNumbers
+		sess.slots[i].unauthenticatedSequenceNumbers =
+			&sess.UnauthenticatedSequenceNumbers
+	}
+	sess.consoleSend = algorithms[len(sess.slots)]
+	sess.consoleReceive = algorithms[len(sess.slots)+1]
 	return sess, nil
 }
 
+// randomSessionID returns a random, non-null session ID for the remote
+// console.
+func randomSessionID() (uint32, error) {
+	id := uint32(0)
+	for id == 0 {
+		if err := binary.Read(rand.Reader, binary.LittleEndian, &id); err != nil {
+			return 0, err
+		}
+	}
+	return id, nil
+}
+
 // determineCipherSuite picks the set of algorithms that will be used to
 // establish the session, doing discovery if we have multiple options.
 func (s *V2SessionlessTransport) determineCipherSuite(ctx context.Context, desiredSuites []ipmi.CipherSuite) (*ipmi.CipherSuite, error) {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"hash"
 	"time"
 
 	"github.com/gebn/bmc/internal/pkg/transport"
@@ -25,12 +26,11 @@ var (
 	v2ConnectionsOpen        = connectionsOpen.WithLabelValues("2.0")
 )
 
-// v2ConnectionLayers contains layers common to all v2.0 connections. Although
-// these layers are common, both V2Sessionless and V2Session embed this as a
-// value, so each gets a fresh set of layers. This uses a little more memory,
-// but it means when a session is closed, its session layer doesn't have a
-// dangling confidentiality layer etc. This is why this is not embedded in
-// v2ConnectionShared.
+// v2ConnectionLayers contains layers common to all v2.0 connections. Each slot
+// of each connection gets a fresh set of layers. This uses a little more
+// memory, but it means concurrent requests don't share layers, and when a
+// session is closed, its session layer doesn't have a dangling
+// confidentiality layer etc.
 type v2ConnectionLayers struct {
 	rmcpLayer            layers.RMCP
 	sessionSelectorLayer ipmi.SessionSelector
@@ -38,64 +38,196 @@ type v2ConnectionLayers struct {
 	messageLayer         ipmi.Message
 }
 
+// v2Slot contains the state required to send a single request and receive its
+// response over a v2.0 connection. See slotTokens for how slots are allocated.
+type v2Slot struct {
+	v2ConnectionLayers
+
+	// sequence and operation identify the IPMI message currently being sent
+	// using this slot. The sequence number is unique among in-flight
+	// requests, and is mirrored back to us in the response along with the
+	// command, so they can be used to match it up.
+	sequence  uint8
+	operation ipmi.Operation
+
+	// sessionID is the session ID the BMC sends responses to us with: 0 for
+	// session-less connections, and the remote console session ID for
+	// sessions.
+	sessionID uint32
+
+	// payloadType is the type of the response expected by matchPayload.
+	payloadType ipmi.PayloadType
+
+	// buffer is used to build all packets to send using this slot. Reusing
+	// this between sends drastically reduces the number of allocations we
+	// have to do when building packets.
+	buffer gopacket.SerializeBuffer
+
+	// response is the buffer the transport copies received packets into. The
+	// layers decoded from a matched response refer to it.
+	response [transport.MaxPacketSize]byte
+
+	// layers contains layer types decoded by decode.
+	layers []gopacket.LayerType
+
+	// decode parses the layers in v2ConnectionLayers, plus the
+	// confidentiality layer if present.
+	decode gopacket.DecodingLayerFunc
+
+	// matchMessage and matchPayload are created once to avoid allocating a
+	// closure each request.
+	matchMessage transport.Matcher
+	matchPayload transport.Matcher
+
+	// backoff saves allocating a backoff each request. We must call .Reset()
+	// to reset this between requests.
+	backoff backoff.BackOff
+
+	// integrityAlgorithm and confidentialityLayer are only set for slots
+	// belonging to sessions. Neither can be used by multiple requests at once,
+	// hence why they are per-slot rather than per-session.
+	integrityAlgorithm   hash.Hash
+	confidentialityLayer layerexts.SerializableDecodingLayer
+
+	// authenticatedSequenceNumbers and unauthenticatedSequenceNumbers belong
+	// to the slot's session, and are used to reject replayed responses. They
+	// are nil for session-less connections, whose packets are not numbered.
+	authenticatedSequenceNumbers   *sequenceNumbers
+	unauthenticatedSequenceNumbers *sequenceNumbers
+}
+
+// init prepares a slot for use. The integrity algorithm and confidentiality
+// layer are nil for session-less connections.
+func (s *v2Slot) init(
+	sessionID uint32,
+	integrityAlgorithm hash.Hash,
+	confidentialityLayer layerexts.SerializableDecodingLayer,
+) {
+	s.sessionID = sessionID
+	s.buffer = gopacket.NewSerializeBuffer()
+	s.backoff = backoff.NewExponentialBackOff()
+	s.integrityAlgorithm = integrityAlgorithm
+	s.confidentialityLayer = confidentialityLayer
+	s.matchMessage = s.isMessageResponse
+	s.matchPayload = s.isPayloadResponse
+
+	// do not set properties of the session layer here, as it is overwritten
+	// each send
+	dlc := gopacket.DecodingLayerContainer(gopacket.DecodingLayerArray(nil))
+	dlc = dlc.Put(&s.rmcpLayer)
+	dlc = dlc.Put(&s.sessionSelectorLayer)
+	dlc = dlc.Put(&s.v2SessionLayer)
+	if confidentialityLayer != nil {
+		dlc = dlc.Put(confidentialityLayer)
+	}
+	dlc = dlc.Put(&s.messageLayer)
+	s.decode = dlc.LayersDecoder(s.rmcpLayer.LayerType(), gopacket.NilDecodeFeedback)
+}
+
+// isMessageResponse returns whether a packet is the response to the IPMI
+// message sent using this slot. It is called by the transport, and decodes the
+// packet into the slot's layers. Anything else, e.g. a late response to a
+// request that timed out, is rejected, leaving the transport to keep waiting.
+func (s *v2Slot) isMessageResponse(b []byte) bool {
+	if _, err := s.decode(b, &s.layers); err != nil {
+		return false
+	}
+	types := layerexts.DecodedTypes(s.layers)
+	if err := types.InnermostEquals(ipmi.LayerTypeMessage); err != nil {
+		return false
+	}
+	// if an integrity algorithm was negotiated, the BMC must sign everything
+	// it sends inside the session, otherwise anyone could forge responses
+	if s.integrityAlgorithm != nil && !s.v2SessionLayer.Authenticated {
+		return false
+	}
+	// an encrypted SOL packet would otherwise be decoded as a message
+	if s.v2SessionLayer.ID != s.sessionID ||
+		s.v2SessionLayer.PayloadType != ipmi.PayloadTypeIPMI ||
+		!isResponseTo(&s.messageLayer, s.sequence, &s.operation) {
+		return false
+	}
+	// checked last, so only packets we would otherwise accept take up a
+	// place in the window
+	if s.authenticatedSequenceNumbers == nil {
+		return true
+	}
+	return acceptV2Sequence(s.authenticatedSequenceNumbers,
+		s.unauthenticatedSequenceNumbers, &s.v2SessionLayer)
+}
+
+// serialize builds an IPMI message packet in the slot's buffer from its
+// layers and the provided request. The confidentiality layer is only included
+// if one was negotiated.
+func (s *v2Slot) serialize(request gopacket.SerializableLayer) error {
+	// session selector only used when decoding
+	if s.confidentialityLayer == nil {
+		return gopacket.SerializeLayers(s.buffer, serializeOptions,
+			&s.rmcpLayer,
+			&s.v2SessionLayer,
+			&s.messageLayer,
+			serializableLayerOrEmpty(request))
+	}
+	return gopacket.SerializeLayers(s.buffer, serializeOptions,
+		&s.rmcpLayer,
+		&s.v2SessionLayer,
+		s.confidentialityLayer,
+		&s.messageLayer,
+		serializableLayerOrEmpty(request))
+}
+
+// isPayloadResponse returns whether a packet is a session setup payload of the
+// expected response type. Tags are checked once the payload is decoded.
+func (s *v2Slot) isPayloadResponse(b []byte) bool {
+	if _, err := s.decode(b, &s.layers); err != nil {
+		return false
+	}
+	types := layerexts.DecodedTypes(s.layers)
+	if err := types.InnermostEquals(ipmi.LayerTypeV2Session); err != nil {
+		return false
+	}
+	return s.v2SessionLayer.PayloadType == s.payloadType
+}
+
 // v2ConnectionShared contains fields that a session-less connection passes to
 // sessions created from it. V2Sessionless embeds a value of this type, and
 // V2Session embeds a pointer which is set to the V2Sessionless's value.
-//
-// Note that a given BMC only supports a single command at a time, which is what
-// makes this possible - if a session is sending a command, the session-less
-// connection it was initiated from cannot send concurrently.
 type v2ConnectionShared struct {
 
 	// transport is the underlying UDP socket for the connection.
 	transport transport.Transport
 
-	// buffer is used to build all packets to send during this connection.
-	// Reusing this between sends drastically reduces the number of allocations
-	// we have to do when building packets, and reusing it between session-less
-	// and session-based connections reduces it a little further.
-	buffer gopacket.SerializeBuffer
-
-	// layers contains layer types decoded by the connection's
-	// gopacket.DecodingLayerParser. Although this slice is shared, each
-	// connection has its own DLP, as each session may have a different
-	// confidentiality layer.
-	layers []gopacket.LayerType
+	// tokens limits the number of requests in flight to the BMC, and allocates
+	// slots to them.
+	tokens slotTokens
 
-	// backoff saves allocating a backoff each request. We must call .Reset() to
-	// reset this between requests.
-	backoff backoff.BackOff
+	// sequence allocates IPMI message sequence numbers to requests.
+	sequence messageSequence
 }
 
 // V2Sessionless represents a session-less connection to a BMC using a "null"
-// IPMI v2.0 session wrapper.
+// IPMI v2.0 session wrapper. It is safe for concurrent use.
 type V2Sessionless struct {
-	v2ConnectionLayers
 	v2ConnectionShared
 
+	slots [packetBufferDepth]v2Slot
+
 	// timeout is the time we allow the BMC to respond to each UDP request. This
 	// contrasts with the context, which includes retries.
 	timeout time.Duration
-
-	// decode parses the layers in v2ConnectionShared.
-	decode gopacket.DecodingLayerFunc
 }
 
 func newV2Sessionless(t transport.Transport, timeout time.Duration) *V2Sessionless {
 	s := &V2Sessionless{
 		v2ConnectionShared: v2ConnectionShared{
 			transport: t,
-			buffer:    gopacket.NewSerializeBuffer(),
-			backoff:   backoff.NewExponentialBackOff(),
+			tokens:    newSlotTokens(),
 		},
 		timeout: timeout,
 	}
-	dlc := gopacket.DecodingLayerContainer(gopacket.DecodingLayerArray(nil))
-	dlc = dlc.Put(&s.rmcpLayer)
-	dlc = dlc.Put(&s.sessionSelectorLayer)
-	dlc = dlc.Put(&s.v2SessionLayer)
-	dlc = dlc.Put(&s.messageLayer)
-	s.decode = dlc.LayersDecoder(s.rmcpLayer.LayerType(), gopacket.NilDecodeFeedback)
+	for i := range s.slots {
+		s.slots[i].init(0, nil, nil)
+	}
 	return s
 }
 
@@ -105,56 +237,56 @@ func (s *V2Sessionless) Version() string {
 
 // SetTimeout configures the per-request timeout for a given RMCP+ or IPMI
 // command. Methods will retry temporary errors until the context expires; this
-// configures how long we will wait for a response.
+// configures how long we will wait for a response. This must not be called
+// concurrently with sending commands.
 func (s *V2Sessionless) SetTimeout(t time.Duration) {
 	s.timeout = t
 }
 
 func (s *V2Sessionless) buildAndSendPayload(ctx context.Context, p ipmi.Payload) error {
-	s.rmcpLayer = layers.RMCP{
+	token, err := s.tokens.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.tokens.release(token)
+	slot := &s.slots[token]
+
+	slot.rmcpLayer = layers.RMCP{
 		Version:  layers.RMCPVersion1,
 		Sequence: 0xFF, // do not send us an ACK
 		Class:    layers.RMCPClassIPMI,
 	}
-	s.v2SessionLayer = ipmi.V2Session{
+	slot.v2SessionLayer = ipmi.V2Session{
 		PayloadDescriptor: *p.Descriptor(),
 	}
+	// each setup payload type has its own response type, which is one higher
+	slot.payloadType = p.Descriptor().PayloadType + 1
 
 	// we don't need to increment a sequence number between retries, so can
 	// serialise this just once
 	// N.B. no message layer as this is only used for RMCP+ session setup (see
 	// ipmi.Payload interface for more details)
-	if err := gopacket.SerializeLayers(s.buffer, serializeOptions,
-		&s.rmcpLayer,
+	if err := gopacket.SerializeLayers(slot.buffer, serializeOptions,
+		&slot.rmcpLayer,
 		// session selector only used when decoding
-		&s.v2SessionLayer,
+		&slot.v2SessionLayer,
 		p.Request()); err != nil {
 		return err
 	}
 
-	s.backoff.Reset()
+	slot.backoff.Reset()
 	retryable := func() error {
 		requestCtx, cancel := context.WithTimeout(ctx, s.timeout)
-		response, err := s.transport.Send(requestCtx, s.buffer.Bytes())
+		_, err := s.transport.Send(requestCtx, slot.buffer.Bytes(),
+			slot.response[:], slot.matchPayload)
 		cancel()
-		if err != nil {
-			return err
-		}
-		if _, err := s.decode(response, &s.layers); err != nil {
-			return err
-		}
-		types := layerexts.DecodedTypes(s.layers)
-		if err := types.InnermostEquals(ipmi.LayerTypeV2Session); err != nil {
-			return err
-		}
-		return nil
+		return err
 	}
-	if err := backoff.Retry(retryable, backoff.WithContext(s.backoff, ctx)); err != nil {
+	if err := backoff.Retry(retryable, backoff.WithContext(slot.backoff, ctx)); err != nil {
 		return err
 	}
 
-	return p.Response().DecodeFromBytes(
-		s.v2SessionLayer.LayerPayload(), gopacket.NilDecodeFeedback)
+	return decodeResponse(p.Response(), slot.v2SessionLayer.LayerPayload())
 }
 
 // saves having to write two SerializeLayers calls in SendCommand
@@ -170,7 +302,15 @@ func (s *V2Sessionless) SendCommand(ctx context.Context, c ipmi.Command) (ipmi.C
 	defer timer.ObserveDuration()
 	commandAttempts.WithLabelValues(c.Name()).Inc()
 
-	if err := s.buildAndSendCommand(ctx, c); err != nil {
+	token, err := s.tokens.acquire(ctx)
+	if err != nil {
+		commandFailures.WithLabelValues(c.Name()).Inc()
+		return 0, err
+	}
+	defer s.tokens.release(token)
+	slot := &s.slots[token]
+
+	if err := s.buildAndSendCommand(ctx, slot, c); err != nil {
 		commandFailures.WithLabelValues(c.Name()).Inc()
 		return 0, err
 	}
@@ -181,13 +321,13 @@ func (s *V2Sessionless) SendCommand(ctx context.Context, c ipmi.Command) (ipmi.C
 	// BMCs that don't. If we get an error, it is passed back along with the
 	// correct completion code. Users of this function should not rely on the
 	// response if the code is non-normal.
-	code := s.messageLayer.CompletionCode
+	code := slot.messageLayer.CompletionCode
 
 	if c.Response() != nil {
 		// the command is expecting a response body in the success case - do our
 		// best; this may validly fail if the code is non-normal
-		if err := c.Response().DecodeFromBytes(s.messageLayer.LayerPayload(),
-			gopacket.NilDecodeFeedback); err != nil {
+		if err := decodeResponse(c.Response(),
+			slot.messageLayer.LayerPayload()); err != nil {
 			commandFailures.WithLabelValues(c.Name()).Inc()
 			return code, err
 		}
@@ -199,35 +339,37 @@ func (s *V2Sessionless) SendCommand(ctx context.Context, c ipmi.Command) (ipmi.C
 	return code, nil
 }
 
-func (s *V2Sessionless) buildAndSendCommand(ctx context.Context, c ipmi.Command) error {
-	s.rmcpLayer = layers.RMCP{
+func (s *V2Sessionless) buildAndSendCommand(ctx context.Context, slot *v2Slot, c ipmi.Command) error {
+	slot.rmcpLayer = layers.RMCP{
 		Version:  layers.RMCPVersion1,
 		Sequence: 0xFF, // do not send us an ACK
 		Class:    layers.RMCPClassIPMI,
 	}
-	s.v2SessionLayer = ipmi.V2Session{
+	slot.v2SessionLayer = ipmi.V2Session{
 		PayloadDescriptor: ipmi.PayloadDescriptorIPMI,
 	}
-	s.messageLayer = ipmi.Message{
-		Operation:     *c.Operation(),
+	slot.sequence = s.sequence.next()
+	slot.operation = *c.Operation()
+	slot.messageLayer = ipmi.Message{
+		Operation:     slot.operation,
 		RemoteAddress: ipmi.SlaveAddressBMC.Address(),
 		RemoteLUN:     c.RemoteLUN(),
 		LocalAddress:  ipmi.SoftwareIDRemoteConsole1.Address(),
-		Sequence:      1,
+		Sequence:      slot.sequence,
 	}
 
 	// we don't need to increment a sequence number between retries, so can
 	// serialise this just once
-	if err := gopacket.SerializeLayers(s.buffer, serializeOptions,
-		&s.rmcpLayer,
+	if err := gopacket.SerializeLayers(slot.buffer, serializeOptions,
+		&slot.rmcpLayer,
 		// session selector only used when decoding
-		&s.v2SessionLayer,
-		&s.messageLayer,
+		&slot.v2SessionLayer,
+		&slot.messageLayer,
 		serializableLayerOrEmpty(c.Request())); err != nil {
 		return err
 	}
 
-	s.backoff.Reset()
+	slot.backoff.Reset()
 	firstAttempt := true
 	return backoff.Retry(func() error {
 		if firstAttempt {
@@ -236,26 +378,17 @@ func (s *V2Sessionless) buildAndSendCommand(ctx context.Context, c ipmi.Command)
 			commandRetries.Inc()
 		}
 
+		// the transport only returns a packet once the slot has decoded it
+		// and found it to be the response to our message
 		requestCtx, cancel := context.WithTimeout(ctx, s.timeout)
-		response, err := s.transport.Send(requestCtx, s.buffer.Bytes())
+		_, err := s.transport.Send(requestCtx, slot.buffer.Bytes(),
+			slot.response[:], slot.matchMessage)
 		cancel()
 		if err != nil {
 			return err
 		}
 
-		// parse bytes
-		if _, err := s.decode(response, &s.layers); err != nil {
-			return err
-		}
-
-		// ensure we got a message (we don't attempt to parse below message
-		// here)
-		types := layerexts.DecodedTypes(s.layers)
-		if err := types.InnermostEquals(ipmi.LayerTypeMessage); err != nil {
-			return err
-		}
-
-		code := s.messageLayer.CompletionCode
+		code := slot.messageLayer.CompletionCode
 		// must increment here, otherwise we'll miss temporary codes at the
 		// higher levels
 		commandResponses.WithLabelValues(code.String()).Inc()
@@ -264,26 +397,13 @@ func (s *V2Sessionless) buildAndSendCommand(ctx context.Context, c ipmi.Command)
 			return errRetryableCode
 		}
 		return nil
-	}, backoff.WithContext(s.backoff, ctx))
+	}, backoff.WithContext(slot.backoff, ctx))
 }
 
 func (s *V2Sessionless) GetSystemGUID(ctx context.Context) ([16]byte, error) {
 	return getSystemGUID(ctx, s)
 }
 
-func getSystemGUID(ctx context.Context, c Connection) ([16]byte, error) {
-	cmd := &ipmi.GetSystemGUIDCmd{}
-	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
-		return [16]byte{}, err
-	}
-
-	// we could return a google/uuid type, however that requires the BMC return
-	// a valid GUID in network byte order, and the spec says it should be
-	// treated as an opaque value. The user can interpret these bytes how they
-	// wish.
-	return cmd.Rsp.GUID, nil
-}
-
 func (s *V2Sessionless) GetChannelAuthenticationCapabilities(
 	ctx context.Context,
 	r *ipmi.GetChannelAuthenticationCapabilitiesReq,
@@ -291,22 +411,6 @@ func (s *V2Sessionless) GetChannelAuthenticationCapabilities(
 	return getChannelAuthenticationCapabilities(ctx, s, r)
 }
 
-func getChannelAuthenticationCapabilities(
-	ctx context.Context,
-	c Connection,
-	req *ipmi.GetChannelAuthenticationCapabilitiesReq,
-) (*ipmi.GetChannelAuthenticationCapabilitiesRsp, error) {
-	// we could set req.ExtendedData here as we're guaranteed to be IPMI v2.0,
-	// however let the user decide
-	cmd := &ipmi.GetChannelAuthenticationCapabilitiesCmd{
-		Req: *req,
-	}
-	if err := ValidateResponse(c.SendCommand(ctx, cmd)); err != nil {
-		return nil, err
-	}
-	return &cmd.Rsp, nil
-}
-
 func (s *V2Sessionless) openSession(ctx context.Context, r *ipmi.OpenSessionReq) (*ipmi.OpenSessionRsp, error) {
 	// if we were being *really* aggressive, we could store these payloads in
 	// the sessionless struct for reuse during any future session establishments
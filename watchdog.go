@@ -0,0 +1,43 @@
+package bmc
+
+import (
+	"context"
+
+	"github.com/gebn/bmc/pkg/ipmi"
+)
+
+// ArmWatchdog configures the BMC's watchdog timer and starts it. The
+// expiration flag of the timer's use is cleared, so WatchdogExpiration()
+// subsequently reports whether this countdown expired. The timer must then be
+// reset via Reset Watchdog Timer, typically by a daemon on the host, before
+// the countdown reaches 0, otherwise the timeout action is taken.
+func ArmWatchdog(ctx context.Context, s Session, t *ipmi.WatchdogTimer) error {
+	if err := s.SetWatchdogTimer(ctx, &ipmi.SetWatchdogTimerReq{
+		WatchdogTimer:        *t,
+		ClearExpirationFlags: ipmi.WatchdogExpirationFlag(t.Use),
+	}); err != nil {
+		return err
+	}
+	return s.ResetWatchdogTimer(ctx)
+}
+
+// WatchdogExpiration reports whether the BMC's watchdog timer has expired
+// since its expiration flags were last cleared, and if so, the timer use of
+// the last expiration. The BMC only records which uses have expired, not when,
+// so if several have, the timer's present use is assumed to be the last if it
+// has expired and the timer is stopped, otherwise the first flagged use is
+// returned.
+func WatchdogExpiration(ctx context.Context, s Session) (bool, ipmi.WatchdogTimerUse, error) {
+	rsp, err := s.GetWatchdogTimer(ctx)
+	if err != nil {
+		return false, 0, err
+	}
+	uses := rsp.ExpirationFlags.Uses()
+	if len(uses) == 0 {
+		return false, 0, nil
+	}
+	if !rsp.Running && rsp.ExpirationFlags.Has(rsp.Use) {
+		return true, rsp.Use, nil
+	}
+	return true, uses[0], nil
+}
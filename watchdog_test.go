@@ -0,0 +1,117 @@
+package bmc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gebn/bmc/pkg/ipmi"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// watchdogBMC records watchdog commands, and returns a fixed Get Watchdog
+// Timer response.
+type watchdogBMC struct {
+	rsp  ipmi.GetWatchdogTimerRsp
+	sent []string
+	set  *ipmi.SetWatchdogTimerReq
+}
+
+// session returns a session handling watchdog commands against the BMC.
+func (b *watchdogBMC) session() *fakeSession {
+	s := &fakeSession{}
+	handle(s, func(c *ipmi.GetWatchdogTimerCmd) (ipmi.CompletionCode, error) {
+		b.sent = append(b.sent, c.Name())
+		c.Rsp = b.rsp
+		return ipmi.CompletionCodeNormal, nil
+	})
+	handle(s, func(c *ipmi.SetWatchdogTimerCmd) (ipmi.CompletionCode, error) {
+		b.sent = append(b.sent, c.Name())
+		b.set = &c.Req
+		return ipmi.CompletionCodeNormal, nil
+	})
+	handle(s, func(c *ipmi.ResetWatchdogTimerCmd) (ipmi.CompletionCode, error) {
+		b.sent = append(b.sent, c.Name())
+		return ipmi.CompletionCodeNormal, nil
+	})
+	return s
+}
+
+func TestArmWatchdog(t *testing.T) {
+	bmc := &watchdogBMC{}
+	timer := &ipmi.WatchdogTimer{
+		Use:              ipmi.WatchdogTimerUseSMSOS,
+		TimeoutAction:    ipmi.WatchdogTimeoutActionPowerCycle,
+		InitialCountdown: time.Minute * 5,
+	}
+	if err := ArmWatchdog(context.Background(), bmc.session(), timer); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"Set Watchdog Timer", "Reset Watchdog Timer"},
+		bmc.sent); diff != "" {
+		t.Errorf("unexpected commands: %v", diff)
+	}
+	want := &ipmi.SetWatchdogTimerReq{
+		WatchdogTimer:        *timer,
+		ClearExpirationFlags: 0x10,
+	}
+	if diff := cmp.Diff(want, bmc.set); diff != "" {
+		t.Errorf("set %v, want %v: %v", bmc.set, want, diff)
+	}
+}
+
+func TestWatchdogExpiration(t *testing.T) {
+	tests := []struct {
+		name        string
+		rsp         ipmi.GetWatchdogTimerRsp
+		wantExpired bool
+		wantUse     ipmi.WatchdogTimerUse
+	}{
+		{
+			name: "not expired",
+			rsp: ipmi.GetWatchdogTimerRsp{
+				WatchdogTimer: ipmi.WatchdogTimer{
+					Use: ipmi.WatchdogTimerUseSMSOS,
+				},
+				Running: true,
+			},
+		},
+		{
+			name: "present use expired",
+			rsp: ipmi.GetWatchdogTimerRsp{
+				WatchdogTimer: ipmi.WatchdogTimer{
+					Use: ipmi.WatchdogTimerUseSMSOS,
+				},
+				ExpirationFlags: 0x14,
+			},
+			wantExpired: true,
+			wantUse:     ipmi.WatchdogTimerUseSMSOS,
+		},
+		{
+			name: "rearmed after expiry",
+			rsp: ipmi.GetWatchdogTimerRsp{
+				WatchdogTimer: ipmi.WatchdogTimer{
+					Use: ipmi.WatchdogTimerUseSMSOS,
+				},
+				Running:         true,
+				ExpirationFlags: 0x04,
+			},
+			wantExpired: true,
+			wantUse:     ipmi.WatchdogTimerUseBIOSPOST,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expired, use, err := WatchdogExpiration(context.Background(),
+				(&watchdogBMC{rsp: test.rsp}).session())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if expired != test.wantExpired || use != test.wantUse {
+				t.Errorf("WatchdogExpiration() = %v, %v, want %v, %v", expired,
+					use, test.wantExpired, test.wantUse)
+			}
+		})
+	}
+}